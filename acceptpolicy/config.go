@@ -0,0 +1,186 @@
+// Package acceptpolicy evaluates operator defined rules before oracle accepts a sign request.
+package acceptpolicy
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/log"
+)
+
+// decision results
+const (
+	ResultAgree    = "AGREE"
+	ResultDisagree = "DISAGREE"
+	ResultHold     = "HOLD"
+)
+
+// default pair policy key, applies to pairs without their own policy
+const defaultPairKey = "*"
+
+// Policy accept policy
+type Policy struct {
+	DeniedTimeWindows []*TimeWindow          `toml:",omitempty" json:",omitempty"`
+	Pairs             map[string]*PairPolicy `toml:",omitempty" json:",omitempty"`
+}
+
+// PairPolicy accept policy of token pair, values are in token units
+type PairPolicy struct {
+	MaxValuePerSwap  float64  `toml:",omitempty" json:",omitempty"`
+	MaxValuePerHour  float64  `toml:",omitempty" json:",omitempty"`
+	AllowedReceivers []string `toml:",omitempty" json:",omitempty"`
+	MinDepositAge    int64    `toml:",omitempty" json:",omitempty"` // seconds
+	ManualApproval   bool     `toml:",omitempty" json:",omitempty"`
+	ExceedAction     string   `toml:",omitempty" json:",omitempty"` // DISAGREE (default) or HOLD if exceed max value
+}
+
+// TimeWindow time window of day in UTC, eg. Start = "22:00", End = "06:00"
+type TimeWindow struct {
+	Start    string
+	End      string
+	Weekdays []string `toml:",omitempty" json:",omitempty"` // eg. ["Sat", "Sun"], empty means every day
+	Action   string   `toml:",omitempty" json:",omitempty"` // DISAGREE (default) or HOLD
+
+	start, end int // minutes of day
+}
+
+// LoadPolicy load accept policy file
+func LoadPolicy(policyFile string) (*Policy, error) {
+	if !common.FileExist(policyFile) {
+		return nil, fmt.Errorf("accept policy file '%v' not exist", policyFile)
+	}
+	policy := &Policy{}
+	if _, err := toml.DecodeFile(policyFile, policy); err != nil {
+		return nil, fmt.Errorf("load accept policy failed: %w", err)
+	}
+	if err := policy.CheckPolicy(); err != nil {
+		return nil, fmt.Errorf("check accept policy failed: %w", err)
+	}
+	log.Info("load accept policy success", "file", policyFile, "pairs", len(policy.Pairs), "deniedTimeWindows", len(policy.DeniedTimeWindows))
+	return policy, nil
+}
+
+// CheckPolicy check accept policy
+func (p *Policy) CheckPolicy() error {
+	for _, window := range p.DeniedTimeWindows {
+		if err := window.check(); err != nil {
+			return err
+		}
+	}
+	pairs := make(map[string]*PairPolicy, len(p.Pairs))
+	for pairID, pairPolicy := range p.Pairs {
+		if pairPolicy == nil {
+			continue
+		}
+		if err := pairPolicy.check(); err != nil {
+			return fmt.Errorf("pair '%v': %w", pairID, err)
+		}
+		pairs[strings.ToLower(pairID)] = pairPolicy
+	}
+	p.Pairs = pairs
+	return nil
+}
+
+// GetPairPolicy get policy of pair, or the default one
+func (p *Policy) GetPairPolicy(pairID string) *PairPolicy {
+	if pairPolicy, exist := p.Pairs[strings.ToLower(pairID)]; exist {
+		return pairPolicy
+	}
+	return p.Pairs[defaultPairKey]
+}
+
+func (p *PairPolicy) check() error {
+	if p.MaxValuePerSwap < 0 || p.MaxValuePerHour < 0 || p.MinDepositAge < 0 {
+		return fmt.Errorf("negative limit is not allowed")
+	}
+	action, err := checkAction(p.ExceedAction)
+	if err != nil {
+		return err
+	}
+	p.ExceedAction = action
+	return nil
+}
+
+func (w *TimeWindow) check() (err error) {
+	w.start, err = parseMinuteOfDay(w.Start)
+	if err != nil {
+		return err
+	}
+	w.end, err = parseMinuteOfDay(w.End)
+	if err != nil {
+		return err
+	}
+	if w.start == w.end {
+		return fmt.Errorf("empty time window %v-%v", w.Start, w.End)
+	}
+	for _, weekday := range w.Weekdays {
+		if _, err = parseWeekday(weekday); err != nil {
+			return err
+		}
+	}
+	w.Action, err = checkAction(w.Action)
+	return err
+}
+
+// contains whether time is in the window, window crossing midnight is supported
+func (w *TimeWindow) contains(t time.Time) bool {
+	t = t.UTC()
+	if len(w.Weekdays) > 0 {
+		match := false
+		for _, weekday := range w.Weekdays {
+			if wd, _ := parseWeekday(weekday); wd == t.Weekday() {
+				match = true
+				break
+			}
+		}
+		if !match {
+			return false
+		}
+	}
+	minute := t.Hour()*60 + t.Minute()
+	if w.start < w.end {
+		return minute >= w.start && minute < w.end
+	}
+	return minute >= w.start || minute < w.end
+}
+
+func checkAction(action string) (string, error) {
+	switch strings.ToUpper(action) {
+	case "", ResultDisagree:
+		return ResultDisagree, nil
+	case ResultHold:
+		return ResultHold, nil
+	default:
+		return "", fmt.Errorf("unknown action '%v'", action)
+	}
+}
+
+func parseMinuteOfDay(s string) (int, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) != 2 {
+		return 0, fmt.Errorf("wrong time of day '%v'", s)
+	}
+	hour, err := strconv.Atoi(parts[0])
+	if err != nil || hour < 0 || hour > 23 {
+		return 0, fmt.Errorf("wrong hour of '%v'", s)
+	}
+	minute, err := strconv.Atoi(parts[1])
+	if err != nil || minute < 0 || minute > 59 {
+		return 0, fmt.Errorf("wrong minute of '%v'", s)
+	}
+	return hour*60 + minute, nil
+}
+
+func parseWeekday(s string) (time.Weekday, error) {
+	for wd := time.Sunday; wd <= time.Saturday; wd++ {
+		name := wd.String()
+		if strings.EqualFold(s, name) || strings.EqualFold(s, name[:3]) {
+			return wd, nil
+		}
+	}
+	return 0, fmt.Errorf("wrong weekday '%v'", s)
+}
@@ -0,0 +1,186 @@
+package acceptpolicy
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// held swaps are saved as files in hold directory,
+// so that local CLI can list them and make manual decisions,
+// which are read by the oracle in its next accept round.
+// holds are keyed by swaps instead of sign keyIDs, as the sign request
+// of the same swap is retried with new keyID after it's expired.
+const (
+	holdFileSuffix   = ".json"
+	manualFileSuffix = ".manual"
+)
+
+// Hold held swap with the decision which holds it
+type Hold struct {
+	HoldKey  string    `json:"holdKey"`
+	Swap     *SwapInfo `json:"swap"`
+	Decision *Decision `json:"decision"`
+}
+
+// GetHoldDir get hold directory in data dir
+func GetHoldDir(dataDir string) string {
+	return filepath.Join(dataDir, "acceptholds")
+}
+
+// GetHoldKey get hold key of swap (swapType:pairID:swapID:bind)
+func GetHoldKey(swap *SwapInfo) string {
+	return strings.ToLower(strings.Join([]string{swap.SwapType, swap.PairID, swap.SwapID, swap.Bind}, ":"))
+}
+
+func getHoldFileName(holdKey string) (string, error) {
+	if holdKey == "" || strings.ContainsAny(holdKey, `/\`) || strings.Contains(holdKey, "..") {
+		return "", fmt.Errorf("invalid hold key '%v'", holdKey)
+	}
+	return strings.ReplaceAll(strings.ToLower(holdKey), ":", "_"), nil
+}
+
+// SaveHold save held decision of each swap,
+// the existing hold of swap is kept unchanged
+func SaveHold(holdDir string, decision *Decision) error {
+	if err := os.MkdirAll(holdDir, 0700); err != nil {
+		return err
+	}
+	for _, swap := range decision.Swaps {
+		holdKey := GetHoldKey(swap)
+		fileName, err := getHoldFileName(holdKey)
+		if err != nil {
+			return err
+		}
+		holdFile := filepath.Join(holdDir, fileName+holdFileSuffix)
+		if _, err = os.Stat(holdFile); err == nil {
+			continue
+		}
+		data, err := json.MarshalIndent(&Hold{HoldKey: holdKey, Swap: swap, Decision: decision}, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err = ioutil.WriteFile(holdFile, data, 0600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadHolds load holds ordered by time
+func LoadHolds(holdDir string) ([]*Hold, error) {
+	files, err := ioutil.ReadDir(holdDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	holds := make([]*Hold, 0, len(files))
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), holdFileSuffix) {
+			continue
+		}
+		data, err := ioutil.ReadFile(filepath.Join(holdDir, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+		hold := &Hold{}
+		if err = json.Unmarshal(data, hold); err != nil || hold.Decision == nil {
+			return nil, fmt.Errorf("parse hold file %v failed: %v", file.Name(), err)
+		}
+		holds = append(holds, hold)
+	}
+	sort.Slice(holds, func(i, j int) bool {
+		return holds[i].Decision.Timestamp < holds[j].Decision.Timestamp
+	})
+	return holds, nil
+}
+
+// SetManualResult set manual result (AGREE or DISAGREE) of held swap
+func SetManualResult(holdDir, holdKey, result string) error {
+	fileName, err := getHoldFileName(holdKey)
+	if err != nil {
+		return err
+	}
+	result = strings.ToUpper(result)
+	if result != ResultAgree && result != ResultDisagree {
+		return fmt.Errorf("wrong manual result '%v'", result)
+	}
+	if _, err := os.Stat(filepath.Join(holdDir, fileName+holdFileSuffix)); err != nil {
+		return fmt.Errorf("swap %v is not held", holdKey)
+	}
+	return ioutil.WriteFile(filepath.Join(holdDir, fileName+manualFileSuffix), []byte(result), 0600)
+}
+
+// GetManualResult get manual result of held swap, return empty if not decided
+func GetManualResult(holdDir, holdKey string) string {
+	fileName, err := getHoldFileName(holdKey)
+	if err != nil {
+		return ""
+	}
+	data, err := ioutil.ReadFile(filepath.Join(holdDir, fileName+manualFileSuffix))
+	if err != nil {
+		return ""
+	}
+	switch result := strings.ToUpper(strings.TrimSpace(string(data))); result {
+	case ResultAgree, ResultDisagree:
+		return result
+	default:
+		return ""
+	}
+}
+
+// GetSwapsManualResult get manual result of swaps signed together,
+// it's DISAGREE if any swap is disagreed, and AGREE if all swaps are agreed
+func GetSwapsManualResult(holdDir string, swaps []*SwapInfo) string {
+	agreed := 0
+	for _, swap := range swaps {
+		switch GetManualResult(holdDir, GetHoldKey(swap)) {
+		case ResultDisagree:
+			return ResultDisagree
+		case ResultAgree:
+			agreed++
+		}
+	}
+	if agreed > 0 && agreed == len(swaps) {
+		return ResultAgree
+	}
+	return ""
+}
+
+// RemoveHold remove held swap and its manual result
+func RemoveHold(holdDir, holdKey string) {
+	fileName, err := getHoldFileName(holdKey)
+	if err != nil {
+		return
+	}
+	_ = os.Remove(filepath.Join(holdDir, fileName+holdFileSuffix))
+	_ = os.Remove(filepath.Join(holdDir, fileName+manualFileSuffix))
+}
+
+// RemoveSwapsHolds remove holds of swaps
+func RemoveSwapsHolds(holdDir string, swaps []*SwapInfo) {
+	for _, swap := range swaps {
+		RemoveHold(holdDir, GetHoldKey(swap))
+	}
+}
+
+// RemoveExpiredHolds remove holds which are expired
+func RemoveExpiredHolds(holdDir string, expiration time.Duration) {
+	holds, err := LoadHolds(holdDir)
+	if err != nil {
+		return
+	}
+	expireTime := time.Now().Add(-expiration).Unix()
+	for _, hold := range holds {
+		if hold.Decision.Timestamp < expireTime {
+			RemoveHold(holdDir, hold.HoldKey)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package acceptpolicy
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// rule names
+const (
+	RuleDefault         = "default"
+	RuleManual          = "manual"
+	RuleDeniedTime      = "deniedTimeWindow"
+	RuleAllowedReceiver = "allowedReceivers"
+	RuleMaxValuePerSwap = "maxValuePerSwap"
+	RuleMaxValuePerHour = "maxValuePerHour"
+	RuleMinDepositAge   = "minDepositAge"
+	RuleManualApproval  = "manualApproval"
+)
+
+const valueWindow = int64(3600) // seconds
+
+// SwapInfo swap info to be evaluated, value is in token units
+type SwapInfo struct {
+	PairID      string  `json:"pairID"`
+	SwapID      string  `json:"swapID"`
+	SwapType    string  `json:"swapType"`
+	Bind        string  `json:"bind"`
+	Value       float64 `json:"value"`
+	DepositTime int64   `json:"depositTime,omitempty"`
+}
+
+// Decision accept decision with the rule fired
+type Decision struct {
+	KeyID     string      `json:"keyID"`
+	Result    string      `json:"result"`
+	Rule      string      `json:"rule"`
+	Reason    string      `json:"reason,omitempty"`
+	Swaps     []*SwapInfo `json:"swaps,omitempty"`
+	Timestamp int64       `json:"timestamp"`
+}
+
+type agreedValue struct {
+	keyID     string
+	value     float64
+	timestamp int64
+}
+
+// Engine accept policy engine
+type Engine struct {
+	policy *Policy
+
+	lock         sync.Mutex
+	agreedValues map[string][]*agreedValue // key is pairID
+}
+
+// NewEngine new accept policy engine
+func NewEngine(policy *Policy) *Engine {
+	return &Engine{
+		policy:       policy,
+		agreedValues: make(map[string][]*agreedValue),
+	}
+}
+
+// Evaluate evaluate swaps of sign request, the first fired rule decides the result
+func (e *Engine) Evaluate(keyID string, swaps []*SwapInfo, now time.Time) *Decision {
+	decision := &Decision{
+		KeyID:     keyID,
+		Result:    ResultAgree,
+		Rule:      RuleDefault,
+		Swaps:     swaps,
+		Timestamp: now.Unix(),
+	}
+	fire := func(result, rule, reason string) *Decision {
+		decision.Result = result
+		decision.Rule = rule
+		decision.Reason = reason
+		return decision
+	}
+
+	for _, window := range e.policy.DeniedTimeWindows {
+		if window.contains(now) {
+			return fire(window.Action, RuleDeniedTime, fmt.Sprintf("in denied time window %v-%v", window.Start, window.End))
+		}
+	}
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	batchValues := make(map[string]float64)
+	for _, swap := range swaps {
+		pairPolicy := e.policy.GetPairPolicy(swap.PairID)
+		if pairPolicy == nil {
+			continue
+		}
+		if len(pairPolicy.AllowedReceivers) > 0 && !containsAddress(pairPolicy.AllowedReceivers, swap.Bind) {
+			return fire(ResultDisagree, RuleAllowedReceiver, fmt.Sprintf("receiver %v of swap %v is not allowed", swap.Bind, swap.SwapID))
+		}
+		if pairPolicy.MaxValuePerSwap > 0 && swap.Value > pairPolicy.MaxValuePerSwap {
+			return fire(pairPolicy.ExceedAction, RuleMaxValuePerSwap, fmt.Sprintf("value %v of swap %v exceeds %v", swap.Value, swap.SwapID, pairPolicy.MaxValuePerSwap))
+		}
+		pairKey := strings.ToLower(swap.PairID)
+		batchValues[pairKey] += swap.Value
+		if pairPolicy.MaxValuePerHour > 0 {
+			hourValue := e.sumAgreedValue(pairKey, keyID, now.Unix()) + batchValues[pairKey]
+			if hourValue > pairPolicy.MaxValuePerHour {
+				return fire(pairPolicy.ExceedAction, RuleMaxValuePerHour, fmt.Sprintf("value %v of pair %v in last hour exceeds %v", hourValue, swap.PairID, pairPolicy.MaxValuePerHour))
+			}
+		}
+		if pairPolicy.MinDepositAge > 0 && (swap.DepositTime == 0 || now.Unix()-swap.DepositTime < pairPolicy.MinDepositAge) {
+			return fire(ResultHold, RuleMinDepositAge, fmt.Sprintf("deposit tx %v is younger than %v seconds", swap.SwapID, pairPolicy.MinDepositAge))
+		}
+		if pairPolicy.ManualApproval {
+			return fire(ResultHold, RuleManualApproval, fmt.Sprintf("pair %v requires manual approval", swap.PairID))
+		}
+	}
+	return decision
+}
+
+// RecordAgreed record agreed swap values to limit value per hour,
+// records of the same keyID are counted only once
+func (e *Engine) RecordAgreed(decision *Decision) {
+	if decision.Result != ResultAgree {
+		return
+	}
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	for _, swap := range decision.Swaps {
+		pairKey := strings.ToLower(swap.PairID)
+		values := e.pruneAgreedValues(pairKey, decision.Timestamp)
+		exist := false
+		for _, v := range values {
+			if v.keyID == decision.KeyID {
+				exist = true
+				break
+			}
+		}
+		if exist {
+			continue
+		}
+		var value float64
+		for _, s := range decision.Swaps {
+			if strings.EqualFold(s.PairID, swap.PairID) {
+				value += s.Value
+			}
+		}
+		e.agreedValues[pairKey] = append(values, &agreedValue{
+			keyID:     decision.KeyID,
+			value:     value,
+			timestamp: decision.Timestamp,
+		})
+	}
+}
+
+func (e *Engine) sumAgreedValue(pairKey, excludeKeyID string, now int64) (sum float64) {
+	for _, v := range e.pruneAgreedValues(pairKey, now) {
+		if v.keyID != excludeKeyID {
+			sum += v.value
+		}
+	}
+	return sum
+}
+
+func (e *Engine) pruneAgreedValues(pairKey string, now int64) []*agreedValue {
+	values := e.agreedValues[pairKey]
+	i := 0
+	for i < len(values) && values[i].timestamp+valueWindow <= now {
+		i++
+	}
+	values = values[i:]
+	e.agreedValues[pairKey] = values
+	return values
+}
+
+func containsAddress(addresses []string, address string) bool {
+	for _, addr := range addresses {
+		if strings.EqualFold(addr, address) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,131 @@
+package acceptpolicy
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+)
+
+func newTestEngine(t *testing.T, policy *Policy) *Engine {
+	if err := policy.CheckPolicy(); err != nil {
+		t.Fatal(err)
+	}
+	return NewEngine(policy)
+}
+
+func TestEvaluate(t *testing.T) {
+	engine := newTestEngine(t, &Policy{
+		DeniedTimeWindows: []*TimeWindow{{Start: "22:00", End: "06:00", Action: "hold"}},
+		Pairs: map[string]*PairPolicy{
+			"ETH": {
+				MaxValuePerSwap:  100,
+				MaxValuePerHour:  150,
+				AllowedReceivers: []string{"0xAbC"},
+				MinDepositAge:    60,
+			},
+			"*": {ManualApproval: true},
+		},
+	})
+
+	now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	newSwap := func(pairID, bind string, value float64) *SwapInfo {
+		return &SwapInfo{PairID: pairID, SwapID: "0x01", Bind: bind, Value: value, DepositTime: now.Unix() - 120}
+	}
+	check := func(decision *Decision, result, rule string) {
+		t.Helper()
+		if decision.Result != result || decision.Rule != rule {
+			t.Fatalf("want %v by %v, have %v by %v (%v)", result, rule, decision.Result, decision.Rule, decision.Reason)
+		}
+	}
+
+	check(engine.Evaluate("k1", []*SwapInfo{newSwap("eth", "0xabc", 80)}, now), ResultAgree, RuleDefault)
+	check(engine.Evaluate("k1", []*SwapInfo{newSwap("eth", "0xabc", 80)}, now.Add(11*time.Hour)), ResultHold, RuleDeniedTime)
+	check(engine.Evaluate("k1", []*SwapInfo{newSwap("eth", "0xdef", 80)}, now), ResultDisagree, RuleAllowedReceiver)
+	check(engine.Evaluate("k1", []*SwapInfo{newSwap("eth", "0xabc", 101)}, now), ResultDisagree, RuleMaxValuePerSwap)
+	check(engine.Evaluate("k1", []*SwapInfo{newSwap("usdt", "0xdef", 1)}, now), ResultHold, RuleManualApproval)
+
+	young := newSwap("eth", "0xabc", 1)
+	young.DepositTime = now.Unix() - 10
+	check(engine.Evaluate("k1", []*SwapInfo{young}, now), ResultHold, RuleMinDepositAge)
+
+	engine.RecordAgreed(engine.Evaluate("k1", []*SwapInfo{newSwap("eth", "0xabc", 80)}, now))
+	// the same keyID is counted only once
+	engine.RecordAgreed(engine.Evaluate("k1", []*SwapInfo{newSwap("eth", "0xabc", 80)}, now))
+	check(engine.Evaluate("k2", []*SwapInfo{newSwap("eth", "0xabc", 60)}, now), ResultAgree, RuleDefault)
+	check(engine.Evaluate("k2", []*SwapInfo{newSwap("eth", "0xabc", 50), newSwap("eth", "0xabc", 30)}, now), ResultDisagree, RuleMaxValuePerHour)
+	check(engine.Evaluate("k2", []*SwapInfo{newSwap("eth", "0xabc", 80)}, now.Add(time.Hour)), ResultAgree, RuleDefault)
+}
+
+func TestTimeWindow(t *testing.T) {
+	window := &TimeWindow{Start: "08:30", End: "09:00", Weekdays: []string{"Monday"}}
+	if err := window.check(); err != nil {
+		t.Fatal(err)
+	}
+	monday := time.Date(2021, 5, 31, 8, 45, 0, 0, time.UTC)
+	if !window.contains(monday) || window.contains(monday.Add(15*time.Minute)) || window.contains(monday.Add(24*time.Hour)) {
+		t.Fatal("wrong time window check")
+	}
+	for _, wrong := range []*TimeWindow{{Start: "24:00", End: "01:00"}, {Start: "01:00", End: "01:00"}, {Start: "01:00", End: "02:00", Action: "pass"}} {
+		if wrong.check() == nil {
+			t.Fatalf("wrong time window %+v should fail check", wrong)
+		}
+	}
+}
+
+func TestManualResult(t *testing.T) {
+	holdDir, err := ioutil.TempDir("", "acceptholds")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(holdDir)
+
+	swaps := []*SwapInfo{
+		{PairID: "pair1", SwapID: "0xaa", SwapType: "swapin", Bind: "0x11"},
+		{PairID: "pair1", SwapID: "0xbb", SwapType: "swapin", Bind: "0x22"},
+	}
+	holdKey := GetHoldKey(swaps[0])
+	if holdKey != "swapin:pair1:0xaa:0x11" {
+		t.Fatalf("wrong hold key %v", holdKey)
+	}
+	if SetManualResult(holdDir, holdKey, ResultAgree) == nil {
+		t.Fatal("manual result of not held swap should fail")
+	}
+	decision := &Decision{KeyID: "0x1234", Result: ResultHold, Swaps: swaps, Timestamp: time.Now().Unix()}
+	if err = SaveHold(holdDir, decision); err != nil {
+		t.Fatal(err)
+	}
+	// hold of the same swap signed with new keyID is not changed
+	if err = SaveHold(holdDir, &Decision{KeyID: "0x5678", Result: ResultHold, Swaps: swaps[:1], Timestamp: time.Now().Unix()}); err != nil {
+		t.Fatal(err)
+	}
+	holds, _ := LoadHolds(holdDir)
+	if len(holds) != 2 || holds[0].Decision.KeyID != "0x1234" || holds[1].Decision.KeyID != "0x1234" {
+		t.Fatalf("wrong held swaps %v", holds)
+	}
+	if err = SetManualResult(holdDir, holdKey, "agree"); err != nil {
+		t.Fatal(err)
+	}
+	if result := GetManualResult(holdDir, holdKey); result != ResultAgree {
+		t.Fatalf("wrong manual result %v", result)
+	}
+	if result := GetSwapsManualResult(holdDir, swaps); result != "" {
+		t.Fatalf("swaps should not be decided if any swap is not decided, result %v", result)
+	}
+	if result := GetSwapsManualResult(holdDir, swaps[:1]); result != ResultAgree {
+		t.Fatalf("wrong manual result of swaps %v", result)
+	}
+	if err = SetManualResult(holdDir, GetHoldKey(swaps[1]), ResultDisagree); err != nil {
+		t.Fatal(err)
+	}
+	if result := GetSwapsManualResult(holdDir, swaps); result != ResultDisagree {
+		t.Fatalf("wrong manual result of swaps %v", result)
+	}
+	if SaveHold(holdDir, &Decision{Swaps: []*SwapInfo{{SwapID: "../x"}}}) == nil {
+		t.Fatal("invalid hold key should fail")
+	}
+	RemoveSwapsHolds(holdDir, swaps)
+	if holds, _ := LoadHolds(holdDir); len(holds) != 0 || GetManualResult(holdDir, holdKey) != "" {
+		t.Fatal("remove hold failed")
+	}
+}
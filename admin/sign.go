@@ -4,14 +4,16 @@ package admin
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"math/big"
 	"time"
 
 	"github.com/anyswap/CrossChain-Bridge/common"
 	"github.com/anyswap/CrossChain-Bridge/common/hexutil"
 	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/signer"
 	"github.com/anyswap/CrossChain-Bridge/tools"
-	"github.com/anyswap/CrossChain-Bridge/tools/keystore"
+	"github.com/anyswap/CrossChain-Bridge/tools/crypto"
 	"github.com/anyswap/CrossChain-Bridge/tools/rlp"
 	"github.com/anyswap/CrossChain-Bridge/types"
 )
@@ -27,7 +29,9 @@ var (
 	adminSigner = types.MakeSigner("EIP155", big.NewInt(swapAdminChainID))
 	adminToAddr = common.HexToAddress(swapAdminToAddress)
 
-	keyWrapper *keystore.Key
+	// admin key signs admin txs through the signer interface as swap txs do
+	keySigner signer.Signer
+	keyPubkey string
 
 	// admin tx lifetime
 	maxExpireSeconds int64 = 120
@@ -58,7 +62,18 @@ func Sign(method string, params []string) (rawTx string, err error) {
 		payload,       // data
 	)
 
-	signedTx, err := types.SignTx(tx, adminSigner, keyWrapper.PrivateKey)
+	if keySigner == nil {
+		return "", errors.New("admin key is not loaded")
+	}
+	msgHash := adminSigner.Hash(tx).String()
+	rsvs, err := keySigner.Sign(keyPubkey, []string{msgHash}, []string{string(payload)})
+	if err != nil {
+		return "", err
+	}
+	if len(rsvs) != 1 {
+		return "", fmt.Errorf("sign require one rsv but have %v", len(rsvs))
+	}
+	signedTx, err := tx.WithSignature(adminSigner, common.FromHex(rsvs[0]))
 	if err != nil {
 		return "", err
 	}
@@ -77,8 +92,13 @@ func LoadKeyStore(keyfile, passfile string) error {
 	if err != nil {
 		return err
 	}
-	keyWrapper = key
-	log.Info("[admin] load keystore success", "address", keyWrapper.Address.String())
+	s, err := signer.NewKeystoreSigner(key.PrivateKey)
+	if err != nil {
+		return err
+	}
+	keySigner = s
+	keyPubkey = common.ToHex(crypto.FromECDSAPub(&key.PrivateKey.PublicKey))
+	log.Info("[admin] load keystore success", "address", key.Address.String())
 	return nil
 }
 
@@ -0,0 +1,38 @@
+package admin
+
+import (
+	"testing"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/signer"
+	"github.com/anyswap/CrossChain-Bridge/tools/crypto"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	privKey, _ := crypto.GenerateKey()
+	s, err := signer.NewKeystoreSigner(privKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keySigner = s
+	keyPubkey = common.ToHex(crypto.FromECDSAPub(&privKey.PublicKey))
+
+	rawTx, err := Sign("maintain", []string{"open", "bidirection"})
+	if err != nil {
+		t.Fatalf("admin sign failed: %v", err)
+	}
+	tx, err := DecodeTransaction(rawTx)
+	if err != nil {
+		t.Fatalf("decode admin tx failed: %v", err)
+	}
+	sender, args, err := VerifyTransaction(tx)
+	if err != nil {
+		t.Fatalf("verify admin tx failed: %v", err)
+	}
+	if *sender != crypto.PubkeyToAddress(privKey.PublicKey) {
+		t.Errorf("admin tx sender mismatch, have %v", sender.String())
+	}
+	if args.Method != "maintain" || len(args.Params) != 2 {
+		t.Errorf("wrong admin call args %+v", args)
+	}
+}
@@ -0,0 +1,73 @@
+// Command dcrmmock run an in-process mock dcrm network for local and CI testing.
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/anyswap/CrossChain-Bridge/cmd/utils"
+	"github.com/anyswap/CrossChain-Bridge/dcrm/mock"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	clientIdentifier = "dcrmmock"
+	// Git SHA1 commit hash of the release (set via linker flags)
+	gitCommit = ""
+	gitDate   = ""
+	// The app that holds all commands and flags.
+	app = utils.NewApp(clientIdentifier, gitCommit, gitDate, "the mock dcrm network command line interface")
+)
+
+func initApp() {
+	// Initialize the CLI app and start action
+	app.Action = dcrmmock
+	app.HideVersion = true // we have a command to print the version
+	app.Copyright = "Copyright 2017-2020 The CrossChain-Bridge Authors"
+	app.Commands = []*cli.Command{
+		utils.LicenseCommand,
+		utils.VersionCommand,
+	}
+	app.Flags = []cli.Flag{
+		utils.ConfigFileFlag,
+		utils.LogFileFlag,
+		utils.LogRotationFlag,
+		utils.LogMaxAgeFlag,
+		utils.VerbosityFlag,
+		utils.JSONFormatFlag,
+		utils.ColorFormatFlag,
+	}
+}
+
+func main() {
+	initApp()
+	if err := app.Run(os.Args); err != nil {
+		log.Println(err)
+		os.Exit(1)
+	}
+}
+
+func dcrmmock(ctx *cli.Context) error {
+	utils.SetLogger(ctx)
+	if ctx.NArg() > 0 {
+		return fmt.Errorf("invalid command: %q", ctx.Args().Get(0))
+	}
+
+	config, err := mock.LoadConfig(utils.GetConfigFilePath(ctx))
+	if err != nil {
+		return err
+	}
+	network, err := mock.NewNetwork(config)
+	if err != nil {
+		return err
+	}
+
+	baseURL := fmt.Sprintf("http://127.0.0.1:%d", config.Port)
+	for i := 0; i < network.GetNodeCount(); i++ {
+		log.Info("mock dcrm node", "index", i, "rpcAddress", mock.NodeRPCAddress(baseURL, i), "enode", network.GetEnode(i))
+	}
+	log.Info("mock dcrm network started", "port", config.Port)
+	return http.ListenAndServe(fmt.Sprintf(":%d", config.Port), network.Handler())
+}
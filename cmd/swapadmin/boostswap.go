@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/anyswap/CrossChain-Bridge/cmd/utils"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	boostswapCommand = &cli.Command{
+		Action:    boostswap,
+		Name:      "boostswap",
+		Usage:     "admin boost swap",
+		ArgsUsage: "<swapin|swapout> <txid> <pairID> <bind>",
+		Description: `
+admin boost swap, the boosted swap is served before the others in swap queue
+`,
+		Flags: commonAdminFlags,
+	}
+)
+
+func boostswap(ctx *cli.Context) error {
+	utils.SetLogger(ctx)
+	method := "boostswap"
+	if ctx.NArg() != 4 {
+		_ = cli.ShowCommandHelp(ctx, method)
+		fmt.Println()
+		return fmt.Errorf("invalid arguments: %q", ctx.Args())
+	}
+	return reverifyOrReswap(ctx, method)
+}
@@ -30,10 +30,14 @@ func initApp() {
 		blacklistCommand,
 		reverifyCommand,
 		reswapCommand,
+		boostswapCommand,
 		replaceswapCommand,
 		manualCommand,
 		setnonceCommand,
 		addpairCommand,
+		psbtCommand,
+		webhookCommand,
+		refundCommand,
 		utils.LicenseCommand,
 		utils.VersionCommand,
 	}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Bridge/cmd/utils"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	exportSwapoutPsbtOp   = "exportswapout"
+	exportAggregatePsbtOp = "exportaggregate"
+	importPsbtOp          = "import"
+)
+
+var (
+	psbtCommand = &cli.Command{
+		Action:    psbt,
+		Name:      "psbt",
+		Usage:     "admin export or import psbt of utxo chains",
+		ArgsUsage: "<exportswapout <txid> <pairID> <bind>|exportaggregate <p2shAddress>|import <psbt|@psbtFile>>",
+		Description: `
+export unsigned swapout or aggregate tx as BIP174 psbt (base64 encoded) for cold wallet signing,
+or import signed psbt, verify and broadcast it.
+please close the swap by 'maintain' command before export to prevent swapping it at the same time.
+`,
+		Flags: commonAdminFlags,
+	}
+)
+
+func psbt(ctx *cli.Context) error {
+	utils.SetLogger(ctx)
+	method := "psbt"
+	if ctx.NArg() == 0 {
+		_ = cli.ShowCommandHelp(ctx, method)
+		fmt.Println()
+		return fmt.Errorf("invalid arguments: %q", ctx.Args())
+	}
+
+	operation := ctx.Args().Get(0)
+	var wantArgs int
+	switch operation {
+	case exportSwapoutPsbtOp:
+		wantArgs = 4
+	case exportAggregatePsbtOp, importPsbtOp:
+		wantArgs = 2
+	default:
+		return fmt.Errorf("unknown operation '%v'", operation)
+	}
+	if ctx.NArg() != wantArgs {
+		_ = cli.ShowCommandHelp(ctx, method)
+		fmt.Println()
+		return fmt.Errorf("invalid number arguments: %q", ctx.Args())
+	}
+
+	err := prepare(ctx)
+	if err != nil {
+		return err
+	}
+
+	params := ctx.Args().Slice()
+	if operation == importPsbtOp && strings.HasPrefix(params[1], "@") {
+		content, errf := ioutil.ReadFile(params[1][1:])
+		if errf != nil {
+			return errf
+		}
+		params[1] = strings.TrimSpace(string(content))
+	}
+
+	log.Printf("admin %v: %v", method, operation)
+
+	result, err := adminCall(method, params)
+
+	log.Printf("result is '%v'", result)
+	return err
+}
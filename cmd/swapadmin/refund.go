@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/anyswap/CrossChain-Bridge/cmd/utils"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	refundCommand = &cli.Command{
+		Action:    refund,
+		Name:      "refund",
+		Usage:     "admin refund swapin which can never be swapped",
+		ArgsUsage: "<mark|retry|fail> <txid> <pairID> <bind>",
+		Description: `
+mark: mark swapin refundable, the deposit (minus refund fee) is returned to its sender on source chain
+retry: refund again after the refund tx is failed
+fail: mark refund failed after the refund tx is dropped (nonce passed, or inputs spent by other tx, or tx missing)
+`,
+		Flags: commonAdminFlags,
+	}
+)
+
+func refund(ctx *cli.Context) error {
+	utils.SetLogger(ctx)
+	method := "refund"
+	if ctx.NArg() != 4 {
+		_ = cli.ShowCommandHelp(ctx, method)
+		fmt.Println()
+		return fmt.Errorf("invalid arguments: %q", ctx.Args())
+	}
+
+	operation := ctx.Args().Get(0)
+	txid := ctx.Args().Get(1)
+	pairID := ctx.Args().Get(2)
+	bind := ctx.Args().Get(3)
+
+	switch operation {
+	case "mark", "retry", "fail":
+	default:
+		return fmt.Errorf("unknown operation '%v'", operation)
+	}
+
+	err := prepare(ctx)
+	if err != nil {
+		return err
+	}
+
+	params := []string{operation, txid, pairID, bind}
+	log.Printf("admin %v: %v %v %v %v", method, operation, txid, pairID, bind)
+
+	result, err := adminCall(method, params)
+
+	log.Printf("result is '%v'", result)
+	return err
+}
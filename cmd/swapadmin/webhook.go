@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/anyswap/CrossChain-Bridge/cmd/utils"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	webhookCommand = &cli.Command{
+		Action:    webhook,
+		Name:      "webhook",
+		Usage:     "admin query or retry webhook deliveries",
+		ArgsUsage: "<status|query <txid>|retry <deliveryID>>",
+		Description: `
+status: show pending, delivered and failed delivery counts of every webhook
+query: show deliveries of swap txid
+retry: deliver failed delivery again
+`,
+		Flags: commonAdminFlags,
+	}
+)
+
+func webhook(ctx *cli.Context) error {
+	utils.SetLogger(ctx)
+	method := "webhook"
+	if ctx.NArg() == 0 {
+		_ = cli.ShowCommandHelp(ctx, method)
+		fmt.Println()
+		return fmt.Errorf("invalid arguments: %q", ctx.Args())
+	}
+
+	operation := ctx.Args().Get(0)
+	var wantArgs int
+	switch operation {
+	case "status":
+		wantArgs = 1
+	case "query", "retry":
+		wantArgs = 2
+	default:
+		return fmt.Errorf("unknown operation '%v'", operation)
+	}
+	if ctx.NArg() != wantArgs {
+		_ = cli.ShowCommandHelp(ctx, method)
+		fmt.Println()
+		return fmt.Errorf("invalid number arguments: %q", ctx.Args())
+	}
+
+	err := prepare(ctx)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("admin %v: %v", method, operation)
+
+	result, err := adminCall(method, ctx.Args().Slice())
+
+	log.Printf("result is '%v'", result)
+	return err
+}
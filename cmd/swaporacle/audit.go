@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/admin"
+	"github.com/anyswap/CrossChain-Bridge/cmd/utils"
+	"github.com/urfave/cli/v2"
+)
+
+const acceptAuditAdminMethod = "acceptaudit"
+
+var (
+	auditAPIFlag = &cli.StringFlag{
+		Name:  "api",
+		Usage: "local accept audit API address of oracle (config 'AuditAPIAddress')",
+		Value: "127.0.0.1:11557",
+	}
+	auditTxIDFlag = &cli.StringFlag{
+		Name:  "txid",
+		Usage: "swap txid or sign keyID",
+	}
+	auditPairIDFlag = &cli.StringFlag{
+		Name:  "pairid",
+		Usage: "token pair ID",
+	}
+	auditDecisionFlag = &cli.StringFlag{
+		Name:  "decision",
+		Usage: "decision (AGREE, DISAGREE, HOLD or DISCARD)",
+	}
+	auditStartFlag = &cli.StringFlag{
+		Name:  "start",
+		Usage: "start of decision time (unix seconds or RFC3339), inclusive",
+	}
+	auditEndFlag = &cli.StringFlag{
+		Name:  "end",
+		Usage: "end of decision time (unix seconds or RFC3339), exclusive",
+	}
+	auditLimitFlag = &cli.IntFlag{
+		Name:  "limit",
+		Usage: "maximum number of the latest records (0 means default, negative means no limit)",
+	}
+	auditExportFlag = &cli.StringFlag{
+		Name:  "export",
+		Usage: "export all matched records to JSONL file",
+	}
+
+	auditCommand = &cli.Command{
+		Action: queryAcceptAudit,
+		Name:   "audit",
+		Usage:  "query accept audit records of running oracle",
+		Description: `
+query accept audit records of running oracle by txid, pairid, decision or time range,
+and export them to JSONL file, the query is signed by admin keystore
+`,
+		Flags: []cli.Flag{
+			utils.KeystoreFileFlag,
+			utils.PasswordFileFlag,
+			auditAPIFlag,
+			auditTxIDFlag,
+			auditPairIDFlag,
+			auditDecisionFlag,
+			auditStartFlag,
+			auditEndFlag,
+			auditLimitFlag,
+			auditExportFlag,
+		},
+	}
+)
+
+func loadAdminKeyStore(ctx *cli.Context) error {
+	keyfile := ctx.String(utils.KeystoreFileFlag.Name)
+	passfile := ctx.String(utils.PasswordFileFlag.Name)
+	return admin.LoadKeyStore(keyfile, passfile)
+}
+
+// getAuditAPIURL get url of audit API with the query signed by admin
+func getAuditAPIURL(apiAddress string, query url.Values) (string, error) {
+	if !strings.Contains(apiAddress, "://") {
+		apiAddress = "http://" + apiAddress
+	}
+	rawTx, err := admin.Sign(acceptAuditAdminMethod, []string{query.Encode()})
+	if err != nil {
+		return "", err
+	}
+	return apiAddress + "/accept/audit?" + url.Values{"rawtx": {rawTx}}.Encode(), nil
+}
+
+func queryAcceptAudit(ctx *cli.Context) error {
+	if err := loadAdminKeyStore(ctx); err != nil {
+		return err
+	}
+	query := url.Values{}
+	for _, flag := range []*cli.StringFlag{auditTxIDFlag, auditPairIDFlag, auditDecisionFlag, auditStartFlag, auditEndFlag} {
+		if value := ctx.String(flag.Name); value != "" {
+			query.Set(flag.Name, value)
+		}
+	}
+	if ctx.IsSet(auditLimitFlag.Name) {
+		query.Set("limit", fmt.Sprint(ctx.Int(auditLimitFlag.Name)))
+	}
+	exportFile := ctx.String(auditExportFlag.Name)
+	if exportFile != "" {
+		query.Set("format", "jsonl")
+	}
+
+	apiURL, err := getAuditAPIURL(ctx.String(auditAPIFlag.Name), query)
+	if err != nil {
+		return err
+	}
+	client := &http.Client{Timeout: 60 * time.Second}
+	resp, err := client.Get(apiURL)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("query accept audit failed: %v %v", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	if exportFile == "" {
+		_, err = io.Copy(os.Stdout, resp.Body)
+		return err
+	}
+	file, err := os.Create(exportFile)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if _, err = io.Copy(file, resp.Body); err != nil {
+		return err
+	}
+	fmt.Printf("export accept audit records to %v success\n", exportFile)
+	return nil
+}
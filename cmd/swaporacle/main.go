@@ -20,6 +20,11 @@ var (
 	gitDate   = ""
 	// The app that holds all commands and flags.
 	app = utils.NewApp(clientIdentifier, gitCommit, gitDate, "the swaporacle command line interface")
+
+	shadowFlag = &cli.BoolFlag{
+		Name:  "shadow",
+		Usage: "run in shadow mode, only record decisions without accepting the sign requests got from live oracle (config 'ShadowSource')",
+	}
 )
 
 func initApp() {
@@ -30,6 +35,9 @@ func initApp() {
 	app.Commands = []*cli.Command{
 		utils.LicenseCommand,
 		utils.VersionCommand,
+		policyCommand,
+		auditCommand,
+		shadowReportCommand,
 	}
 	app.Flags = []cli.Flag{
 		utils.DataDirFlag,
@@ -41,6 +49,7 @@ func initApp() {
 		utils.VerbosityFlag,
 		utils.JSONFormatFlag,
 		utils.ColorFormatFlag,
+		shadowFlag,
 	}
 }
 
@@ -60,6 +69,12 @@ func swaporacle(ctx *cli.Context) error {
 	params.SetDataDir(utils.GetDataDir(ctx))
 	configFile := utils.GetConfigFilePath(ctx)
 	params.LoadConfig(configFile, false)
+	if ctx.Bool(shadowFlag.Name) {
+		params.GetOracleConfig().ShadowMode = true
+	}
+	if params.IsOracleShadowMode() {
+		log.Info("swaporacle is running in shadow mode")
+	}
 
 	tokens.SetTokenPairsDir(utils.GetTokenPairsDir(ctx))
 
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Bridge/acceptpolicy"
+	"github.com/anyswap/CrossChain-Bridge/cmd/utils"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	policyCommand = &cli.Command{
+		Name:  "policy",
+		Usage: "manage swaps held by accept policy",
+		Description: `
+list held swaps and make manual decisions,
+which are applied by the running oracle in its next accept round,
+the swap is specified by its hold key (swapType:pairID:swapID:bind)
+`,
+		Subcommands: []*cli.Command{
+			{
+				Action:      listHolds,
+				Name:        "list",
+				Usage:       "list held swaps",
+				Flags:       []cli.Flag{utils.DataDirFlag},
+				Description: "list held swaps with the rules fired",
+			},
+			{
+				Action:      agreeHold,
+				Name:        "agree",
+				Usage:       "manual AGREE held swap",
+				ArgsUsage:   "<holdKey>",
+				Flags:       []cli.Flag{utils.DataDirFlag},
+				Description: "manual AGREE held swap",
+			},
+			{
+				Action:      disagreeHold,
+				Name:        "disagree",
+				Usage:       "manual DISAGREE held swap",
+				ArgsUsage:   "<holdKey>",
+				Flags:       []cli.Flag{utils.DataDirFlag},
+				Description: "manual DISAGREE held swap",
+			},
+		},
+	}
+)
+
+func getHoldDir(ctx *cli.Context) (string, error) {
+	dataDir := utils.GetDataDir(ctx)
+	if dataDir == "" {
+		return "", errors.New("must specify data dir of oracle")
+	}
+	return acceptpolicy.GetHoldDir(dataDir), nil
+}
+
+func listHolds(ctx *cli.Context) error {
+	holdDir, err := getHoldDir(ctx)
+	if err != nil {
+		return err
+	}
+	holds, err := acceptpolicy.LoadHolds(holdDir)
+	if err != nil {
+		return err
+	}
+	for _, hold := range holds {
+		data, _ := json.MarshalIndent(hold, "", "  ")
+		fmt.Println(string(data))
+		if manualResult := acceptpolicy.GetManualResult(holdDir, hold.HoldKey); manualResult != "" {
+			fmt.Printf("manual result: %v (to be applied)\n", manualResult)
+		}
+	}
+	fmt.Printf("%v held swaps\n", len(holds))
+	return nil
+}
+
+func agreeHold(ctx *cli.Context) error {
+	return setManualResult(ctx, acceptpolicy.ResultAgree)
+}
+
+func disagreeHold(ctx *cli.Context) error {
+	return setManualResult(ctx, acceptpolicy.ResultDisagree)
+}
+
+func setManualResult(ctx *cli.Context, result string) error {
+	if ctx.NArg() != 1 {
+		return fmt.Errorf("invalid arguments: %q", ctx.Args())
+	}
+	holdDir, err := getHoldDir(ctx)
+	if err != nil {
+		return err
+	}
+	holdKey := ctx.Args().Get(0)
+	err = acceptpolicy.SetManualResult(holdDir, holdKey, result)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("manual %v swap %v, it will be applied in next accept round\n", strings.ToUpper(result), holdKey)
+	return nil
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/cmd/utils"
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/worker"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	shadowSourceFlag = &cli.StringFlag{
+		Name:     "shadow",
+		Usage:    "audit API address or exported JSONL file of shadow oracle",
+		Required: true,
+	}
+	realSourceFlag = &cli.StringFlag{
+		Name:     "real",
+		Usage:    "audit API address or exported JSONL file of real oracle",
+		Required: true,
+	}
+
+	shadowReportCommand = &cli.Command{
+		Action: shadowReport,
+		Name:   "shadowreport",
+		Usage:  "compare decisions of shadow oracle with real oracle",
+		Description: `
+compare accept audit records of shadow oracle with the real oracle's,
+and report the sign requests they decided differently,
+the query of audit API is signed by admin keystore
+`,
+		Flags: []cli.Flag{
+			utils.KeystoreFileFlag,
+			utils.PasswordFileFlag,
+			shadowSourceFlag,
+			realSourceFlag,
+			auditStartFlag,
+			auditEndFlag,
+		},
+	}
+)
+
+// shadowDiff sign request decided differently
+type shadowDiff struct {
+	KeyID          string   `json:"keyID"`
+	SwapIDs        []string `json:"swapIDs,omitempty"`
+	ShadowDecision string   `json:"shadowDecision"`
+	ShadowReason   string   `json:"shadowReason,omitempty"`
+	RealDecision   string   `json:"realDecision"`
+	RealReason     string   `json:"realReason,omitempty"`
+}
+
+// shadowReportResult comparison report
+type shadowReportResult struct {
+	ShadowCount int           `json:"shadowCount"`
+	RealCount   int           `json:"realCount"`
+	Matched     int           `json:"matched"`
+	Mismatched  []*shadowDiff `json:"mismatched"`
+	ShadowOnly  []string      `json:"shadowOnly"` // keyIDs
+	RealOnly    []string      `json:"realOnly"`   // keyIDs
+}
+
+func shadowReport(ctx *cli.Context) error {
+	query := url.Values{}
+	query.Set("format", "jsonl")
+	for _, flag := range []*cli.StringFlag{auditStartFlag, auditEndFlag} {
+		if value := ctx.String(flag.Name); value != "" {
+			query.Set(flag.Name, value)
+		}
+	}
+	if !common.FileExist(ctx.String(shadowSourceFlag.Name)) || !common.FileExist(ctx.String(realSourceFlag.Name)) {
+		if err := loadAdminKeyStore(ctx); err != nil {
+			return err
+		}
+	}
+	shadowRecords, err := loadAuditRecords(ctx.String(shadowSourceFlag.Name), query)
+	if err != nil {
+		return fmt.Errorf("load shadow records failed: %w", err)
+	}
+	realRecords, err := loadAuditRecords(ctx.String(realSourceFlag.Name), query)
+	if err != nil {
+		return fmt.Errorf("load real records failed: %w", err)
+	}
+	result := compareAuditRecords(shadowRecords, realRecords)
+	data, _ := json.MarshalIndent(result, "", "  ")
+	fmt.Println(string(data))
+	return nil
+}
+
+// loadAuditRecords load from JSONL file if exist, otherwise query the audit API
+func loadAuditRecords(source string, query url.Values) ([]*worker.AcceptAuditRecord, error) {
+	var reader io.Reader
+	if common.FileExist(source) {
+		file, err := os.Open(source)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		reader = file
+	} else {
+		apiURL, err := getAuditAPIURL(source, query)
+		if err != nil {
+			return nil, err
+		}
+		client := &http.Client{Timeout: 60 * time.Second}
+		resp, err := client.Get(apiURL)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("query accept audit failed: %v", resp.Status)
+		}
+		reader = resp.Body
+	}
+
+	var records []*worker.AcceptAuditRecord
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		record := &worker.AcceptAuditRecord{}
+		if err := json.Unmarshal([]byte(line), record); err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, scanner.Err()
+}
+
+func compareAuditRecords(shadowRecords, realRecords []*worker.AcceptAuditRecord) *shadowReportResult {
+	result := &shadowReportResult{
+		ShadowCount: len(shadowRecords),
+		RealCount:   len(realRecords),
+		Mismatched:  []*shadowDiff{},
+		ShadowOnly:  []string{},
+		RealOnly:    []string{},
+	}
+	realMap := make(map[string]*worker.AcceptAuditRecord, len(realRecords))
+	for _, record := range realRecords {
+		realMap[strings.ToLower(record.KeyID)] = record
+	}
+	for _, shadow := range shadowRecords {
+		key := strings.ToLower(shadow.KeyID)
+		realRecord, exist := realMap[key]
+		if !exist {
+			result.ShadowOnly = append(result.ShadowOnly, shadow.KeyID)
+			continue
+		}
+		delete(realMap, key)
+		if strings.EqualFold(shadow.Decision, realRecord.Decision) {
+			result.Matched++
+			continue
+		}
+		diff := &shadowDiff{
+			KeyID:          shadow.KeyID,
+			ShadowDecision: shadow.Decision,
+			ShadowReason:   shadow.Reason,
+			RealDecision:   realRecord.Decision,
+			RealReason:     realRecord.Reason,
+		}
+		for _, swap := range shadow.Swaps {
+			diff.SwapIDs = append(diff.SwapIDs, swap.SwapID)
+		}
+		result.Mismatched = append(result.Mismatched, diff)
+	}
+	for _, realRecord := range realMap {
+		result.RealOnly = append(result.RealOnly, realRecord.KeyID)
+	}
+	sort.Strings(result.RealOnly)
+	return result
+}
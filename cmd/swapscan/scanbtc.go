@@ -173,7 +173,7 @@ func (scanner *btcSwapScanner) initBridge() {
 		Confirmations: &scanner.stableHeight,
 	}
 	pairConfig := &tokens.TokenPairConfig{
-		PairID: btc.PairID,
+		PairID: btc.BitcoinParams.PairID,
 		SrcToken: &tokens.TokenConfig{
 			ID:             "BTC",
 			Name:           "BTC",
@@ -183,7 +183,7 @@ func (scanner *btcSwapScanner) initBridge() {
 		},
 	}
 	pairsConfig := make(map[string]*tokens.TokenPairConfig)
-	pairsConfig[btc.PairID] = pairConfig
+	pairsConfig[btc.BitcoinParams.PairID] = pairConfig
 	tokens.SetTokenPairsConfig(pairsConfig, false)
 	tokens.SrcBridge = scanner.bridge
 	tokens.DstBridge = eth.NewCrossChainBridge(false)
@@ -347,10 +347,10 @@ func (scanner *btcSwapScanner) processTx(tx *electrs.ElectTx) {
 		if !bindOk {
 			return
 		}
-		log.Info("post swapin register", "txid", txid, "pairid", btc.PairID, "bind", bindAddress)
+		log.Info("post swapin register", "txid", txid, "pairid", btc.BitcoinParams.PairID, "bind", bindAddress)
 		args := map[string]interface{}{
 			"txid":   txid,
-			"pairid": btc.PairID,
+			"pairid": btc.BitcoinParams.PairID,
 		}
 		var result interface{}
 		for i := 0; i < scanner.rpcRetryCount; i++ {
@@ -10,11 +10,11 @@ import (
 	"github.com/anyswap/CrossChain-Bridge/common"
 	"github.com/anyswap/CrossChain-Bridge/log"
 	"github.com/anyswap/CrossChain-Bridge/tokens"
-	"github.com/anyswap/CrossChain-Bridge/tokens/ltc"
+	"github.com/anyswap/CrossChain-Bridge/tokens/btc"
 	"github.com/anyswap/CrossChain-Bridge/tools"
-	"github.com/ltcsuite/ltcd/btcec"
-	"github.com/ltcsuite/ltcutil"
-	"github.com/ltcsuite/ltcutil/base58"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcutil"
+	"github.com/btcsuite/btcutil/base58"
 	"github.com/urfave/cli/v2"
 )
 
@@ -61,7 +61,7 @@ type ltcTxSender struct {
 }
 
 var (
-	ltcBridge *ltc.Bridge
+	ltcBridge *btc.Bridge
 	ltcSender = &ltcTxSender{}
 )
 
@@ -116,7 +116,7 @@ func sendLtc(ctx *cli.Context) error {
 	}
 	log.Info("SignTransaction success", "txHash", txHash)
 
-	fmt.Println(ltc.AuthoredTxToString(signedTx, true))
+	fmt.Println(btc.AuthoredTxToString(signedTx, true))
 
 	if !ltcSender.dryRun {
 		_, err = ltcBridge.SendTransaction(signedTx)
@@ -130,7 +130,7 @@ func sendLtc(ctx *cli.Context) error {
 }
 
 func (bts *ltcTxSender) initBridge() {
-	ltcBridge = ltc.NewCrossChainBridge(true)
+	ltcBridge = btc.NewCrossChainBridgeWithParams(true, btc.LitecoinParams)
 	ltcBridge.ChainConfig = &tokens.ChainConfig{
 		BlockChain: "Litecoin",
 		NetID:      bts.netID,
@@ -167,13 +167,13 @@ func (bts *ltcTxSender) loadWIFForAddress() string {
 			}
 		}
 		pri, _ := btcec.PrivKeyFromBytes(btcec.S256(), pribs)
-		wif, err := ltcutil.NewWIF(pri, ltcBridge.GetChainParams(), true)
+		wif, err := btcutil.NewWIF(pri, ltcBridge.GetChainParams(), true)
 		if err != nil {
 			log.Fatal("failed to parse private key")
 		}
 		wifStr = wif.String()
 	}
-	wif, err := ltcutil.DecodeWIF(wifStr)
+	wif, err := btcutil.DecodeWIF(wifStr)
 	if err != nil {
 		log.Fatal("failed to decode WIF to verify")
 	}
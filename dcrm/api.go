@@ -66,7 +66,8 @@ func GetSignNonce(dcrmUser, rpcAddr string) (uint64, error) {
 	return bi.Uint64(), nil
 }
 
-// GetSignStatus call getSignStatus
+// GetSignStatus call getSignStatus,
+// sign status is also returned if sign is failed or timeout
 func GetSignStatus(key, rpcAddr string) (*SignStatus, error) {
 	var result DataResultResp
 	err := httpPostTo(&result, rpcAddr, "getSignStatus", key)
@@ -85,10 +86,10 @@ func GetSignStatus(key, rpcAddr string) (*SignStatus, error) {
 	switch signStatus.Status {
 	case "Failure":
 		log.Info("getSignStatus Failure", "keyID", key, "status", data)
-		return nil, ErrGetSignStatusFailed
+		return &signStatus, ErrGetSignStatusFailed
 	case "Timeout":
 		log.Info("getSignStatus Timeout", "keyID", key, "status", data)
-		return nil, ErrGetSignStatusTimeout
+		return &signStatus, ErrGetSignStatusTimeout
 	case successStatus:
 		return &signStatus, nil
 	default:
@@ -0,0 +1,105 @@
+// Package mock implements an in-process mock dcrm network for local and CI testing.
+package mock
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/BurntSushi/toml"
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+const (
+	defaultAPIPrefix   = "dcrm_"
+	defaultSignTimeout = 120 // seconds
+)
+
+// Config mock dcrm network config
+type Config struct {
+	Port        int
+	APIPrefix   string
+	SignTimeout uint64 // seconds
+	NodeCount   int
+	Groups      []*GroupConfig
+	Keys        []*KeyConfig
+
+	// simulate failures, the ratio in range [0, 1)
+	FailSignRate    float64
+	TimeoutSignRate float64
+}
+
+// GroupConfig group config, 'Members' are the indexes of nodes
+type GroupConfig struct {
+	GroupID string
+	Members []int
+}
+
+// KeyConfig key config, 'PrivateKey' is hex string of ECDSA private key or ED25519 seed
+type KeyConfig struct {
+	KeyType    string
+	PrivateKey string `json:"-"`
+}
+
+// LoadConfig load mock dcrm config file
+func LoadConfig(configFile string) (*Config, error) {
+	config := &Config{}
+	if _, err := toml.DecodeFile(configFile, &config); err != nil {
+		return nil, err
+	}
+	if err := config.CheckConfig(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// CheckConfig check config
+func (c *Config) CheckConfig() error {
+	if c.APIPrefix == "" {
+		c.APIPrefix = defaultAPIPrefix
+	}
+	if c.SignTimeout == 0 {
+		c.SignTimeout = defaultSignTimeout
+	}
+	if c.NodeCount <= 0 {
+		return errors.New("mock dcrm must config positive 'NodeCount'")
+	}
+	if len(c.Groups) == 0 {
+		return errors.New("mock dcrm must config 'Groups'")
+	}
+	groupIDs := make(map[string]struct{}, len(c.Groups))
+	for _, group := range c.Groups {
+		if group.GroupID == "" || len(group.Members) == 0 {
+			return errors.New("mock dcrm group must config 'GroupID' and 'Members'")
+		}
+		if _, exist := groupIDs[group.GroupID]; exist {
+			return fmt.Errorf("duplicate group %v", group.GroupID)
+		}
+		groupIDs[group.GroupID] = struct{}{}
+		for _, member := range group.Members {
+			if member < 0 || member >= c.NodeCount {
+				return fmt.Errorf("group %v has wrong member index %v", group.GroupID, member)
+			}
+		}
+	}
+	if len(c.Keys) == 0 {
+		return errors.New("mock dcrm must config 'Keys'")
+	}
+	for _, key := range c.Keys {
+		if key.KeyType == "" {
+			key.KeyType = tokens.KeyTypeECDSA
+		}
+		switch key.KeyType {
+		case tokens.KeyTypeECDSA, tokens.KeyTypeED25519:
+		default:
+			return fmt.Errorf("unknown key type %v", key.KeyType)
+		}
+		if len(common.FromHex(key.PrivateKey)) != 32 {
+			return errors.New("mock dcrm key must config 32 bytes hex 'PrivateKey'")
+		}
+	}
+	if c.FailSignRate < 0 || c.TimeoutSignRate < 0 || c.FailSignRate+c.TimeoutSignRate >= 1 {
+		return errors.New("mock dcrm wrong 'FailSignRate' or 'TimeoutSignRate'")
+	}
+	return nil
+}
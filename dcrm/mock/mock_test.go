@@ -0,0 +1,216 @@
+package mock
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/dcrm"
+	"github.com/anyswap/CrossChain-Bridge/rpc/client"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tools/crypto"
+	"github.com/anyswap/CrossChain-Bridge/tools/keystore"
+)
+
+const (
+	testGroupID  = "testgroup"
+	testECDSAKey = "0x1111111111111111111111111111111111111111111111111111111111111111"
+	testEDDSAKey = "0x2222222222222222222222222222222222222222222222222222222222222222"
+	testMsgHash  = "0x3333333333333333333333333333333333333333333333333333333333333333"
+)
+
+type testEnv struct {
+	network *Network
+	baseURL string
+	users   []*keystore.Key
+	close   func()
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	config := &Config{
+		NodeCount: 3,
+		Groups:    []*GroupConfig{{GroupID: testGroupID, Members: []int{0, 1, 2}}},
+		Keys: []*KeyConfig{
+			{PrivateKey: testECDSAKey},
+			{KeyType: tokens.KeyTypeED25519, PrivateKey: testEDDSAKey},
+		},
+	}
+	network, err := NewNetwork(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(network.Handler())
+	env := &testEnv{network: network, baseURL: server.URL, close: server.Close}
+	for i := 0; i < config.NodeCount; i++ {
+		privKey, _ := crypto.GenerateKey()
+		env.users = append(env.users, &keystore.Key{Address: crypto.PubkeyToAddress(privKey.PublicKey), PrivateKey: privKey})
+	}
+	return env
+}
+
+func (env *testEnv) sign(t *testing.T, keyType, pubkey string) string {
+	initiator := env.users[0]
+	rpcAddr := NodeRPCAddress(env.baseURL, 0)
+	nonce, err := dcrm.GetSignNonce(initiator.Address.String(), rpcAddr)
+	if err != nil {
+		t.Fatalf("get sign nonce failed: %v", err)
+	}
+	payload, _ := json.Marshal(&dcrm.SignData{
+		TxType:    "SIGN",
+		PubKey:    pubkey,
+		MsgHash:   []string{testMsgHash},
+		Keytype:   keyType,
+		GroupID:   testGroupID,
+		ThresHold: "3/3",
+		Mode:      "0",
+		TimeStamp: common.NowMilliStr(),
+	})
+	rawTx, err := dcrm.BuildDcrmRawTx(nonce, payload, initiator)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyID, err := dcrm.Sign(rawTx, rpcAddr)
+	if err != nil {
+		t.Fatalf("sign failed: %v", err)
+	}
+	return keyID
+}
+
+func (env *testEnv) accept(t *testing.T, nodeIndex int, keyID, agreeResult string) {
+	payload, _ := json.Marshal(&dcrm.AcceptData{
+		TxType:    "ACCEPTSIGN",
+		Key:       keyID,
+		Accept:    agreeResult,
+		MsgHash:   []string{testMsgHash},
+		TimeStamp: common.NowMilliStr(),
+	})
+	rawTx, err := dcrm.BuildDcrmRawTx(0, payload, env.users[nodeIndex])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result dcrm.DataResultResp
+	err = client.RPCPost(&result, NodeRPCAddress(env.baseURL, nodeIndex), "dcrm_acceptSign", rawTx)
+	if err != nil || result.Status != statusSuccess {
+		t.Fatalf("accept sign failed: err=%v status=%v error=%v", err, result.Status, result.Error)
+	}
+}
+
+func (env *testEnv) pendingCount(t *testing.T, nodeIndex int) int {
+	var result dcrm.SignInfoResp
+	err := client.RPCPost(&result, NodeRPCAddress(env.baseURL, nodeIndex), "dcrm_getCurNodeSignInfo", env.users[nodeIndex].Address.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return len(result.Data)
+}
+
+func TestGroupAndEnode(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.close()
+
+	group, err := dcrm.GetGroupByID(testGroupID, NodeRPCAddress(env.baseURL, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if group.Count != 3 || len(group.Enodes) != 3 {
+		t.Fatalf("wrong group info %+v", group)
+	}
+	enode, err := dcrm.GetEnode(NodeRPCAddress(env.baseURL, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if enode != group.Enodes[1] {
+		t.Fatalf("enode mismatch: have %v want %v", enode, group.Enodes[1])
+	}
+
+	env.network.SetNodeDown(1, true)
+	if _, err = dcrm.GetEnode(NodeRPCAddress(env.baseURL, 1)); err == nil {
+		t.Fatal("get enode of down node should fail")
+	}
+}
+
+func TestSignECDSA(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.close()
+
+	privKey, _ := crypto.ToECDSA(common.FromHex(testECDSAKey))
+	pubkey := crypto.FromECDSAPub(&privKey.PublicKey)
+
+	keyID := env.sign(t, tokens.KeyTypeECDSA, common.ToHex(pubkey))
+	if _, err := dcrm.GetSignStatus(keyID, NodeRPCAddress(env.baseURL, 0)); err == nil {
+		t.Fatal("sign should be pending before all accepted")
+	}
+	if env.pendingCount(t, 0) != 0 || env.pendingCount(t, 1) != 1 {
+		t.Fatal("wrong pending sign info")
+	}
+	env.accept(t, 1, keyID, acceptAgree)
+	env.accept(t, 2, keyID, acceptAgree)
+
+	signStatus, err := dcrm.GetSignStatus(keyID, NodeRPCAddress(env.baseURL, 0))
+	if err != nil {
+		t.Fatalf("get sign status failed: %v", err)
+	}
+	if err = dcrm.VerifySignResult(tokens.KeyTypeECDSA, common.ToHex(pubkey), []string{testMsgHash}, signStatus.Rsv); err != nil {
+		t.Fatalf("verify sign result failed: %v", err)
+	}
+	if nonce, _ := dcrm.GetSignNonce(env.users[0].Address.String(), NodeRPCAddress(env.baseURL, 0)); nonce != 1 {
+		t.Fatalf("sign nonce is not increased, have %v", nonce)
+	}
+}
+
+func TestSignED25519(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.close()
+
+	pubkey := ed25519.NewKeyFromSeed(common.FromHex(testEDDSAKey)).Public().(ed25519.PublicKey)
+
+	keyID := env.sign(t, tokens.KeyTypeED25519, common.ToHex(pubkey))
+	env.accept(t, 1, keyID, acceptAgree)
+	env.accept(t, 2, keyID, acceptAgree)
+
+	signStatus, err := dcrm.GetSignStatus(keyID, NodeRPCAddress(env.baseURL, 0))
+	if err != nil {
+		t.Fatalf("get sign status failed: %v", err)
+	}
+	if err = dcrm.VerifySignResult(tokens.KeyTypeED25519, common.ToHex(pubkey), []string{testMsgHash}, signStatus.Rsv); err != nil {
+		t.Fatalf("verify sign result failed: %v", err)
+	}
+}
+
+func TestSignDisagree(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.close()
+
+	privKey, _ := crypto.ToECDSA(common.FromHex(testECDSAKey))
+	keyID := env.sign(t, tokens.KeyTypeECDSA, common.ToHex(crypto.CompressPubkey(&privKey.PublicKey)))
+	env.accept(t, 1, keyID, acceptAgree)
+	env.accept(t, 2, keyID, acceptDisagree)
+
+	_, err := dcrm.GetSignStatus(keyID, NodeRPCAddress(env.baseURL, 0))
+	if !errors.Is(err, dcrm.ErrGetSignStatusFailed) {
+		t.Fatalf("disagreed sign should fail, err=%v", err)
+	}
+}
+
+func TestSignTimeout(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.close()
+
+	env.network.signTimeout = 100 * time.Millisecond
+	env.network.TimeoutNextSign()
+
+	privKey, _ := crypto.ToECDSA(common.FromHex(testECDSAKey))
+	keyID := env.sign(t, tokens.KeyTypeECDSA, common.ToHex(crypto.FromECDSAPub(&privKey.PublicKey)))
+	env.accept(t, 1, keyID, acceptAgree)
+	env.accept(t, 2, keyID, acceptAgree)
+
+	time.Sleep(200 * time.Millisecond)
+	_, err := dcrm.GetSignStatus(keyID, NodeRPCAddress(env.baseURL, 0))
+	if !errors.Is(err, dcrm.ErrGetSignStatusTimeout) {
+		t.Fatalf("sign should timeout, err=%v", err)
+	}
+}
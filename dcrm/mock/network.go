@@ -0,0 +1,435 @@
+package mock
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tools/crypto"
+)
+
+// sign status
+const (
+	StatusPending = "Pending"
+	StatusSuccess = "Success"
+	StatusFailure = "Failure"
+	StatusTimeout = "Timeout"
+
+	acceptAgree    = "AGREE"
+	acceptDisagree = "DISAGREE"
+)
+
+// simulated outcome of sign
+const (
+	outcomeNormal = iota
+	outcomeFailure
+	outcomeTimeout
+)
+
+var (
+	errUnknownGroup     = errors.New("unknown group")
+	errUnknownKey       = errors.New("unknown public key")
+	errUnknownSign      = errors.New("unknown sign key id")
+	errKeyTypeMismatch  = errors.New("key type mismatch")
+	errNotGroupMember   = errors.New("node is not member of sign group")
+	errAlreadyReplied   = errors.New("node already replied")
+	errSignNotPending   = errors.New("sign is not pending")
+	errMsgHashMismatch  = errors.New("msg hash mismatch")
+	errWrongSignNonce   = errors.New("wrong sign nonce")
+	errWrongThreshold   = errors.New("wrong threshold")
+	errWrongAcceptValue = errors.New("wrong accept value")
+)
+
+type mockNode struct {
+	index int
+	enode string
+	down  bool
+}
+
+type mockKey struct {
+	keyType    string
+	ecdsaKey   *ecdsa.PrivateKey
+	ed25519Key ed25519.PrivateKey
+}
+
+type signSession struct {
+	keyID      string
+	account    string
+	initiator  int
+	groupID    string
+	members    []int
+	key        *mockKey
+	pubkey     string
+	keyType    string
+	mode       string
+	threshold  string
+	nonce      uint64
+	msgHash    []string
+	msgContext []string
+	timestamp  string
+	createTime time.Time
+	outcome    int
+
+	replies map[int]string // node index -> AGREE / DISAGREE
+	status  string
+	rsvs    []string
+	errInfo string
+}
+
+// Network mock dcrm network
+type Network struct {
+	config      *Config
+	signTimeout time.Duration
+
+	nodes    []*mockNode
+	groups   map[string][]int
+	keys     map[string]*mockKey // normalized public key hex -> key
+	nonces   map[string]uint64   // account -> sign nonce
+	sessions map[string]*signSession
+	order    []string // keyIDs in creation order
+
+	nextOutcome int
+	lock        sync.Mutex
+}
+
+// NewNetwork new mock dcrm network
+func NewNetwork(config *Config) (*Network, error) {
+	if err := config.CheckConfig(); err != nil {
+		return nil, err
+	}
+	n := &Network{
+		config:      config,
+		signTimeout: time.Duration(config.SignTimeout) * time.Second,
+		groups:      make(map[string][]int),
+		keys:        make(map[string]*mockKey),
+		nonces:      make(map[string]uint64),
+		sessions:    make(map[string]*signSession),
+	}
+	for i := 0; i < config.NodeCount; i++ {
+		nodeID := crypto.Keccak512([]byte(fmt.Sprintf("mock dcrm node %d", i)))
+		n.nodes = append(n.nodes, &mockNode{
+			index: i,
+			enode: fmt.Sprintf("enode://%x@127.0.0.1:%d", nodeID, config.Port),
+		})
+	}
+	for _, group := range config.Groups {
+		n.groups[group.GroupID] = group.Members
+	}
+	for _, keyCfg := range config.Keys {
+		if err := n.addKey(keyCfg); err != nil {
+			return nil, err
+		}
+	}
+	return n, nil
+}
+
+func normalizePubkey(pubkey string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimPrefix(pubkey, "0x"), "0X"))
+}
+
+func (n *Network) addKey(keyCfg *KeyConfig) error {
+	key := &mockKey{keyType: keyCfg.KeyType}
+	keyData := common.FromHex(keyCfg.PrivateKey)
+	switch keyCfg.KeyType {
+	case tokens.KeyTypeED25519:
+		key.ed25519Key = ed25519.NewKeyFromSeed(keyData)
+		pubkey, _ := key.ed25519Key.Public().(ed25519.PublicKey)
+		n.keys[hex.EncodeToString(pubkey)] = key
+		log.Info("mock dcrm add key", "keyType", key.keyType, "pubkey", hex.EncodeToString(pubkey))
+	default:
+		priKey, err := crypto.ToECDSA(keyData)
+		if err != nil {
+			return err
+		}
+		key.ecdsaKey = priKey
+		pubkey := crypto.FromECDSAPub(&priKey.PublicKey)
+		n.keys[hex.EncodeToString(pubkey)] = key
+		n.keys[hex.EncodeToString(crypto.CompressPubkey(&priKey.PublicKey))] = key
+		log.Info("mock dcrm add key", "keyType", key.keyType, "pubkey", hex.EncodeToString(pubkey),
+			"address", crypto.PubkeyToAddress(priKey.PublicKey).String())
+	}
+	return nil
+}
+
+// GetNodeCount get node count
+func (n *Network) GetNodeCount() int {
+	return len(n.nodes)
+}
+
+// GetEnode get enode of node
+func (n *Network) GetEnode(nodeIndex int) string {
+	return n.nodes[nodeIndex].enode
+}
+
+// SetNodeDown simulate node is down (or up again)
+func (n *Network) SetNodeDown(nodeIndex int, down bool) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.nodes[nodeIndex].down = down
+}
+
+// FailNextSign make the next sign failed
+func (n *Network) FailNextSign() {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.nextOutcome = outcomeFailure
+}
+
+// TimeoutNextSign make the next sign timeout
+func (n *Network) TimeoutNextSign() {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.nextOutcome = outcomeTimeout
+}
+
+func (n *Network) isNodeDown(nodeIndex int) bool {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return n.nodes[nodeIndex].down
+}
+
+func (n *Network) pickOutcome() int {
+	if n.nextOutcome != outcomeNormal {
+		outcome := n.nextOutcome
+		n.nextOutcome = outcomeNormal
+		return outcome
+	}
+	//nolint:gosec // simulation only
+	r := rand.Float64()
+	switch {
+	case r < n.config.FailSignRate:
+		return outcomeFailure
+	case r < n.config.FailSignRate+n.config.TimeoutSignRate:
+		return outcomeTimeout
+	default:
+		return outcomeNormal
+	}
+}
+
+func isMember(members []int, nodeIndex int) bool {
+	for _, member := range members {
+		if member == nodeIndex {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *Network) getSignNonce(account string) uint64 {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return n.nonces[strings.ToLower(account)]
+}
+
+func (n *Network) getGroup(groupID string) ([]string, error) {
+	members, exist := n.groups[groupID]
+	if !exist {
+		return nil, errUnknownGroup
+	}
+	enodes := make([]string, 0, len(members))
+	for _, member := range members {
+		enodes = append(enodes, n.nodes[member].enode)
+	}
+	return enodes, nil
+}
+
+type signRequest struct {
+	nodeIndex  int
+	account    string
+	nonce      uint64
+	pubkey     string
+	keyType    string
+	groupID    string
+	threshold  string
+	mode       string
+	msgHash    []string
+	msgContext []string
+	timestamp  string
+}
+
+func (n *Network) newSign(req *signRequest) (keyID string, err error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	account := strings.ToLower(req.account)
+	if req.nonce != n.nonces[account] {
+		return "", errWrongSignNonce
+	}
+	members, exist := n.groups[req.groupID]
+	if !exist {
+		return "", errUnknownGroup
+	}
+	if !isMember(members, req.nodeIndex) {
+		return "", errNotGroupMember
+	}
+	var needed, total int
+	if _, err = fmt.Sscanf(req.threshold, "%d/%d", &needed, &total); err != nil || needed != len(members) {
+		return "", errWrongThreshold
+	}
+	key, exist := n.keys[normalizePubkey(req.pubkey)]
+	if !exist {
+		return "", errUnknownKey
+	}
+	if key.keyType != req.keyType {
+		return "", errKeyTypeMismatch
+	}
+	keyID = crypto.Keccak256Hash([]byte(account), []byte(fmt.Sprint(req.nonce)), []byte(req.timestamp), []byte(strings.Join(req.msgHash, ","))).Hex()
+	session := &signSession{
+		keyID:      keyID,
+		account:    req.account,
+		initiator:  req.nodeIndex,
+		groupID:    req.groupID,
+		members:    members,
+		key:        key,
+		pubkey:     req.pubkey,
+		keyType:    req.keyType,
+		mode:       req.mode,
+		threshold:  req.threshold,
+		nonce:      req.nonce,
+		msgHash:    req.msgHash,
+		msgContext: req.msgContext,
+		timestamp:  req.timestamp,
+		createTime: time.Now(),
+		outcome:    n.pickOutcome(),
+		replies:    map[int]string{req.nodeIndex: acceptAgree}, // initiator agrees implicitly
+		status:     StatusPending,
+	}
+	n.sessions[keyID] = session
+	n.order = append(n.order, keyID)
+	n.nonces[account]++
+	n.updateStatus(session)
+	log.Info("mock dcrm new sign", "keyID", keyID, "account", req.account, "groupID", req.groupID, "msgHash", req.msgHash)
+	return keyID, nil
+}
+
+func (n *Network) acceptSign(nodeIndex int, keyID, accept string, msgHash []string) error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	session, exist := n.sessions[keyID]
+	if !exist {
+		return errUnknownSign
+	}
+	n.updateStatus(session)
+	if session.status != StatusPending {
+		return errSignNotPending
+	}
+	if !isMember(session.members, nodeIndex) {
+		return errNotGroupMember
+	}
+	if _, exist := session.replies[nodeIndex]; exist {
+		return errAlreadyReplied
+	}
+	if accept != acceptAgree && accept != acceptDisagree {
+		return errWrongAcceptValue
+	}
+	if strings.Join(msgHash, ",") != strings.Join(session.msgHash, ",") {
+		return errMsgHashMismatch
+	}
+	session.replies[nodeIndex] = accept
+	n.updateStatus(session)
+	log.Info("mock dcrm accept sign", "keyID", keyID, "node", nodeIndex, "accept", accept, "status", session.status)
+	return nil
+}
+
+// updateStatus aggregate replies, the sign succeed only if all members agree
+func (n *Network) updateStatus(session *signSession) {
+	if session.status != StatusPending {
+		return
+	}
+	if time.Since(session.createTime) > n.signTimeout {
+		session.status = StatusTimeout
+		session.errInfo = "sign timeout"
+		return
+	}
+	for _, reply := range session.replies {
+		if reply == acceptDisagree {
+			session.status = StatusFailure
+			session.errInfo = "sign is disagreed"
+			return
+		}
+	}
+	if len(session.replies) < len(session.members) {
+		return
+	}
+	switch session.outcome {
+	case outcomeTimeout:
+		return // keep pending until timeout
+	case outcomeFailure:
+		session.status = StatusFailure
+		session.errInfo = "simulated sign failure"
+		return
+	}
+	rsvs, err := session.key.sign(session.msgHash)
+	if err != nil {
+		session.status = StatusFailure
+		session.errInfo = err.Error()
+		return
+	}
+	session.rsvs = rsvs
+	session.status = StatusSuccess
+}
+
+func (key *mockKey) sign(msgHashes []string) (rsvs []string, err error) {
+	rsvs = make([]string, 0, len(msgHashes))
+	for _, msgHash := range msgHashes {
+		hash := common.FromHex(msgHash)
+		var signature []byte
+		if key.keyType == tokens.KeyTypeED25519 {
+			signature = ed25519.Sign(key.ed25519Key, hash)
+		} else {
+			signature, err = crypto.Sign(hash, key.ecdsaKey)
+			if err != nil {
+				return nil, err
+			}
+		}
+		// dcrm returns upper case hex string without 0x prefix
+		rsvs = append(rsvs, strings.ToUpper(hex.EncodeToString(signature)))
+	}
+	return rsvs, nil
+}
+
+func (n *Network) getSession(keyID string) (*signSession, error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	session, exist := n.sessions[keyID]
+	if !exist {
+		return nil, errUnknownSign
+	}
+	n.updateStatus(session)
+	copied := *session
+	copied.replies = make(map[int]string, len(session.replies))
+	for member, reply := range session.replies {
+		copied.replies[member] = reply
+	}
+	return &copied, nil
+}
+
+// getPendingSigns get pending signs which wait for the node to accept
+func (n *Network) getPendingSigns(nodeIndex int) []*signSession {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	var result []*signSession
+	for _, keyID := range n.order {
+		session := n.sessions[keyID]
+		n.updateStatus(session)
+		if session.status != StatusPending || !isMember(session.members, nodeIndex) {
+			continue
+		}
+		if _, exist := session.replies[nodeIndex]; exist {
+			continue
+		}
+		copied := *session
+		result = append(result, &copied)
+	}
+	return result
+}
@@ -0,0 +1,280 @@
+package mock
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/dcrm"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/tools/rlp"
+	"github.com/anyswap/CrossChain-Bridge/types"
+)
+
+const (
+	nodePathPrefix = "/node"
+
+	statusSuccess = "Success"
+	statusError   = "Error"
+
+	dcrmWalletServiceID = 30400
+	dcrmToAddress       = "0x00000000000000000000000000000000000000dc"
+)
+
+var (
+	dcrmSigner = types.MakeSigner("EIP155", big.NewInt(dcrmWalletServiceID))
+	dcrmToAddr = common.HexToAddress(dcrmToAddress)
+
+	errWrongParams    = errors.New("wrong params")
+	errWrongToAddress = errors.New("wrong dcrm to address")
+	errWrongTxType    = errors.New("wrong tx type")
+)
+
+type rpcRequest struct {
+	Version string            `json:"jsonrpc"`
+	Method  string            `json:"method"`
+	Params  []json.RawMessage `json:"params"`
+	ID      json.RawMessage   `json:"id"`
+}
+
+type rpcResponse struct {
+	Version string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result"`
+}
+
+// apiResult is the common result struct of dcrm apis
+type apiResult struct {
+	Status string
+	Tip    string
+	Error  string
+	Data   interface{}
+}
+
+// NodeRPCPath get rpc path of node, eg. '/node0'
+func NodeRPCPath(nodeIndex int) string {
+	return fmt.Sprintf("%v%d", nodePathPrefix, nodeIndex)
+}
+
+// NodeRPCAddress get rpc address of node under base url
+func NodeRPCAddress(baseURL string, nodeIndex int) string {
+	return strings.TrimSuffix(baseURL, "/") + NodeRPCPath(nodeIndex)
+}
+
+// Handler get http handler which serves the rpc of all nodes
+func (n *Network) Handler() http.Handler {
+	return http.HandlerFunc(n.serveHTTP)
+}
+
+func (n *Network) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	nodeIndex, err := strconv.Atoi(strings.TrimPrefix(r.URL.Path, nodePathPrefix))
+	if err != nil || !strings.HasPrefix(r.URL.Path, nodePathPrefix) || nodeIndex < 0 || nodeIndex >= n.GetNodeCount() {
+		http.NotFound(w, r)
+		return
+	}
+	if n.isNodeDown(nodeIndex) {
+		http.Error(w, "node is down", http.StatusServiceUnavailable)
+		return
+	}
+	var req rpcRequest
+	if err = json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	data, err := n.dispatch(nodeIndex, &req)
+	result := &apiResult{Status: statusSuccess, Data: data}
+	if err != nil {
+		log.Debug("mock dcrm call failed", "node", nodeIndex, "method", req.Method, "err", err)
+		result = &apiResult{Status: statusError, Error: err.Error()}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(&rpcResponse{Version: "2.0", ID: req.ID, Result: result})
+}
+
+func (n *Network) dispatch(nodeIndex int, req *rpcRequest) (interface{}, error) {
+	if !strings.HasPrefix(req.Method, n.config.APIPrefix) {
+		return nil, fmt.Errorf("method %v not found", req.Method)
+	}
+	method := strings.TrimPrefix(req.Method, n.config.APIPrefix)
+	switch method {
+	case "getEnode":
+		return &dcrm.DataEnode{Enode: n.GetEnode(nodeIndex)}, nil
+	case "getGroupByID":
+		return n.callGetGroupByID(req.Params)
+	case "getSignNonce":
+		return n.callGetSignNonce(req.Params)
+	case "sign":
+		return n.callSign(nodeIndex, req.Params)
+	case "acceptSign":
+		return n.callAcceptSign(nodeIndex, req.Params)
+	case "getSignStatus":
+		return n.callGetSignStatus(req.Params)
+	case "getCurNodeSignInfo":
+		return n.callGetCurNodeSignInfo(nodeIndex), nil
+	default:
+		return nil, fmt.Errorf("method %v not found", req.Method)
+	}
+}
+
+func getStringParam(params []json.RawMessage) (string, error) {
+	if len(params) == 0 {
+		return "", errWrongParams
+	}
+	var param string
+	if err := json.Unmarshal(params[0], &param); err != nil {
+		return "", errWrongParams
+	}
+	return param, nil
+}
+
+func (n *Network) callGetGroupByID(params []json.RawMessage) (interface{}, error) {
+	groupID, err := getStringParam(params)
+	if err != nil {
+		return nil, err
+	}
+	enodes, err := n.getGroup(groupID)
+	if err != nil {
+		return nil, err
+	}
+	return &dcrm.GroupInfo{GID: groupID, Count: len(enodes), Enodes: enodes}, nil
+}
+
+func (n *Network) callGetSignNonce(params []json.RawMessage) (interface{}, error) {
+	account, err := getStringParam(params)
+	if err != nil {
+		return nil, err
+	}
+	return &dcrm.DataResult{Result: fmt.Sprint(n.getSignNonce(account))}, nil
+}
+
+// parseRawTx parse dcrm raw tx, return sender, nonce and payload
+func parseRawTx(params []json.RawMessage) (sender string, nonce uint64, payload []byte, err error) {
+	raw, err := getStringParam(params)
+	if err != nil {
+		return "", 0, nil, err
+	}
+	var tx types.Transaction
+	if err = rlp.DecodeBytes(common.FromHex(raw), &tx); err != nil {
+		return "", 0, nil, err
+	}
+	if tx.To() == nil || *tx.To() != dcrmToAddr {
+		return "", 0, nil, errWrongToAddress
+	}
+	from, err := types.Sender(dcrmSigner, &tx)
+	if err != nil {
+		return "", 0, nil, err
+	}
+	return from.String(), tx.Nonce(), tx.Data(), nil
+}
+
+func (n *Network) callSign(nodeIndex int, params []json.RawMessage) (interface{}, error) {
+	sender, nonce, payload, err := parseRawTx(params)
+	if err != nil {
+		return nil, err
+	}
+	var data dcrm.SignData
+	if err = json.Unmarshal(payload, &data); err != nil {
+		return nil, err
+	}
+	if data.TxType != "SIGN" {
+		return nil, errWrongTxType
+	}
+	keyID, err := n.newSign(&signRequest{
+		nodeIndex:  nodeIndex,
+		account:    sender,
+		nonce:      nonce,
+		pubkey:     data.PubKey,
+		keyType:    data.Keytype,
+		groupID:    data.GroupID,
+		threshold:  data.ThresHold,
+		mode:       data.Mode,
+		msgHash:    data.MsgHash,
+		msgContext: data.MsgContext,
+		timestamp:  data.TimeStamp,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &dcrm.DataResult{Result: keyID}, nil
+}
+
+func (n *Network) callAcceptSign(nodeIndex int, params []json.RawMessage) (interface{}, error) {
+	_, _, payload, err := parseRawTx(params)
+	if err != nil {
+		return nil, err
+	}
+	var data dcrm.AcceptData
+	if err = json.Unmarshal(payload, &data); err != nil {
+		return nil, err
+	}
+	if data.TxType != "ACCEPTSIGN" {
+		return nil, errWrongTxType
+	}
+	err = n.acceptSign(nodeIndex, data.Key, data.Accept, data.MsgHash)
+	if err != nil {
+		return nil, err
+	}
+	return &dcrm.DataResult{Result: "Success"}, nil
+}
+
+func (n *Network) callGetSignStatus(params []json.RawMessage) (interface{}, error) {
+	keyID, err := getStringParam(params)
+	if err != nil {
+		return nil, err
+	}
+	session, err := n.getSession(keyID)
+	if err != nil {
+		return nil, err
+	}
+	signStatus := &dcrm.SignStatus{
+		Status:    session.status,
+		Rsv:       session.rsvs,
+		Error:     session.errInfo,
+		TimeStamp: session.timestamp,
+	}
+	for _, member := range session.members {
+		reply := &dcrm.SignReply{
+			Enode:     n.GetEnode(member),
+			Status:    session.replies[member],
+			TimeStamp: session.timestamp,
+			Initiator: fmt.Sprint(member == session.initiator),
+		}
+		signStatus.AllReply = append(signStatus.AllReply, reply)
+	}
+	// dcrm returns the sign status as json string
+	status, err := json.Marshal(signStatus)
+	if err != nil {
+		return nil, err
+	}
+	return &dcrm.DataResult{Result: string(status)}, nil
+}
+
+func (n *Network) callGetCurNodeSignInfo(nodeIndex int) interface{} {
+	sessions := n.getPendingSigns(nodeIndex)
+	result := make([]*dcrm.SignInfoData, 0, len(sessions))
+	for _, session := range sessions {
+		result = append(result, &dcrm.SignInfoData{
+			Account:    session.account,
+			GroupID:    session.groupID,
+			Key:        session.keyID,
+			KeyType:    session.keyType,
+			Mode:       session.mode,
+			MsgHash:    session.msgHash,
+			MsgContext: session.msgContext,
+			Nonce:      fmt.Sprint(session.nonce),
+			PubKey:     session.pubkey,
+			ThresHold:  session.threshold,
+			TimeStamp:  session.timestamp,
+		})
+	}
+	return result
+}
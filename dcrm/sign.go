@@ -1,16 +1,18 @@
 package dcrm
 
 import (
-	"crypto/rand"
+	"crypto/ed25519"
 	"encoding/json"
 	"errors"
 	"math/big"
+	"strings"
 	"time"
 
 	"github.com/anyswap/CrossChain-Bridge/common"
 	"github.com/anyswap/CrossChain-Bridge/log"
 	"github.com/anyswap/CrossChain-Bridge/mongodb"
 	"github.com/anyswap/CrossChain-Bridge/params"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
 	"github.com/anyswap/CrossChain-Bridge/tools/crypto"
 	"github.com/anyswap/CrossChain-Bridge/tools/keystore"
 	"github.com/anyswap/CrossChain-Bridge/tools/rlp"
@@ -30,6 +32,9 @@ var (
 	errGetSignResultFailed  = errors.New("get sign result failed")
 	errRValueIsUsed         = errors.New("r value is already used")
 	errWrongSignatureLength = errors.New("wrong signature length")
+	errWrongPublicKey       = errors.New("wrong public key")
+	errVerifySignature      = errors.New("verify signature failed")
+	errUnknownKeyType       = errors.New("unknown sign key type")
 )
 
 func pingDcrmNode(nodeInfo *NodeInfo) (err error) {
@@ -52,43 +57,89 @@ func DoSignOne(signPubkey, msgHash, msgContext string) (keyID string, rsvs []str
 
 // DoSign dcrm sign msgHash with context msgContext
 func DoSign(signPubkey string, msgHash, msgContext []string) (keyID string, rsvs []string, err error) {
+	return DoSignWithKeyType(tokens.KeyTypeECDSA, signPubkey, msgHash, msgContext)
+}
+
+// DoSignOneWithKeyType dcrm sign single msgHash with specified key type
+func DoSignOneWithKeyType(keyType, signPubkey, msgHash, msgContext string) (keyID string, rsvs []string, err error) {
+	return DoSignWithKeyType(keyType, signPubkey, []string{msgHash}, []string{msgContext})
+}
+
+// DoSignWithKeyType dcrm sign msgHash with specified key type (ECDSA or ED25519)
+func DoSignWithKeyType(keyType, signPubkey string, msgHash, msgContext []string) (keyID string, rsvs []string, err error) {
 	if !params.IsDcrmEnabled() {
 		return "", nil, errSignIsDisabled
 	}
-	log.Debug("dcrm DoSign", "msgHash", msgHash, "msgContext", msgContext)
+	log.Debug("dcrm DoSign", "keyType", keyType, "msgHash", msgHash, "msgContext", msgContext)
 	if signPubkey == "" {
 		return "", nil, errSignWithoutPublickey
 	}
+	if GetSignatureLength(keyType) == 0 {
+		return "", nil, errUnknownKeyType
+	}
 	for i := 0; i < retrySignLoop; i++ {
+		// groups in backoff are only tried in the last loop
+		includeBackoff := i == retrySignLoop-1
 		for _, dcrmNode := range allInitiatorNodes {
 			if err = pingDcrmNode(dcrmNode); err != nil {
+				recordPingResult(dcrmNode, err)
 				continue
 			}
-			signGroupsCount := int64(len(dcrmNode.signGroups))
-			// randomly pick first subgroup to sign
-			randIndex, _ := rand.Int(rand.Reader, big.NewInt(signGroupsCount))
-			startIndex := randIndex.Int64()
-			i := startIndex
-			for {
-				keyID, rsvs, err = doSignImpl(dcrmNode, i, signPubkey, msgHash, msgContext)
+			for _, groupID := range getOrderedSignGroups(dcrmNode, includeBackoff) {
+				keyID, rsvs, err = doSignImpl(dcrmNode, groupID, keyType, signPubkey, msgHash, msgContext)
 				if err == nil {
 					return keyID, rsvs, nil
 				}
-				i = (i + 1) % signGroupsCount
-				if i == startIndex {
-					break
-				}
 			}
 		}
 		time.Sleep(2 * time.Second)
 	}
-	log.Warn("dcrm DoSign failed", "msgHash", msgHash, "msgContext", msgContext, "err", err)
+	log.Warn("dcrm DoSign failed", "keyType", keyType, "msgHash", msgHash, "msgContext", msgContext, "err", err)
 	return "", nil, errDoSignFailed
 }
 
-func doSignImpl(dcrmNode *NodeInfo, signGroupIndex int64, signPubkey string, msgHash, msgContext []string) (keyID string, rsvs []string, err error) {
+// GetSignatureLength get signature length of key type,
+// ECDSA is 65 bytes (r || s || v), ED25519 is 64 bytes (R || S).
+// returns 0 if key type is unknown.
+func GetSignatureLength(keyType string) int {
+	switch keyType {
+	case tokens.KeyTypeECDSA:
+		return crypto.SignatureLength
+	case tokens.KeyTypeED25519:
+		return tokens.ED25519SignatureLength
+	default:
+		return 0
+	}
+}
+
+// VerifySignResult verify sign result (length, and ed25519 signature)
+func VerifySignResult(keyType, signPubkey string, msgHash, rsvs []string) error {
+	if len(rsvs) != len(msgHash) {
+		return errGetSignResultFailed
+	}
+	for i, rsv := range rsvs {
+		signature := common.FromHex(rsv)
+		if len(signature) != GetSignatureLength(keyType) {
+			return errWrongSignatureLength
+		}
+		if keyType != tokens.KeyTypeED25519 {
+			continue
+		}
+		pubkey := common.FromHex(signPubkey)
+		if len(pubkey) != ed25519.PublicKeySize {
+			return errWrongPublicKey
+		}
+		if !ed25519.Verify(pubkey, common.FromHex(msgHash[i]), signature) {
+			return errVerifySignature
+		}
+	}
+	return nil
+}
+
+func doSignImpl(dcrmNode *NodeInfo, signGroupID, keyType, signPubkey string, msgHash, msgContext []string) (keyID string, rsvs []string, err error) {
 	nonce, err := GetSignNonce(dcrmNode.dcrmUser.String(), dcrmNode.dcrmRPCAddress)
 	if err != nil {
+		recordSignRPCError(dcrmNode, err)
 		return "", nil, err
 	}
 	txdata := SignData{
@@ -96,8 +147,8 @@ func doSignImpl(dcrmNode *NodeInfo, signGroupIndex int64, signPubkey string, msg
 		PubKey:     signPubkey,
 		MsgHash:    msgHash,
 		MsgContext: msgContext,
-		Keytype:    "ECDSA",
-		GroupID:    dcrmNode.signGroups[signGroupIndex],
+		Keytype:    keyType,
+		GroupID:    signGroupID,
 		ThresHold:  dcrmThreshold,
 		Mode:       dcrmMode,
 		TimeStamp:  common.NowMilliStr(),
@@ -109,21 +160,30 @@ func doSignImpl(dcrmNode *NodeInfo, signGroupIndex int64, signPubkey string, msg
 	}
 
 	rpcAddr := dcrmNode.dcrmRPCAddress
+	startTime := time.Now()
 	keyID, err = Sign(rawTX, rpcAddr)
 	if err != nil {
+		recordSignRPCError(dcrmNode, err)
 		return "", nil, err
 	}
+	// record keyID to sign session (if exist) to resume getting sign result after restart
+	_ = mongodb.UpdateSignSessionKeyID(mongodb.GetSignSessionKey(signPubkey, msgHash), keyID, dcrmNode.dcrmUser.String())
 
-	rsvs, err = getSignResult(keyID, rpcAddr)
+	signStatus, err := getSignResult(keyID, rpcAddr)
+	if err == nil {
+		rsvs = signStatus.Rsv
+		err = VerifySignResult(keyType, signPubkey, msgHash, rsvs)
+		if err != nil {
+			log.Warn("dcrm verify sign result failed", "keyID", keyID, "keyType", keyType, "err", err)
+		}
+	}
+	recordSignResult(dcrmNode, signGroupID, time.Since(startTime), signStatus, err)
 	if err != nil {
 		return "", nil, err
 	}
 	for _, rsv := range rsvs {
 		signature := common.FromHex(rsv)
-		if len(signature) != crypto.SignatureLength {
-			return "", nil, errWrongSignatureLength
-		}
-		r := common.ToHex(signature[:32])
+		r := common.ToHex(signature[:32]) // ECDSA r or ED25519 R
 		err = mongodb.AddUsedRValue(signPubkey, r)
 		if err != nil {
 			return "", nil, errRValueIsUsed
@@ -135,12 +195,35 @@ func doSignImpl(dcrmNode *NodeInfo, signGroupIndex int64, signPubkey string, msg
 
 // GetSignStatusByKeyID get sign status by keyID
 func GetSignStatusByKeyID(keyID string) (rsvs []string, err error) {
-	return getSignResult(keyID, defaultDcrmNode.dcrmRPCAddress)
+	signStatus, err := getSignResult(keyID, defaultDcrmNode.dcrmRPCAddress)
+	if err != nil {
+		return nil, err
+	}
+	return signStatus.Rsv, nil
+}
+
+// GetSignStatusByInitiator get sign status by keyID from the node of initiator,
+// use default dcrm node if initiator is not found
+func GetSignStatusByInitiator(keyID, initiator string) (rsvs []string, err error) {
+	rpcAddr := defaultDcrmNode.dcrmRPCAddress
+	for _, dcrmNode := range allInitiatorNodes {
+		if strings.EqualFold(dcrmNode.dcrmUser.String(), initiator) {
+			rpcAddr = dcrmNode.dcrmRPCAddress
+			break
+		}
+	}
+	signStatus, err := getSignResult(keyID, rpcAddr)
+	if err != nil {
+		return nil, err
+	}
+	return signStatus.Rsv, nil
 }
 
-func getSignResult(keyID, rpcAddr string) (rsvs []string, err error) {
+// getSignResult get sign result, the returned sign status maybe not nil
+// even if error occurs (eg. sign is failed or timeout)
+func getSignResult(keyID, rpcAddr string) (signStatus *SignStatus, err error) {
 	log.Info("start get sign status", "keyID", keyID)
-	var signStatus *SignStatus
+	var rsvs []string
 	i := 0
 	signTimer := time.NewTimer(dcrmSignTimeout)
 	defer signTimer.Stop()
@@ -169,10 +252,10 @@ LOOP_GET_SIGN_STATUS:
 	}
 	if len(rsvs) == 0 || err != nil {
 		log.Info("get sign status failed", "keyID", keyID, "retryCount", i, "err", err)
-		return nil, errGetSignResultFailed
+		return signStatus, errGetSignResultFailed
 	}
 	log.Info("get sign status success", "keyID", keyID, "retryCount", i)
-	return rsvs, nil
+	return signStatus, nil
 }
 
 // BuildDcrmRawTx build dcrm raw tx
@@ -0,0 +1,241 @@
+package dcrm
+
+import (
+	"crypto/rand"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/log"
+)
+
+const (
+	minGroupBackoff = 30 * time.Second
+	maxGroupBackoff = 30 * time.Minute
+
+	latencySmoothFactor = 0.2 // weight of the latest latency in average
+)
+
+var (
+	signStatsLock sync.Mutex
+	nodeStats     = make(map[string]*NodeStat)  // key is dcrm user
+	groupStats    = make(map[string]*GroupStat) // key is dcrm user + group id
+)
+
+// NodeStat sign statistics of dcrm initiator node
+type NodeStat struct {
+	Initiator       string `json:"initiator"`
+	PingFailures    uint64 `json:"pingFailures"`
+	RPCFailures     uint64 `json:"rpcFailures"`
+	SuccessCount    uint64 `json:"successCount"`
+	FailureCount    uint64 `json:"failureCount"`
+	LastFailureTime int64  `json:"lastFailureTime,omitempty"`
+	LastError       string `json:"lastError,omitempty"`
+}
+
+// GroupStat sign statistics of dcrm sign group
+type GroupStat struct {
+	Initiator           string            `json:"initiator"`
+	GroupID             string            `json:"groupID"`
+	SuccessCount        uint64            `json:"successCount"`
+	FailureCount        uint64            `json:"failureCount"`
+	ConsecutiveFailures uint64            `json:"consecutiveFailures"`
+	AvgLatencyMillis    int64             `json:"avgLatencyMillis"`
+	LastSuccessTime     int64             `json:"lastSuccessTime,omitempty"`
+	LastFailureTime     int64             `json:"lastFailureTime,omitempty"`
+	LastError           string            `json:"lastError,omitempty"`
+	BackoffUntil        int64             `json:"backoffUntil,omitempty"`
+	Score               float64           `json:"score"`
+	NotAcceptedOracles  map[string]uint64 `json:"notAcceptedOracles,omitempty"` // enode -> count
+}
+
+// SignStats dcrm sign statistics
+type SignStats struct {
+	Nodes  []*NodeStat  `json:"nodes"`
+	Groups []*GroupStat `json:"groups"`
+}
+
+func getGroupStatKey(initiator, groupID string) string {
+	return strings.ToLower(initiator + ":" + groupID)
+}
+
+func getNodeStat(dcrmNode *NodeInfo) *NodeStat {
+	initiator := dcrmNode.dcrmUser.String()
+	stat, exist := nodeStats[initiator]
+	if !exist {
+		stat = &NodeStat{Initiator: initiator}
+		nodeStats[initiator] = stat
+	}
+	return stat
+}
+
+func getGroupStat(dcrmNode *NodeInfo, groupID string) *GroupStat {
+	initiator := dcrmNode.dcrmUser.String()
+	key := getGroupStatKey(initiator, groupID)
+	stat, exist := groupStats[key]
+	if !exist {
+		stat = &GroupStat{Initiator: initiator, GroupID: groupID}
+		groupStats[key] = stat
+	}
+	return stat
+}
+
+// calcScore success rate with Laplace smoothing, so new group has score 0.5
+func (s *GroupStat) calcScore() float64 {
+	return float64(s.SuccessCount+1) / float64(s.SuccessCount+s.FailureCount+2)
+}
+
+func (s *GroupStat) isInBackoff(now int64) bool {
+	return s.BackoffUntil > now
+}
+
+func recordPingResult(dcrmNode *NodeInfo, err error) {
+	if err == nil {
+		return
+	}
+	signStatsLock.Lock()
+	defer signStatsLock.Unlock()
+
+	stat := getNodeStat(dcrmNode)
+	stat.PingFailures++
+	stat.LastFailureTime = time.Now().Unix()
+	stat.LastError = err.Error()
+}
+
+// recordSignRPCError record error of calling sign rpc of initiator node,
+// it's not a failure of the sign group as the sign is not started
+func recordSignRPCError(dcrmNode *NodeInfo, err error) {
+	signStatsLock.Lock()
+	defer signStatsLock.Unlock()
+
+	stat := getNodeStat(dcrmNode)
+	stat.RPCFailures++
+	stat.LastFailureTime = time.Now().Unix()
+	stat.LastError = err.Error()
+}
+
+// recordSignResult record sign result of sign group,
+// failing group is backed off exponentially by its consecutive failures
+func recordSignResult(dcrmNode *NodeInfo, groupID string, latency time.Duration, signStatus *SignStatus, err error) {
+	signStatsLock.Lock()
+	defer signStatsLock.Unlock()
+
+	now := time.Now().Unix()
+	nodeStat := getNodeStat(dcrmNode)
+	stat := getGroupStat(dcrmNode, groupID)
+	if err == nil {
+		nodeStat.SuccessCount++
+		stat.SuccessCount++
+		stat.ConsecutiveFailures = 0
+		stat.LastSuccessTime = now
+		stat.BackoffUntil = 0
+		latencyMillis := latency.Milliseconds()
+		if stat.AvgLatencyMillis == 0 {
+			stat.AvgLatencyMillis = latencyMillis
+		} else {
+			stat.AvgLatencyMillis = int64(float64(stat.AvgLatencyMillis)*(1-latencySmoothFactor) + float64(latencyMillis)*latencySmoothFactor)
+		}
+	} else {
+		nodeStat.FailureCount++
+		nodeStat.LastFailureTime = now
+		nodeStat.LastError = err.Error()
+		stat.FailureCount++
+		stat.ConsecutiveFailures++
+		stat.LastFailureTime = now
+		stat.LastError = err.Error()
+		backoff := minGroupBackoff << (stat.ConsecutiveFailures - 1)
+		if backoff > maxGroupBackoff || backoff <= 0 {
+			backoff = maxGroupBackoff
+		}
+		stat.BackoffUntil = time.Now().Add(backoff).Unix()
+		log.Warn("dcrm sign group backoff", "initiator", stat.Initiator, "groupID", groupID, "failures", stat.ConsecutiveFailures, "backoff", backoff.String())
+	}
+	if signStatus != nil {
+		for _, reply := range signStatus.AllReply {
+			if reply == nil || strings.EqualFold(reply.Status, "AGREE") {
+				continue
+			}
+			if stat.NotAcceptedOracles == nil {
+				stat.NotAcceptedOracles = make(map[string]uint64)
+			}
+			stat.NotAcceptedOracles[reply.Enode]++
+		}
+	}
+	stat.Score = stat.calcScore()
+}
+
+// getOrderedSignGroups get sign groups of node ordered by score,
+// groups in backoff are excluded unless 'includeBackoff' is true (put at last)
+func getOrderedSignGroups(dcrmNode *NodeInfo, includeBackoff bool) []string {
+	signStatsLock.Lock()
+	defer signStatsLock.Unlock()
+
+	type scoredGroup struct {
+		groupID   string
+		score     float64
+		inBackoff bool
+		tiebreak  int64
+	}
+
+	now := time.Now().Unix()
+	groups := make([]*scoredGroup, 0, len(dcrmNode.signGroups))
+	for _, groupID := range dcrmNode.signGroups {
+		stat := getGroupStat(dcrmNode, groupID)
+		inBackoff := stat.isInBackoff(now)
+		if inBackoff && !includeBackoff {
+			continue
+		}
+		// randomly break ties to spread load among groups of the same score
+		tiebreak, _ := rand.Int(rand.Reader, big.NewInt(1<<32))
+		groups = append(groups, &scoredGroup{
+			groupID:   groupID,
+			score:     stat.calcScore(),
+			inBackoff: inBackoff,
+			tiebreak:  tiebreak.Int64(),
+		})
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].inBackoff != groups[j].inBackoff {
+			return !groups[i].inBackoff
+		}
+		if groups[i].score != groups[j].score {
+			return groups[i].score > groups[j].score
+		}
+		return groups[i].tiebreak < groups[j].tiebreak
+	})
+	result := make([]string, len(groups))
+	for i, group := range groups {
+		result[i] = group.groupID
+	}
+	return result
+}
+
+// GetSignStats get dcrm sign statistics of nodes and groups (server only)
+func GetSignStats() *SignStats {
+	signStatsLock.Lock()
+	defer signStatsLock.Unlock()
+
+	stats := &SignStats{
+		Nodes:  make([]*NodeStat, 0, len(allInitiatorNodes)),
+		Groups: make([]*GroupStat, 0, len(groupStats)),
+	}
+	for _, dcrmNode := range allInitiatorNodes {
+		nodeStat := *getNodeStat(dcrmNode)
+		stats.Nodes = append(stats.Nodes, &nodeStat)
+		for _, groupID := range dcrmNode.signGroups {
+			groupStat := *getGroupStat(dcrmNode, groupID)
+			groupStat.Score = groupStat.calcScore()
+			if groupStat.NotAcceptedOracles != nil {
+				notAccepted := make(map[string]uint64, len(groupStat.NotAcceptedOracles))
+				for enode, count := range groupStat.NotAcceptedOracles {
+					notAccepted[enode] = count
+				}
+				groupStat.NotAcceptedOracles = notAccepted
+			}
+			stats.Groups = append(stats.Groups, &groupStat)
+		}
+	}
+	return stats
+}
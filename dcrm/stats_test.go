@@ -0,0 +1,63 @@
+package dcrm
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+)
+
+func TestSignGroupSelection(t *testing.T) {
+	node := &NodeInfo{
+		dcrmUser:   common.HexToAddress("0x1111111111111111111111111111111111111111"),
+		signGroups: []string{"group1", "group2", "group3"},
+	}
+
+	if groups := getOrderedSignGroups(node, false); len(groups) != 3 {
+		t.Fatalf("new groups should all be available, have %v", groups)
+	}
+
+	errTest := errors.New("test sign failed")
+	recordSignResult(node, "group1", time.Second, &SignStatus{
+		AllReply: []*SignReply{{Enode: "enode1", Status: "AGREE"}, {Enode: "enode2", Status: "DISAGREE"}},
+	}, errTest)
+	recordSignResult(node, "group2", time.Second, nil, nil)
+
+	groups := getOrderedSignGroups(node, false)
+	if len(groups) != 2 || groups[0] != "group2" || groups[1] != "group3" {
+		t.Fatalf("wrong ordered groups without backoff, have %v", groups)
+	}
+	groups = getOrderedSignGroups(node, true)
+	if len(groups) != 3 || groups[2] != "group1" {
+		t.Fatalf("backoff group should be the last, have %v", groups)
+	}
+
+	stats := GetSignStats()
+	if len(stats.Groups) != 0 {
+		t.Fatalf("stats of non initiator node should not be reported")
+	}
+	stat := getGroupStat(node, "group1")
+	if stat.NotAcceptedOracles["enode2"] != 1 || stat.NotAcceptedOracles["enode1"] != 0 {
+		t.Fatalf("wrong not accepted oracles %v", stat.NotAcceptedOracles)
+	}
+	firstBackoff := stat.BackoffUntil
+
+	recordSignResult(node, "group1", time.Second, nil, errTest)
+	if stat.ConsecutiveFailures != 2 || stat.BackoffUntil <= firstBackoff {
+		t.Fatalf("backoff should increase exponentially")
+	}
+
+	recordSignResult(node, "group1", time.Second, nil, nil)
+	if stat.ConsecutiveFailures != 0 || stat.BackoffUntil != 0 {
+		t.Fatalf("backoff should be reset after success")
+	}
+
+	recordSignRPCError(node, errTest)
+	if stat.FailureCount != 2 || stat.BackoffUntil != 0 {
+		t.Fatalf("sign rpc error should not be charged to sign group")
+	}
+	if nodeStat := getNodeStat(node); nodeStat.RPCFailures != 1 || nodeStat.FailureCount != 2 {
+		t.Fatalf("sign rpc error should be recorded to node, have %+v", nodeStat)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/anyswap/CrossChain-Bridge/params"
 	"github.com/anyswap/CrossChain-Bridge/tokens"
 	"github.com/anyswap/CrossChain-Bridge/tokens/btc"
+	"github.com/anyswap/CrossChain-Bridge/worker"
 	"github.com/btcsuite/btcd/txscript"
 	rpcjson "github.com/gorilla/rpc/v2/json2"
 )
@@ -21,6 +22,8 @@ var (
 	errTokenPairNotExist = newRPCError(-32095, "token pair not exist")
 	errSwapCannotRetry   = newRPCError(-32094, "swap can not retry")
 
+	errDcrmSignNotEnabled = newRPCError(-32093, "dcrm sign is not enabled")
+
 	oraclesHeartbeats sync.Map // string -> int64 // key is enode
 )
 
@@ -49,6 +52,8 @@ func GetServerInfo() (*ServerInfo, error) {
 		DestChain:           config.DestChain,
 		PairIDs:             tokens.GetAllPairIDs(),
 		Version:             params.VersionWithMeta,
+		SrcFinalizedHeight:  tokens.GetFinalizedHeight(true),
+		DestFinalizedHeight: tokens.GetFinalizedHeight(false),
 	}, nil
 }
 
@@ -130,6 +135,19 @@ func GetNonceInfo() (*SwapNonceInfo, error) {
 	}, nil
 }
 
+// GetDcrmSignStats api
+func GetDcrmSignStats() (*dcrm.SignStats, error) {
+	if !params.IsDcrmEnabled() || !dcrm.IsSwapServer() {
+		return nil, errDcrmSignNotEnabled
+	}
+	return dcrm.GetSignStats(), nil
+}
+
+// GetSwapQueueStatus api
+func GetSwapQueueStatus() ([]*SwapQueueStatus, error) {
+	return worker.GetSwapQueueStatus(), nil
+}
+
 // GetRawSwapin api
 func GetRawSwapin(txid, pairID, bindAddr *string) (*Swap, error) {
 	return mongodb.FindSwapin(*txid, *pairID, *bindAddr)
@@ -147,11 +165,11 @@ func GetSwapin(txid, pairID, bindAddr *string) (*SwapInfo, error) {
 	bindStr := *bindAddr
 	result, err := mongodb.FindSwapinResult(txidstr, pairIDStr, bindStr)
 	if err == nil {
-		return ConvertMgoSwapResultToSwapInfo(result), nil
+		return withSwapQueuePosition(ConvertMgoSwapResultToSwapInfo(result), true), nil
 	}
 	register, err := mongodb.FindSwapin(txidstr, pairIDStr, bindStr)
 	if err == nil {
-		return ConvertMgoSwapToSwapInfo(register), nil
+		return withSwapQueuePosition(ConvertMgoSwapToSwapInfo(register), true), nil
 	}
 	return nil, mongodb.ErrSwapNotFound
 }
@@ -173,15 +191,25 @@ func GetSwapout(txid, pairID, bindAddr *string) (*SwapInfo, error) {
 	bindStr := *bindAddr
 	result, err := mongodb.FindSwapoutResult(txidstr, pairIDStr, bindStr)
 	if err == nil {
-		return ConvertMgoSwapResultToSwapInfo(result), nil
+		return withSwapQueuePosition(ConvertMgoSwapResultToSwapInfo(result), false), nil
 	}
 	register, err := mongodb.FindSwapout(txidstr, pairIDStr, bindStr)
 	if err == nil {
-		return ConvertMgoSwapToSwapInfo(register), nil
+		return withSwapQueuePosition(ConvertMgoSwapToSwapInfo(register), false), nil
 	}
 	return nil, mongodb.ErrSwapNotFound
 }
 
+// GetSwapHistoryEvents api
+func GetSwapHistoryEvents(txid, pairID, bindAddr *string) ([]*SwapStatusEvent, error) {
+	return mongodb.GetSwapStatusEvents(*txid, *pairID, *bindAddr)
+}
+
+func withSwapQueuePosition(info *SwapInfo, isSwapin bool) *SwapInfo {
+	info.QueuePosition, info.QueueDepth = worker.GetSwapQueuePosition(isSwapin, info.PairID, info.TxID, info.Bind)
+	return info
+}
+
 func processHistoryLimit(limit int) int {
 	switch {
 	case limit == 0:
@@ -245,7 +273,7 @@ func RetrySwapin(txid, pairID *string) (*PostResult, error) {
 	if !swap.Status.CanRetry() {
 		return nil, errSwapCannotRetry
 	}
-	err = mongodb.UpdateSwapinStatus(txidstr, pairIDStr, bindStr, mongodb.TxNotStable, time.Now().Unix(), "")
+	err = mongodb.UpdateSwapStatusBy(true, txidstr, pairIDStr, bindStr, mongodb.TxNotStable, time.Now().Unix(), "", mongodb.ActorUser, "retry swapin")
 	if err != nil {
 		return nil, err
 	}
@@ -383,7 +411,7 @@ func P2shSwapin(txid, bindAddr *string) (*PostResult, error) {
 		return nil, errNotBtcBridge
 	}
 	txidstr := *txid
-	pairID := btc.PairID
+	pairID := btc.BridgeInstance.GetPairID()
 	if swap, _ := mongodb.FindSwapin(txidstr, pairID, *bindAddr); swap != nil {
 		return nil, mongodb.ErrItemIsDup
 	}
@@ -418,7 +446,12 @@ func P2shSwapin(txid, bindAddr *string) (*PostResult, error) {
 
 // GetLatestScanInfo api
 func GetLatestScanInfo(isSrc bool) (*LatestScanInfo, error) {
-	return mongodb.FindLatestScanInfo(isSrc)
+	info, err := mongodb.FindLatestScanInfo(isSrc)
+	if err != nil {
+		return nil, err
+	}
+	info.FinalizedHeight = tokens.GetFinalizedHeight(isSrc)
+	return info, nil
 }
 
 // RegisterAddress register address for ETH like chain
@@ -1,6 +1,7 @@
 package swapapi
 
 import (
+	"github.com/anyswap/CrossChain-Bridge/common"
 	"github.com/anyswap/CrossChain-Bridge/mongodb"
 	"github.com/anyswap/CrossChain-Bridge/tokens"
 )
@@ -65,7 +66,24 @@ func ConvertMgoSwapResultToSwapInfo(mr *mongodb.MgoSwapResult) *SwapInfo {
 		Memo:          mr.Memo,
 		ReplaceCount:  len(mr.OldSwapTxs),
 		Confirmations: confirmations,
+
+		RequiredConfirmations: getRequiredConfirmations(mr),
+
+		RefundTx:     mr.RefundTx,
+		RefundTo:     mr.RefundTo,
+		RefundValue:  mr.RefundValue,
+		RefundHeight: mr.RefundHeight,
+	}
+}
+
+// getRequiredConfirmations get required confirmations of the deposit tx (by value tier)
+func getRequiredConfirmations(mr *mongodb.MgoSwapResult) uint64 {
+	isSrc := mr.SwapType == uint32(tokens.SwapinType)
+	value, err := common.GetBigIntFromStr(mr.Value)
+	if err != nil {
+		return tokens.GetMinRequiredConfirmations(mr.PairID, isSrc)
 	}
+	return tokens.GetRequiredConfirmations(mr.PairID, value, isSrc)
 }
 
 // ConvertMgoSwapResultsToSwapInfos convert
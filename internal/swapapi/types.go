@@ -3,6 +3,7 @@ package swapapi
 import (
 	"github.com/anyswap/CrossChain-Bridge/mongodb"
 	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/worker"
 )
 
 // SwapStatus type alias
@@ -14,6 +15,9 @@ type Swap = mongodb.MgoSwap
 // SwapResult type alias
 type SwapResult = mongodb.MgoSwapResult
 
+// SwapStatusEvent type alias
+type SwapStatusEvent = mongodb.MgoSwapStatusEvent
+
 // LatestScanInfo type alias
 type LatestScanInfo = mongodb.MgoLatestScanInfo
 
@@ -28,6 +32,8 @@ type ServerInfo struct {
 	DestChain           *tokens.ChainConfig
 	PairIDs             []string
 	Version             string
+	SrcFinalizedHeight  uint64 `json:",omitempty"`
+	DestFinalizedHeight uint64 `json:",omitempty"`
 }
 
 // PostResult post result
@@ -58,8 +64,21 @@ type SwapInfo struct {
 	Memo          string     `json:"memo"`
 	ReplaceCount  int        `json:"replaceCount"`
 	Confirmations uint64     `json:"confirmations"`
+
+	RequiredConfirmations uint64 `json:"requiredConfirmations"`
+
+	QueuePosition int `json:"queuePosition,omitempty"` // position in swap queue (start from 1)
+	QueueDepth    int `json:"queueDepth,omitempty"`
+
+	RefundTx     string `json:"refundtx,omitempty"` // refund tx on source chain
+	RefundTo     string `json:"refundto,omitempty"`
+	RefundValue  string `json:"refundvalue,omitempty"`
+	RefundHeight uint64 `json:"refundheight,omitempty"`
 }
 
+// SwapQueueStatus type alias
+type SwapQueueStatus = worker.SwapQueueStatus
+
 // SwapNonceInfo swap nonce info
 type SwapNonceInfo struct {
 	SwapinNonces  map[string]uint64 `json:"swapinNonces"`
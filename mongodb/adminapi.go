@@ -89,11 +89,11 @@ func passBigValue(txid, pairID, bind string, isSwapin bool) error {
 	if res.SwapTx != "" || res.SwapHeight != 0 || len(res.OldSwapTxs) > 0 {
 		return fmt.Errorf("already swapped with swaptx %v", res.SwapTx)
 	}
-	err = UpdateSwapResultStatus(isSwapin, txid, pairID, bind, MatchTxEmpty, time.Now().Unix(), "")
+	err = UpdateSwapResultStatusBy(isSwapin, txid, pairID, bind, MatchTxEmpty, time.Now().Unix(), "", ActorAdmin, "pass big value")
 	if err != nil {
 		return err
 	}
-	return UpdateSwapStatus(isSwapin, txid, pairID, bind, TxNotSwapped, time.Now().Unix(), "")
+	return UpdateSwapStatusBy(isSwapin, txid, pairID, bind, TxNotSwapped, time.Now().Unix(), "", ActorAdmin, "pass big value")
 }
 
 // ReverifySwapin reverify swapin
@@ -114,7 +114,7 @@ func reverifySwap(txid, pairID, bind string, isSwapin bool) error {
 	if !swap.Status.CanReverify() {
 		return fmt.Errorf("swap status is %v, no need to reverify", swap.Status.String())
 	}
-	return UpdateSwapStatus(isSwapin, txid, pairID, bind, TxNotStable, time.Now().Unix(), "")
+	return UpdateSwapStatusBy(isSwapin, txid, pairID, bind, TxNotStable, time.Now().Unix(), "", ActorAdmin, "reverify")
 }
 
 // Reswapin reswapin
@@ -145,12 +145,12 @@ func reswap(txid, pairID, bind string, isSwapin bool) error {
 	}
 
 	log.Info("[reswap] update status to TxNotSwapped to retry", "txid", txid, "pairID", pairID, "bind", bind, "swaptx", swapResult.SwapTx)
-	err = UpdateSwapResultStatus(isSwapin, txid, pairID, bind, Reswapping, time.Now().Unix(), "")
+	err = UpdateSwapResultStatusBy(isSwapin, txid, pairID, bind, Reswapping, time.Now().Unix(), "", ActorAdmin, "reswap")
 	if err != nil {
 		return err
 	}
 
-	return UpdateSwapStatus(isSwapin, txid, pairID, bind, TxNotSwapped, time.Now().Unix(), "")
+	return UpdateSwapStatusBy(isSwapin, txid, pairID, bind, TxNotSwapped, time.Now().Unix(), "", ActorAdmin, "reswap")
 }
 
 func checkCanReswap(res *MgoSwapResult, isSwapin bool) error {
@@ -170,7 +170,7 @@ func checkCanReswap(res *MgoSwapResult, isSwapin bool) error {
 	txStatus, txHash := getSwapResultsTxStatus(bridge, res)
 	if txStatus != nil && txStatus.BlockHeight > 0 &&
 		!txStatus.IsSwapTxOnChainAndFailed(bridge.GetTokenConfig(res.PairID)) {
-		_ = UpdateSwapResultStatus(isSwapin, res.TxID, res.PairID, res.Bind, MatchTxNotStable, time.Now().Unix(), "")
+		_ = UpdateSwapResultStatusBy(isSwapin, res.TxID, res.PairID, res.Bind, MatchTxNotStable, time.Now().Unix(), "", ActorAdmin, "reswap found swap tx succeed")
 		return fmt.Errorf("swap succeed with swaptx %v", txHash)
 	}
 
@@ -218,15 +218,54 @@ func ManualManageSwap(txid, pairID, bind, memo string, isSwapin, isPass bool) er
 			return passBigValue(txid, pairID, bind, isSwapin)
 		}
 		if swap.Status.CanReverify() || swap.Status == ManualMakeFail {
-			return UpdateSwapStatus(isSwapin, txid, pairID, bind, TxNotStable, time.Now().Unix(), memo)
+			return UpdateSwapStatusBy(isSwapin, txid, pairID, bind, TxNotStable, time.Now().Unix(), memo, ActorAdmin, "")
 		}
 	} else if swap.Status.CanManualMakeFail() {
-		_ = UpdateSwapResultStatus(isSwapin, txid, pairID, bind, ManualMakeFail, time.Now().Unix(), memo)
-		return UpdateSwapStatus(isSwapin, txid, pairID, bind, ManualMakeFail, time.Now().Unix(), memo)
+		_ = UpdateSwapResultStatusBy(isSwapin, txid, pairID, bind, ManualMakeFail, time.Now().Unix(), memo, ActorAdmin, "")
+		return UpdateSwapStatusBy(isSwapin, txid, pairID, bind, ManualMakeFail, time.Now().Unix(), memo, ActorAdmin, "")
 	}
 	return fmt.Errorf("swap status is %v, can not operate. txid=%v pairID=%v bind=%v isSwapin=%v isPass=%v", swap.Status.String(), txid, pairID, bind, isSwapin, isPass)
 }
 
+// MarkSwapinRefundable mark swapin which can never be swapped as refundable
+func MarkSwapinRefundable(txid, pairID, bind, actor, reason string) error {
+	swap, err := FindSwap(true, txid, pairID, bind)
+	if err != nil {
+		return err
+	}
+	if !swap.Status.CanRefund() {
+		return fmt.Errorf("swap status is %v, can not refund", swap.Status.String())
+	}
+	res, err := FindSwapResult(true, txid, pairID, bind)
+	if err != nil {
+		return err
+	}
+	if res.SwapTx != "" || res.SwapNonce != 0 || res.SwapHeight != 0 || len(res.OldSwapTxs) > 0 {
+		return fmt.Errorf("already swapped with swaptx %v", res.SwapTx)
+	}
+	err = UpdateSwapResultStatusBy(true, txid, pairID, bind, TxRefundable, time.Now().Unix(), "", actor, reason)
+	if err != nil {
+		return err
+	}
+	return UpdateSwapStatusBy(true, txid, pairID, bind, TxRefundable, time.Now().Unix(), "", actor, reason)
+}
+
+// RetrySwapinRefund refund swapin again after the refund tx is failed
+func RetrySwapinRefund(txid, pairID, bind string) error {
+	swap, err := FindSwap(true, txid, pairID, bind)
+	if err != nil {
+		return err
+	}
+	if swap.Status != RefundTxFailed {
+		return fmt.Errorf("swap status is %v, not %v", swap.Status.String(), RefundTxFailed.String())
+	}
+	err = UpdateSwapResultStatusBy(true, txid, pairID, bind, TxRefundable, time.Now().Unix(), "", ActorAdmin, "retry refund")
+	if err != nil {
+		return err
+	}
+	return UpdateSwapStatusBy(true, txid, pairID, bind, TxRefundable, time.Now().Unix(), "", ActorAdmin, "retry refund")
+}
+
 func getSwapResultsTxStatus(bridge tokens.CrossChainBridge, res *MgoSwapResult) (status *tokens.TxStatus, txHash string) {
 	var err error
 	if status, err = bridge.GetTransactionStatus(res.SwapTx); err == nil {
@@ -9,8 +9,10 @@ import (
 
 	"github.com/anyswap/CrossChain-Bridge/common"
 	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/tools/crypto"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
@@ -21,28 +23,47 @@ const (
 )
 
 var (
-	retryLock        sync.Mutex
+	swapStatusLock   sync.Mutex
 	updateResultLock sync.Mutex
 
+	workerStatusChange = &statusChange{actor: ActorWorker}
+
+	// SwapAddedHook is called after swap is registered (eg. to notify webhooks)
+	SwapAddedHook func(isSwapin bool, swap *MgoSwap)
+	// SwapStatusChangedHook is called after swap status transition event is added and the status lock is released
+	SwapStatusChangedHook func(event *MgoSwapStatusEvent)
+
 	maxCountOfResults = int64(1000)
 )
 
 // --------------- swapin and swapout uniform --------------------------------
 
-// UpdateSwapStatus update swap status
+// UpdateSwapStatus update swap status by worker
 func UpdateSwapStatus(isSwapin bool, txid, pairID, bind string, status SwapStatus, timestamp int64, memo string) error {
+	return UpdateSwapStatusBy(isSwapin, txid, pairID, bind, status, timestamp, memo, ActorWorker, "")
+}
+
+// UpdateSwapStatusBy update swap status by actor, reason defaults to memo
+func UpdateSwapStatusBy(isSwapin bool, txid, pairID, bind string, status SwapStatus, timestamp int64, memo, actor, reason string) error {
+	change := &statusChange{actor: actor, reason: reason}
 	if isSwapin {
-		return updateSwapStatus(collSwapin, txid, pairID, bind, status, timestamp, memo)
+		return updateSwapStatus(collSwapin, txid, pairID, bind, status, timestamp, memo, change)
 	}
-	return updateSwapStatus(collSwapout, txid, pairID, bind, status, timestamp, memo)
+	return updateSwapStatus(collSwapout, txid, pairID, bind, status, timestamp, memo, change)
 }
 
-// UpdateSwapResultStatus update swap result status
+// UpdateSwapResultStatus update swap result status by worker
 func UpdateSwapResultStatus(isSwapin bool, txid, pairID, bind string, status SwapStatus, timestamp int64, memo string) error {
+	return UpdateSwapResultStatusBy(isSwapin, txid, pairID, bind, status, timestamp, memo, ActorWorker, "")
+}
+
+// UpdateSwapResultStatusBy update swap result status by actor, reason defaults to memo
+func UpdateSwapResultStatusBy(isSwapin bool, txid, pairID, bind string, status SwapStatus, timestamp int64, memo, actor, reason string) error {
+	change := &statusChange{actor: actor, reason: reason}
 	if isSwapin {
-		return updateSwapResultStatus(collSwapinResult, txid, pairID, bind, status, timestamp, memo)
+		return updateSwapResultStatus(collSwapinResult, txid, pairID, bind, status, timestamp, memo, change)
 	}
-	return updateSwapResultStatus(collSwapoutResult, txid, pairID, bind, status, timestamp, memo)
+	return updateSwapResultStatus(collSwapoutResult, txid, pairID, bind, status, timestamp, memo, change)
 }
 
 // FindSwapResult find swap result
@@ -70,7 +91,7 @@ func AddSwapin(ms *MgoSwap) error {
 
 // UpdateSwapinStatus update swapin status
 func UpdateSwapinStatus(txid, pairID, bind string, status SwapStatus, timestamp int64, memo string) error {
-	return updateSwapStatus(collSwapin, txid, pairID, bind, status, timestamp, memo)
+	return updateSwapStatus(collSwapin, txid, pairID, bind, status, timestamp, memo, workerStatusChange)
 }
 
 // FindSwapin find swapin
@@ -97,7 +118,7 @@ func AddSwapout(ms *MgoSwap) error {
 
 // UpdateSwapoutStatus update swapout status
 func UpdateSwapoutStatus(txid, pairID, bind string, status SwapStatus, timestamp int64, memo string) error {
-	return updateSwapStatus(collSwapout, txid, pairID, bind, status, timestamp, memo)
+	return updateSwapStatus(collSwapout, txid, pairID, bind, status, timestamp, memo, workerStatusChange)
 }
 
 // FindSwapout find swapout
@@ -128,13 +149,16 @@ func addSwap(collection *mongo.Collection, ms *MgoSwap) error {
 	_, err := collection.InsertOne(clientCtx, ms)
 	if err == nil {
 		log.Info("mongodb add swap", "txid", ms.TxID, "pairID", ms.PairID, "bind", ms.Bind, "isSwapin", isSwapin(collection))
+		if SwapAddedHook != nil {
+			SwapAddedHook(isSwapin(collection), ms)
+		}
 	} else {
 		log.Debug("mongodb add swap", "txid", ms.TxID, "pairID", ms.PairID, "bind", ms.Bind, "isSwapin", isSwapin(collection), "err", err)
 	}
 	return mgoError(err)
 }
 
-func updateSwapStatus(collection *mongo.Collection, txid, pairID, bind string, status SwapStatus, timestamp int64, memo string) error {
+func updateSwapStatus(collection *mongo.Collection, txid, pairID, bind string, status SwapStatus, timestamp int64, memo string, change *statusChange) error {
 	pairID = strings.ToLower(pairID)
 	updates := bson.M{"status": status, "timestamp": timestamp}
 	if memo != "" {
@@ -142,15 +166,20 @@ func updateSwapStatus(collection *mongo.Collection, txid, pairID, bind string, s
 	} else if status == TxNotSwapped || status == TxNotStable {
 		updates["memo"] = ""
 	}
-	if status == TxNotStable {
-		retryLock.Lock()
-		defer retryLock.Unlock()
-		swap, _ := findSwap(collection, txid, pairID, bind)
-		if !(swap.Status.CanRetry() || swap.Status.CanReverify()) {
-			return nil
-		}
+	var event *MgoSwapStatusEvent
+	defer func() { notifySwapStatusChanged(event) }()
+	swapStatusLock.Lock()
+	defer swapStatusLock.Unlock()
+	swap, err := findSwap(collection, txid, pairID, bind)
+	if err != nil {
+		return err
 	}
-	_, err := collection.UpdateByID(clientCtx, GetSwapKey(txid, pairID, bind), bson.M{"$set": updates})
+	err = CheckSwapStatusTransition(swap.Status, status)
+	if err != nil {
+		log.Warn("mongodb update swap status failed", "txid", txid, "pairID", pairID, "bind", bind, "isSwapin", isSwapin(collection), "actor", change.actor, "err", err)
+		return err
+	}
+	_, err = collection.UpdateByID(clientCtx, GetSwapKey(txid, pairID, bind), bson.M{"$set": updates})
 	if err == nil {
 		printLog := log.Info
 		switch status {
@@ -159,6 +188,7 @@ func updateSwapStatus(collection *mongo.Collection, txid, pairID, bind string, s
 		default:
 		}
 		printLog("mongodb update swap status", "txid", txid, "pairID", pairID, "bind", bind, "status", status, "isSwapin", isSwapin(collection))
+		event = addSwapStatusEvent(collection, txid, pairID, bind, swap.Status, status, memo, change)
 	} else {
 		log.Debug("mongodb update swap status", "txid", txid, "pairID", pairID, "bind", bind, "status", status, "isSwapin", isSwapin(collection), "err", err)
 	}
@@ -247,7 +277,7 @@ func UpdateSwapinResult(txid, pairID, bind string, items *SwapResultUpdateItems)
 
 // UpdateSwapinResultStatus update swapin result status
 func UpdateSwapinResultStatus(txid, pairID, bind string, status SwapStatus, timestamp int64, memo string) error {
-	return updateSwapResultStatus(collSwapinResult, txid, pairID, bind, status, timestamp, memo)
+	return updateSwapResultStatus(collSwapinResult, txid, pairID, bind, status, timestamp, memo, workerStatusChange)
 }
 
 // FindSwapinResult find swapin result
@@ -291,6 +321,73 @@ func FindSwapResultsToReplace(status SwapStatus, septime int64, isSwapin bool) (
 	return result, mgoError(err)
 }
 
+// --------------- swapin refund --------------------------------
+
+// UpdateSwapinRefund update refund of swapin result, status is changed with transition check
+func UpdateSwapinRefund(txid, pairID, bind string, items *RefundUpdateItems) error {
+	return updateSwapRefund(collSwapinResult, txid, pairID, bind, items)
+}
+
+func updateSwapRefund(collection *mongo.Collection, txid, pairID, bind string, items *RefundUpdateItems) error {
+	pairID = strings.ToLower(pairID)
+	updates := bson.M{
+		"timestamp": items.Timestamp,
+	}
+	if items.Status != KeepStatus {
+		updates["status"] = items.Status
+	}
+	if items.RefundTx != "" {
+		updates["refundtx"] = items.RefundTx
+	}
+	if items.RefundTo != "" {
+		updates["refundto"] = items.RefundTo
+	}
+	if items.RefundValue != "" {
+		updates["refundvalue"] = items.RefundValue
+	}
+	if items.RefundNonce != 0 {
+		updates["refundnonce"] = items.RefundNonce
+	}
+	if items.RefundHeight != 0 {
+		updates["refundheight"] = items.RefundHeight
+	}
+	if items.RefundTime != 0 {
+		updates["refundtime"] = items.RefundTime
+	}
+	if items.Memo != "" {
+		updates["memo"] = items.Memo
+	}
+	var event *MgoSwapStatusEvent
+	defer func() { notifySwapStatusChanged(event) }()
+	updateResultLock.Lock()
+	defer updateResultLock.Unlock()
+	swapRes, err := findSwapResult(collection, txid, pairID, bind)
+	if err != nil {
+		return err
+	}
+	if items.RefundTx != "" && swapRes.RefundTx != "" && swapRes.RefundTx != items.RefundTx {
+		log.Error("forbid update refund tx again", "old", swapRes.RefundTx, "new", items.RefundTx)
+		return ErrForbidUpdateSwapTx
+	}
+	if items.Status != KeepStatus {
+		err = CheckSwapResultStatusTransition(swapRes.Status, items.Status)
+		if err != nil {
+			log.Warn("mongodb update swap refund failed", "txid", txid, "pairID", pairID, "bind", bind, "isSwapin", isSwapin(collection), "err", err)
+			return err
+		}
+	}
+	_, err = collection.UpdateByID(clientCtx, GetSwapKey(txid, pairID, bind), bson.M{"$set": updates})
+	if err == nil {
+		log.Info("mongodb update swap refund", "txid", txid, "pairID", pairID, "bind", bind, "updates", updates, "isSwapin", isSwapin(collection))
+		if items.Status != KeepStatus {
+			event = addSwapStatusEvent(collection, txid, pairID, bind, swapRes.Status, items.Status, items.Memo, workerStatusChange)
+		}
+	} else {
+		log.Debug("mongodb update swap refund", "txid", txid, "pairID", pairID, "bind", bind, "updates", updates, "isSwapin", isSwapin(collection), "err", err)
+	}
+	return mgoError(err)
+}
+
 // --------------- swapout result --------------------------------
 
 // AddSwapoutResult add swapout result
@@ -305,7 +402,7 @@ func UpdateSwapoutResult(txid, pairID, bind string, items *SwapResultUpdateItems
 
 // UpdateSwapoutResultStatus update swapout result status
 func UpdateSwapoutResultStatus(txid, pairID, bind string, status SwapStatus, timestamp int64, memo string) error {
-	return updateSwapResultStatus(collSwapoutResult, txid, pairID, bind, status, timestamp, memo)
+	return updateSwapResultStatus(collSwapoutResult, txid, pairID, bind, status, timestamp, memo, workerStatusChange)
 }
 
 // FindSwapoutResult find swapout result
@@ -376,35 +473,51 @@ func updateSwapResult(collection *mongo.Collection, txid, pairID, bind string, i
 	} else if items.Status == MatchTxNotStable {
 		updates["memo"] = ""
 	}
-	if items.SwapNonce != 0 || items.Status == MatchTxNotStable {
+	fromStatus := SwapStatus(KeepStatus)
+	var event *MgoSwapStatusEvent
+	defer func() { notifySwapStatusChanged(event) }()
+	if items.SwapNonce != 0 || items.Status != KeepStatus {
 		updateResultLock.Lock()
 		defer updateResultLock.Unlock()
 		swapRes, err := findSwapResult(collection, txid, pairID, bind)
 		if err != nil {
 			return err
 		}
-		if swapRes.SwapNonce != 0 {
-			log.Error("forbid update swap nonce again", "old", swapRes.SwapNonce, "new", items.SwapNonce)
-			return ErrForbidUpdateNonce
-		}
-		if swapRes.SwapTx != "" {
-			log.Error("forbid update swap tx again", "old", swapRes.SwapTx, "new", items.SwapTx)
-			return ErrForbidUpdateSwapTx
+		if items.SwapNonce != 0 || items.Status == MatchTxNotStable {
+			if swapRes.SwapNonce != 0 {
+				log.Error("forbid update swap nonce again", "old", swapRes.SwapNonce, "new", items.SwapNonce)
+				return ErrForbidUpdateNonce
+			}
+			if swapRes.SwapTx != "" {
+				log.Error("forbid update swap tx again", "old", swapRes.SwapTx, "new", items.SwapTx)
+				return ErrForbidUpdateSwapTx
+			}
+			if items.SwapNonce != 0 {
+				updates["swapnonce"] = items.SwapNonce
+			}
 		}
-		if items.SwapNonce != 0 {
-			updates["swapnonce"] = items.SwapNonce
+		if items.Status != KeepStatus {
+			err = CheckSwapResultStatusTransition(swapRes.Status, items.Status)
+			if err != nil {
+				log.Warn("mongodb update swap result failed", "txid", txid, "pairID", pairID, "bind", bind, "isSwapin", isSwapin(collection), "err", err)
+				return err
+			}
+			fromStatus = swapRes.Status
 		}
 	}
 	_, err := collection.UpdateByID(clientCtx, GetSwapKey(txid, pairID, bind), bson.M{"$set": updates})
 	if err == nil {
 		log.Info("mongodb update swap result", "txid", txid, "pairID", pairID, "bind", bind, "updates", updates, "isSwapin", isSwapin(collection))
+		if items.Status != KeepStatus {
+			event = addSwapStatusEvent(collection, txid, pairID, bind, fromStatus, items.Status, items.Memo, workerStatusChange)
+		}
 	} else {
 		log.Debug("mongodb update swap result", "txid", txid, "pairID", pairID, "bind", bind, "updates", updates, "isSwapin", isSwapin(collection), "err", err)
 	}
 	return mgoError(err)
 }
 
-func updateSwapResultStatus(collection *mongo.Collection, txid, pairID, bind string, status SwapStatus, timestamp int64, memo string) error {
+func updateSwapResultStatus(collection *mongo.Collection, txid, pairID, bind string, status SwapStatus, timestamp int64, memo string, change *statusChange) error {
 	pairID = strings.ToLower(pairID)
 	updates := bson.M{"status": status, "timestamp": timestamp}
 	if memo != "" {
@@ -418,10 +531,31 @@ func updateSwapResultStatus(collection *mongo.Collection, txid, pairID, bind str
 		updates["swaptime"] = 0
 		updates["swapnonce"] = 0
 	}
-	_, err := collection.UpdateByID(clientCtx, GetSwapKey(txid, pairID, bind), bson.M{"$set": updates})
+	if status == TxRefundable {
+		updates["refundtx"] = ""
+		updates["refundvalue"] = ""
+		updates["refundnonce"] = 0
+		updates["refundheight"] = 0
+		updates["refundtime"] = 0
+	}
 	isSwapin := isSwapin(collection)
+	var event *MgoSwapStatusEvent
+	defer func() { notifySwapStatusChanged(event) }()
+	updateResultLock.Lock()
+	defer updateResultLock.Unlock()
+	swapRes, err := findSwapResult(collection, txid, pairID, bind)
+	if err != nil {
+		return err
+	}
+	err = CheckSwapResultStatusTransition(swapRes.Status, status)
+	if err != nil {
+		log.Warn("mongodb update swap result status failed", "txid", txid, "pairID", pairID, "bind", bind, "isSwapin", isSwapin, "actor", change.actor, "err", err)
+		return err
+	}
+	_, err = collection.UpdateByID(clientCtx, GetSwapKey(txid, pairID, bind), bson.M{"$set": updates})
 	if err == nil {
 		log.Info("mongodb update swap result status", "txid", txid, "pairID", pairID, "bind", bind, "status", status, "isSwapin", isSwapin)
+		event = addSwapStatusEvent(collection, txid, pairID, bind, swapRes.Status, status, memo, change)
 	} else {
 		log.Debug("mongodb update swap result status", "txid", txid, "pairID", pairID, "bind", bind, "status", status, "isSwapin", isSwapin, "err", err)
 	}
@@ -437,6 +571,21 @@ func findSwapResult(collection *mongo.Collection, txid, pairID, bind string) (*M
 	return result, nil
 }
 
+// FindSwapResultByKey find swap result by key (see GetSwapKey) in swapout and swapin results
+func FindSwapResultByKey(key string) (*MgoSwapResult, error) {
+	result := &MgoSwapResult{}
+	for _, collection := range []*mongo.Collection{collSwapoutResult, collSwapinResult} {
+		err := collection.FindOne(clientCtx, bson.M{"_id": key}).Decode(result)
+		if err == nil {
+			return result, nil
+		}
+		if !errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, mgoError(err)
+		}
+	}
+	return nil, ErrItemNotFound
+}
+
 func findSwapResultsWithStatus(collection *mongo.Collection, status SwapStatus, septime int64) (result []*MgoSwapResult, err error) {
 	err = findSwapsOrSwapResultsWithStatus(&result, collection, status, septime)
 	return result, err
@@ -757,6 +906,149 @@ func GetSwapHistory(isSwapin bool, txid, bind string) ([]*MgoSwapHistory, error)
 	return result, mgoError(err)
 }
 
+// ---------------------- swap status events -----------------------------
+
+// statusChange actor and reason of swap status transition
+type statusChange struct {
+	actor  string
+	reason string
+}
+
+// addSwapStatusEvent add status transition event, the returned event is notified
+// by notifySwapStatusChanged after the status lock is released
+func addSwapStatusEvent(collection *mongo.Collection, txid, pairID, bind string, from, to SwapStatus, memo string, change *statusChange) *MgoSwapStatusEvent {
+	if from == to {
+		return nil
+	}
+	reason := change.reason
+	if reason == "" {
+		reason = memo
+	}
+	event := &MgoSwapStatusEvent{
+		Key:        newObjectID(),
+		IsSwapin:   isSwapin(collection),
+		IsResult:   collection == collSwapinResult || collection == collSwapoutResult,
+		TxID:       txid,
+		PairID:     strings.ToLower(pairID),
+		Bind:       bind,
+		FromStatus: from,
+		ToStatus:   to,
+		Actor:      change.actor,
+		Reason:     reason,
+		Timestamp:  common.NowMilli(),
+	}
+	_, err := collSwapStatusEvent.InsertOne(clientCtx, event)
+	if err != nil {
+		log.Warn("mongodb add swap status event failed", "txid", txid, "pairID", pairID, "bind", bind, "from", from.String(), "to", to.String(), "err", err)
+	}
+	return event
+}
+
+func notifySwapStatusChanged(event *MgoSwapStatusEvent) {
+	if event != nil && SwapStatusChangedHook != nil {
+		SwapStatusChangedHook(event)
+	}
+}
+
+// GetSwapStatusEvents get status transition events of swap ordered by time,
+// pairID and bind are optional
+func GetSwapStatusEvents(txid, pairID, bind string) ([]*MgoSwapStatusEvent, error) {
+	queries := []bson.M{{"txid": txid}}
+	if pairID != "" {
+		queries = append(queries, bson.M{"pairid": strings.ToLower(pairID)})
+	}
+	if bind != "" {
+		queries = append(queries, bson.M{"bind": bind})
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "timestamp", Value: 1}}).SetLimit(maxCountOfResults)
+	cur, err := collSwapStatusEvent.Find(clientCtx, bson.M{"$and": queries}, opts)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	result := make([]*MgoSwapStatusEvent, 0, 10)
+	err = cur.All(clientCtx, &result)
+	return result, mgoError(err)
+}
+
+// ---------------------- webhook deliveries -----------------------------
+
+// AddWebhookDelivery add webhook delivery to outbox
+func AddWebhookDelivery(item *MgoWebhookDelivery) error {
+	item.InitTime = common.NowMilli()
+	item.Timestamp = item.InitTime
+	_, err := collWebhookDelivery.InsertOne(clientCtx, item)
+	if err == nil {
+		log.Info("mongodb add webhook delivery success", "webhook", item.Webhook, "event", item.EventType, "txid", item.TxID)
+	} else {
+		log.Warn("mongodb add webhook delivery failed", "webhook", item.Webhook, "event", item.EventType, "txid", item.TxID, "err", err)
+	}
+	return mgoError(err)
+}
+
+// FindWebhookDeliveriesToSend find pending webhook deliveries whose retry time is due
+func FindWebhookDeliveriesToSend(now int64, limit int64) ([]*MgoWebhookDelivery, error) {
+	qstatus := bson.M{"status": WebhookDeliveryPending}
+	qtime := bson.M{"nextretry": bson.M{"$lte": now}}
+	opts := options.Find().SetSort(bson.D{{Key: "nextretry", Value: 1}}).SetLimit(limit)
+	cur, err := collWebhookDelivery.Find(clientCtx, bson.M{"$and": []bson.M{qstatus, qtime}}, opts)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	result := make([]*MgoWebhookDelivery, 0, limit)
+	err = cur.All(clientCtx, &result)
+	return result, mgoError(err)
+}
+
+// UpdateWebhookDelivery update webhook delivery status after delivering
+func UpdateWebhookDelivery(item *MgoWebhookDelivery) error {
+	item.Timestamp = common.NowMilli()
+	updates := bson.M{
+		"status":    item.Status,
+		"attempts":  item.Attempts,
+		"nextretry": item.NextRetry,
+		"lastcode":  item.LastCode,
+		"lasterror": item.LastError,
+		"timestamp": item.Timestamp,
+	}
+	_, err := collWebhookDelivery.UpdateByID(clientCtx, item.Key, bson.M{"$set": updates})
+	if err != nil {
+		log.Warn("mongodb update webhook delivery failed", "id", item.Key.Hex(), "status", item.Status, "err", err)
+	}
+	return mgoError(err)
+}
+
+// FindWebhookDelivery find webhook delivery by id
+func FindWebhookDelivery(id string) (*MgoWebhookDelivery, error) {
+	key, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+	result := &MgoWebhookDelivery{}
+	err = collWebhookDelivery.FindOne(clientCtx, bson.M{"_id": key}).Decode(result)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	return result, nil
+}
+
+// FindWebhookDeliveries find webhook deliveries of tx, the latest first
+func FindWebhookDeliveries(txid string) ([]*MgoWebhookDelivery, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "inittime", Value: -1}}).SetLimit(maxCountOfResults)
+	cur, err := collWebhookDelivery.Find(clientCtx, bson.M{"txid": txid}, opts)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	result := make([]*MgoWebhookDelivery, 0, 10)
+	err = cur.All(clientCtx, &result)
+	return result, mgoError(err)
+}
+
+// CountWebhookDeliveries count webhook deliveries of webhook with status
+func CountWebhookDeliveries(webhook, status string) (int64, error) {
+	count, err := collWebhookDelivery.CountDocuments(clientCtx, bson.M{"webhook": webhook, "status": status})
+	return count, mgoError(err)
+}
+
 // ---------------------- used rvalue -----------------------------
 
 // AddUsedRValue add used r, if error mean already exist
@@ -788,3 +1080,207 @@ func AddUsedRValue(pubkey, r string) error {
 		return mgoError(err)
 	}
 }
+
+// ---------------------- utxo reservation -----------------------------
+
+// GetUtxoReservationKey txid + vout
+func GetUtxoReservationKey(txid string, vout uint32) string {
+	return strings.ToLower(fmt.Sprintf("%v:%v", txid, vout))
+}
+
+// ReserveUtxo reserve utxo for swap, if error mean already reserved by others
+func ReserveUtxo(txid string, vout uint32, swapKey string) error {
+	key := GetUtxoReservationKey(txid, vout)
+	mr := &MgoUtxoReservation{
+		Key:       key,
+		TxID:      strings.ToLower(txid),
+		Vout:      vout,
+		SwapKey:   swapKey,
+		Timestamp: common.NowMilli(),
+	}
+	_, err := collUtxoReservation.InsertOne(clientCtx, mr)
+	if err == nil {
+		log.Info("mongodb reserve utxo success", "txid", txid, "vout", vout, "swapKey", swapKey)
+		return nil
+	}
+	old, errf := FindUtxoReservation(txid, vout)
+	if errf == nil && old.SwapKey == swapKey {
+		return nil // already reserved by the same swap
+	}
+	log.Warn("mongodb reserve utxo failed", "txid", txid, "vout", vout, "swapKey", swapKey, "err", err)
+	return mgoError(err)
+}
+
+// FindUtxoReservation find utxo reservation
+func FindUtxoReservation(txid string, vout uint32) (*MgoUtxoReservation, error) {
+	key := GetUtxoReservationKey(txid, vout)
+	result := &MgoUtxoReservation{}
+	err := collUtxoReservation.FindOne(clientCtx, bson.M{"_id": key}).Decode(result)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	return result, nil
+}
+
+// FindUtxoReservations find utxo reservations reserved before septime
+func FindUtxoReservations(septime int64) ([]*MgoUtxoReservation, error) {
+	qtime := bson.M{"timestamp": bson.M{"$lt": septime}}
+	opts := &options.FindOptions{
+		Sort:  bson.D{{Key: "timestamp", Value: 1}},
+		Limit: &maxCountOfResults,
+	}
+	cur, err := collUtxoReservation.Find(clientCtx, qtime, opts)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	result := make([]*MgoUtxoReservation, 0, 20)
+	err = cur.All(clientCtx, &result)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	return result, nil
+}
+
+// FindUtxoReservationsBySwapKey find utxos reserved by swap
+func FindUtxoReservationsBySwapKey(swapKey string) ([]*MgoUtxoReservation, error) {
+	cur, err := collUtxoReservation.Find(clientCtx, bson.M{"swapkey": swapKey})
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	result := make([]*MgoUtxoReservation, 0, 5)
+	err = cur.All(clientCtx, &result)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	return result, nil
+}
+
+// ReleaseUtxo release utxo reservation
+func ReleaseUtxo(txid string, vout uint32) error {
+	key := GetUtxoReservationKey(txid, vout)
+	_, err := collUtxoReservation.DeleteOne(clientCtx, bson.M{"_id": key})
+	if err == nil {
+		log.Info("mongodb release utxo success", "txid", txid, "vout", vout)
+	} else {
+		log.Warn("mongodb release utxo failed", "txid", txid, "vout", vout, "err", err)
+	}
+	return mgoError(err)
+}
+
+// RefreshUtxoReservations refresh timestamp of utxos reserved by swap
+func RefreshUtxoReservations(swapKey string) error {
+	_, err := collUtxoReservation.UpdateMany(clientCtx, bson.M{"swapkey": swapKey}, bson.M{"$set": bson.M{"timestamp": common.NowMilli()}})
+	if err != nil {
+		log.Warn("mongodb refresh utxo reservations failed", "swapKey", swapKey, "err", err)
+	}
+	return mgoError(err)
+}
+
+// ReleaseUtxosBySwapKey release all utxos reserved by swap
+func ReleaseUtxosBySwapKey(swapKey string) error {
+	res, err := collUtxoReservation.DeleteMany(clientCtx, bson.M{"swapkey": swapKey})
+	if err == nil {
+		if res.DeletedCount > 0 {
+			log.Info("mongodb release utxos success", "swapKey", swapKey, "count", res.DeletedCount)
+		}
+	} else {
+		log.Warn("mongodb release utxos failed", "swapKey", swapKey, "err", err)
+	}
+	return mgoError(err)
+}
+
+// ---------------------- sign session -----------------------------
+
+// GetSignSessionKey get sign session key of public key and msg hashes
+func GetSignSessionKey(pubkey string, msgHashes []string) string {
+	data := strings.ToLower(pubkey + ":" + strings.Join(msgHashes, ","))
+	return crypto.Keccak256Hash([]byte(data)).Hex()
+}
+
+// GetSwapSignSessionKey get sign session key of single swap
+func GetSwapSignSessionKey(swapKey string) string {
+	return crypto.Keccak256Hash([]byte(strings.ToLower("swap:" + swapKey))).Hex()
+}
+
+// AddSignSession add sign session (replace the old one of the same key when retry)
+func AddSignSession(session *MgoSignSession) error {
+	session.Status = SignSessionSigning
+	session.Timestamp = time.Now().Unix()
+	_, err := collSignSession.ReplaceOne(clientCtx, bson.M{"_id": session.Key}, session, options.Replace().SetUpsert(true))
+	if err == nil {
+		log.Info("mongodb add sign session success", "key", session.Key, "swapKeys", session.SwapKeys)
+	} else {
+		log.Warn("mongodb add sign session failed", "key", session.Key, "swapKeys", session.SwapKeys, "err", err)
+	}
+	return mgoError(err)
+}
+
+// FindSignSession find sign session by key
+func FindSignSession(key string) (*MgoSignSession, error) {
+	var result MgoSignSession
+	err := collSignSession.FindOne(clientCtx, bson.M{"_id": key}).Decode(&result)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	return &result, nil
+}
+
+// UpdateSignSessionKeyID update keyID of sign session (no effect if session not exist)
+func UpdateSignSessionKeyID(key, keyID, initiator string) error {
+	updates := bson.M{
+		"keyid":     keyID,
+		"initiator": initiator,
+		"timestamp": time.Now().Unix(),
+	}
+	res, err := collSignSession.UpdateByID(clientCtx, key, bson.M{"$set": updates})
+	if err == nil {
+		if res.MatchedCount > 0 {
+			log.Info("mongodb update sign session keyID success", "key", key, "keyID", keyID, "initiator", initiator)
+		}
+	} else {
+		log.Warn("mongodb update sign session keyID failed", "key", key, "keyID", keyID, "initiator", initiator, "err", err)
+	}
+	return mgoError(err)
+}
+
+// UpdateSignSessionStatus update sign session status (and rsvs if not empty)
+func UpdateSignSessionStatus(key, status string, rsvs []string) error {
+	updates := bson.M{
+		"status":    status,
+		"timestamp": time.Now().Unix(),
+	}
+	if len(rsvs) > 0 {
+		updates["rsvs"] = rsvs
+	}
+	_, err := collSignSession.UpdateByID(clientCtx, key, bson.M{"$set": updates})
+	if err == nil {
+		log.Info("mongodb update sign session status success", "key", key, "status", status)
+	} else {
+		log.Warn("mongodb update sign session status failed", "key", key, "status", status, "err", err)
+	}
+	return mgoError(err)
+}
+
+// FindUnfinishedSignSessions find sign sessions of swap txs which are signing or signed,
+// including sessions of single swap which has swap args but no raw txs.
+// sessions without swap txs are resumed when signing the same msg hashes again
+func FindUnfinishedSignSessions() ([]*MgoSignSession, error) {
+	qstatus := bson.M{
+		"status":     bson.M{"$in": []string{SignSessionSigning, SignSessionSigned}},
+		"swapargs.0": bson.M{"$exists": true},
+	}
+	opts := &options.FindOptions{
+		Sort:  bson.D{{Key: "timestamp", Value: 1}},
+		Limit: &maxCountOfResults,
+	}
+	cur, err := collSignSession.Find(clientCtx, qstatus, opts)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	result := make([]*MgoSignSession, 0, 20)
+	err = cur.All(clientCtx, &result)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	return result, nil
+}
@@ -35,4 +35,6 @@ var (
 	ErrWrongKey           = newError(-32012, "mgoError: Wrong key")
 	ErrForbidUpdateNonce  = newError(-32013, "mgoError: Forbid update swap nonce")
 	ErrForbidUpdateSwapTx = newError(-32014, "mgoError: Forbid update swap tx")
+
+	ErrIllegalStatusTransition = newError(-32015, "mgoError: Illegal swap status transition")
 )
@@ -5,7 +5,7 @@ import (
 )
 
 // -----------------------------------------------
-// swap status change graph
+// swap status change graph (enforced by the following transition tables)
 // symbol '--->' mean transfer only under checked condition (eg. manual process)
 //
 // -----------------------------------------------
@@ -17,10 +17,19 @@ import (
 //                |- TxWithBigValue        -> admin bigvalue ---> TxNotSwapped
 //                |- TxWithWrongMemo   -> manual
 //                |- TxWithWrongSender -> manual
-//                |- TxWithWrongValue  -> manual
-//                |- SwapInBlacklist   -> manual
-//                |- ManualMakeFail    -> manual
+//                |- TxWithWrongValue  -> admin reverify ---> TxNotStable
+//                |- SwapInBlacklist   -> admin reverify ---> TxNotStable
+//                |- ManualMakeFail    -> manual pass    ---> TxNotStable
 //                |- TxNotSwapped -> |- TxProcessed (->MatchTxNotStable or ->MatchTxFailed)
+//                                   |- SwapInBlacklist
+//                                   |- ManualMakeFail
+//                                   |- status of swap result if it is not swappable
+// TxProcessed -> admin reswap ---> TxNotSwapped
+// TxWithWrongMemo, TxWithWrongValue, BindAddrIsContract, SwapInBlacklist
+//      -> admin refund (or auto refund of wrong value) ---> TxRefundable
+// TxRefundable -> RefundTxNotStable -> |- RefundTxStable
+//                                      |- RefundTxFailed -> admin refund retry ---> TxRefundable
+// RefundTxNotStable -> tx dropped (or admin refund fail) ---> RefundTxFailed
 // -----------------------------------------------
 // 2. swap result status change graph
 //
@@ -28,9 +37,68 @@ import (
 // TxWithBigValue  -> admin bigvalue ---> MatchTxEmpty
 // MatchTxEmpty    -> |- MatchTxNotStable [admin replace]
 // -> |- MatchTxStable
-//    |- MatchTxFailed -> admin reswap ---> MatchTxEmpty
+//    |- MatchTxFailed -> admin reswap ---> Reswapping -> MatchTxNotStable
+// not swapped result -> manual make fail ---> ManualMakeFail
+// TxWithWrongMemo, TxWithWrongValue, BindAddrIsContract, MatchTxEmpty
+//      -> admin refund (or auto refund of wrong value) ---> TxRefundable
+// TxRefundable -> RefundTxNotStable -> |- RefundTxStable
+//                                      |- RefundTxFailed -> admin refund retry ---> TxRefundable
+// RefundTxNotStable -> tx dropped (or admin refund fail) ---> RefundTxFailed
 // -----------------------------------------------
 
+// swap register status transitions, keeping the same status is always allowed
+var swapStatusTransitions = map[SwapStatus][]SwapStatus{
+	TxNotStable: {
+		TxVerifyFailed,
+		TxWithWrongSender,
+		TxWithWrongValue,
+		TxNotSwapped,
+		TxWithWrongMemo,
+		TxWithBigValue,
+		TxSenderNotRegistered,
+		SwapInBlacklist,
+		ManualMakeFail,
+		BindAddrIsContract,
+	},
+	TxVerifyFailed:        {TxNotStable},
+	TxWithWrongMemo:       {TxRefundable},
+	TxWithWrongValue:      {TxNotStable, TxRefundable},
+	TxWithBigValue:        {TxNotStable, TxNotSwapped},
+	TxSenderNotRegistered: {TxNotStable},
+	SwapInBlacklist:       {TxNotStable, TxRefundable},
+	BindAddrIsContract:    {TxNotStable, TxRefundable},
+	ManualMakeFail:        {TxNotStable},
+	TxNotSwapped: {
+		TxProcessed,
+		SwapInBlacklist,
+		ManualMakeFail,
+		TxWithBigValue,
+		TxWithWrongMemo,
+		TxWithWrongValue,
+		BindAddrIsContract,
+	},
+	TxProcessed:       {TxNotSwapped},
+	TxRefundable:      {RefundTxNotStable},
+	RefundTxNotStable: {RefundTxStable, RefundTxFailed},
+	RefundTxFailed:    {TxRefundable},
+}
+
+// swap result status transitions, keeping the same status is always allowed
+var swapResultStatusTransitions = map[SwapStatus][]SwapStatus{
+	MatchTxEmpty:       {MatchTxNotStable, ManualMakeFail, TxRefundable},
+	Reswapping:         {MatchTxNotStable, ManualMakeFail},
+	TxWithBigValue:     {MatchTxEmpty, ManualMakeFail},
+	TxWithWrongMemo:    {ManualMakeFail, TxRefundable},
+	TxWithWrongValue:   {ManualMakeFail, TxRefundable},
+	BindAddrIsContract: {ManualMakeFail, TxRefundable},
+	ManualMakeFail:     {MatchTxNotStable},
+	MatchTxNotStable:   {MatchTxStable, MatchTxFailed},
+	MatchTxFailed:      {Reswapping, MatchTxNotStable},
+	TxRefundable:       {RefundTxNotStable},
+	RefundTxNotStable:  {RefundTxStable, RefundTxFailed},
+	RefundTxFailed:     {TxRefundable},
+}
+
 // SwapStatus swap status
 type SwapStatus uint16
 
@@ -54,11 +122,37 @@ const (
 	SwapInBlacklist                         // 15
 	ManualMakeFail                          // 16
 	BindAddrIsContract                      // 17
+	TxRefundable                            // 18
+	RefundTxNotStable                       // 19
+	RefundTxStable                          // 20
+	RefundTxFailed                          // 21
 
 	KeepStatus = 255
 	Reswapping = 256
 )
 
+// CheckSwapStatusTransition check swap register status transition
+func CheckSwapStatusTransition(from, to SwapStatus) error {
+	return checkStatusTransition(swapStatusTransitions, from, to)
+}
+
+// CheckSwapResultStatusTransition check swap result status transition
+func CheckSwapResultStatusTransition(from, to SwapStatus) error {
+	return checkStatusTransition(swapResultStatusTransitions, from, to)
+}
+
+func checkStatusTransition(transitions map[SwapStatus][]SwapStatus, from, to SwapStatus) error {
+	if from == to {
+		return nil
+	}
+	for _, status := range transitions[from] {
+		if status == to {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w from %v to %v", ErrIllegalStatusTransition, from.String(), to.String())
+}
+
 // CanManualMakeFail can manual make fail
 func (status SwapStatus) CanManualMakeFail() bool {
 	switch status {
@@ -90,6 +184,35 @@ func (status SwapStatus) CanReverify() bool {
 	}
 }
 
+// CanRefund can mark refundable (deposit that can never be swapped)
+func (status SwapStatus) CanRefund() bool {
+	switch status {
+	case
+		TxWithWrongMemo,
+		TxWithWrongValue,
+		BindAddrIsContract,
+		SwapInBlacklist:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsResultPending is swap result pending, utxos selected by it are kept reserved
+func (status SwapStatus) IsResultPending() bool {
+	switch status {
+	case
+		MatchTxEmpty,
+		MatchTxNotStable,
+		Reswapping,
+		TxRefundable,
+		RefundTxNotStable:
+		return true
+	default:
+		return false
+	}
+}
+
 // CanReswap can reswap
 func (status SwapStatus) CanReswap() bool {
 	return status == TxProcessed
@@ -134,6 +257,14 @@ func (status SwapStatus) String() string {
 		return "ManualMakeFail"
 	case BindAddrIsContract:
 		return "BindAddrIsContract"
+	case TxRefundable:
+		return "TxRefundable"
+	case RefundTxNotStable:
+		return "RefundTxNotStable"
+	case RefundTxStable:
+		return "RefundTxStable"
+	case RefundTxFailed:
+		return "RefundTxFailed"
 	case Reswapping:
 		return "Reswapping"
 	default:
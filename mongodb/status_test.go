@@ -0,0 +1,74 @@
+package mongodb
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSwapStatusTransition(t *testing.T) {
+	allowed := [][2]SwapStatus{
+		{TxNotStable, TxNotSwapped},
+		{TxNotStable, TxNotStable},
+		{TxWithBigValue, TxNotSwapped},
+		{TxNotSwapped, TxProcessed},
+		{TxProcessed, TxNotSwapped},
+		{ManualMakeFail, TxNotStable},
+		{TxWithWrongMemo, TxRefundable},
+		{SwapInBlacklist, TxRefundable},
+		{TxRefundable, RefundTxNotStable},
+		{RefundTxNotStable, RefundTxStable},
+		{RefundTxFailed, TxRefundable},
+	}
+	for _, c := range allowed {
+		if err := CheckSwapStatusTransition(c[0], c[1]); err != nil {
+			t.Errorf("transition from %v to %v should be allowed, %v", c[0], c[1], err)
+		}
+	}
+	illegal := [][2]SwapStatus{
+		{TxProcessed, TxNotStable},
+		{TxWithWrongMemo, TxNotStable},
+		{TxVerifyFailed, TxNotSwapped},
+		{TxNotSwapped, TxNotStable},
+		{TxNotSwapped, TxRefundable},
+		{TxProcessed, TxRefundable},
+		{TxRefundable, TxNotSwapped},
+		{RefundTxStable, TxRefundable},
+	}
+	for _, c := range illegal {
+		if err := CheckSwapStatusTransition(c[0], c[1]); !errors.Is(err, ErrIllegalStatusTransition) {
+			t.Errorf("transition from %v to %v should be illegal, %v", c[0], c[1], err)
+		}
+	}
+}
+
+func TestSwapResultStatusTransition(t *testing.T) {
+	allowed := [][2]SwapStatus{
+		{MatchTxEmpty, MatchTxNotStable},
+		{MatchTxNotStable, MatchTxStable},
+		{MatchTxNotStable, MatchTxFailed},
+		{MatchTxFailed, Reswapping},
+		{Reswapping, MatchTxNotStable},
+		{TxWithBigValue, MatchTxEmpty},
+		{TxWithWrongValue, TxRefundable},
+		{MatchTxEmpty, TxRefundable},
+		{RefundTxNotStable, RefundTxFailed},
+	}
+	for _, c := range allowed {
+		if err := CheckSwapResultStatusTransition(c[0], c[1]); err != nil {
+			t.Errorf("transition from %v to %v should be allowed, %v", c[0], c[1], err)
+		}
+	}
+	illegal := [][2]SwapStatus{
+		{MatchTxStable, MatchTxFailed},
+		{MatchTxStable, Reswapping},
+		{MatchTxEmpty, MatchTxStable},
+		{TxWithWrongMemo, MatchTxEmpty},
+		{MatchTxNotStable, TxRefundable},
+		{TxRefundable, MatchTxNotStable},
+	}
+	for _, c := range illegal {
+		if err := CheckSwapResultStatusTransition(c[0], c[1]); !errors.Is(err, ErrIllegalStatusTransition) {
+			t.Errorf("transition from %v to %v should be illegal, %v", c[0], c[1], err)
+		}
+	}
+}
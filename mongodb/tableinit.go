@@ -19,6 +19,10 @@ const (
 	tbLatestSwapNonces  string = "LatestSwapNonces"
 	tbSwapHistory       string = "SwapHistory"
 	tbUsedRValues       string = "UsedRValues"
+	tbUtxoReservations  string = "UtxoReservations"
+	tbSignSessions      string = "SignSessions"
+	tbSwapStatusEvents  string = "SwapStatusEvents"
+	tbWebhookDeliveries string = "WebhookDeliveries"
 
 	keyOfSrcLatestScanInfo string = "srclatest"
 	keyOfDstLatestScanInfo string = "dstlatest"
@@ -38,6 +42,10 @@ var (
 	collLatestSwapNonces  *mongo.Collection
 	collSwapHistory       *mongo.Collection
 	collUsedRValue        *mongo.Collection
+	collUtxoReservation   *mongo.Collection
+	collSignSession       *mongo.Collection
+	collSwapStatusEvent   *mongo.Collection
+	collWebhookDelivery   *mongo.Collection
 )
 
 func isSwapin(collection *mongo.Collection) bool {
@@ -58,6 +66,10 @@ func initCollections() {
 	initCollection(tbLatestSwapNonces, &collLatestSwapNonces, "address")
 	initCollection(tbSwapHistory, &collSwapHistory, "txid")
 	initCollection(tbUsedRValues, &collUsedRValue)
+	initCollection(tbUtxoReservations, &collUtxoReservation, "swapkey")
+	initCollection(tbSignSessions, &collSignSession, "status")
+	initCollection(tbSwapStatusEvents, &collSwapStatusEvent, "txid")
+	initCollection(tbWebhookDeliveries, &collWebhookDelivery, "status", "nextretry")
 }
 
 func initCollection(table string, collection **mongo.Collection, indexKey ...string) {
@@ -42,6 +42,26 @@ type MgoSwapResult struct {
 	InitTime    int64      `bson:"inittime"`
 	Timestamp   int64      `bson:"timestamp"`
 	Memo        string     `bson:"memo"`
+
+	RefundTx     string `bson:"refundtx,omitempty"`
+	RefundTo     string `bson:"refundto,omitempty"`
+	RefundValue  string `bson:"refundvalue,omitempty"`
+	RefundNonce  uint64 `bson:"refundnonce,omitempty"`
+	RefundHeight uint64 `bson:"refundheight,omitempty"`
+	RefundTime   uint64 `bson:"refundtime,omitempty"`
+}
+
+// RefundUpdateItems refund update items
+type RefundUpdateItems struct {
+	RefundTx     string
+	RefundTo     string
+	RefundValue  string
+	RefundNonce  uint64
+	RefundHeight uint64
+	RefundTime   uint64
+	Status       SwapStatus
+	Timestamp    int64
+	Memo         string
 }
 
 // SwapResultUpdateItems swap update items
@@ -77,6 +97,8 @@ type MgoLatestScanInfo struct {
 	Key         string `bson:"_id"`
 	BlockHeight uint64 `bson:"blockheight"`
 	Timestamp   int64  `bson:"timestamp"`
+
+	FinalizedHeight uint64 `bson:"-" json:",omitempty"` // not stored
 }
 
 // MgoBlackAccount key is address
@@ -105,12 +127,93 @@ type MgoSwapHistory struct {
 	SwapTx   string             `bson:"swaptx"`
 }
 
+// status transition actors
+const (
+	ActorWorker = "worker"
+	ActorAdmin  = "admin"
+	ActorUser   = "user"
+)
+
+// MgoSwapStatusEvent swap status transition event
+type MgoSwapStatusEvent struct {
+	Key        primitive.ObjectID `bson:"_id"`
+	IsSwapin   bool               `bson:"isswapin"`
+	IsResult   bool               `bson:"isresult"` // transition of swap result or swap register
+	TxID       string             `bson:"txid"`
+	PairID     string             `bson:"pairid"`
+	Bind       string             `bson:"bind"`
+	FromStatus SwapStatus         `bson:"from"`
+	ToStatus   SwapStatus         `bson:"to"`
+	Actor      string             `bson:"actor"`
+	Reason     string             `bson:"reason"`
+	Timestamp  int64              `bson:"timestamp"` // milliseconds
+}
+
+// webhook delivery status
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliveryDelivered = "delivered"
+	WebhookDeliveryFailed    = "failed"
+)
+
+// MgoWebhookDelivery webhook outbox item, one item per event and webhook
+type MgoWebhookDelivery struct {
+	Key       primitive.ObjectID `bson:"_id"`
+	Webhook   string             `bson:"webhook"`
+	EventType string             `bson:"eventtype"`
+	TxID      string             `bson:"txid"`
+	PairID    string             `bson:"pairid"`
+	Bind      string             `bson:"bind"`
+	Payload   string             `bson:"payload"` // json encoded event
+	Status    string             `bson:"status"`
+	Attempts  int                `bson:"attempts"`
+	NextRetry int64              `bson:"nextretry"` // unix seconds
+	LastCode  int                `bson:"lastcode"`  // last http status code
+	LastError string             `bson:"lasterror"`
+	InitTime  int64              `bson:"inittime"`  // milliseconds
+	Timestamp int64              `bson:"timestamp"` // milliseconds
+}
+
 // MgoUsedRValue security enhancement
 type MgoUsedRValue struct {
 	Key       string `bson:"_id"` // r + pubkey
 	Timestamp int64  `bson:"timestamp"`
 }
 
+// sign session status
+const (
+	SignSessionSigning = "signing" // sign request is to be sent or in process
+	SignSessionSigned  = "signed"  // sign result is got, swap txs are to be sent
+	SignSessionDone    = "done"    // swap txs are processed
+	SignSessionFailed  = "failed"  // sign is failed
+)
+
+// MgoSignSession in-flight dcrm sign session, swap txs (swap keys, args and raw txs)
+// are only recorded by batch sign, which can make signed txs from the rsvs directly
+type MgoSignSession struct {
+	Key       string   `bson:"_id"` // hash of pubkey and msg hashes
+	KeyID     string   `bson:"keyid"`
+	Initiator string   `bson:"initiator"`
+	Pubkey    string   `bson:"pubkey"`
+	MsgHashes []string `bson:"msghashes"`
+	IsSwapin  bool     `bson:"isswapin"`
+	SwapKeys  []string `bson:"swapkeys"`
+	SwapArgs  []string `bson:"swapargs"` // json of build tx args
+	RawTxs    []string `bson:"rawtxs"`   // empty for single swap, which is rebuilt from swap args
+	Rsvs      []string `bson:"rsvs"`
+	Status    string   `bson:"status"`
+	Timestamp int64    `bson:"timestamp"`
+}
+
+// MgoUtxoReservation reserved utxo which is selected to spend by swap
+type MgoUtxoReservation struct {
+	Key       string `bson:"_id"` // txid + vout
+	TxID      string `bson:"txid"`
+	Vout      uint32 `bson:"vout"`
+	SwapKey   string `bson:"swapkey"`
+	Timestamp int64  `bson:"timestamp"`
+}
+
 func newObjectID() primitive.ObjectID {
 	return primitive.NewObjectID()
 }
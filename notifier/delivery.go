@@ -0,0 +1,196 @@
+package notifier
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/cmd/utils"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/mongodb"
+	"github.com/anyswap/CrossChain-Bridge/params"
+	"github.com/anyswap/CrossChain-Bridge/rpc/client"
+)
+
+const (
+	deliveryInterval  = 5 * time.Second
+	deliveryBatchSize = 100
+	deliveryTimeout   = 10 // seconds
+
+	minRetryInterval = int64(10)   // seconds
+	maxRetryInterval = int64(3600) // seconds
+
+	maxErrorLength = 256
+
+	// http headers of webhook request
+	headerEvent     = "X-Bridge-Event"
+	headerDelivery  = "X-Bridge-Delivery"
+	headerTimestamp = "X-Bridge-Timestamp"
+	headerSignature = "X-Bridge-Signature"
+)
+
+var wakeupChan = make(chan struct{}, 1)
+
+// DeliveryStats delivery stats of webhook
+type DeliveryStats struct {
+	Webhook   string `json:"webhook"`
+	Pending   int64  `json:"pending"`
+	Delivered int64  `json:"delivered"`
+	Failed    int64  `json:"failed"`
+}
+
+func wakeupDeliveryJob() {
+	select {
+	case wakeupChan <- struct{}{}:
+	default:
+	}
+}
+
+func startDeliveryJob() {
+	log.Info("start webhook delivery job")
+	for {
+		deliverPending()
+		select {
+		case <-utils.CleanupChan:
+			log.Info("stop webhook delivery job")
+			return
+		case <-wakeupChan:
+		case <-time.After(deliveryInterval):
+		}
+	}
+}
+
+func deliverPending() {
+	items, err := mongodb.FindWebhookDeliveriesToSend(time.Now().Unix(), deliveryBatchSize)
+	if err != nil {
+		log.Warn("find webhook deliveries failed", "err", err)
+		return
+	}
+	for _, item := range items {
+		if utils.IsCleanuping() {
+			return
+		}
+		deliver(item)
+	}
+}
+
+// deliver post event to webhook, retry with exponential backoff if failed
+func deliver(item *mongodb.MgoWebhookDelivery) {
+	webhook := params.GetWebhook(item.Webhook)
+	if webhook == nil {
+		item.Status = mongodb.WebhookDeliveryFailed
+		item.LastError = "webhook is not configed"
+		_ = mongodb.UpdateWebhookDelivery(item)
+		return
+	}
+
+	item.Attempts++
+	statusCode, err := post(webhook, item)
+	item.LastCode = statusCode
+	if err == nil {
+		item.Status = mongodb.WebhookDeliveryDelivered
+		item.LastError = ""
+		log.Info("deliver webhook event success", "webhook", item.Webhook, "event", item.EventType, "txid", item.TxID, "id", item.Key.Hex(), "attempts", item.Attempts)
+	} else {
+		item.LastError = err.Error()
+		if len(item.LastError) > maxErrorLength {
+			item.LastError = item.LastError[:maxErrorLength]
+		}
+		if item.Attempts >= webhook.GetMaxRetries() {
+			item.Status = mongodb.WebhookDeliveryFailed
+		} else {
+			item.NextRetry = time.Now().Unix() + getRetryInterval(item.Attempts)
+		}
+		log.Warn("deliver webhook event failed", "webhook", item.Webhook, "event", item.EventType, "txid", item.TxID, "id", item.Key.Hex(), "attempts", item.Attempts, "status", item.Status, "err", err)
+	}
+	_ = mongodb.UpdateWebhookDelivery(item)
+}
+
+func post(webhook *params.WebhookConfig, item *mongodb.MgoWebhookDelivery) (statusCode int, err error) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	headers := map[string]string{
+		headerEvent:     item.EventType,
+		headerDelivery:  item.Key.Hex(),
+		headerTimestamp: timestamp,
+	}
+	if webhook.Secret != "" {
+		headers[headerSignature] = Sign(webhook.Secret, timestamp, item.Payload)
+	}
+	resp, err := client.HTTPPost(webhook.URL, json.RawMessage(item.Payload), nil, headers, deliveryTimeout)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("http status %v", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// Sign sign webhook request, receivers should verify it by the same algorithm:
+// "sha256=" + hex(HMAC-SHA256(secret, timestamp + "." + body))
+func Sign(secret, timestamp, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write([]byte(timestamp + "." + body))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func getRetryInterval(attempts int) int64 {
+	interval := minRetryInterval
+	for i := 1; i < attempts && interval < maxRetryInterval; i++ {
+		interval *= 2
+	}
+	if interval > maxRetryInterval {
+		interval = maxRetryInterval
+	}
+	return interval
+}
+
+// GetDeliveryStats get delivery stats of configed webhooks
+func GetDeliveryStats() ([]*DeliveryStats, error) {
+	webhooks := params.GetWebhooks()
+	result := make([]*DeliveryStats, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		stats := &DeliveryStats{Webhook: webhook.Name}
+		var err error
+		if stats.Pending, err = mongodb.CountWebhookDeliveries(webhook.Name, mongodb.WebhookDeliveryPending); err != nil {
+			return nil, err
+		}
+		if stats.Delivered, err = mongodb.CountWebhookDeliveries(webhook.Name, mongodb.WebhookDeliveryDelivered); err != nil {
+			return nil, err
+		}
+		if stats.Failed, err = mongodb.CountWebhookDeliveries(webhook.Name, mongodb.WebhookDeliveryFailed); err != nil {
+			return nil, err
+		}
+		result = append(result, stats)
+	}
+	return result, nil
+}
+
+// RetryDelivery deliver failed event again
+func RetryDelivery(id string) error {
+	item, err := mongodb.FindWebhookDelivery(id)
+	if err != nil {
+		return err
+	}
+	if item.Status != mongodb.WebhookDeliveryFailed {
+		return fmt.Errorf("delivery status is %v, not %v", item.Status, mongodb.WebhookDeliveryFailed)
+	}
+	item.Status = mongodb.WebhookDeliveryPending
+	item.Attempts = 0
+	item.NextRetry = 0
+	err = mongodb.UpdateWebhookDelivery(item)
+	if err == nil {
+		wakeupDeliveryJob()
+	}
+	return err
+}
@@ -0,0 +1,253 @@
+// Package notifier notifies webhooks of swap lifecycle events.
+package notifier
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/mongodb"
+	"github.com/anyswap/CrossChain-Bridge/params"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// swap lifecycle event types
+const (
+	EventRegistered   = "registered"
+	EventVerified     = "verified"
+	EventVerifyFailed = "verifyFailed"
+	EventBigValueHeld = "bigValueHeld"
+	EventSwapSent     = "swapSent"
+	EventReplaced     = "replaced"
+	EventStable       = "stable"
+	EventFailed       = "failed"
+	EventRefundable   = "refundable"
+	EventRefundSent   = "refundSent"
+	EventRefunded     = "refunded"
+)
+
+var allEventTypes = []string{
+	EventRegistered,
+	EventVerified,
+	EventVerifyFailed,
+	EventBigValueHeld,
+	EventSwapSent,
+	EventReplaced,
+	EventStable,
+	EventFailed,
+	EventRefundable,
+	EventRefundSent,
+	EventRefunded,
+}
+
+// Event webhook event (POST body)
+type Event struct {
+	ID        string `json:"id"` // delivery id, the same event has different id for different webhooks
+	Type      string `json:"type"`
+	SwapType  string `json:"swapType"`
+	PairID    string `json:"pairID"`
+	TxID      string `json:"txid"`
+	Bind      string `json:"bind"`
+	Status    string `json:"status"`
+	Value     string `json:"value,omitempty"`
+	SwapTx    string `json:"swapTx,omitempty"`
+	SwapValue string `json:"swapValue,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+	Timestamp int64  `json:"timestamp"` // milliseconds
+}
+
+// Start start notifier if webhooks are configed
+func Start() {
+	webhooks := params.GetWebhooks()
+	if len(webhooks) == 0 {
+		return
+	}
+	for _, webhook := range webhooks {
+		for _, eventType := range webhook.Events {
+			if !isValidEventType(eventType) {
+				log.Fatal("webhook has unknown event type", "webhook", webhook.Name, "event", eventType)
+			}
+		}
+	}
+	mongodb.SwapAddedHook = onSwapAdded
+	mongodb.SwapStatusChangedHook = onSwapStatusChanged
+	go startDeliveryJob()
+	log.Info("start webhook notifier", "webhooks", len(webhooks))
+}
+
+func isValidEventType(eventType string) bool {
+	for _, typ := range allEventTypes {
+		if typ == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+func getSwapType(isSwapin bool) string {
+	if isSwapin {
+		return tokens.SwapinType.String()
+	}
+	return tokens.SwapoutType.String()
+}
+
+func onSwapAdded(isSwapin bool, swap *mongodb.MgoSwap) {
+	notify(&Event{
+		Type:      EventRegistered,
+		SwapType:  getSwapType(isSwapin),
+		PairID:    swap.PairID,
+		TxID:      swap.TxID,
+		Bind:      swap.Bind,
+		Status:    swap.Status.String(),
+		Reason:    swap.Memo,
+		Timestamp: swap.InitTime,
+	})
+}
+
+func onSwapStatusChanged(statusEvent *mongodb.MgoSwapStatusEvent) {
+	eventType := getEventType(statusEvent)
+	if eventType == "" {
+		return
+	}
+	event := &Event{
+		Type:      eventType,
+		SwapType:  getSwapType(statusEvent.IsSwapin),
+		PairID:    statusEvent.PairID,
+		TxID:      statusEvent.TxID,
+		Bind:      statusEvent.Bind,
+		Status:    statusEvent.ToStatus.String(),
+		Reason:    statusEvent.Reason,
+		Timestamp: statusEvent.Timestamp,
+	}
+	if statusEvent.IsResult {
+		res, err := mongodb.FindSwapResult(statusEvent.IsSwapin, statusEvent.TxID, statusEvent.PairID, statusEvent.Bind)
+		if err == nil {
+			event.Value = res.Value
+			event.SwapTx = res.SwapTx
+			event.SwapValue = res.SwapValue
+			if res.RefundTx != "" {
+				event.SwapTx = res.RefundTx
+				event.SwapValue = res.RefundValue
+			}
+		}
+	}
+	notify(event)
+}
+
+// getEventType map swap status transition to lifecycle event, empty if not notified
+func getEventType(statusEvent *mongodb.MgoSwapStatusEvent) string {
+	if statusEvent.IsResult {
+		switch statusEvent.ToStatus {
+		case mongodb.MatchTxNotStable:
+			if statusEvent.FromStatus != mongodb.MatchTxFailed {
+				return EventSwapSent
+			}
+		case mongodb.MatchTxStable:
+			return EventStable
+		case mongodb.MatchTxFailed, mongodb.RefundTxFailed:
+			return EventFailed
+		case mongodb.TxRefundable:
+			return EventRefundable
+		case mongodb.RefundTxNotStable:
+			return EventRefundSent
+		case mongodb.RefundTxStable:
+			return EventRefunded
+		default:
+		}
+		return ""
+	}
+	switch statusEvent.ToStatus {
+	case mongodb.TxNotSwapped:
+		if statusEvent.FromStatus == mongodb.TxNotStable || statusEvent.FromStatus == mongodb.TxWithBigValue {
+			return EventVerified
+		}
+	case mongodb.TxWithBigValue:
+		return EventBigValueHeld
+	case mongodb.TxVerifyFailed,
+		mongodb.TxWithWrongMemo,
+		mongodb.TxWithWrongValue,
+		mongodb.TxWithWrongSender,
+		mongodb.TxSenderNotRegistered,
+		mongodb.BindAddrIsContract,
+		mongodb.SwapInBlacklist:
+		return EventVerifyFailed
+	case mongodb.ManualMakeFail:
+		return EventFailed
+	default:
+	}
+	return ""
+}
+
+// NotifySwapReplaced notify swap tx is replaced by new tx
+func NotifySwapReplaced(isSwapin bool, pairID, txid, bind, swapTx, swapValue string) {
+	if len(params.GetWebhooks()) == 0 {
+		return
+	}
+	notify(&Event{
+		Type:      EventReplaced,
+		SwapType:  getSwapType(isSwapin),
+		PairID:    strings.ToLower(pairID),
+		TxID:      txid,
+		Bind:      bind,
+		Status:    mongodb.MatchTxNotStable.String(),
+		SwapTx:    swapTx,
+		SwapValue: swapValue,
+		Timestamp: common.NowMilli(),
+	})
+}
+
+// notify add event to outbox of every matched webhook
+func notify(event *Event) {
+	added := false
+	for _, webhook := range params.GetWebhooks() {
+		if !isWebhookMatch(webhook, event) {
+			continue
+		}
+		key := primitive.NewObjectID()
+		event.ID = key.Hex()
+		payload, err := json.Marshal(event)
+		if err != nil {
+			continue
+		}
+		err = mongodb.AddWebhookDelivery(&mongodb.MgoWebhookDelivery{
+			Key:       key,
+			Webhook:   webhook.Name,
+			EventType: event.Type,
+			TxID:      event.TxID,
+			PairID:    event.PairID,
+			Bind:      event.Bind,
+			Payload:   string(payload),
+			Status:    mongodb.WebhookDeliveryPending,
+		})
+		if err == nil {
+			added = true
+		}
+	}
+	if added {
+		wakeupDeliveryJob()
+	}
+}
+
+func isWebhookMatch(webhook *params.WebhookConfig, event *Event) bool {
+	if len(webhook.Events) > 0 && !containsFold(webhook.Events, event.Type) {
+		return false
+	}
+	if len(webhook.PairIDs) > 0 && !containsFold(webhook.PairIDs, event.PairID) {
+		return false
+	}
+	if len(webhook.Addresses) > 0 && !containsFold(webhook.Addresses, event.Bind) {
+		return false
+	}
+	return true
+}
+
+func containsFold(items []string, item string) bool {
+	for _, s := range items {
+		if strings.EqualFold(s, item) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package notifier
+
+import (
+	"testing"
+
+	"github.com/anyswap/CrossChain-Bridge/mongodb"
+	"github.com/anyswap/CrossChain-Bridge/params"
+)
+
+func TestSign(t *testing.T) {
+	// echo -n '1600000000.{"id":"1"}' | openssl dgst -sha256 -hmac secret
+	want := "sha256=3831eb7dbf183fdbdf6145e3aa0b7029f210195f352de3815ebec7b67268edbc"
+	if got := Sign("secret", "1600000000", `{"id":"1"}`); got != want {
+		t.Errorf("wrong signature, want %v, got %v", want, got)
+	}
+}
+
+func TestGetRetryInterval(t *testing.T) {
+	tests := map[int]int64{1: 10, 2: 20, 3: 40, 9: 2560, 10: 3600, 100: 3600}
+	for attempts, want := range tests {
+		if got := getRetryInterval(attempts); got != want {
+			t.Errorf("retry interval of %v attempts, want %v, got %v", attempts, want, got)
+		}
+	}
+}
+
+func TestGetEventType(t *testing.T) {
+	tests := []struct {
+		isResult bool
+		from, to mongodb.SwapStatus
+		want     string
+	}{
+		{false, mongodb.TxNotStable, mongodb.TxNotSwapped, EventVerified},
+		{false, mongodb.TxWithBigValue, mongodb.TxNotSwapped, EventVerified},
+		{false, mongodb.TxProcessed, mongodb.TxNotSwapped, ""},
+		{false, mongodb.TxNotStable, mongodb.TxWithBigValue, EventBigValueHeld},
+		{false, mongodb.TxNotStable, mongodb.TxWithWrongMemo, EventVerifyFailed},
+		{false, mongodb.TxNotSwapped, mongodb.ManualMakeFail, EventFailed},
+		{true, mongodb.MatchTxEmpty, mongodb.MatchTxNotStable, EventSwapSent},
+		{true, mongodb.MatchTxFailed, mongodb.MatchTxNotStable, ""},
+		{true, mongodb.MatchTxNotStable, mongodb.MatchTxStable, EventStable},
+		{true, mongodb.MatchTxNotStable, mongodb.MatchTxFailed, EventFailed},
+		{true, mongodb.TxWithWrongValue, mongodb.TxRefundable, EventRefundable},
+		{true, mongodb.TxRefundable, mongodb.RefundTxNotStable, EventRefundSent},
+		{true, mongodb.RefundTxNotStable, mongodb.RefundTxStable, EventRefunded},
+		{true, mongodb.RefundTxNotStable, mongodb.RefundTxFailed, EventFailed},
+		{false, mongodb.TxWithWrongValue, mongodb.TxRefundable, ""},
+	}
+	for _, test := range tests {
+		event := &mongodb.MgoSwapStatusEvent{IsResult: test.isResult, FromStatus: test.from, ToStatus: test.to}
+		if got := getEventType(event); got != test.want {
+			t.Errorf("event of %v -> %v, want '%v', got '%v'", test.from, test.to, test.want, got)
+		}
+	}
+}
+
+func TestIsWebhookMatch(t *testing.T) {
+	event := &Event{Type: EventStable, PairID: "btc", Bind: "0xAbC"}
+	tests := []struct {
+		webhook *params.WebhookConfig
+		want    bool
+	}{
+		{&params.WebhookConfig{}, true},
+		{&params.WebhookConfig{Events: []string{EventStable}}, true},
+		{&params.WebhookConfig{Events: []string{EventFailed}}, false},
+		{&params.WebhookConfig{PairIDs: []string{"BTC"}}, true},
+		{&params.WebhookConfig{PairIDs: []string{"eth"}}, false},
+		{&params.WebhookConfig{Addresses: []string{"0xabc"}}, true},
+		{&params.WebhookConfig{Addresses: []string{"0xdef"}}, false},
+	}
+	for i, test := range tests {
+		if got := isWebhookMatch(test.webhook, event); got != test.want {
+			t.Errorf("test %v: want %v, got %v", i, test.want, got)
+		}
+	}
+}
@@ -2,10 +2,12 @@ package params
 
 import (
 	"errors"
+	"fmt"
 	"regexp"
 	"strings"
 	"time"
 
+	"github.com/anyswap/CrossChain-Bridge/common"
 	"github.com/anyswap/CrossChain-Bridge/log"
 	"github.com/anyswap/CrossChain-Bridge/rpc/client"
 )
@@ -92,6 +94,59 @@ func (c *ServerConfig) CheckConfig() error {
 	if c.APIServer == nil {
 		return errors.New("server must config 'Server.APIServer'")
 	}
+	if c.MaxSignBatchSize < 0 || c.MaxSignBatchSize > MaxSignBatchSizeLimit {
+		return fmt.Errorf("server 'MaxSignBatchSize' must be in range [0, %v]", MaxSignBatchSizeLimit)
+	}
+	if c.SwapQueue != nil {
+		if err := c.SwapQueue.CheckConfig(); err != nil {
+			return err
+		}
+	}
+	webhookNames := make(map[string]struct{}, len(c.Webhooks))
+	for _, webhook := range c.Webhooks {
+		if err := webhook.CheckConfig(); err != nil {
+			return err
+		}
+		if _, exist := webhookNames[webhook.Name]; exist {
+			return fmt.Errorf("duplicate webhook name '%v'", webhook.Name)
+		}
+		webhookNames[webhook.Name] = struct{}{}
+	}
+	if c.Refund != nil && c.Refund.AutoRefundDelay < 0 {
+		return errors.New("refund 'AutoRefundDelay' must not be negative")
+	}
+	return nil
+}
+
+// CheckConfig check webhook config
+func (c *WebhookConfig) CheckConfig() error {
+	if c.Name == "" {
+		return errors.New("webhook must config 'Name'")
+	}
+	if !strings.HasPrefix(c.URL, "http://") && !strings.HasPrefix(c.URL, "https://") {
+		return fmt.Errorf("webhook '%v' has wrong 'URL' %v", c.Name, c.URL)
+	}
+	if c.MaxRetries < 0 {
+		return fmt.Errorf("webhook '%v' has negative 'MaxRetries'", c.Name)
+	}
+	return nil
+}
+
+// CheckConfig check swap queue config
+func (c *SwapQueueConfig) CheckConfig() error {
+	switch c.GetOrderBy() {
+	case SwapQueueOrderByAge, SwapQueueOrderByValue:
+	default:
+		return fmt.Errorf("swap queue 'OrderBy' must be '%v' or '%v'", SwapQueueOrderByAge, SwapQueueOrderByValue)
+	}
+	for pairID, priority := range c.PairPriorities {
+		if priority <= 0 {
+			return fmt.Errorf("swap queue priority of pair '%v' must be positive", pairID)
+		}
+	}
+	if c.MaxQueueSize < 0 {
+		return errors.New("swap queue 'MaxQueueSize' must not be negative")
+	}
 	return nil
 }
 
@@ -174,6 +229,14 @@ func (c *OracleConfig) CheckConfig() (err error) {
 	if ServerAPIAddress == "" {
 		return errors.New("oracle must config 'ServerAPIAddress'")
 	}
+	if c.AuditAPIAddress != "" && len(c.Admins) == 0 {
+		return errors.New("oracle audit API must config 'Admins'")
+	}
+	for _, admin := range c.Admins {
+		if !common.IsHexAddress(admin) {
+			return fmt.Errorf("oracle wrong admin address '%v'", admin)
+		}
+	}
 	var version string
 	for i := 0; i < 3; i++ {
 		err = client.RPCPostWithTimeout(60, &version, ServerAPIAddress, "swap.GetVersionInfo")
@@ -13,6 +13,20 @@ import (
 
 const (
 	defaultAPIPort = 11556
+
+	// MaxSignBatchSizeLimit max number of swaps batched in one dcrm sign round
+	MaxSignBatchSizeLimit = 20
+
+	defaultMaxSwapQueueSize = 1000
+
+	defaultWebhookMaxRetries = 10
+
+	defaultAutoRefundDelay = 3600 // seconds
+
+	// SwapQueueOrderByAge order swap tasks by register time (older first)
+	SwapQueueOrderByAge = "age"
+	// SwapQueueOrderByValue order swap tasks by swap value (bigger first)
+	SwapQueueOrderByValue = "value"
 )
 
 var (
@@ -46,6 +60,41 @@ type ServerConfig struct {
 	MongoDB   *MongoDBConfig   `toml:",omitempty" json:",omitempty"`
 	APIServer *APIServerConfig `toml:",omitempty" json:",omitempty"`
 	Admins    []string         `toml:",omitempty" json:",omitempty"`
+
+	// batch several swaps of the same dcrm account into one sign round (default to 1, no batching)
+	MaxSignBatchSize int `toml:",omitempty" json:",omitempty"`
+
+	SwapQueue *SwapQueueConfig `toml:",omitempty" json:",omitempty"`
+
+	Webhooks []*WebhookConfig `toml:",omitempty" json:",omitempty"`
+
+	Refund *RefundConfig `toml:",omitempty" json:",omitempty"`
+}
+
+// RefundConfig refund deposits which can never be swapped
+type RefundConfig struct {
+	AutoRefundTooSmall bool  `toml:",omitempty" json:",omitempty"` // auto refund deposit value below MinimumSwap
+	AutoRefundTooLarge bool  `toml:",omitempty" json:",omitempty"` // auto refund deposit value above MaximumSwap
+	AutoRefundDelay    int64 `toml:",omitempty" json:",omitempty"` // seconds after registered, default to 3600
+}
+
+// WebhookConfig webhook notification config
+type WebhookConfig struct {
+	Name       string   // identify webhook in delivery outbox
+	URL        string   // receive events by http POST
+	Secret     string   `json:"-"`                            // sign events by HMAC-SHA256 if not empty
+	Events     []string `toml:",omitempty" json:",omitempty"` // empty means all events
+	PairIDs    []string `toml:",omitempty" json:",omitempty"` // empty means all pairs
+	Addresses  []string `toml:",omitempty" json:",omitempty"` // bind addresses, empty means all
+	MaxRetries int      `toml:",omitempty" json:",omitempty"` // default to 10
+}
+
+// SwapQueueConfig swap task queue (one queue per dcrm account) config
+type SwapQueueConfig struct {
+	OrderBy        string         `toml:",omitempty" json:",omitempty"` // "age" (default) or "value"
+	PairPriorities map[string]int `toml:",omitempty" json:",omitempty"` // key is pairID, default to 1
+	FairPerPair    bool           `toml:",omitempty" json:",omitempty"`
+	MaxQueueSize   int            `toml:",omitempty" json:",omitempty"` // default to 1000
 }
 
 // DcrmConfig dcrm related config
@@ -75,6 +124,12 @@ type DcrmNodeConfig struct {
 type OracleConfig struct {
 	ServerAPIAddress      string
 	GetAcceptListInterval uint64
+	AcceptPolicyFile      string   `toml:",omitempty" json:",omitempty"`
+	AuditAPIAddress       string   `toml:",omitempty" json:",omitempty"`
+	AuditRetentionDays    int64    `toml:",omitempty" json:",omitempty"` // default to 90
+	Admins                []string `toml:",omitempty" json:",omitempty"` // admins who can query audit API
+	ShadowMode            bool     `toml:",omitempty" json:",omitempty"`
+	ShadowSource          string   `toml:",omitempty" json:",omitempty"` // audit API address of live oracle
 }
 
 // APIServerConfig api service config
@@ -110,6 +165,100 @@ func GetAPIPort() int {
 	return apiPort
 }
 
+// GetMaxSignBatchSize get max number of swaps batched in one dcrm sign round
+func GetMaxSignBatchSize() int {
+	serverCfg := GetServerConfig()
+	if serverCfg == nil || serverCfg.MaxSignBatchSize <= 1 {
+		return 1
+	}
+	return serverCfg.MaxSignBatchSize
+}
+
+// GetSwapQueueConfig get swap task queue config (never nil)
+func GetSwapQueueConfig() *SwapQueueConfig {
+	serverCfg := GetServerConfig()
+	if serverCfg == nil || serverCfg.SwapQueue == nil {
+		return &SwapQueueConfig{}
+	}
+	return serverCfg.SwapQueue
+}
+
+// GetOrderBy get order of swap tasks
+func (c *SwapQueueConfig) GetOrderBy() string {
+	if c.OrderBy == "" {
+		return SwapQueueOrderByAge
+	}
+	return strings.ToLower(c.OrderBy)
+}
+
+// GetPairPriority get priority of pair, higher is served first
+// (or served more often if fair per pair)
+func (c *SwapQueueConfig) GetPairPriority(pairID string) int {
+	for pair, priority := range c.PairPriorities {
+		if strings.EqualFold(pair, pairID) {
+			return priority
+		}
+	}
+	return 1
+}
+
+// GetMaxQueueSize get max number of swap tasks in one queue
+func (c *SwapQueueConfig) GetMaxQueueSize() int {
+	if c.MaxQueueSize <= 0 {
+		return defaultMaxSwapQueueSize
+	}
+	return c.MaxQueueSize
+}
+
+// GetRefundConfig get refund config (never nil)
+func GetRefundConfig() *RefundConfig {
+	serverCfg := GetServerConfig()
+	if serverCfg == nil || serverCfg.Refund == nil {
+		return &RefundConfig{}
+	}
+	return serverCfg.Refund
+}
+
+// IsAutoRefundEnabled is auto refund of wrong value deposit enabled
+func (c *RefundConfig) IsAutoRefundEnabled() bool {
+	return c.AutoRefundTooSmall || c.AutoRefundTooLarge
+}
+
+// GetAutoRefundDelay get seconds to wait before auto refund (for admin to intervene)
+func (c *RefundConfig) GetAutoRefundDelay() int64 {
+	if c.AutoRefundDelay <= 0 {
+		return defaultAutoRefundDelay
+	}
+	return c.AutoRefundDelay
+}
+
+// GetWebhooks get webhook configs
+func GetWebhooks() []*WebhookConfig {
+	serverCfg := GetServerConfig()
+	if serverCfg == nil {
+		return nil
+	}
+	return serverCfg.Webhooks
+}
+
+// GetWebhook get webhook config by name
+func GetWebhook(name string) *WebhookConfig {
+	for _, webhook := range GetWebhooks() {
+		if webhook.Name == name {
+			return webhook
+		}
+	}
+	return nil
+}
+
+// GetMaxRetries get max delivery attempts of webhook
+func (c *WebhookConfig) GetMaxRetries() int {
+	if c.MaxRetries <= 0 {
+		return defaultWebhookMaxRetries
+	}
+	return c.MaxRetries
+}
+
 // GetIdentifier get identifier (to distiguish in dcrm accept)
 func GetIdentifier() string {
 	return GetConfig().Identifier
@@ -180,6 +329,12 @@ func GetOracleConfig() *OracleConfig {
 	return GetConfig().Oracle
 }
 
+// IsOracleShadowMode is oracle in shadow mode (only record decisions without accepting)
+func IsOracleShadowMode() bool {
+	oracleConfig := GetOracleConfig()
+	return oracleConfig != nil && oracleConfig.ShadowMode
+}
+
 // GetExtraConfig get extra config
 func GetExtraConfig() *ExtraConfig {
 	return GetConfig().Extra
@@ -237,6 +392,16 @@ func IsAdmin(account string) bool {
 	return false
 }
 
+// IsOracleAdmin is admin of oracle
+func IsOracleAdmin(account string) bool {
+	for _, admin := range GetOracleConfig().Admins {
+		if strings.EqualFold(account, admin) {
+			return true
+		}
+	}
+	return false
+}
+
 // SetDataDir set data dir
 func SetDataDir(dir string) {
 	if dir == "" {
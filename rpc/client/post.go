@@ -162,3 +162,33 @@ func RPCRawPostWithTimeout(url, reqBody string, timeout int) (string, error) {
 	}
 	return string(body), nil
 }
+
+// RPCPostJSON post json body and unmarshal json response (eg. REST API)
+func RPCPostJSON(result interface{}, url string, body interface{}) error {
+	return RPCPostJSONWithTimeout(result, url, body, defaultSlowTimeout)
+}
+
+// RPCPostJSONWithTimeout post json body with timeout and unmarshal json response
+func RPCPostJSONWithTimeout(result interface{}, url string, body interface{}, timeout int) error {
+	resp, err := HTTPPost(url, body, nil, nil, timeout)
+	if err != nil {
+		log.Trace("post json error", "url", url, "body", body, "err", err)
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	const maxReadContentLength int64 = 1024 * 1024 * 10 // 10M
+	respBody, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxReadContentLength))
+	if err != nil {
+		return fmt.Errorf("read body error: %w", err)
+	}
+	if resp.StatusCode != 200 {
+		return fmt.Errorf("wrong response status %v. message: %v", resp.StatusCode, string(respBody))
+	}
+	err = json.Unmarshal(respBody, &result)
+	if err != nil {
+		return fmt.Errorf("unmarshal result error: %w", err)
+	}
+	return nil
+}
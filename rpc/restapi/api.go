@@ -83,6 +83,18 @@ func NonceInfoHandler(w http.ResponseWriter, r *http.Request) {
 	writeResponse(w, res, err)
 }
 
+// DcrmSignStatsHandler handler
+func DcrmSignStatsHandler(w http.ResponseWriter, r *http.Request) {
+	res, err := swapapi.GetDcrmSignStats()
+	writeResponse(w, res, err)
+}
+
+// SwapQueueStatusHandler handler
+func SwapQueueStatusHandler(w http.ResponseWriter, r *http.Request) {
+	res, err := swapapi.GetSwapQueueStatus()
+	writeResponse(w, res, err)
+}
+
 func getBindParam(r *http.Request) string {
 	vals := r.URL.Query()
 	bindVals, exist := vals["bind"]
@@ -1,6 +1,7 @@
 package rpcapi
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
@@ -8,6 +9,7 @@ import (
 	"github.com/anyswap/CrossChain-Bridge/admin"
 	"github.com/anyswap/CrossChain-Bridge/common"
 	"github.com/anyswap/CrossChain-Bridge/mongodb"
+	"github.com/anyswap/CrossChain-Bridge/notifier"
 	"github.com/anyswap/CrossChain-Bridge/params"
 	"github.com/anyswap/CrossChain-Bridge/tokens"
 	"github.com/anyswap/CrossChain-Bridge/worker"
@@ -21,6 +23,18 @@ const (
 	passSwapoutOp = "passswapout"
 	failSwapinOp  = "failswapin"
 	failSwapoutOp = "failswapout"
+
+	exportSwapoutPsbtOp   = "exportswapout"
+	exportAggregatePsbtOp = "exportaggregate"
+	importPsbtOp          = "import"
+
+	webhookStatusOp = "status"
+	webhookQueryOp  = "query"
+	webhookRetryOp  = "retry"
+
+	refundMarkOp  = "mark"
+	refundRetryOp = "retry"
+	refundFailOp  = "fail"
 )
 
 // AdminCall admin call
@@ -54,6 +68,8 @@ func doCall(args *admin.CallArgs, result *string) error {
 		return reverify(args, result)
 	case "reswap":
 		return reswap(args, result)
+	case "boostswap":
+		return boostswap(args, result)
 	case "replaceswap":
 		return replaceswap(args, result)
 	case "manual":
@@ -62,6 +78,12 @@ func doCall(args *admin.CallArgs, result *string) error {
 		return setnonce(args, result)
 	case "addpair":
 		return addpair(args, result)
+	case "psbt":
+		return psbt(args, result)
+	case "webhook":
+		return webhook(args, result)
+	case "refund":
+		return refund(args, result)
 	default:
 		return fmt.Errorf("unknown admin method '%v'", args.Method)
 	}
@@ -247,6 +269,31 @@ func reswap(args *admin.CallArgs, result *string) (err error) {
 	return nil
 }
 
+func boostswap(args *admin.CallArgs, result *string) (err error) {
+	operation, txid, pairID, bind, err := getOpTxAndPairID(args)
+	if err != nil {
+		return err
+	}
+	var isSwapin bool
+	switch operation {
+	case swapinOp:
+		isSwapin = true
+	case swapoutOp:
+	default:
+		return fmt.Errorf("unknown operation '%v'", operation)
+	}
+	_, err = mongodb.FindSwapResult(isSwapin, txid, pairID, bind)
+	if err != nil {
+		return err
+	}
+	err = worker.BoostSwap(isSwapin, pairID, txid, bind)
+	if err != nil {
+		return err
+	}
+	*result = successReuslt
+	return nil
+}
+
 func replaceswap(args *admin.CallArgs, result *string) (err error) {
 	if len(args.Params) != 5 {
 		err = fmt.Errorf("wrong number of params, have %v want 5", len(args.Params))
@@ -354,3 +401,95 @@ func addpair(args *admin.CallArgs, result *string) (err error) {
 	*result = successReuslt
 	return nil
 }
+
+func psbt(args *admin.CallArgs, result *string) (err error) {
+	if len(args.Params) == 0 {
+		return fmt.Errorf("wrong number of params, have 0")
+	}
+	operation := args.Params[0]
+	var wantParams int
+	switch operation {
+	case exportSwapoutPsbtOp:
+		wantParams = 4
+	case exportAggregatePsbtOp, importPsbtOp:
+		wantParams = 2
+	default:
+		return fmt.Errorf("unknown operation '%v'", operation)
+	}
+	if len(args.Params) != wantParams {
+		return fmt.Errorf("wrong number of params, have %v want %v", len(args.Params), wantParams)
+	}
+	switch operation {
+	case exportSwapoutPsbtOp:
+		*result, err = worker.ExportSwapoutPsbt(args.Params[1], args.Params[2], args.Params[3])
+	case exportAggregatePsbtOp:
+		*result, err = worker.ExportAggregatePsbt(args.Params[1])
+	case importPsbtOp:
+		var txHash string
+		txHash, err = worker.ImportPsbt(args.Params[1])
+		if err == nil {
+			*result = successReuslt + " txHash is " + txHash
+		}
+	}
+	return err
+}
+
+func webhook(args *admin.CallArgs, result *string) (err error) {
+	if len(args.Params) == 0 {
+		return fmt.Errorf("wrong number of params, have 0")
+	}
+	operation := args.Params[0]
+	wantParams := 2
+	if operation == webhookStatusOp {
+		wantParams = 1
+	}
+	if len(args.Params) != wantParams {
+		return fmt.Errorf("wrong number of params, have %v want %v", len(args.Params), wantParams)
+	}
+	var res interface{}
+	switch operation {
+	case webhookStatusOp:
+		res, err = notifier.GetDeliveryStats()
+	case webhookQueryOp:
+		res, err = mongodb.FindWebhookDeliveries(args.Params[1])
+	case webhookRetryOp:
+		err = notifier.RetryDelivery(args.Params[1])
+		if err == nil {
+			*result = successReuslt
+		}
+		return err
+	default:
+		return fmt.Errorf("unknown operation '%v'", operation)
+	}
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(res)
+	if err != nil {
+		return err
+	}
+	*result = string(data)
+	return nil
+}
+
+func refund(args *admin.CallArgs, result *string) (err error) {
+	operation, txid, pairID, bind, err := getOpTxAndPairID(args)
+	if err != nil {
+		return err
+	}
+	switch operation {
+	case refundMarkOp:
+		err = worker.MarkRefundable(txid, pairID, bind)
+	case refundRetryOp:
+		err = worker.RetryRefund(txid, pairID, bind)
+	case refundFailOp:
+		err = worker.FailRefund(txid, pairID, bind)
+	default:
+		return fmt.Errorf("unknown operation '%v'", operation)
+	}
+	if err != nil {
+		return err
+	}
+	*result = successReuslt
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"net/http"
 
+	"github.com/anyswap/CrossChain-Bridge/dcrm"
 	"github.com/anyswap/CrossChain-Bridge/internal/swapapi"
 	"github.com/anyswap/CrossChain-Bridge/params"
 	"github.com/anyswap/CrossChain-Bridge/tokens"
@@ -82,6 +83,24 @@ func (s *RPCAPI) GetNonceInfo(r *http.Request, args *RPCNullArgs, result *swapap
 	return err
 }
 
+// GetDcrmSignStats api
+func (s *RPCAPI) GetDcrmSignStats(r *http.Request, args *RPCNullArgs, result *dcrm.SignStats) error {
+	res, err := swapapi.GetDcrmSignStats()
+	if err == nil && res != nil {
+		*result = *res
+	}
+	return err
+}
+
+// GetSwapQueueStatus api
+func (s *RPCAPI) GetSwapQueueStatus(r *http.Request, args *RPCNullArgs, result *[]*swapapi.SwapQueueStatus) error {
+	res, err := swapapi.GetSwapQueueStatus()
+	if err == nil && res != nil {
+		*result = res
+	}
+	return err
+}
+
 // RPCTxAndPairIDArgs txid and pairID
 type RPCTxAndPairIDArgs struct {
 	TxID   string `json:"txid"`
@@ -180,6 +199,19 @@ func (s *RPCAPI) GetSwapout(r *http.Request, args *RPCTxAndPairIDArgs, result *s
 	return err
 }
 
+// GetSwapHistoryEvents api
+func (s *RPCAPI) GetSwapHistoryEvents(r *http.Request, args *RPCTxAndPairIDArgs, result *[]*swapapi.SwapStatusEvent) error {
+	txid, pairID, bind, err := args.getTxAndPairID()
+	if err != nil {
+		return err
+	}
+	res, err := swapapi.GetSwapHistoryEvents(txid, pairID, bind)
+	if err == nil && res != nil {
+		*result = res
+	}
+	return err
+}
+
 // RPCQueryHistoryArgs args
 type RPCQueryHistoryArgs struct {
 	Address string `json:"address"`
@@ -87,6 +87,8 @@ func initRouter(r *mux.Router) {
 	r.HandleFunc("/versioninfo", restapi.VersionInfoHandler).Methods("GET")
 	r.HandleFunc("/oracleinfo", restapi.OracleInfoHandler).Methods("GET")
 	r.HandleFunc("/nonceinfo", restapi.NonceInfoHandler).Methods("GET")
+	r.HandleFunc("/dcrmstats", restapi.DcrmSignStatsHandler).Methods("GET")
+	r.HandleFunc("/swapqueue", restapi.SwapQueueStatusHandler).Methods("GET")
 	r.HandleFunc("/pairinfo/{pairid}", restapi.TokenPairInfoHandler).Methods("GET")
 	r.HandleFunc("/pairsinfo/{pairids}", restapi.TokenPairsInfoHandler).Methods("GET")
 
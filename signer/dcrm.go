@@ -0,0 +1,94 @@
+package signer
+
+import (
+	"errors"
+
+	"github.com/anyswap/CrossChain-Bridge/dcrm"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/mongodb"
+)
+
+// DcrmSigner sign with dcrm
+type DcrmSigner struct {
+	keyType string
+}
+
+// NewDcrmSigner new dcrm signer of key type (ECDSA or ED25519)
+func NewDcrmSigner(keyType string) *DcrmSigner {
+	return &DcrmSigner{keyType: keyType}
+}
+
+// Sign impl Signer, the sign session is persisted before sending sign request,
+// and the in-flight sign session before restart is resumed by its keyID
+// instead of signing the same msg hashes again
+func (s *DcrmSigner) Sign(pubkey string, msgHashes, msgContexts []string) (rsvs []string, err error) {
+	if !mongodb.HasClient() {
+		return s.doSign(pubkey, msgHashes, msgContexts)
+	}
+	key := mongodb.GetSignSessionKey(pubkey, msgHashes)
+	session, err := mongodb.FindSignSession(key)
+	switch {
+	case err == nil:
+		if session.KeyID == "" && len(session.Rsvs) == 0 {
+			break // sign request is not sent yet
+		}
+		rsvs, err = s.resumeSignSession(session)
+		if err == nil {
+			return rsvs, nil
+		}
+		log.Info("resume dcrm sign session failed", "key", key, "keyID", session.KeyID, "status", session.Status, "err", err)
+	case errors.Is(err, mongodb.ErrItemNotFound):
+		// batch sign has added its session with swap txs
+		session = &mongodb.MgoSignSession{Key: key, Pubkey: pubkey, MsgHashes: msgHashes}
+		if err = mongodb.AddSignSession(session); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, err
+	}
+
+	rsvs, err = s.doSign(pubkey, msgHashes, msgContexts)
+	if len(session.SwapArgs) > 0 {
+		return rsvs, err // status of batch sign session is updated by the batch signer
+	}
+	if err != nil {
+		_ = mongodb.UpdateSignSessionStatus(key, mongodb.SignSessionFailed, nil)
+		return nil, err
+	}
+	_ = mongodb.UpdateSignSessionStatus(key, mongodb.SignSessionDone, rsvs)
+	return rsvs, nil
+}
+
+func (s *DcrmSigner) doSign(pubkey string, msgHashes, msgContexts []string) (rsvs []string, err error) {
+	keyID, rsvs, err := dcrm.DoSignWithKeyType(s.keyType, pubkey, msgHashes, msgContexts)
+	if err != nil {
+		return nil, err
+	}
+	log.Info("dcrm sign finished", "keyID", keyID, "msgHashes", msgHashes)
+	return rsvs, nil
+}
+
+func (s *DcrmSigner) resumeSignSession(session *mongodb.MgoSignSession) (rsvs []string, err error) {
+	switch {
+	case len(session.Rsvs) > 0 && session.Status != mongodb.SignSessionFailed:
+		rsvs = session.Rsvs
+	case session.Status == mongodb.SignSessionSigning && session.KeyID != "":
+		rsvs, err = dcrm.GetSignStatusByInitiator(session.KeyID, session.Initiator)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errSignSessionNotResumable
+	}
+	err = dcrm.VerifySignResult(s.keyType, session.Pubkey, session.MsgHashes, rsvs)
+	if err != nil {
+		return nil, err
+	}
+	status := mongodb.SignSessionDone
+	if len(session.SwapArgs) > 0 {
+		status = mongodb.SignSessionSigned
+	}
+	_ = mongodb.UpdateSignSessionStatus(session.Key, status, rsvs)
+	log.Info("dcrm sign session resumed", "key", session.Key, "keyID", session.KeyID, "msgHashes", session.MsgHashes)
+	return rsvs, nil
+}
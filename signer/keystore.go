@@ -0,0 +1,44 @@
+package signer
+
+import (
+	"crypto/ecdsa"
+	"errors"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/tools/crypto"
+)
+
+// KeystoreSigner sign with local private key
+type KeystoreSigner struct {
+	privKey *ecdsa.PrivateKey
+}
+
+// NewKeystoreSigner new keystore signer
+func NewKeystoreSigner(privKey *ecdsa.PrivateKey) (*KeystoreSigner, error) {
+	if privKey == nil {
+		return nil, errors.New("keystore signer without private key")
+	}
+	return &KeystoreSigner{privKey: privKey}, nil
+}
+
+// Sign impl Signer
+func (s *KeystoreSigner) Sign(pubkey string, msgHashes, msgContexts []string) (rsvs []string, err error) {
+	if pubkey != "" {
+		pubKey, errp := ParsePublicKey(pubkey)
+		if errp != nil {
+			return nil, errp
+		}
+		if crypto.PubkeyToAddress(*pubKey) != crypto.PubkeyToAddress(s.privKey.PublicKey) {
+			return nil, errWrongPublicKey
+		}
+	}
+	rsvs = make([]string, 0, len(msgHashes))
+	for _, msgHash := range msgHashes {
+		signature, errs := crypto.Sign(common.FromHex(msgHash), s.privKey)
+		if errs != nil {
+			return nil, errs
+		}
+		rsvs = append(rsvs, common.ToHex(signature))
+	}
+	return rsvs, nil
+}
@@ -0,0 +1,157 @@
+//go:build pkcs11
+// +build pkcs11
+
+package signer
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tools/crypto"
+	"github.com/miekg/pkcs11"
+)
+
+var (
+	secp256k1N     = crypto.S256().Params().N
+	secp256k1HalfN = new(big.Int).Rsh(secp256k1N, 1)
+)
+
+// Pkcs11Signer sign with HSM private key through pkcs11 (eg. SoftHSM)
+type Pkcs11Signer struct {
+	config *tokens.SignerConfig
+
+	ctx     *pkcs11.Ctx
+	session pkcs11.SessionHandle
+	privKey pkcs11.ObjectHandle
+	lock    sync.Mutex
+}
+
+// NewPkcs11Signer new pkcs11 signer
+func NewPkcs11Signer(config *tokens.SignerConfig) (Signer, error) {
+	s := &Pkcs11Signer{config: config}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *Pkcs11Signer) open() error {
+	pin, err := ioutil.ReadFile(s.config.PinFile)
+	if err != nil {
+		return fmt.Errorf("read pkcs11 pin file failed, %w", err)
+	}
+	ctx := pkcs11.New(s.config.Module)
+	if ctx == nil {
+		return fmt.Errorf("load pkcs11 module '%v' failed", s.config.Module)
+	}
+	if err = ctx.Initialize(); err != nil {
+		return fmt.Errorf("initialize pkcs11 module failed, %w", err)
+	}
+	slot, err := findTokenSlot(ctx, s.config.TokenLabel)
+	if err != nil {
+		return err
+	}
+	session, err := ctx.OpenSession(slot, pkcs11.CKF_SERIAL_SESSION)
+	if err != nil {
+		return fmt.Errorf("open pkcs11 session failed, %w", err)
+	}
+	err = ctx.Login(session, pkcs11.CKU_USER, strings.TrimSpace(string(pin)))
+	if err != nil && !errors.Is(err, pkcs11.Error(pkcs11.CKR_USER_ALREADY_LOGGED_IN)) {
+		return fmt.Errorf("login pkcs11 token failed, %w", err)
+	}
+	privKey, err := findPrivateKey(ctx, session, s.config.KeyLabel)
+	if err != nil {
+		return err
+	}
+	s.ctx = ctx
+	s.session = session
+	s.privKey = privKey
+	log.Info("open pkcs11 signer success", "module", s.config.Module, "token", s.config.TokenLabel, "key", s.config.KeyLabel)
+	return nil
+}
+
+func findTokenSlot(ctx *pkcs11.Ctx, tokenLabel string) (uint, error) {
+	slots, err := ctx.GetSlotList(true)
+	if err != nil {
+		return 0, fmt.Errorf("get pkcs11 slot list failed, %w", err)
+	}
+	for _, slot := range slots {
+		info, err := ctx.GetTokenInfo(slot)
+		if err == nil && info.Label == tokenLabel {
+			return slot, nil
+		}
+	}
+	return 0, fmt.Errorf("pkcs11 token '%v' not found", tokenLabel)
+}
+
+func findPrivateKey(ctx *pkcs11.Ctx, session pkcs11.SessionHandle, keyLabel string) (pkcs11.ObjectHandle, error) {
+	template := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_PRIVATE_KEY),
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_EC),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, keyLabel),
+	}
+	if err := ctx.FindObjectsInit(session, template); err != nil {
+		return 0, fmt.Errorf("find pkcs11 private key failed, %w", err)
+	}
+	objs, _, err := ctx.FindObjects(session, 1)
+	_ = ctx.FindObjectsFinal(session)
+	if err != nil {
+		return 0, fmt.Errorf("find pkcs11 private key failed, %w", err)
+	}
+	if len(objs) == 0 {
+		return 0, fmt.Errorf("pkcs11 private key '%v' not found", keyLabel)
+	}
+	return objs[0], nil
+}
+
+// Sign impl Signer
+func (s *Pkcs11Signer) Sign(pubkey string, msgHashes, msgContexts []string) (rsvs []string, err error) {
+	pubKey, err := ParsePublicKey(pubkey)
+	if err != nil {
+		return nil, err
+	}
+	rsvs = make([]string, 0, len(msgHashes))
+	for _, msgHash := range msgHashes {
+		hash := common.FromHex(msgHash)
+		signature, errs := s.signOne(hash, pubKey)
+		if errs != nil {
+			return nil, errs
+		}
+		rsvs = append(rsvs, common.ToHex(signature))
+	}
+	return rsvs, nil
+}
+
+func (s *Pkcs11Signer) signOne(hash []byte, pubKey *ecdsa.PublicKey) ([]byte, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	mechanism := []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_ECDSA, nil)}
+	if err := s.ctx.SignInit(s.session, mechanism, s.privKey); err != nil {
+		return nil, fmt.Errorf("pkcs11 sign init failed, %w", err)
+	}
+	rs, err := s.ctx.Sign(s.session, hash)
+	if err != nil {
+		return nil, fmt.Errorf("pkcs11 sign failed, %w", err)
+	}
+	if len(rs) != 64 {
+		return nil, errWrongSignatureLength
+	}
+	// normalize to low S value as required by eth-like chains
+	sValue := new(big.Int).SetBytes(rs[32:])
+	if sValue.Cmp(secp256k1HalfN) > 0 {
+		sValue.Sub(secp256k1N, sValue)
+		copy(rs[32:], common.LeftPadBytes(sValue.Bytes(), 32))
+	}
+	signature := make([]byte, crypto.SignatureLength)
+	copy(signature, rs)
+	return adjustRecoveryID(pubKey, hash, signature)
+}
@@ -0,0 +1,15 @@
+//go:build !pkcs11
+// +build !pkcs11
+
+package signer
+
+import (
+	"errors"
+
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+// NewPkcs11Signer new pkcs11 signer (build with tag 'pkcs11' to enable)
+func NewPkcs11Signer(config *tokens.SignerConfig) (Signer, error) {
+	return nil, errors.New("pkcs11 signer is not supported, please build with tag 'pkcs11'")
+}
@@ -0,0 +1,150 @@
+//go:build pkcs11
+// +build pkcs11
+
+package signer
+
+import (
+	"encoding/asn1"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tools/crypto"
+	"github.com/miekg/pkcs11"
+)
+
+const (
+	testTokenLabel = "bridge-test"
+	testKeyLabel   = "swap-key"
+	testUserPin    = "1234"
+	testSOPin      = "5678"
+)
+
+// der encoded oid of secp256k1 curve (1.3.132.0.10)
+var secp256k1OID = []byte{0x06, 0x05, 0x2b, 0x81, 0x04, 0x00, 0x0a}
+
+// initSoftHSMToken init a token of softhsm in temp dir and generate
+// a secp256k1 key in it, returns the uncompressed public key
+func initSoftHSMToken(t *testing.T, module string) []byte {
+	dir, err := ioutil.TempDir("", "softhsm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	confFile := filepath.Join(dir, "softhsm2.conf")
+	conf := "directories.tokendir = " + dir + "\nobjectstore.backend = file\n"
+	if err = ioutil.WriteFile(confFile, []byte(conf), 0600); err != nil {
+		t.Fatal(err)
+	}
+	_ = os.Setenv("SOFTHSM2_CONF", confFile)
+
+	ctx := pkcs11.New(module)
+	if ctx == nil {
+		t.Fatalf("load pkcs11 module %v failed", module)
+	}
+	if err = ctx.Initialize(); err != nil {
+		t.Fatalf("initialize pkcs11 module failed: %v", err)
+	}
+	defer func() {
+		_ = ctx.Finalize()
+		ctx.Destroy()
+	}()
+
+	slots, err := ctx.GetSlotList(false)
+	if err != nil || len(slots) == 0 {
+		t.Fatalf("get pkcs11 slots failed: %v", err)
+	}
+	slot := slots[len(slots)-1] // softhsm always has a free slot at last
+	if err = ctx.InitToken(slot, testSOPin, testTokenLabel); err != nil {
+		t.Fatalf("init pkcs11 token failed: %v", err)
+	}
+	session, err := ctx.OpenSession(slot, pkcs11.CKF_SERIAL_SESSION|pkcs11.CKF_RW_SESSION)
+	if err != nil {
+		t.Fatalf("open pkcs11 session failed: %v", err)
+	}
+	defer func() { _ = ctx.CloseSession(session) }()
+	if err = ctx.Login(session, pkcs11.CKU_SO, testSOPin); err != nil {
+		t.Fatalf("login as so failed: %v", err)
+	}
+	if err = ctx.InitPIN(session, testUserPin); err != nil {
+		t.Fatalf("init user pin failed: %v", err)
+	}
+	_ = ctx.Logout(session)
+	if err = ctx.Login(session, pkcs11.CKU_USER, testUserPin); err != nil {
+		t.Fatalf("login as user failed: %v", err)
+	}
+
+	pubKeyTemplate := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_TOKEN, true),
+		pkcs11.NewAttribute(pkcs11.CKA_VERIFY, true),
+		pkcs11.NewAttribute(pkcs11.CKA_EC_PARAMS, secp256k1OID),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, testKeyLabel),
+	}
+	privKeyTemplate := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_TOKEN, true),
+		pkcs11.NewAttribute(pkcs11.CKA_PRIVATE, true),
+		pkcs11.NewAttribute(pkcs11.CKA_SIGN, true),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, testKeyLabel),
+	}
+	mechanism := []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_EC_KEY_PAIR_GEN, nil)}
+	pubKey, _, err := ctx.GenerateKeyPair(session, mechanism, pubKeyTemplate, privKeyTemplate)
+	if err != nil {
+		t.Fatalf("generate secp256k1 key failed: %v", err)
+	}
+	attrs, err := ctx.GetAttributeValue(session, pubKey, []*pkcs11.Attribute{pkcs11.NewAttribute(pkcs11.CKA_EC_POINT, nil)})
+	if err != nil || len(attrs) != 1 {
+		t.Fatalf("get public key failed: %v", err)
+	}
+	var ecPoint []byte // der encoded octet string
+	if _, err = asn1.Unmarshal(attrs[0].Value, &ecPoint); err != nil {
+		t.Fatalf("decode ec point failed: %v", err)
+	}
+	return ecPoint
+}
+
+// run with softhsm, eg.
+// SOFTHSM2_MODULE=/usr/lib/softhsm/libsofthsm2.so go test -tags pkcs11 ./signer/
+func TestPkcs11Signer(t *testing.T) {
+	module := os.Getenv("SOFTHSM2_MODULE")
+	if module == "" {
+		t.Skip("SOFTHSM2_MODULE is not set")
+	}
+	pubkey := initSoftHSMToken(t, module)
+	if _, err := crypto.UnmarshalPubkey(pubkey); err != nil {
+		t.Fatalf("wrong public key %x: %v", pubkey, err)
+	}
+
+	pinFile := filepath.Join(filepath.Dir(os.Getenv("SOFTHSM2_CONF")), "pin")
+	if err := ioutil.WriteFile(pinFile, []byte(testUserPin+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	s, err := NewPkcs11Signer(&tokens.SignerConfig{
+		Type:       tokens.Pkcs11SignerType,
+		Module:     module,
+		TokenLabel: testTokenLabel,
+		KeyLabel:   testKeyLabel,
+		PinFile:    pinFile,
+	})
+	if err != nil {
+		t.Fatalf("new pkcs11 signer failed: %v", err)
+	}
+	rsvs, err := s.Sign(common.ToHex(pubkey), testMsgHashes, testMsgContexts)
+	if err != nil {
+		t.Fatalf("pkcs11 sign failed: %v", err)
+	}
+	checkSignatures(t, pubkey, rsvs)
+	for _, rsv := range rsvs {
+		if sValue := common.FromHex(rsv)[32:64]; new(big.Int).SetBytes(sValue).Cmp(secp256k1HalfN) > 0 {
+			t.Errorf("signature is not in low S form")
+		}
+	}
+
+	otherKey, _ := crypto.GenerateKey()
+	if _, err = s.Sign(common.ToHex(crypto.FromECDSAPub(&otherKey.PublicKey)), testMsgHashes, testMsgContexts); err == nil {
+		t.Errorf("sign with other public key should fail")
+	}
+}
@@ -0,0 +1,159 @@
+// Package signer provides the signers of swap tx (dcrm, keystore, remote and HSM).
+package signer
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tools/crypto"
+)
+
+// Signer sign msg hashes with the key of public key,
+// msgContexts is the context to verify the signing (used by dcrm)
+type Signer interface {
+	Sign(pubkey string, msgHashes, msgContexts []string) (rsvs []string, err error)
+}
+
+// MessageSigner sign the keccak256 hashes of messages, it's implemented
+// by remote signers which hash the message to be signed themselves
+type MessageSigner interface {
+	SignMessages(pubkey string, messages [][]byte) (rsvs []string, err error)
+}
+
+var (
+	signers     = make(map[string]Signer)
+	signersLock sync.Mutex
+
+	errWrongPublicKey          = errors.New("wrong public key")
+	errWrongSignatureLength    = errors.New("wrong signature length")
+	errSignatureMismatch       = errors.New("signature mismatch public key")
+	errPreimageMismatch        = errors.New("keccak256 hash of preimage mismatch msg hash")
+	errSignSessionNotResumable = errors.New("sign session is not resumable")
+
+	errPrehashedSignNotSupported = errors.New("signer can not sign prehashed msg hash")
+)
+
+func getSignerKey(pairID string, isSrc bool) string {
+	return fmt.Sprintf("%v:%v", strings.ToLower(pairID), isSrc)
+}
+
+// GetSigner get signer of token pair at the specified endpoint
+func GetSigner(pairID string, isSrc bool) (Signer, error) {
+	key := getSignerKey(pairID, isSrc)
+
+	signersLock.Lock()
+	defer signersLock.Unlock()
+
+	if s, exist := signers[key]; exist {
+		return s, nil
+	}
+	tokenCfg := tokens.GetTokenConfig(pairID, isSrc)
+	if tokenCfg == nil {
+		return nil, tokens.ErrUnknownPairID
+	}
+	s, err := NewSigner(tokenCfg, tokens.GetSignKeyType(isSrc))
+	if err != nil {
+		return nil, err
+	}
+	signers[key] = s
+	log.Info("init signer success", "pairID", pairID, "isSrc", isSrc, "type", tokenCfg.GetSignerType())
+	return s, nil
+}
+
+// NewSigner new signer of token config
+func NewSigner(tokenCfg *tokens.TokenConfig, keyType string) (Signer, error) {
+	signerType := tokenCfg.GetSignerType()
+	if signerType != tokens.DcrmSignerType && keyType != tokens.KeyTypeECDSA {
+		return nil, fmt.Errorf("%v signer does not support key type %v", signerType, keyType)
+	}
+	switch signerType {
+	case tokens.DcrmSignerType:
+		return NewDcrmSigner(keyType), nil
+	case tokens.KeystoreSignerType:
+		return NewKeystoreSigner(tokenCfg.GetDcrmAddressPrivateKey())
+	case tokens.Web3SignerType:
+		return NewWeb3Signer(tokenCfg.Signer.URL, tokenCfg.Signer.Timeout), nil
+	case tokens.Pkcs11SignerType:
+		return NewPkcs11Signer(tokenCfg.Signer)
+	default:
+		return nil, fmt.Errorf("unknown signer type '%v'", signerType)
+	}
+}
+
+// SignOne sign single msg hash by the signer of token pair
+func SignOne(pairID string, isSrc bool, pubkey, msgHash, msgContext string) (rsv string, err error) {
+	s, err := GetSigner(pairID, isSrc)
+	if err != nil {
+		return "", err
+	}
+	rsvs, err := s.Sign(pubkey, []string{msgHash}, []string{msgContext})
+	if err != nil {
+		return "", err
+	}
+	if len(rsvs) != 1 {
+		return "", fmt.Errorf("sign require one rsv but have %v", len(rsvs))
+	}
+	return rsvs[0], nil
+}
+
+// SignOneWithPreimage sign single msg hash which is the keccak256 hash of preimage,
+// message signers sign the preimage and others sign the msg hash
+func SignOneWithPreimage(pairID string, isSrc bool, pubkey, msgHash, msgContext string, preimage []byte) (rsv string, err error) {
+	s, err := GetSigner(pairID, isSrc)
+	if err != nil {
+		return "", err
+	}
+	ms, ok := s.(MessageSigner)
+	if !ok {
+		return SignOne(pairID, isSrc, pubkey, msgHash, msgContext)
+	}
+	if !bytes.Equal(crypto.Keccak256(preimage), common.FromHex(msgHash)) {
+		return "", errPreimageMismatch
+	}
+	rsvs, err := ms.SignMessages(pubkey, [][]byte{preimage})
+	if err != nil {
+		return "", err
+	}
+	if len(rsvs) != 1 {
+		return "", fmt.Errorf("sign require one rsv but have %v", len(rsvs))
+	}
+	return rsvs[0], nil
+}
+
+// ParsePublicKey parse ecdsa public key (compressed or not)
+func ParsePublicKey(pubkey string) (*ecdsa.PublicKey, error) {
+	pkData := common.FromHex(pubkey)
+	switch len(pkData) {
+	case 33:
+		return crypto.DecompressPubkey(pkData)
+	case 65:
+		return crypto.UnmarshalPubkey(pkData)
+	default:
+		return nil, errWrongPublicKey
+	}
+}
+
+// adjustRecoveryID verify signature (r || s || v) is signed by public key,
+// and set v to the right recovery id (0 or 1).
+func adjustRecoveryID(pubKey *ecdsa.PublicKey, msgHash, signature []byte) ([]byte, error) {
+	if len(signature) != crypto.SignatureLength {
+		return nil, errWrongSignatureLength
+	}
+	want := crypto.FromECDSAPub(pubKey)
+	vPos := crypto.SignatureLength - 1
+	for v := byte(0); v < 2; v++ {
+		signature[vPos] = v
+		recovered, err := crypto.Ecrecover(msgHash, signature)
+		if err == nil && bytes.Equal(recovered, want) {
+			return signature, nil
+		}
+	}
+	return nil, errSignatureMismatch
+}
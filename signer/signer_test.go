@@ -0,0 +1,117 @@
+package signer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/tools/crypto"
+)
+
+var (
+	testMsgHashes = []string{
+		"0x1111111111111111111111111111111111111111111111111111111111111111",
+		"0x2222222222222222222222222222222222222222222222222222222222222222",
+	}
+	testMsgContexts = []string{"ctx1", "ctx2"}
+)
+
+func checkSignatures(t *testing.T, pubkey []byte, rsvs []string) {
+	if len(rsvs) != len(testMsgHashes) {
+		t.Fatalf("wrong number of rsvs: have %v want %v", len(rsvs), len(testMsgHashes))
+	}
+	for i, rsv := range rsvs {
+		signature := common.FromHex(rsv)
+		if len(signature) != crypto.SignatureLength {
+			t.Fatalf("wrong signature length %v", len(signature))
+		}
+		recovered, err := crypto.Ecrecover(common.FromHex(testMsgHashes[i]), signature)
+		if err != nil {
+			t.Fatalf("recover signature failed: %v", err)
+		}
+		if common.ToHex(recovered) != common.ToHex(pubkey) {
+			t.Fatalf("recovered public key mismatch")
+		}
+	}
+}
+
+func TestKeystoreSigner(t *testing.T) {
+	privKey, _ := crypto.GenerateKey()
+	pubkey := crypto.FromECDSAPub(&privKey.PublicKey)
+
+	s, err := NewKeystoreSigner(privKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsvs, err := s.Sign(common.ToHex(pubkey), testMsgHashes, testMsgContexts)
+	if err != nil {
+		t.Fatalf("keystore sign failed: %v", err)
+	}
+	checkSignatures(t, pubkey, rsvs)
+
+	otherKey, _ := crypto.GenerateKey()
+	otherPubkey := crypto.CompressPubkey(&otherKey.PublicKey)
+	if _, err = s.Sign(common.ToHex(otherPubkey), testMsgHashes, testMsgContexts); !errors.Is(err, errWrongPublicKey) {
+		t.Fatalf("sign with other public key should fail, err=%v", err)
+	}
+}
+
+func TestWeb3Signer(t *testing.T) {
+	privKey, _ := crypto.GenerateKey()
+	pubkey := crypto.FromECDSAPub(&privKey.PublicKey)
+	identifier := common.ToHex(pubkey[1:])
+
+	// as Web3Signer, sign keccak256 hash of data and return v in {27, 28}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != web3SignerSignPath+identifier {
+			http.Error(w, "wrong request", http.StatusNotFound)
+			return
+		}
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		signature, err := crypto.Sign(crypto.Keccak256(common.FromHex(body["data"])), privKey)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		signature[crypto.SignatureLength-1] += 27
+		_, _ = w.Write([]byte(common.ToHex(signature)))
+	}))
+	defer server.Close()
+
+	s := NewWeb3Signer(server.URL+"/", 0)
+	compressedPubkey := common.ToHex(crypto.CompressPubkey(&privKey.PublicKey))
+	if _, err := s.Sign(compressedPubkey, testMsgHashes, testMsgContexts); !errors.Is(err, errPrehashedSignNotSupported) {
+		t.Fatalf("web3signer sign prehashed msg hash should fail, err=%v", err)
+	}
+
+	messages := [][]byte{[]byte("message1"), []byte("message2")}
+	rsvs, err := s.SignMessages(compressedPubkey, messages)
+	if err != nil {
+		t.Fatalf("web3signer sign failed: %v", err)
+	}
+	if len(rsvs) != len(messages) {
+		t.Fatalf("wrong number of rsvs: have %v want %v", len(rsvs), len(messages))
+	}
+	for i, rsv := range rsvs {
+		recovered, err := crypto.Ecrecover(crypto.Keccak256(messages[i]), common.FromHex(rsv))
+		if err != nil || !bytes.Equal(recovered, pubkey) {
+			t.Fatalf("recover signature of message %v failed, err=%v", i, err)
+		}
+	}
+
+	otherKey, _ := crypto.GenerateKey()
+	otherPubkey := crypto.FromECDSAPub(&otherKey.PublicKey)
+	_, err = s.SignMessages(common.ToHex(otherPubkey), messages)
+	if err == nil || !strings.Contains(err.Error(), "404") {
+		t.Fatalf("sign with unknown public key should fail, err=%v", err)
+	}
+}
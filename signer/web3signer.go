@@ -0,0 +1,95 @@
+package signer
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/rpc/client"
+	"github.com/anyswap/CrossChain-Bridge/tools/crypto"
+)
+
+const (
+	web3SignerSignPath       = "/api/v1/eth1/sign/"
+	defaultWeb3SignerTimeout = 10 // seconds
+)
+
+// Web3Signer sign with remote http signer which is Web3Signer compatible,
+// POST {URL}/api/v1/eth1/sign/{publicKey} with body {"data": message},
+// and the response is the hex signature (r || s || v) of keccak256(message).
+// As it hashes the message itself, it can only sign eth like txs (see MessageSigner).
+type Web3Signer struct {
+	url     string
+	timeout int
+}
+
+// NewWeb3Signer new web3signer
+func NewWeb3Signer(url string, timeout int) *Web3Signer {
+	if timeout <= 0 {
+		timeout = defaultWeb3SignerTimeout
+	}
+	return &Web3Signer{
+		url:     strings.TrimSuffix(url, "/"),
+		timeout: timeout,
+	}
+}
+
+// Sign impl Signer, web3signer can not sign prehashed msg hashes
+func (s *Web3Signer) Sign(pubkey string, msgHashes, msgContexts []string) (rsvs []string, err error) {
+	return nil, errPrehashedSignNotSupported
+}
+
+// SignMessages impl MessageSigner
+func (s *Web3Signer) SignMessages(pubkey string, messages [][]byte) (rsvs []string, err error) {
+	pubKey, err := ParsePublicKey(pubkey)
+	if err != nil {
+		return nil, err
+	}
+	// identifier is the hex of uncompressed public key without prefix 0x04
+	identifier := common.ToHex(crypto.FromECDSAPub(pubKey)[1:])
+	url := s.url + web3SignerSignPath + identifier
+
+	rsvs = make([]string, 0, len(messages))
+	for _, message := range messages {
+		signature, errs := s.signOne(url, message)
+		if errs != nil {
+			return nil, errs
+		}
+		if signature[crypto.SignatureLength-1] >= 27 {
+			signature[crypto.SignatureLength-1] -= 27
+		}
+		signature, errs = adjustRecoveryID(pubKey, crypto.Keccak256(message), signature)
+		if errs != nil {
+			return nil, errs
+		}
+		rsvs = append(rsvs, common.ToHex(signature))
+	}
+	return rsvs, nil
+}
+
+func (s *Web3Signer) signOne(url string, message []byte) ([]byte, error) {
+	body := map[string]string{"data": common.ToHex(message)}
+	resp, err := client.HTTPPost(url, body, nil, nil, s.timeout)
+	if err != nil {
+		return nil, fmt.Errorf("web3signer sign failed, %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	const maxReadContentLength int64 = 1024
+	respBody, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxReadContentLength))
+	if err != nil {
+		return nil, fmt.Errorf("web3signer read body error: %w", err)
+	}
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("web3signer wrong response status %v. message: %v", resp.StatusCode, string(respBody))
+	}
+	result := strings.Trim(strings.TrimSpace(string(respBody)), "\"")
+	signature := common.FromHex(result)
+	if len(signature) != crypto.SignatureLength {
+		return nil, errWrongSignatureLength
+	}
+	return signature, nil
+}
@@ -12,9 +12,25 @@ import (
 const (
 	LockMemoPrefix   = "SWAPTO:"
 	UnlockMemoPrefix = "SWAPTX:"
+	RefundMemoPrefix = "REFUNDTX:"
 	AggregateMemo    = "aggregate"
 
 	MaxPlusGasPricePercentage = uint64(100)
+
+	// max interval (seconds) from accepting to expiration of tx without nonce
+	MaxTxExpirationInterval = int64(900)
+
+	FinalizedBlockTag = "finalized"
+	SafeBlockTag      = "safe"
+)
+
+// dcrm sign key types
+const (
+	KeyTypeECDSA   = "ECDSA"
+	KeyTypeED25519 = "ED25519"
+
+	ED25519PublicKeyLength = 32
+	ED25519SignatureLength = 64
 )
 
 // common variables
@@ -30,9 +46,15 @@ var (
 	SrcForkChecker ForkChecker
 	DstForkChecker ForkChecker
 
+	SrcFinalityChecker FinalityChecker
+	DstFinalityChecker FinalityChecker
+
 	SrcLatestBlockHeight uint64
 	DstLatestBlockHeight uint64
 
+	SrcFinalizedHeight uint64
+	DstFinalizedHeight uint64
+
 	SrcStableConfirmations uint64
 	DstStableConfirmations uint64
 
@@ -86,11 +108,19 @@ func (b *CrossChainBridgeBase) GetDcrmPublicKey(pairID string) string {
 	return ""
 }
 
+// TxResultReceipt receipt of non eth-like blockchain which reports tx result
+type TxResultReceipt interface {
+	IsTxFailed() bool
+}
+
 // IsSwapTxOnChainAndFailed to make failed of swaptx
 func (s *TxStatus) IsSwapTxOnChainAndFailed(token *TokenConfig) bool {
 	if s == nil || s.BlockHeight == 0 {
 		return false // not on chain
 	}
+	if receipt, ok := s.Receipt.(TxResultReceipt); ok {
+		return receipt.IsTxFailed()
+	}
 	if s.Receipt != nil { // for eth-like blockchain
 		receipt, ok := s.Receipt.(*types.RPCTxReceipt)
 		if !ok || receipt == nil || *receipt.Status != 1 {
@@ -127,6 +157,37 @@ func GetForkChecker(isSrc bool) ForkChecker {
 	return DstForkChecker
 }
 
+// GetFinalityChecker get finality checker of specified endpoint
+func GetFinalityChecker(isSrc bool) FinalityChecker {
+	if isSrc {
+		return SrcFinalityChecker
+	}
+	return DstFinalityChecker
+}
+
+// GetSignKeyType get dcrm sign key type of bridge, default is ECDSA
+func GetSignKeyType(isSrc bool) string {
+	bridge := GetCrossChainBridge(isSrc)
+	if typer, ok := bridge.(SignKeyTyper); ok {
+		if keyType := typer.GetSignKeyType(); keyType != "" {
+			return keyType
+		}
+	}
+	return KeyTypeECDSA
+}
+
+// IsStable returns if tx is stable, judge by finality if enabled,
+// otherwise judge by the required confirmations
+func (s *TxStatus) IsStable(chainCfg *ChainConfig, confirmations uint64) bool {
+	if s == nil || s.BlockHeight == 0 {
+		return false
+	}
+	if chainCfg.IsFinalityEnabled() {
+		return s.Finalized
+	}
+	return s.Confirmations >= confirmations
+}
+
 // FromBits convert from bits
 func FromBits(value *big.Int, decimals uint8) float64 {
 	oneToken := math.Pow(10, float64(decimals))
@@ -218,6 +279,24 @@ func CalcSwappedValue(pairID string, value *big.Int, isSrc bool) *big.Int {
 	return swappedValue
 }
 
+// CheckSwapValueRange check if value is out of the range of [MinimumSwap, MaximumSwap]
+func CheckSwapValueRange(pairID string, value *big.Int, isSrc bool) (tooSmall, tooLarge bool) {
+	token := GetTokenConfig(pairID, isSrc)
+	if token == nil || value == nil {
+		return false, false
+	}
+	return value.Cmp(token.minSwap) < 0, value.Cmp(token.maxSwap) > 0
+}
+
+// CalcRefundValue calc refund value of deposit (get rid of refund fee)
+func CalcRefundValue(pairID string, value *big.Int, isSrc bool) *big.Int {
+	token := GetTokenConfig(pairID, isSrc)
+	if token == nil || value == nil || value.Cmp(token.refundFee) <= 0 {
+		return big.NewInt(0)
+	}
+	return new(big.Int).Sub(value, token.refundFee)
+}
+
 // SetLatestBlockHeight set latest block height
 func SetLatestBlockHeight(latest uint64, isSrc bool) {
 	if isSrc {
@@ -240,6 +319,82 @@ func CmpAndSetLatestBlockHeight(latest uint64, isSrc bool) {
 	}
 }
 
+// CmpAndSetFinalizedHeight cmp and set finalized block height
+func CmpAndSetFinalizedHeight(finalized uint64, isSrc bool) {
+	if isSrc {
+		if finalized > SrcFinalizedHeight {
+			SrcFinalizedHeight = finalized
+		}
+	} else {
+		if finalized > DstFinalizedHeight {
+			DstFinalizedHeight = finalized
+		}
+	}
+}
+
+// GetFinalizedHeight get finalized block height if stable by finality,
+// returns the cached value if query failed
+func GetFinalizedHeight(isSrc bool) uint64 {
+	bridge := GetCrossChainBridge(isSrc)
+	checker := GetFinalityChecker(isSrc)
+	if bridge == nil || checker == nil || !bridge.GetChainConfig().IsFinalityEnabled() {
+		return 0
+	}
+	if finalized, err := checker.GetFinalizedBlockNumber(); err == nil {
+		CmpAndSetFinalizedHeight(finalized, isSrc)
+	}
+	if isSrc {
+		return SrcFinalizedHeight
+	}
+	return DstFinalizedHeight
+}
+
+// GetRequiredConfirmations get required confirmations of swap value,
+// use the confirmation tiers of pair if configed
+func GetRequiredConfirmations(pairID string, value *big.Int, isSrc bool) uint64 {
+	chainCfg := GetCrossChainBridge(isSrc).GetChainConfig()
+	token := GetTokenConfig(pairID, isSrc)
+	if token != nil && value != nil {
+		if confirmations, ok := token.GetRequiredConfirmations(value); ok {
+			return confirmations
+		}
+	}
+	return *chainCfg.Confirmations
+}
+
+// GetMinRequiredConfirmations get the minimum required confirmations of pair,
+// which is used to check tx stable before swap value is known
+func GetMinRequiredConfirmations(pairID string, isSrc bool) uint64 {
+	chainCfg := GetCrossChainBridge(isSrc).GetChainConfig()
+	token := GetTokenConfig(pairID, isSrc)
+	if token != nil {
+		if confirmations, ok := token.GetMinRequiredConfirmations(); ok {
+			return confirmations
+		}
+	}
+	return *chainCfg.Confirmations
+}
+
+// CheckTieredConfirmations check tx has enough confirmations required by
+// the swap value tier, it's no-op if stable by finality
+func CheckTieredConfirmations(bridge CrossChainBridge, swapInfo *TxSwapInfo) error {
+	chainCfg := bridge.GetChainConfig()
+	if chainCfg.IsFinalityEnabled() {
+		return nil
+	}
+	isSrc := bridge.IsSrcEndpoint()
+	required := GetRequiredConfirmations(swapInfo.PairID, swapInfo.Value, isSrc)
+	if required <= GetMinRequiredConfirmations(swapInfo.PairID, isSrc) {
+		return nil // already checked
+	}
+	txStatus, err := bridge.GetTransactionStatus(swapInfo.Hash)
+	if err != nil || !txStatus.IsStable(chainCfg, required) {
+		log.Debug("tx has not enough confirmations of value tier", "pairID", swapInfo.PairID, "txid", swapInfo.Hash, "value", swapInfo.Value, "required", required)
+		return ErrTxNotStable
+	}
+	return nil
+}
+
 // GetStableConfirmations get stable confirmations
 func GetStableConfirmations(isSrc bool) uint64 {
 	if isSrc {
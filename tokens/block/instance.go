@@ -1,8 +0,0 @@
-package block
-
-import (
-	"github.com/anyswap/CrossChain-Bridge/tokens/btc"
-)
-
-// BridgeInstance btc bridge instance
-var BridgeInstance btc.BridgeInterface
@@ -9,40 +9,53 @@ import (
 	"github.com/anyswap/CrossChain-Bridge/log"
 	"github.com/anyswap/CrossChain-Bridge/params"
 	"github.com/anyswap/CrossChain-Bridge/tokens"
-	"github.com/anyswap/CrossChain-Bridge/tokens/block"
 	"github.com/anyswap/CrossChain-Bridge/tokens/btc"
-	"github.com/anyswap/CrossChain-Bridge/tokens/colx"
+	"github.com/anyswap/CrossChain-Bridge/tokens/cosmos"
 	"github.com/anyswap/CrossChain-Bridge/tokens/etc"
 	"github.com/anyswap/CrossChain-Bridge/tokens/eth"
+	"github.com/anyswap/CrossChain-Bridge/tokens/evm"
 	"github.com/anyswap/CrossChain-Bridge/tokens/fsn"
 	"github.com/anyswap/CrossChain-Bridge/tokens/kusama"
-	"github.com/anyswap/CrossChain-Bridge/tokens/ltc"
 	"github.com/anyswap/CrossChain-Bridge/tokens/okex"
+	"github.com/anyswap/CrossChain-Bridge/tokens/substrate"
 	"github.com/anyswap/CrossChain-Bridge/tokens/tools"
+	"github.com/anyswap/CrossChain-Bridge/tokens/tron"
 )
 
 // NewCrossChainBridge new bridge according to chain name
 func NewCrossChainBridge(id string, isSrc bool) tokens.CrossChainBridge {
 	blockChainIden := strings.ToUpper(id)
 	switch {
+	case strings.HasPrefix(blockChainIden, "BITCOINCASH"):
+		return btc.NewCrossChainBridgeWithParams(isSrc, btc.BitcoinCashParams)
 	case strings.HasPrefix(blockChainIden, "BITCOIN"):
 		return btc.NewCrossChainBridge(isSrc)
 	case strings.HasPrefix(blockChainIden, "LITECOIN"):
-		return ltc.NewCrossChainBridge(isSrc)
+		return btc.NewCrossChainBridgeWithParams(isSrc, btc.LitecoinParams)
+	case strings.HasPrefix(blockChainIden, "DOGECOIN"):
+		return btc.NewCrossChainBridgeWithParams(isSrc, btc.DogecoinParams)
 	case strings.HasPrefix(blockChainIden, "BLOCK"):
-		return block.NewCrossChainBridge(isSrc)
+		return btc.NewCrossChainBridgeWithParams(isSrc, btc.BlocknetParams)
 	case strings.HasPrefix(blockChainIden, "ETHCLASSIC"):
 		return etc.NewCrossChainBridge(isSrc)
 	case strings.HasPrefix(blockChainIden, "ETHEREUM"):
 		return eth.NewCrossChainBridge(isSrc)
+	case strings.HasPrefix(blockChainIden, "EVM"):
+		return evm.NewCrossChainBridge(isSrc)
 	case strings.HasPrefix(blockChainIden, "OKEX"):
 		return okex.NewCrossChainBridge(isSrc)
 	case strings.HasPrefix(blockChainIden, "FUSION"):
 		return fsn.NewCrossChainBridge(isSrc)
 	case strings.HasPrefix(blockChainIden, "COLOSSUS") || strings.HasPrefix(blockChainIden, "COLX"):
-		return colx.NewCrossChainBridge(isSrc)
+		return btc.NewCrossChainBridgeWithParams(isSrc, btc.ColossusParams)
+	case strings.HasPrefix(blockChainIden, "SUBSTRATE") || strings.HasPrefix(blockChainIden, "POLKADOT"):
+		return substrate.NewCrossChainBridge(isSrc)
 	case strings.HasPrefix(blockChainIden, "KUSAMA"):
 		return kusama.NewCrossChainBridge(isSrc)
+	case strings.HasPrefix(blockChainIden, "TRON"):
+		return tron.NewCrossChainBridge(isSrc)
+	case strings.HasPrefix(blockChainIden, "COSMOS"):
+		return cosmos.NewCrossChainBridge(isSrc)
 	default:
 		log.Fatalf("Unsupported block chain %v", id)
 		return nil
@@ -80,6 +93,11 @@ func InitCrossChainBridge(isServer bool) {
 	tokens.SrcForkChecker, _ = tokens.SrcBridge.(tokens.ForkChecker)
 	tokens.DstForkChecker, _ = tokens.DstBridge.(tokens.ForkChecker)
 
+	tokens.SrcFinalityChecker, _ = tokens.SrcBridge.(tokens.FinalityChecker)
+	tokens.DstFinalityChecker, _ = tokens.DstBridge.(tokens.FinalityChecker)
+	checkStableFinality(true)
+	checkStableFinality(false)
+
 	tokens.SrcStableConfirmations = *tokens.SrcBridge.GetChainConfig().Confirmations
 	tokens.DstStableConfirmations = *tokens.DstBridge.GetChainConfig().Confirmations
 
@@ -91,14 +109,8 @@ func InitCrossChainBridge(isServer bool) {
 
 	BlockChain := strings.ToUpper(srcChain.BlockChain)
 	switch BlockChain {
-	case "BITCOIN":
+	case "BITCOIN", "BITCOINCASH", "LITECOIN", "DOGECOIN", "BLOCK", "COLX":
 		btc.Init(cfg.BtcExtra)
-	case "LITECOIN":
-		ltc.Init(cfg.BtcExtra)
-	case "BLOCK":
-		block.Init(cfg.BtcExtra)
-	case "COLX":
-		colx.Init(cfg.BtcExtra)
 	default:
 		cfg.BtcExtra = nil
 	}
@@ -107,3 +119,10 @@ func InitCrossChainBridge(isServer bool) {
 
 	log.Info("Init bridge success", "isServer", isServer, "dcrmEnabled", !cfg.Dcrm.Disable)
 }
+
+func checkStableFinality(isSrc bool) {
+	chainCfg := tokens.GetCrossChainBridge(isSrc).GetChainConfig()
+	if chainCfg.IsFinalityEnabled() && tokens.GetFinalityChecker(isSrc) == nil {
+		log.Fatalf("block chain %v does not support stable finality", chainCfg.BlockChain)
+	}
+}
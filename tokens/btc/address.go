@@ -8,6 +8,12 @@ import (
 
 // DecodeAddress decode address
 func (b *Bridge) DecodeAddress(addr string) (address btcutil.Address, err error) {
+	if prefix := b.GetCashAddrPrefix(); prefix != "" {
+		address, err = b.decodeCashAddress(addr, prefix)
+		if err == nil {
+			return address, nil
+		}
+	}
 	chainConfig := b.Inherit.GetChainParams()
 	address, err = btcutil.DecodeAddress(addr, chainConfig)
 	if err != nil {
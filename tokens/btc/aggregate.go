@@ -5,18 +5,15 @@ import (
 
 	"github.com/anyswap/CrossChain-Bridge/tokens"
 	"github.com/anyswap/CrossChain-Bridge/tokens/btc/electrs"
-)
-
-const (
-	redeemAggregateP2SHInputSize = 198
+	"github.com/btcsuite/btcwallet/wallet/txauthor"
 )
 
 // ShouldAggregate should aggregate
 func (b *Bridge) ShouldAggregate(aggUtxoCount int, aggSumVal uint64) bool {
-	if aggUtxoCount >= cfgUtxoAggregateMinCount {
+	if aggUtxoCount >= b.Params.UtxoAggregateMinCount {
 		return true
 	}
-	if aggSumVal >= cfgUtxoAggregateMinValue {
+	if aggSumVal >= b.Params.UtxoAggregateMinValue {
 		return true
 	}
 	return false
@@ -24,19 +21,62 @@ func (b *Bridge) ShouldAggregate(aggUtxoCount int, aggSumVal uint64) bool {
 
 // AggregateUtxos aggregate uxtos
 func (b *Bridge) AggregateUtxos(addrs []string, utxos []*electrs.ElectUtxo) (string, error) {
-	relayFee, err := b.getRelayFeePerKb()
+	authoredTx, args, err := b.BuildAggregateTxWithArgs(addrs, utxos)
 	if err != nil {
 		return "", err
 	}
 
-	authoredTx, err := b.BuildAggregateTransaction(relayFee, addrs, utxos)
+	err = b.ReserveTxInputs(authoredTx.Tx.TxIn, GetAggregateReserveKey(authoredTx.Tx.TxIn))
+	if err != nil {
+		return "", err
+	}
+
+	var signedTx interface{}
+	var txHash string
+	signedTx, txHash, err = b.DcrmSignTransaction(authoredTx, args.GetExtraArgs())
+	if err == nil {
+		_, err = b.SendTransaction(signedTx)
+	}
 	if err != nil {
+		b.ReleaseTxInputs(authoredTx.Tx.TxIn)
 		return "", err
 	}
+	return txHash, nil
+}
+
+// ExportAggregatePsbt build aggregate tx and export it as psbt
+func (b *Bridge) ExportAggregatePsbt(addrs []string, utxos []*electrs.ElectUtxo) (string, error) {
+	authoredTx, args, err := b.BuildAggregateTxWithArgs(addrs, utxos)
+	if err != nil {
+		return "", err
+	}
+	err = b.ReserveTxInputs(authoredTx.Tx.TxIn, GetAggregateReserveKey(authoredTx.Tx.TxIn))
+	if err != nil {
+		return "", err
+	}
+	psbtStr, err := b.ExportPsbt(authoredTx, args)
+	if err != nil {
+		b.ReleaseTxInputs(authoredTx.Tx.TxIn)
+		return "", err
+	}
+	return psbtStr, nil
+}
+
+// BuildAggregateTxWithArgs build aggregate tx and its build args
+func (b *Bridge) BuildAggregateTxWithArgs(addrs []string, utxos []*electrs.ElectUtxo) (*txauthor.AuthoredTx, *tokens.BuildTxArgs, error) {
+	relayFee, err := b.getRelayFeePerKb()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	authoredTx, err := b.BuildAggregateTransaction(relayFee, addrs, utxos)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	args := &tokens.BuildTxArgs{
 		SwapInfo: tokens.SwapInfo{
-			PairID:     PairID,
+			PairID:     b.Params.PairID,
 			Identifier: tokens.AggregateIdentifier,
 		},
 		Extra: &tokens.AllExtras{
@@ -54,23 +94,7 @@ func (b *Bridge) AggregateUtxos(addrs []string, utxos []*electrs.ElectUtxo) (str
 			Index: point.Index,
 		}
 	}
-
-	var signedTx interface{}
-	var txHash string
-	tokenCfg := b.GetTokenConfig(PairID)
-	if tokenCfg.GetDcrmAddressPrivateKey() != nil {
-		signedTx, txHash, err = b.SignTransaction(authoredTx, PairID)
-	} else {
-		signedTx, txHash, err = b.DcrmSignTransaction(authoredTx, args.GetExtraArgs())
-	}
-	if err != nil {
-		return "", err
-	}
-	_, err = b.SendTransaction(signedTx)
-	if err != nil {
-		return "", err
-	}
-	return txHash, nil
+	return authoredTx, args, nil
 }
 
 // VerifyAggregateMsgHash verify aggregate msgHash
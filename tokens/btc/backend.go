@@ -0,0 +1,220 @@
+package btc
+
+import (
+	"strings"
+
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tokens/btc/blocknet"
+	"github.com/anyswap/CrossChain-Bridge/tokens/btc/corerpc"
+	"github.com/anyswap/CrossChain-Bridge/tokens/btc/electrs"
+)
+
+// backend names which can be configed in `GatewayConfig.Backend`
+const (
+	BackendElectrs  = "electrs"
+	BackendCoreRPC  = "corerpc"
+	BackendBlocknet = "blocknet"
+)
+
+// Backend interface of blockchain api backend
+type Backend interface {
+	GetLatestBlockNumberOf(apiAddress string) (uint64, error)
+	GetLatestBlockNumber() (uint64, error)
+	GetTransactionByHash(txHash string) (*electrs.ElectTx, error)
+	GetElectTransactionStatus(txHash string) (*electrs.ElectTxStatus, error)
+	FindUtxos(addr string) ([]*electrs.ElectUtxo, error)
+	GetPoolTxidList() ([]string, error)
+	GetPoolTransactions(addr string) ([]*electrs.ElectTx, error)
+	GetTransactionHistory(addr, lastSeenTxid string) ([]*electrs.ElectTx, error)
+	GetOutspend(txHash string, vout uint32) (*electrs.ElectOutspend, error)
+	PostTransaction(txHex string) (txHash string, err error)
+	GetBlockHash(height uint64) (string, error)
+	GetBlockTxids(blockHash string) ([]string, error)
+	GetBlock(blockHash string) (*electrs.ElectBlock, error)
+	GetBlockTransactions(blockHash string, startIndex uint32) ([]*electrs.ElectTx, error)
+	EstimateFeePerKb(blocks int) (int64, error)
+}
+
+// NewBackend new backend of the config name
+// use the chain's default backend if not configed
+func NewBackend(b *Bridge, gatewayCfg *tokens.GatewayConfig) (backend Backend) {
+	name := gatewayCfg.Backend
+	if name == "" {
+		name = b.Params.DefaultBackend
+	}
+	switch strings.ToLower(name) {
+	case "", BackendElectrs:
+		backend = &ElectrsBackend{bridge: b}
+		if b.Params.ExplorerNetParams != nil {
+			backend = NewExplorerBackend(b, backend, b.Params.ExplorerNetParams)
+		}
+	case BackendCoreRPC:
+		backend = &CoreRPCBackend{bridge: b}
+	case BackendBlocknet:
+		backend = blocknet.NewBackend(b)
+	default:
+		log.Fatal("unsupported gateway backend", "backend", name)
+	}
+	return backend
+}
+
+// ElectrsBackend electrs api backend
+type ElectrsBackend struct {
+	bridge tokens.CrossChainBridge
+}
+
+// GetLatestBlockNumberOf impl
+func (e *ElectrsBackend) GetLatestBlockNumberOf(apiAddress string) (uint64, error) {
+	return electrs.GetLatestBlockNumberOf(apiAddress)
+}
+
+// GetLatestBlockNumber impl
+func (e *ElectrsBackend) GetLatestBlockNumber() (uint64, error) {
+	return electrs.GetLatestBlockNumber(e.bridge)
+}
+
+// GetTransactionByHash impl
+func (e *ElectrsBackend) GetTransactionByHash(txHash string) (*electrs.ElectTx, error) {
+	return electrs.GetTransactionByHash(e.bridge, txHash)
+}
+
+// GetElectTransactionStatus impl
+func (e *ElectrsBackend) GetElectTransactionStatus(txHash string) (*electrs.ElectTxStatus, error) {
+	return electrs.GetElectTransactionStatus(e.bridge, txHash)
+}
+
+// FindUtxos impl
+func (e *ElectrsBackend) FindUtxos(addr string) ([]*electrs.ElectUtxo, error) {
+	return electrs.FindUtxos(e.bridge, addr)
+}
+
+// GetPoolTxidList impl
+func (e *ElectrsBackend) GetPoolTxidList() ([]string, error) {
+	return electrs.GetPoolTxidList(e.bridge)
+}
+
+// GetPoolTransactions impl
+func (e *ElectrsBackend) GetPoolTransactions(addr string) ([]*electrs.ElectTx, error) {
+	return electrs.GetPoolTransactions(e.bridge, addr)
+}
+
+// GetTransactionHistory impl
+func (e *ElectrsBackend) GetTransactionHistory(addr, lastSeenTxid string) ([]*electrs.ElectTx, error) {
+	return electrs.GetTransactionHistory(e.bridge, addr, lastSeenTxid)
+}
+
+// GetOutspend impl
+func (e *ElectrsBackend) GetOutspend(txHash string, vout uint32) (*electrs.ElectOutspend, error) {
+	return electrs.GetOutspend(e.bridge, txHash, vout)
+}
+
+// PostTransaction impl
+func (e *ElectrsBackend) PostTransaction(txHex string) (txHash string, err error) {
+	return electrs.PostTransaction(e.bridge, txHex)
+}
+
+// GetBlockHash impl
+func (e *ElectrsBackend) GetBlockHash(height uint64) (string, error) {
+	return electrs.GetBlockHash(e.bridge, height)
+}
+
+// GetBlockTxids impl
+func (e *ElectrsBackend) GetBlockTxids(blockHash string) ([]string, error) {
+	return electrs.GetBlockTxids(e.bridge, blockHash)
+}
+
+// GetBlock impl
+func (e *ElectrsBackend) GetBlock(blockHash string) (*electrs.ElectBlock, error) {
+	return electrs.GetBlock(e.bridge, blockHash)
+}
+
+// GetBlockTransactions impl
+func (e *ElectrsBackend) GetBlockTransactions(blockHash string, startIndex uint32) ([]*electrs.ElectTx, error) {
+	return electrs.GetBlockTransactions(e.bridge, blockHash, startIndex)
+}
+
+// EstimateFeePerKb impl
+func (e *ElectrsBackend) EstimateFeePerKb(blocks int) (int64, error) {
+	return electrs.EstimateFeePerKb(e.bridge, blocks)
+}
+
+// CoreRPCBackend bitcoin core json-rpc api backend
+type CoreRPCBackend struct {
+	bridge tokens.CrossChainBridge
+}
+
+// GetLatestBlockNumberOf impl
+func (c *CoreRPCBackend) GetLatestBlockNumberOf(apiAddress string) (uint64, error) {
+	return corerpc.GetLatestBlockNumberOf(apiAddress)
+}
+
+// GetLatestBlockNumber impl
+func (c *CoreRPCBackend) GetLatestBlockNumber() (uint64, error) {
+	return corerpc.GetLatestBlockNumber(c.bridge)
+}
+
+// GetTransactionByHash impl
+func (c *CoreRPCBackend) GetTransactionByHash(txHash string) (*electrs.ElectTx, error) {
+	return corerpc.GetTransactionByHash(c.bridge, txHash)
+}
+
+// GetElectTransactionStatus impl
+func (c *CoreRPCBackend) GetElectTransactionStatus(txHash string) (*electrs.ElectTxStatus, error) {
+	return corerpc.GetElectTransactionStatus(c.bridge, txHash)
+}
+
+// FindUtxos impl
+func (c *CoreRPCBackend) FindUtxos(addr string) ([]*electrs.ElectUtxo, error) {
+	return corerpc.FindUtxos(c.bridge, addr)
+}
+
+// GetPoolTxidList impl
+func (c *CoreRPCBackend) GetPoolTxidList() ([]string, error) {
+	return corerpc.GetPoolTxidList(c.bridge)
+}
+
+// GetPoolTransactions impl
+func (c *CoreRPCBackend) GetPoolTransactions(addr string) ([]*electrs.ElectTx, error) {
+	return corerpc.GetPoolTransactions(c.bridge, addr)
+}
+
+// GetTransactionHistory impl
+func (c *CoreRPCBackend) GetTransactionHistory(addr, lastSeenTxid string) ([]*electrs.ElectTx, error) {
+	return corerpc.GetTransactionHistory(c.bridge, addr, lastSeenTxid)
+}
+
+// GetOutspend impl
+func (c *CoreRPCBackend) GetOutspend(txHash string, vout uint32) (*electrs.ElectOutspend, error) {
+	return corerpc.GetOutspend(c.bridge, txHash, vout)
+}
+
+// PostTransaction impl
+func (c *CoreRPCBackend) PostTransaction(txHex string) (txHash string, err error) {
+	return corerpc.PostTransaction(c.bridge, txHex)
+}
+
+// GetBlockHash impl
+func (c *CoreRPCBackend) GetBlockHash(height uint64) (string, error) {
+	return corerpc.GetBlockHash(c.bridge, height)
+}
+
+// GetBlockTxids impl
+func (c *CoreRPCBackend) GetBlockTxids(blockHash string) ([]string, error) {
+	return corerpc.GetBlockTxids(c.bridge, blockHash)
+}
+
+// GetBlock impl
+func (c *CoreRPCBackend) GetBlock(blockHash string) (*electrs.ElectBlock, error) {
+	return corerpc.GetBlock(c.bridge, blockHash)
+}
+
+// GetBlockTransactions impl
+func (c *CoreRPCBackend) GetBlockTransactions(blockHash string, startIndex uint32) ([]*electrs.ElectTx, error) {
+	return corerpc.GetBlockTransactions(c.bridge, blockHash, startIndex)
+}
+
+// EstimateFeePerKb impl
+func (c *CoreRPCBackend) EstimateFeePerKb(blocks int) (int64, error) {
+	return corerpc.EstimateFeePerKb(c.bridge, blocks)
+}
@@ -1,15 +1,18 @@
-package block
+// Package blocknet implements the blocknet api backend of utxo bridge.
+//
+// It queries blocknet core nodes by json-rpc and finds utxos from cloudchains.
+package blocknet
 
 import (
 	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
-	"math/big"
 	"net/http"
 	"sort"
 	"strings"
 
+	"github.com/anyswap/CrossChain-Bridge/tokens"
 	"github.com/anyswap/CrossChain-Bridge/tokens/btc/electrs"
 	"github.com/btcsuite/btcd/btcjson"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
@@ -46,17 +49,27 @@ func (c *Client) Closer() {
 	}
 }
 
+// Backend blocknet api backend
+type Backend struct {
+	bridge tokens.CrossChainBridge
+}
+
+// NewBackend new blocknet backend
+func NewBackend(b tokens.CrossChainBridge) *Backend {
+	return &Backend{bridge: b}
+}
+
 var blockClient *Client
 
 var cclis = make([]CoreClient, 0)
 
 // GetClient returns new Client
-func (b *Bridge) GetClient() *Client {
+func (b *Backend) GetClient() *Client {
 	if blockClient != nil {
 		return blockClient
 	}
 
-	cfg := b.GetGatewayConfig()
+	cfg := b.bridge.GetGatewayConfig()
 	if cfg.Extras == nil || cfg.Extras.BlockExtra == nil {
 		return nil
 	}
@@ -93,7 +106,7 @@ func (b *Bridge) GetClient() *Client {
 }
 
 // GetLatestBlockNumberOf impl
-func (b *Bridge) GetLatestBlockNumberOf(apiAddress string) (uint64, error) {
+func (b *Backend) GetLatestBlockNumberOf(apiAddress string) (uint64, error) {
 	cli := b.GetClient()
 	//# defer cli.Closer()
 	for _, ccli := range cli.CClients {
@@ -106,7 +119,7 @@ func (b *Bridge) GetLatestBlockNumberOf(apiAddress string) (uint64, error) {
 }
 
 // GetLatestBlockNumber impl
-func (b *Bridge) GetLatestBlockNumber() (blocknumber uint64, err error) {
+func (b *Backend) GetLatestBlockNumber() (blocknumber uint64, err error) {
 	cli := b.GetClient()
 	//# defer cli.Closer()
 	errs := make([]error, 0)
@@ -122,7 +135,7 @@ func (b *Bridge) GetLatestBlockNumber() (blocknumber uint64, err error) {
 }
 
 // GetTransactionByHash impl
-func (b *Bridge) GetTransactionByHash(txHash string) (etx *electrs.ElectTx, err error) {
+func (b *Backend) GetTransactionByHash(txHash string) (etx *electrs.ElectTx, err error) {
 	cli := b.GetClient()
 	//# defer cli.Closer()
 	errs := make([]error, 0)
@@ -143,7 +156,7 @@ func (b *Bridge) GetTransactionByHash(txHash string) (etx *electrs.ElectTx, err
 }
 
 // GetElectTransactionStatus impl
-func (b *Bridge) GetElectTransactionStatus(txHash string) (txstatus *electrs.ElectTxStatus, err error) {
+func (b *Backend) GetElectTransactionStatus(txHash string) (txstatus *electrs.ElectTxStatus, err error) {
 	cli := b.GetClient()
 	//# defer cli.Closer()
 	errs := make([]error, 0)
@@ -169,7 +182,7 @@ func (b *Bridge) GetElectTransactionStatus(txHash string) (txstatus *electrs.Ele
 }
 
 // FindUtxos impl
-func (b *Bridge) FindUtxos(addr string) (utxos []*electrs.ElectUtxo, err error) {
+func (b *Backend) FindUtxos(addr string) (utxos []*electrs.ElectUtxo, err error) {
 	// cloudchainsinc
 	cli := b.GetClient()
 
@@ -267,7 +280,7 @@ type CloudchainUtxo struct {
 }
 
 // GetPoolTxidList impl
-func (b *Bridge) GetPoolTxidList() (txids []string, err error) {
+func (b *Backend) GetPoolTxidList() (txids []string, err error) {
 	cli := b.GetClient()
 	//# defer cli.Closer()
 	txids = make([]string, 0)
@@ -287,7 +300,7 @@ func (b *Bridge) GetPoolTxidList() (txids []string, err error) {
 }
 
 // GetPoolTransactions impl
-func (b *Bridge) GetPoolTransactions(addr string) (txs []*electrs.ElectTx, err error) {
+func (b *Backend) GetPoolTransactions(addr string) (txs []*electrs.ElectTx, err error) {
 	txids, err := b.GetPoolTxidList()
 	if err != nil {
 		return
@@ -299,9 +312,7 @@ func (b *Bridge) GetPoolTransactions(addr string) (txs []*electrs.ElectTx, err e
 			errs = append(errs, err0)
 			continue
 		}
-		if true {
-			txs = append(txs, tx)
-		}
+		txs = append(txs, tx)
 	}
 	if len(errs) > 0 {
 		err = fmt.Errorf("%+v", errs)
@@ -309,15 +320,14 @@ func (b *Bridge) GetPoolTransactions(addr string) (txs []*electrs.ElectTx, err e
 	return
 }
 
-// GetTransactionHistory impl
-// lastSeenTxis 以后所有的交易
-func (b *Bridge) GetTransactionHistory(addr, lastSeenTxid string) (etxs []*electrs.ElectTx, err error) {
+// GetTransactionHistory impl (not supported by blocknet backend)
+func (b *Backend) GetTransactionHistory(addr, lastSeenTxid string) (etxs []*electrs.ElectTx, err error) {
 	return
 }
 
 // GetOutspend impl
 // Only to find out if txout is spent, does not tell in which transactions it is spent.
-func (b *Bridge) GetOutspend(txHash string, vout uint32) (evout *electrs.ElectOutspend, err error) {
+func (b *Backend) GetOutspend(txHash string, vout uint32) (evout *electrs.ElectOutspend, err error) {
 	cli := b.GetClient()
 	//# defer cli.Closer()
 	errs := make([]error, 0)
@@ -338,7 +348,7 @@ func (b *Bridge) GetOutspend(txHash string, vout uint32) (evout *electrs.ElectOu
 }
 
 // PostTransaction impl
-func (b *Bridge) PostTransaction(txHex string) (txHash string, err error) {
+func (b *Backend) PostTransaction(txHex string) (txHash string, err error) {
 	cli := b.GetClient()
 	//# defer cli.Closer()
 	errs := make([]error, 0)
@@ -360,7 +370,7 @@ func (b *Bridge) PostTransaction(txHex string) (txHash string, err error) {
 }
 
 // GetBlockHash impl
-func (b *Bridge) GetBlockHash(height uint64) (hash string, err error) {
+func (b *Backend) GetBlockHash(height uint64) (hash string, err error) {
 	cli := b.GetClient()
 	//# defer cli.Closer()
 	errs := make([]error, 0)
@@ -377,7 +387,7 @@ func (b *Bridge) GetBlockHash(height uint64) (hash string, err error) {
 }
 
 // GetBlockTxids impl
-func (b *Bridge) GetBlockTxids(blockHash string) (txids []string, err error) {
+func (b *Backend) GetBlockTxids(blockHash string) (txids []string, err error) {
 	cli := b.GetClient()
 	//# defer cli.Closer()
 	errs := make([]error, 0)
@@ -398,7 +408,7 @@ func (b *Bridge) GetBlockTxids(blockHash string) (txids []string, err error) {
 }
 
 // GetBlock impl
-func (b *Bridge) GetBlock(blockHash string) (eblock *electrs.ElectBlock, err error) {
+func (b *Backend) GetBlock(blockHash string) (eblock *electrs.ElectBlock, err error) {
 	cli := b.GetClient()
 	//# defer cli.Closer()
 	errs := make([]error, 0)
@@ -419,7 +429,7 @@ func (b *Bridge) GetBlock(blockHash string) (eblock *electrs.ElectBlock, err err
 }
 
 // GetBlockTransactions impl
-func (b *Bridge) GetBlockTransactions(blockHash string, startIndex uint32) (etxs []*electrs.ElectTx, err error) {
+func (b *Backend) GetBlockTransactions(blockHash string, startIndex uint32) (etxs []*electrs.ElectTx, err error) {
 	cli := b.GetClient()
 	//# defer cli.Closer()
 	errs := make([]error, 0)
@@ -447,7 +457,7 @@ func (b *Bridge) GetBlockTransactions(blockHash string, startIndex uint32) (etxs
 }
 
 // EstimateFeePerKb impl
-func (b *Bridge) EstimateFeePerKb(blocks int) (fee int64, err error) {
+func (b *Backend) EstimateFeePerKb(blocks int) (fee int64, err error) {
 	cli := b.GetClient()
 	//# defer cli.Closer()
 	errs := make([]error, 0)
@@ -465,26 +475,3 @@ func (b *Bridge) EstimateFeePerKb(blocks int) (fee int64, err error) {
 	err = fmt.Errorf("%+v", errs)
 	return
 }
-
-// GetBalance impl
-func (b *Bridge) GetBalance(account string) (*big.Int, error) {
-	utxos, err := b.FindUtxos(account)
-	if err != nil {
-		return nil, err
-	}
-	var balance uint64
-	for _, utxo := range utxos {
-		balance += *utxo.Value
-	}
-	return new(big.Int).SetUint64(balance), nil
-}
-
-// GetTokenBalance impl
-func (b *Bridge) GetTokenBalance(tokenType, tokenAddress, accountAddress string) (*big.Int, error) {
-	return nil, fmt.Errorf("[%v] can not get token balance of token with type '%v'", b.ChainConfig.BlockChain, tokenType)
-}
-
-// GetTokenSupply impl
-func (b *Bridge) GetTokenSupply(tokenType, tokenAddress string) (*big.Int, error) {
-	return nil, fmt.Errorf("[%v] can not get token supply of token with type '%v'", b.ChainConfig.BlockChain, tokenType)
-}
@@ -1,4 +1,4 @@
-package block
+package blocknet
 
 import (
 	"bytes"
@@ -11,6 +11,13 @@ import (
 	"github.com/btcsuite/btcd/wire"
 )
 
+// script pubkey types in electrs format
+const (
+	p2pkhType    = "p2pkh"
+	p2shType     = "p2sh"
+	opReturnType = "op_return"
+)
+
 // ConvertTx converts btcjson raw tx result to elect tx
 func ConvertTx(tx *btcjson.TxRawResult) *electrs.ElectTx {
 	etx := &electrs.ElectTx{
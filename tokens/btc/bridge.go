@@ -1,4 +1,5 @@
-// Package btc implements the bridge interfaces for btc blockchain.
+// Package btc implements the bridge interfaces for btc blockchain
+// and other utxo blockchains which are described by `ChainParams`.
 package btc
 
 import (
@@ -12,30 +13,48 @@ import (
 
 const (
 	netMainnet  = "mainnet"
+	netTestnet  = "testnet"
 	netTestnet3 = "testnet3"
+	netTestnet4 = "testnet4"
 	netCustom   = "custom"
 )
 
-// PairID unique btc pair ID
-var PairID = "btc"
-
 // Bridge btc bridge
 type Bridge struct {
 	*tokens.CrossChainBridgeBase
 	Inherit Inheritable
+	Backend Backend
+	Params  *ChainParams // copy of chain params, fee and aggregate policy are overwritten by 'BtcExtra'
+
+	fromPublicKey          string
+	utxoAggregateToAddress string
 }
 
 // NewCrossChainBridge new btc bridge
 func NewCrossChainBridge(isSrc bool) *Bridge {
+	return NewCrossChainBridgeWithParams(isSrc, BitcoinParams)
+}
+
+// NewCrossChainBridgeWithParams new utxo bridge of chain params
+func NewCrossChainBridgeWithParams(isSrc bool, chainParams *ChainParams) *Bridge {
 	if !isSrc {
-		log.Fatalf("btc::NewCrossChainBridge error %v", tokens.ErrBridgeDestinationNotSupported)
+		log.Fatalf("%v::NewCrossChainBridge error %v", chainParams.PairID, tokens.ErrBridgeDestinationNotSupported)
+	}
+	params := *chainParams
+	instance := &Bridge{
+		CrossChainBridgeBase: tokens.NewCrossChainBridgeBase(isSrc),
+		Params:               &params,
 	}
-	instance := &Bridge{CrossChainBridgeBase: tokens.NewCrossChainBridgeBase(isSrc)}
 	BridgeInstance = instance
 	instance.SetInherit(instance)
 	return instance
 }
 
+// GetPairID get pair ID of the native coin
+func (b *Bridge) GetPairID() string {
+	return b.Params.PairID
+}
+
 // SetInherit set inherit
 func (b *Bridge) SetInherit(inherit Inheritable) {
 	b.Inherit = inherit
@@ -44,6 +63,7 @@ func (b *Bridge) SetInherit(inherit Inheritable) {
 // SetChainAndGateway set chain and gateway config
 func (b *Bridge) SetChainAndGateway(chainCfg *tokens.ChainConfig, gatewayCfg *tokens.GatewayConfig) {
 	b.CrossChainBridgeBase.SetChainAndGateway(chainCfg, gatewayCfg)
+	b.Backend = NewBackend(b, gatewayCfg)
 	b.VerifyChainConfig()
 	b.InitLatestBlockNumber()
 }
@@ -51,26 +71,34 @@ func (b *Bridge) SetChainAndGateway(chainCfg *tokens.ChainConfig, gatewayCfg *to
 // VerifyChainConfig verify chain config
 func (b *Bridge) VerifyChainConfig() {
 	chainCfg := b.ChainConfig
-	networkID := strings.ToLower(chainCfg.NetID)
-	switch networkID {
-	case netMainnet, netTestnet3:
-	case netCustom:
-		return
-	default:
-		log.Fatal("unsupported bitcoin network", "netID", chainCfg.NetID)
+	if !b.Params.IsSupportedNet(chainCfg.NetID) {
+		log.Fatal("unsupported "+b.Params.Name+" network", "netID", chainCfg.NetID)
 	}
 }
 
 // VerifyTokenConfig verify token config
 func (b *Bridge) VerifyTokenConfig(tokenCfg *tokens.TokenConfig) error {
+	if err := tokenCfg.VerifySignerWithoutPreimage(); err != nil {
+		return err
+	}
 	if !b.IsP2pkhAddress(tokenCfg.DcrmAddress) {
 		return fmt.Errorf("invalid dcrm address (not p2pkh): %v", tokenCfg.DcrmAddress)
 	}
 	if !b.IsValidAddress(tokenCfg.DepositAddress) {
 		return fmt.Errorf("invalid deposit address: %v", tokenCfg.DepositAddress)
 	}
-	if strings.EqualFold(tokenCfg.Symbol, "BTC") && *tokenCfg.Decimals != 8 {
-		return fmt.Errorf("invalid decimals for BTC: want 8 but have %v", *tokenCfg.Decimals)
+	if b.GetCashAddrPrefix() != "" {
+		// addresses from api backend are compared with them literally
+		if !b.IsCanonicalAddress(tokenCfg.DcrmAddress) {
+			return fmt.Errorf("dcrm address is not in cashaddr format: %v", tokenCfg.DcrmAddress)
+		}
+		if !b.IsCanonicalAddress(tokenCfg.DepositAddress) {
+			return fmt.Errorf("deposit address is not in cashaddr format: %v", tokenCfg.DepositAddress)
+		}
+	}
+	symbol := b.Params.Symbol
+	if strings.EqualFold(tokenCfg.Symbol, symbol) && *tokenCfg.Decimals != 8 {
+		return fmt.Errorf("invalid decimals for %v: want 8 but have %v", symbol, *tokenCfg.Decimals)
 	}
 	return nil
 }
@@ -4,7 +4,6 @@ import (
 	"crypto/ecdsa"
 	"fmt"
 	"math/big"
-	"strings"
 
 	"github.com/btcsuite/btcd/btcec"
 	"github.com/btcsuite/btcd/chaincfg"
@@ -19,6 +18,9 @@ type Inheritable interface {
 	GetChainParams() *chaincfg.Params
 }
 
+// sigHashForkID replay protected sig hash flag of bitcoin cash
+const sigHashForkID txscript.SigHashType = 0x40
+
 type btcAmountType = btcutil.Amount
 type wireTxInType = wire.TxIn
 type wireTxOutType = wire.TxOut
@@ -37,13 +39,7 @@ func newAmount(value float64) (btcAmountType, error) {
 
 // GetChainParams get chain config (net params)
 func (b *Bridge) GetChainParams() *chaincfg.Params {
-	networkID := strings.ToLower(b.ChainConfig.NetID)
-	switch networkID {
-	case netMainnet:
-		return &chaincfg.MainNetParams
-	default:
-		return &chaincfg.TestNet3Params
-	}
+	return b.Params.GetNetParams(b.ChainConfig.NetID)
 }
 
 // ParsePkScript parse pkScript
@@ -79,15 +75,31 @@ func (b *Bridge) IsPayToScriptHash(sigScript []byte) bool {
 	return txscript.IsPayToScriptHash(sigScript)
 }
 
+// GetSigHashType get sig hash type
+func (b *Bridge) GetSigHashType() txscript.SigHashType {
+	if b.Params.SigHashForkID {
+		return txscript.SigHashAll | sigHashForkID
+	}
+	return txscript.SigHashAll
+}
+
 // CalcSignatureHash calc sig hash
-func (b *Bridge) CalcSignatureHash(sigScript []byte, tx *wire.MsgTx, i int) (sigHash []byte, err error) {
-	return txscript.CalcSignatureHash(sigScript, txscript.SigHashAll, tx, i)
+// forkid sig hash (BIP143 digest algorithm) signs the input values
+func (b *Bridge) CalcSignatureHash(sigScript []byte, tx *wire.MsgTx, i int, prevInputValues []btcAmountType) (sigHash []byte, err error) {
+	if !b.Params.SigHashForkID {
+		return txscript.CalcSignatureHash(sigScript, txscript.SigHashAll, tx, i)
+	}
+	if i >= len(prevInputValues) {
+		return nil, fmt.Errorf("calc forkid sig hash without input value of index %v", i)
+	}
+	sigHashes := txscript.NewTxSigHashes(tx)
+	return txscript.CalcWitnessSigHash(sigScript, sigHashes, b.GetSigHashType(), tx, i, int64(prevInputValues[i]))
 }
 
 // SerializeSignature serialize signature
 func (b *Bridge) SerializeSignature(r, s *big.Int) []byte {
 	sign := &btcec.Signature{R: r, S: s}
-	return append(sign.Serialize(), byte(txscript.SigHashAll))
+	return append(sign.Serialize(), byte(b.GetSigHashType()))
 }
 
 // GetSigScript get script
@@ -0,0 +1,79 @@
+package btc
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// native P2WPKH example of BIP143, the forkid digest of bitcoin cash is the same
+// algorithm with hash type SIGHASH_ALL|SIGHASH_FORKID (0x41)
+const (
+	bip143UnsignedTx   = "0100000002fff7f7881a8099afa6940d42d1e7f6362bec38171ea3edf433541db4e4ad969f0000000000eeffffffef51e1b804cc89d182d279655c3aa89e815b1b309fe287d9b2b55d57b90ec68a0100000000ffffffff02202cb206000000001976a9148280b37df378db99f66f85c95a783a76ac7a6d5988ac9093510d000000001976a9143bde42dbee7e4dbe6a21b2d50ce2f0167faa815988ac11000000"
+	bip143ScriptCode   = "76a9141d0f172a0ecb48aee1be1f2687d2963ae33f71a188ac"
+	bip143HashPrevouts = "96b827c8483d4e9b96712b6713a7b68d6e8003a781feba36c31143470b4efd37"
+	bip143HashSequence = "52b0a642eea2fb7ae638c36f6252b6750293dbe574a806984b8e4d8548339a3b"
+	bip143HashOutputs  = "863ef3e1a92afbfdb97f31ad0fc7683ee943e9abcf2501590ff8f6551f47e5e5"
+	bip143SigHashAll   = "c37af31116d1b27caf68aae9e3ac82f1477929014d5b917657d0eb49478cb670"
+)
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	data, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatalf("decode hex %v failed: %v", s, err)
+	}
+	return data
+}
+
+func TestCalcSignatureHashForkID(t *testing.T) {
+	tx := wire.NewMsgTx(wire.TxVersion)
+	if err := tx.Deserialize(bytes.NewReader(mustDecodeHex(t, bip143UnsignedTx))); err != nil {
+		t.Fatalf("deserialize tx failed: %v", err)
+	}
+	scriptCode := mustDecodeHex(t, bip143ScriptCode)
+	prevInputValues := []btcAmountType{625000000, 600000000}
+
+	// the published SIGHASH_ALL digest anchors the vector
+	sigHash, err := txscript.CalcWitnessSigHash(scriptCode, txscript.NewTxSigHashes(tx), txscript.SigHashAll, tx, 1, int64(prevInputValues[1]))
+	if err != nil {
+		t.Fatalf("calc witness sig hash failed: %v", err)
+	}
+	if hex.EncodeToString(sigHash) != bip143SigHashAll {
+		t.Fatalf("wrong BIP143 sig hash %x", sigHash)
+	}
+
+	// BIP143 preimage of input 1 with hash type 0x41
+	var preimage bytes.Buffer
+	preimage.Write([]byte{0x01, 0x00, 0x00, 0x00})
+	preimage.Write(mustDecodeHex(t, bip143HashPrevouts))
+	preimage.Write(mustDecodeHex(t, bip143HashSequence))
+	preimage.Write(mustDecodeHex(t, "ef51e1b804cc89d182d279655c3aa89e815b1b309fe287d9b2b55d57b90ec68a01000000"))
+	preimage.WriteByte(byte(len(scriptCode)))
+	preimage.Write(scriptCode)
+	preimage.Write([]byte{0x00, 0x46, 0xc3, 0x23, 0x00, 0x00, 0x00, 0x00}) // 600000000
+	preimage.Write([]byte{0xff, 0xff, 0xff, 0xff})
+	preimage.Write(mustDecodeHex(t, bip143HashOutputs))
+	preimage.Write([]byte{0x11, 0x00, 0x00, 0x00})
+	preimage.Write([]byte{0x41, 0x00, 0x00, 0x00})
+	want := chainhash.DoubleHashB(preimage.Bytes())
+
+	b := &Bridge{Params: &ChainParams{SigHashForkID: true}}
+	have, err := b.CalcSignatureHash(scriptCode, tx, 1, prevInputValues)
+	if err != nil {
+		t.Fatalf("calc forkid sig hash failed: %v", err)
+	}
+	if !bytes.Equal(have, want) {
+		t.Errorf("wrong forkid sig hash: have %x want %x", have, want)
+	}
+
+	if _, err = b.CalcSignatureHash(scriptCode, tx, 1, prevInputValues[:1]); err == nil {
+		t.Errorf("calc forkid sig hash without input value should fail")
+	}
+	if b.GetSigHashType() != txscript.SigHashAll|sigHashForkID {
+		t.Errorf("wrong sig hash type %x", b.GetSigHashType())
+	}
+}
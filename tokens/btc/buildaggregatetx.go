@@ -26,7 +26,7 @@ func (b *Bridge) BuildAggregateTransaction(relayFeePerKb int64, addrs []string,
 	}
 
 	changeSource := func() ([]byte, error) {
-		return b.GetPayToAddrScript(cfgUtxoAggregateToAddress)
+		return b.GetPayToAddrScript(b.utxoAggregateToAddress)
 	}
 
 	return b.NewUnsignedTransaction(txOuts, btcAmountType(relayFeePerKb), inputSource, changeSource, true)
@@ -0,0 +1,33 @@
+package btc
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+var errInvalidRefundAddress = errors.New("invalid refund address")
+
+// IsRefundSupported impl tokens.Refunder
+func (b *Bridge) IsRefundSupported() bool {
+	return b.IsSrc
+}
+
+// getRefundReceiverAndValue get receiver and value to return deposit (minus refund fee) to its sender
+func (b *Bridge) getRefundReceiverAndValue(args *tokens.BuildTxArgs) (receiver string, refundValue *big.Int, err error) {
+	if !b.IsSrc {
+		return "", nil, tokens.ErrBuildSwapTxInWrongEndpoint
+	}
+	if !b.IsValidAddress(args.RefundTo) {
+		log.Warn("refund to wrong address", "receiver", args.RefundTo)
+		return "", nil, errInvalidRefundAddress
+	}
+	refundValue = tokens.CalcRefundValue(args.PairID, args.OriginValue, b.IsSrc)
+	if refundValue.Sign() <= 0 {
+		return "", nil, tokens.ErrRefundValueTooSmall
+	}
+	args.SwapValue = refundValue // refund value
+	return args.RefundTo, refundValue, nil
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/mongodb"
 	"github.com/anyswap/CrossChain-Bridge/params"
 	"github.com/anyswap/CrossChain-Bridge/tokens"
 	"github.com/anyswap/CrossChain-Bridge/tokens/btc/electrs"
@@ -25,8 +26,15 @@ const (
 )
 
 func (b *Bridge) getRelayFeePerKb() (estimateFee int64, err error) {
+	p := b.Params
+	estimateFeePerKb := b.EstimateFeePerKb
+	if p.EstimateFeePerKb != nil {
+		estimateFeePerKb = func(blocks int) (int64, error) {
+			return p.EstimateFeePerKb(p, blocks)
+		}
+	}
 	for i := 0; i < retryCount; i++ {
-		estimateFee, err = b.EstimateFeePerKb(cfgEstimateFeeBlocks)
+		estimateFee, err = estimateFeePerKb(p.EstimateFeeBlocks)
 		if err == nil {
 			break
 		}
@@ -36,13 +44,13 @@ func (b *Bridge) getRelayFeePerKb() (estimateFee int64, err error) {
 		log.Warn("estimate smart fee failed", "err", err)
 		return 0, err
 	}
-	if cfgPlusFeePercentage > 0 {
-		estimateFee += estimateFee * int64(cfgPlusFeePercentage) / 100
+	if p.PlusFeePercentage > 0 {
+		estimateFee += estimateFee * int64(p.PlusFeePercentage) / 100
 	}
-	if estimateFee > cfgMaxRelayFeePerKb {
-		estimateFee = cfgMaxRelayFeePerKb
-	} else if estimateFee < cfgMinRelayFeePerKb {
-		estimateFee = cfgMinRelayFeePerKb
+	if estimateFee > p.MaxRelayFeePerKb {
+		estimateFee = p.MaxRelayFeePerKb
+	} else if estimateFee < p.MinRelayFeePerKb {
+		estimateFee = p.MinRelayFeePerKb
 	}
 	return estimateFee, nil
 }
@@ -73,6 +81,14 @@ func (b *Bridge) BuildRawTransaction(args *tokens.BuildTxArgs) (rawTx interface{
 		changeAddress = token.DcrmAddress                                 // change
 		amount = tokens.CalcSwappedValue(pairID, args.OriginValue, false) // amount
 		memo = tokens.UnlockMemoPrefix + args.SwapID
+	case tokens.RefundType:
+		from = token.DcrmAddress          // from
+		changeAddress = token.DcrmAddress // change
+		to, amount, err = b.getRefundReceiverAndValue(args)
+		if err != nil {
+			return nil, err
+		}
+		memo = tokens.RefundMemoPrefix + args.SwapID
 	default:
 		return nil, tokens.ErrUnknownSwapType
 	}
@@ -108,8 +124,9 @@ func (b *Bridge) BuildRawTransaction(args *tokens.BuildTxArgs) (rawTx interface{
 		return nil, err
 	}
 
+	isSelectUtxos := len(extra.PreviousOutPoints) == 0
 	inputSource := func(target btcAmountType) (total btcAmountType, inputs []*wireTxInType, inputValues []btcAmountType, scripts [][]byte, err error) {
-		if len(extra.PreviousOutPoints) != 0 {
+		if !isSelectUtxos {
 			return b.getUtxos(from, target, extra.PreviousOutPoints)
 		}
 		return b.selectUtxos(from, target)
@@ -124,6 +141,14 @@ func (b *Bridge) BuildRawTransaction(args *tokens.BuildTxArgs) (rawTx interface{
 		return nil, err
 	}
 
+	if isSelectUtxos {
+		swapKey := mongodb.GetSwapKey(args.SwapID, pairID, args.Bind)
+		err = b.ReserveTxInputs(authoredTx.Tx.TxIn, swapKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	updateExtraInfo(extra, authoredTx.Tx.TxIn)
 
 	if args.SwapType != tokens.NoSwapType {
@@ -270,6 +295,9 @@ func (b *Bridge) selectUtxos(from string, target btcAmountType) (total btcAmount
 	)
 
 	for _, utxo := range utxos {
+		if b.IsUtxoReserved(*utxo.Txid, *utxo.Vout) {
+			continue
+		}
 		value := btcAmountType(*utxo.Value)
 		if !isValidValue(value) {
 			continue
@@ -400,8 +428,8 @@ func (b *Bridge) NewUnsignedTransaction(outputs []*wireTxOutType, relayFeePerKb
 
 		maxSignedSize := b.estimateSize(scripts, outputs, true, isAggregate)
 		maxRequiredFee := txrules.FeeForSerializeSize(relayFeePerKb, maxSignedSize)
-		if maxRequiredFee < btcAmountType(cfgMinRelayFee) {
-			maxRequiredFee = btcAmountType(cfgMinRelayFee)
+		if maxRequiredFee < btcAmountType(b.Params.MinRelayFee) {
+			maxRequiredFee = btcAmountType(b.Params.MinRelayFee)
 		}
 		remainingAmount := inputAmount - targetAmount
 		if remainingAmount < maxRequiredFee {
@@ -426,7 +454,7 @@ func (b *Bridge) NewUnsignedTransaction(outputs []*wireTxOutType, relayFeePerKb
 			//	return nil, errors.New("fee estimation requires change " +
 			//		"scripts no larger than P2WPKH output scripts")
 			//}
-			threshold := txrules.GetDustThreshold(len(changeScript), txrules.DefaultRelayFeePerKb)
+			threshold := b.Params.GetDustThreshold(len(changeScript))
 			if changeAmount < threshold {
 				log.Debug("get rid of dust change", "amount", changeAmount, "threshold", threshold, "scriptsize", len(changeScript))
 			} else {
@@ -465,7 +493,7 @@ func (b *Bridge) estimateSize(scripts [][]byte, txOuts []*wireTxOutType, addChan
 
 	size := txsizes.EstimateSerializeSize(p2pkh, txOuts, addChangeOutput)
 	if p2sh > 0 {
-		size += p2sh * redeemAggregateP2SHInputSize
+		size += p2sh * b.Params.RedeemAggregateP2SHInputSize
 	}
 
 	return size
@@ -0,0 +1,192 @@
+// Package cashaddr implements the CashAddr address format of bitcoin cash.
+//
+// ref. https://github.com/bitcoincashorg/bitcoincash.org/blob/master/spec/cashaddr.md
+package cashaddr
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// address types
+const (
+	P2PKH byte = 0
+	P2SH  byte = 1
+)
+
+const charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+var charsetRev [128]int8
+
+var generators = [5]uint64{
+	0x98f2bc8e61,
+	0x79b76d99e2,
+	0xf33e5fb3c4,
+	0xae2eabe2a8,
+	0x1e4f43e470,
+}
+
+// hash size in bytes of size code in version byte
+var hashSizes = [8]int{20, 24, 28, 32, 40, 48, 56, 64}
+
+// errors
+var (
+	ErrMixedCase       = errors.New("cashaddr: mixed case")
+	ErrInvalidChar     = errors.New("cashaddr: invalid character")
+	ErrInvalidChecksum = errors.New("cashaddr: invalid checksum")
+	ErrInvalidPrefix   = errors.New("cashaddr: prefix mismatch")
+	ErrInvalidLength   = errors.New("cashaddr: invalid length")
+	ErrInvalidPadding  = errors.New("cashaddr: invalid padding")
+)
+
+func init() {
+	for i := range charsetRev {
+		charsetRev[i] = -1
+	}
+	for i, c := range charset {
+		charsetRev[c] = int8(i)
+	}
+}
+
+func polymod(values []byte) uint64 {
+	c := uint64(1)
+	for _, d := range values {
+		c0 := byte(c >> 35)
+		c = ((c & 0x07ffffffff) << 5) ^ uint64(d)
+		for i, g := range generators {
+			if (c0>>uint(i))&1 != 0 {
+				c ^= g
+			}
+		}
+	}
+	return c ^ 1
+}
+
+func expandPrefix(prefix string) []byte {
+	ret := make([]byte, len(prefix)+1)
+	for i := 0; i < len(prefix); i++ {
+		ret[i] = prefix[i] & 0x1f
+	}
+	return ret
+}
+
+func createChecksum(prefix string, payload []byte) []byte {
+	values := append(expandPrefix(prefix), payload...)
+	values = append(values, make([]byte, 8)...)
+	mod := polymod(values)
+	checksum := make([]byte, 8)
+	for i := 0; i < 8; i++ {
+		checksum[i] = byte((mod >> uint(5*(7-i))) & 0x1f)
+	}
+	return checksum
+}
+
+func verifyChecksum(prefix string, payload []byte) bool {
+	return polymod(append(expandPrefix(prefix), payload...)) == 0
+}
+
+// convertBits regroup bits of data from `fromBits` to `toBits` per element
+func convertBits(data []byte, fromBits, toBits uint, pad bool) ([]byte, error) {
+	var (
+		acc    uint32
+		bits   uint
+		result []byte
+		maxv   = uint32(1)<<toBits - 1
+	)
+	for _, value := range data {
+		acc = acc<<fromBits | uint32(value)
+		bits += fromBits
+		for bits >= toBits {
+			bits -= toBits
+			result = append(result, byte((acc>>bits)&maxv))
+		}
+	}
+	if pad {
+		if bits > 0 {
+			result = append(result, byte((acc<<(toBits-bits))&maxv))
+		}
+	} else if bits >= fromBits || (acc<<(toBits-bits))&maxv != 0 {
+		return nil, ErrInvalidPadding
+	}
+	return result, nil
+}
+
+// Encode encode hash of address type to cashaddr with prefix
+func Encode(prefix string, addrType byte, hash []byte) (string, error) {
+	sizeCode := -1
+	for i, size := range hashSizes {
+		if size == len(hash) {
+			sizeCode = i
+			break
+		}
+	}
+	if sizeCode < 0 {
+		return "", ErrInvalidLength
+	}
+	if addrType > 0x0f {
+		return "", fmt.Errorf("cashaddr: invalid address type %v", addrType)
+	}
+	prefix = strings.ToLower(prefix)
+	versionByte := addrType<<3 | byte(sizeCode)
+	payload, err := convertBits(append([]byte{versionByte}, hash...), 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+	payload = append(payload, createChecksum(prefix, payload)...)
+
+	var sb strings.Builder
+	sb.Grow(len(prefix) + 1 + len(payload))
+	sb.WriteString(prefix)
+	sb.WriteByte(':')
+	for _, d := range payload {
+		sb.WriteByte(charset[d])
+	}
+	return sb.String(), nil
+}
+
+// Decode decode cashaddr to address type and hash,
+// the prefix is optional in address and use `defaultPrefix` if it's missing.
+func Decode(addr, defaultPrefix string) (prefix string, addrType byte, hash []byte, err error) {
+	lower := strings.ToLower(addr)
+	if lower != addr && strings.ToUpper(addr) != addr {
+		return "", 0, nil, ErrMixedCase
+	}
+	prefix = strings.ToLower(defaultPrefix)
+	if pos := strings.LastIndexByte(lower, ':'); pos >= 0 {
+		if lower[:pos] != prefix {
+			return "", 0, nil, ErrInvalidPrefix
+		}
+		lower = lower[pos+1:]
+	}
+	if len(lower) <= 8 {
+		return "", 0, nil, ErrInvalidLength
+	}
+	payload := make([]byte, len(lower))
+	for i := 0; i < len(lower); i++ {
+		c := lower[i]
+		if c >= 128 || charsetRev[c] < 0 {
+			return "", 0, nil, ErrInvalidChar
+		}
+		payload[i] = byte(charsetRev[c])
+	}
+	if !verifyChecksum(prefix, payload) {
+		return "", 0, nil, ErrInvalidChecksum
+	}
+	data, err := convertBits(payload[:len(payload)-8], 5, 8, false)
+	if err != nil {
+		return "", 0, nil, err
+	}
+	if len(data) == 0 {
+		return "", 0, nil, ErrInvalidLength
+	}
+	versionByte := data[0]
+	if versionByte&0x80 != 0 {
+		return "", 0, nil, fmt.Errorf("cashaddr: invalid version byte %v", versionByte)
+	}
+	hash = data[1:]
+	if len(hash) != hashSizes[versionByte&0x07] {
+		return "", 0, nil, ErrInvalidLength
+	}
+	return prefix, (versionByte >> 3) & 0x0f, hash, nil
+}
@@ -0,0 +1,63 @@
+package cashaddr
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+var testHash, _ = hex.DecodeString("76a04053bda0a88bda5177b86a15c3b29f559873")
+
+var testCases = []struct {
+	prefix   string
+	addrType byte
+	address  string
+}{
+	{"bitcoincash", P2PKH, "bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a"},
+	{"bitcoincash", P2SH, "bitcoincash:ppm2qsznhks23z7629mms6s4cwef74vcwvn0h829pq"},
+	{"bchtest", P2PKH, "bchtest:qpm2qsznhks23z7629mms6s4cwef74vcwvqcw003ap"},
+	{"bchtest", P2SH, "bchtest:ppm2qsznhks23z7629mms6s4cwef74vcwvhanqgjxu"},
+}
+
+func TestEncode(t *testing.T) {
+	for _, tc := range testCases {
+		address, err := Encode(tc.prefix, tc.addrType, testHash)
+		if err != nil {
+			t.Fatalf("encode %v failed: %v", tc.address, err)
+		}
+		if address != tc.address {
+			t.Errorf("encode mismatch: have %v want %v", address, tc.address)
+		}
+	}
+}
+
+func TestDecode(t *testing.T) {
+	for _, tc := range testCases {
+		for _, addr := range []string{tc.address, tc.address[len(tc.prefix)+1:]} {
+			prefix, addrType, hash, err := Decode(addr, tc.prefix)
+			if err != nil {
+				t.Fatalf("decode %v failed: %v", addr, err)
+			}
+			if prefix != tc.prefix || addrType != tc.addrType || !bytes.Equal(hash, testHash) {
+				t.Errorf("decode %v mismatch: %v %v %x", addr, prefix, addrType, hash)
+			}
+		}
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	errCases := []struct {
+		address string
+		prefix  string
+	}{
+		{"bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6b", "bitcoincash"}, // checksum
+		{"bchtest:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a", "bitcoincash"},     // prefix
+		{"bitcoincash:Qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a", "bitcoincash"}, // mixed case
+		{"bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdxib", "bitcoincash"}, // invalid char
+	}
+	for _, tc := range errCases {
+		if _, _, _, err := Decode(tc.address, tc.prefix); err == nil {
+			t.Errorf("decode %v should fail", tc.address)
+		}
+	}
+}
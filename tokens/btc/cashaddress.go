@@ -0,0 +1,64 @@
+package btc
+
+import (
+	"fmt"
+
+	"github.com/anyswap/CrossChain-Bridge/tokens/btc/cashaddr"
+	"github.com/btcsuite/btcutil"
+)
+
+// GetCashAddrPrefix get cashaddr prefix of current net,
+// return empty string if the chain does not use cashaddr format.
+func (b *Bridge) GetCashAddrPrefix() string {
+	if len(b.Params.CashAddrPrefixes) == 0 {
+		return ""
+	}
+	return b.Params.CashAddrPrefixes[b.Inherit.GetChainParams().Name]
+}
+
+func (b *Bridge) decodeCashAddress(addr, prefix string) (btcutil.Address, error) {
+	_, addrType, hash, err := cashaddr.Decode(addr, prefix)
+	if err != nil {
+		return nil, err
+	}
+	chainConfig := b.Inherit.GetChainParams()
+	switch addrType {
+	case cashaddr.P2PKH:
+		return btcutil.NewAddressPubKeyHash(hash, chainConfig)
+	case cashaddr.P2SH:
+		return btcutil.NewAddressScriptHashFromHash(hash, chainConfig)
+	default:
+		return nil, fmt.Errorf("unsupported cashaddr type %v", addrType)
+	}
+}
+
+// EncodeAddress encode address to the chain's preferred format
+func (b *Bridge) EncodeAddress(address btcutil.Address) string {
+	prefix := b.GetCashAddrPrefix()
+	if prefix == "" {
+		return address.EncodeAddress()
+	}
+	var addrType byte
+	switch address.(type) {
+	case *btcutil.AddressPubKeyHash:
+		addrType = cashaddr.P2PKH
+	case *btcutil.AddressScriptHash:
+		addrType = cashaddr.P2SH
+	default:
+		return address.EncodeAddress()
+	}
+	cashAddress, err := cashaddr.Encode(prefix, addrType, address.ScriptAddress())
+	if err != nil {
+		return address.EncodeAddress()
+	}
+	return cashAddress
+}
+
+// IsCanonicalAddress is address in the chain's preferred format
+func (b *Bridge) IsCanonicalAddress(addr string) bool {
+	address, err := b.DecodeAddress(addr)
+	if err != nil {
+		return false
+	}
+	return b.EncodeAddress(address) == addr
+}
@@ -0,0 +1,337 @@
+package btc
+
+import (
+	"strings"
+
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcwallet/wallet/txrules"
+)
+
+// ChainParams describe the differences between utxo blockchains
+// which share the same bitcoin-like transaction model.
+type ChainParams struct {
+	Name   string // used in logs, eg. Bitcoin
+	Symbol string // native coin symbol, eg. BTC
+	PairID string // unique pair ID of the native coin
+
+	// NetParams address and key prefixes, key is lower case net id
+	NetParams map[string]*chaincfg.Params
+	// DefaultNetParams used by net ids not in NetParams (eg. custom)
+	DefaultNetParams *chaincfg.Params
+	// AllowCustomNet allow net id 'custom'
+	AllowCustomNet bool
+
+	// default fee policy, can be overwritten by 'BtcExtra' config
+	MinRelayFee       int64
+	MaxMinRelayFee    int64
+	MinRelayFeePerKb  int64
+	MaxRelayFeePerKb  int64
+	PlusFeePercentage uint64
+	EstimateFeeBlocks int
+	// EstimateFeePerKb if not nil, use it instead of querying backend
+	EstimateFeePerKb func(p *ChainParams, blocks int) (int64, error)
+	// DustRelayFeePerKb relay fee used to calc dust threshold
+	DustRelayFeePerKb int64
+	// DustLimit fixed minimum dust threshold
+	DustLimit int64
+
+	// default aggregate policy, can be overwritten by 'BtcExtra' config
+	UtxoAggregateMinCount        int
+	UtxoAggregateMinValue        uint64
+	RedeemAggregateP2SHInputSize int
+
+	// DefaultBackend backend used if not configed in gateway
+	DefaultBackend string
+	// ExplorerNetParams if not nil, addresses in electrs are in this format
+	ExplorerNetParams *chaincfg.Params
+
+	// CashAddrPrefixes cashaddr prefixes, key is net params name
+	CashAddrPrefixes map[string]string
+	// SigHashForkID sign with SIGHASH_FORKID (BIP143 digest algorithm)
+	SigHashForkID bool
+}
+
+// GetNetParams get net params of net id
+func (p *ChainParams) GetNetParams(netID string) *chaincfg.Params {
+	if netParams, exist := p.NetParams[strings.ToLower(netID)]; exist {
+		return netParams
+	}
+	return p.DefaultNetParams
+}
+
+// IsSupportedNet is supported net id
+func (p *ChainParams) IsSupportedNet(netID string) bool {
+	netID = strings.ToLower(netID)
+	if _, exist := p.NetParams[netID]; exist {
+		return true
+	}
+	return p.AllowCustomNet && netID == netCustom
+}
+
+// GetDustThreshold get dust threshold of output with script size
+func (p *ChainParams) GetDustThreshold(scriptSize int) btcAmountType {
+	relayFeePerKb := txrules.DefaultRelayFeePerKb
+	if p.DustRelayFeePerKb > 0 {
+		relayFeePerKb = btcAmountType(p.DustRelayFeePerKb)
+	}
+	threshold := txrules.GetDustThreshold(scriptSize, relayFeePerKb)
+	if threshold < btcAmountType(p.DustLimit) {
+		threshold = btcAmountType(p.DustLimit)
+	}
+	return threshold
+}
+
+// BitcoinParams bitcoin chain params
+var BitcoinParams = &ChainParams{
+	Name:   "Bitcoin",
+	Symbol: "BTC",
+	PairID: "btc",
+	NetParams: map[string]*chaincfg.Params{
+		netMainnet:  &chaincfg.MainNetParams,
+		netTestnet3: &chaincfg.TestNet3Params,
+	},
+	DefaultNetParams:             &chaincfg.TestNet3Params,
+	AllowCustomNet:               true,
+	MinRelayFee:                  400,
+	MaxMinRelayFee:               100000, // 0.001 BTC
+	MinRelayFeePerKb:             2000,
+	MaxRelayFeePerKb:             500000,
+	EstimateFeeBlocks:            6,
+	UtxoAggregateMinCount:        20,
+	UtxoAggregateMinValue:        1000000,
+	RedeemAggregateP2SHInputSize: 198,
+	DefaultBackend:               BackendElectrs,
+}
+
+// LitecoinParams litecoin chain params
+var LitecoinParams = &ChainParams{
+	Name:   "Litecoin",
+	Symbol: "LTC",
+	PairID: "ltc",
+	NetParams: map[string]*chaincfg.Params{
+		netMainnet:  &LitecoinMainNetParams,
+		netTestnet4: &LitecoinTestNet4Params,
+	},
+	DefaultNetParams:             &LitecoinMainNetParams,
+	AllowCustomNet:               true,
+	MinRelayFee:                  400,
+	MaxMinRelayFee:               100000, // 0.001 LTC
+	MinRelayFeePerKb:             2000,
+	MaxRelayFeePerKb:             500000,
+	EstimateFeeBlocks:            6,
+	UtxoAggregateMinCount:        20,
+	UtxoAggregateMinValue:        1000000,
+	RedeemAggregateP2SHInputSize: 198,
+	DefaultBackend:               BackendElectrs,
+	ExplorerNetParams:            &chaincfg.MainNetParams,
+}
+
+// BlocknetParams blocknet chain params
+var BlocknetParams = &ChainParams{
+	Name:   "Block",
+	Symbol: "BLOCK",
+	PairID: "block",
+	NetParams: map[string]*chaincfg.Params{
+		netMainnet: &BlocknetMainNetParams,
+	},
+	DefaultNetParams: &BlocknetMainNetParams,
+	MinRelayFee:      3000,
+	MaxMinRelayFee:   100000, // 0.001 BLOCK
+	MinRelayFeePerKb: 10000,
+	MaxRelayFeePerKb: 500000,
+	// use min relay fee as fee per kb
+	EstimateFeePerKb: func(p *ChainParams, _ int) (int64, error) {
+		return p.MinRelayFee, nil
+	},
+	EstimateFeeBlocks:            6,
+	UtxoAggregateMinCount:        20,
+	UtxoAggregateMinValue:        1000000,
+	RedeemAggregateP2SHInputSize: 198,
+	DefaultBackend:               BackendBlocknet,
+}
+
+// ColossusParams colossus chain params
+var ColossusParams = &ChainParams{
+	Name:   "Colx",
+	Symbol: "COLX",
+	PairID: "colx",
+	NetParams: map[string]*chaincfg.Params{
+		netMainnet:  &ColossusMainNetParams,
+		netTestnet4: &ColossusTestNet4Params,
+	},
+	DefaultNetParams:  &ColossusMainNetParams,
+	AllowCustomNet:    true,
+	MinRelayFee:       300000000,
+	MaxMinRelayFee:    500000000, // 5 COLX
+	MinRelayFeePerKb:  100000000,
+	MaxRelayFeePerKb:  1000000000,
+	PlusFeePercentage: 20,
+	EstimateFeeBlocks: 3,
+	EstimateFeePerKb: func(*ChainParams, int) (int64, error) {
+		return 100100000, nil
+	},
+	UtxoAggregateMinCount:        1,
+	UtxoAggregateMinValue:        100000000,
+	RedeemAggregateP2SHInputSize: 200,
+	DefaultBackend:               BackendElectrs,
+	ExplorerNetParams:            &chaincfg.MainNetParams,
+}
+
+// DogecoinParams dogecoin chain params
+var DogecoinParams = &ChainParams{
+	Name:   "Dogecoin",
+	Symbol: "DOGE",
+	PairID: "doge",
+	NetParams: map[string]*chaincfg.Params{
+		netMainnet: &DogecoinMainNetParams,
+		netTestnet: &DogecoinTestNetParams,
+	},
+	DefaultNetParams:  &DogecoinMainNetParams,
+	AllowCustomNet:    true,
+	MinRelayFee:       100000000,  // 1 DOGE
+	MaxMinRelayFee:    1000000000, // 10 DOGE
+	MinRelayFeePerKb:  100000000,  // 1 DOGE/kB
+	MaxRelayFeePerKb:  1000000000, // 10 DOGE/kB
+	EstimateFeeBlocks: 6,
+	// dogecoin use fixed fee rate, use min relay fee per kb
+	EstimateFeePerKb: func(p *ChainParams, _ int) (int64, error) {
+		return p.MinRelayFeePerKb, nil
+	},
+	DustRelayFeePerKb:            100000000, // 1 DOGE/kB
+	DustLimit:                    100000000, // 1 DOGE
+	UtxoAggregateMinCount:        20,
+	UtxoAggregateMinValue:        100000000000, // 1000 DOGE
+	RedeemAggregateP2SHInputSize: 198,
+	DefaultBackend:               BackendElectrs,
+}
+
+// BitcoinCashParams bitcoin cash chain params
+var BitcoinCashParams = &ChainParams{
+	Name:   "BitcoinCash",
+	Symbol: "BCH",
+	PairID: "bch",
+	NetParams: map[string]*chaincfg.Params{
+		netMainnet:  &chaincfg.MainNetParams,
+		netTestnet3: &chaincfg.TestNet3Params,
+		netTestnet4: &chaincfg.TestNet3Params,
+	},
+	DefaultNetParams:  &chaincfg.TestNet3Params,
+	AllowCustomNet:    true,
+	MinRelayFee:       400,
+	MaxMinRelayFee:    100000, // 0.001 BCH
+	MinRelayFeePerKb:  1000,
+	MaxRelayFeePerKb:  100000,
+	EstimateFeeBlocks: 6,
+	// bitcoin cash blocks are not full, use min relay fee per kb
+	EstimateFeePerKb: func(p *ChainParams, _ int) (int64, error) {
+		return p.MinRelayFeePerKb, nil
+	},
+	UtxoAggregateMinCount:        20,
+	UtxoAggregateMinValue:        1000000,
+	RedeemAggregateP2SHInputSize: 198,
+	DefaultBackend:               BackendCoreRPC,
+	CashAddrPrefixes: map[string]string{
+		chaincfg.MainNetParams.Name:  "bitcoincash",
+		chaincfg.TestNet3Params.Name: "bchtest",
+	},
+	SigHashForkID: true,
+}
+
+// LitecoinMainNetParams litecoin mainnet address params
+var LitecoinMainNetParams = chaincfg.Params{
+	Name:                    "mainnet",
+	Net:                     wire.BitcoinNet(0xdbb6c0fb),
+	Bech32HRPSegwit:         "ltc",
+	PubKeyHashAddrID:        0x30,                            // starts with L
+	ScriptHashAddrID:        0x32,                            // starts with M
+	PrivateKeyID:            0xB0,                            // starts with 6 (uncompressed) or T (compressed)
+	WitnessPubKeyHashAddrID: 0x06,                            // starts with p2
+	WitnessScriptHashAddrID: 0x0A,                            // starts with 7Xh
+	HDPrivateKeyID:          [4]byte{0x04, 0x88, 0xad, 0xe4}, // starts with xprv
+	HDPublicKeyID:           [4]byte{0x04, 0x88, 0xb2, 0x1e}, // starts with xpub
+	HDCoinType:              2,
+}
+
+// LitecoinTestNet4Params litecoin testnet4 address params
+var LitecoinTestNet4Params = chaincfg.Params{
+	Name:                    "testnet4",
+	Net:                     wire.BitcoinNet(0xf1c8d2fd),
+	Bech32HRPSegwit:         "tltc",
+	PubKeyHashAddrID:        0x6f,                            // starts with m or n
+	ScriptHashAddrID:        0x3a,                            // starts with Q
+	WitnessPubKeyHashAddrID: 0x52,                            // starts with QW
+	WitnessScriptHashAddrID: 0x31,                            // starts with T7n
+	PrivateKeyID:            0xef,                            // starts with 9 (uncompressed) or c (compressed)
+	HDPrivateKeyID:          [4]byte{0x04, 0x35, 0x83, 0x94}, // starts with tprv
+	HDPublicKeyID:           [4]byte{0x04, 0x35, 0x87, 0xcf}, // starts with tpub
+	HDCoinType:              1,
+}
+
+// BlocknetMainNetParams blocknet mainnet address params
+var BlocknetMainNetParams = chaincfg.Params{
+	Name:                    "mainnet",
+	Net:                     wire.BitcoinNet(0xa3a2a0a1),
+	Bech32HRPSegwit:         "block",
+	PubKeyHashAddrID:        0x1a,                            // starts with B
+	ScriptHashAddrID:        0x1c,                            // starts with C
+	PrivateKeyID:            0x9a,                            // starts with 6 (uncompressed) or P (compressed)
+	WitnessPubKeyHashAddrID: 0x06,                            // starts with p2
+	WitnessScriptHashAddrID: 0x0A,                            // starts with 7Xh
+	HDPrivateKeyID:          [4]byte{0x04, 0x88, 0xAD, 0xE4}, // starts with xprv
+	HDPublicKeyID:           [4]byte{0x04, 0x88, 0xB2, 0x1E}, // starts with xpub
+}
+
+// ColossusMainNetParams colossus mainnet address params (no segwit)
+var ColossusMainNetParams = chaincfg.Params{
+	Name:             "mainnet",
+	PubKeyHashAddrID: 0x1e,
+	ScriptHashAddrID: 0x0d,
+	PrivateKeyID:     0xd4,
+}
+
+// ColossusTestNet4Params colossus testnet address params (no segwit)
+var ColossusTestNet4Params = chaincfg.Params{
+	Name:             "testnet4",
+	PubKeyHashAddrID: 0x8b, // starts with 'x' or 'y'
+	ScriptHashAddrID: 0x13, // starts with '8' or '9'
+	PrivateKeyID:     0xef, // starts with 9 (uncompressed) or c (compressed)
+}
+
+// DogecoinMainNetParams dogecoin mainnet address params (no segwit)
+var DogecoinMainNetParams = chaincfg.Params{
+	Name:             "mainnet",
+	Net:              wire.BitcoinNet(0xc0c0c0c0),
+	PubKeyHashAddrID: 0x1e,                            // starts with D
+	ScriptHashAddrID: 0x16,                            // starts with 9 or A
+	PrivateKeyID:     0x9e,                            // starts with 6 (uncompressed) or Q (compressed)
+	HDPrivateKeyID:   [4]byte{0x02, 0xfa, 0xc3, 0x98}, // starts with dgpv
+	HDPublicKeyID:    [4]byte{0x02, 0xfa, 0xca, 0xfd}, // starts with dgub
+	HDCoinType:       3,
+}
+
+// DogecoinTestNetParams dogecoin testnet address params (no segwit)
+var DogecoinTestNetParams = chaincfg.Params{
+	Name:             "testnet",
+	Net:              wire.BitcoinNet(0xdcb7c1fc),
+	PubKeyHashAddrID: 0x71,                            // starts with n
+	ScriptHashAddrID: 0xc4,                            // starts with 2
+	PrivateKeyID:     0xf1,                            // starts with 9 (uncompressed) or c (compressed)
+	HDPrivateKeyID:   [4]byte{0x04, 0x35, 0x83, 0x94}, // starts with tprv
+	HDPublicKeyID:    [4]byte{0x04, 0x35, 0x87, 0xcf}, // starts with tpub
+	HDCoinType:       1,
+}
+
+func init() {
+	// register to recognize the bech32 segwit address prefixes
+	for _, params := range []*chaincfg.Params{
+		&LitecoinMainNetParams,
+		&LitecoinTestNet4Params,
+		&BlocknetMainNetParams,
+	} {
+		if err := chaincfg.Register(params); err != nil {
+			log.Warn("register chain params failed", "name", params.Name, "hrp", params.Bech32HRPSegwit, "err", err)
+		}
+	}
+}
@@ -0,0 +1,314 @@
+package corerpc
+
+import (
+	"github.com/anyswap/CrossChain-Bridge/tokens/btc/electrs"
+)
+
+// CoreBlock struct (getblock verbosity 1)
+type CoreBlock struct {
+	Hash          string   `json:"hash"`
+	Confirmations int64    `json:"confirmations"`
+	Size          uint32   `json:"size"`
+	Weight        uint32   `json:"weight"`
+	Height        uint32   `json:"height"`
+	Version       uint32   `json:"version"`
+	MerkleRoot    string   `json:"merkleroot"`
+	Tx            []string `json:"tx"`
+	Time          uint32   `json:"time"`
+	Nonce         uint32   `json:"nonce"`
+	Bits          string   `json:"bits"`
+	Difficulty    float64  `json:"difficulty"`
+	PreviousHash  string   `json:"previousblockhash"`
+}
+
+// CoreBlockWithTxs struct (getblock verbosity 2 or 3)
+type CoreBlockWithTxs struct {
+	Hash   string    `json:"hash"`
+	Height uint32    `json:"height"`
+	Time   uint32    `json:"time"`
+	Tx     []*CoreTx `json:"tx"`
+}
+
+// CoreBlockHeader struct
+type CoreBlockHeader struct {
+	Hash          string `json:"hash"`
+	Confirmations int64  `json:"confirmations"`
+	Height        uint64 `json:"height"`
+	Time          uint64 `json:"time"`
+}
+
+// CoreTx struct (getrawtransaction verbose)
+type CoreTx struct {
+	Txid          string       `json:"txid"`
+	Version       uint32       `json:"version"`
+	Size          uint32       `json:"size"`
+	Weight        uint32       `json:"weight"`
+	Locktime      uint32       `json:"locktime"`
+	Fee           *float64     `json:"fee,omitempty"`
+	Vin           []*CoreTxin  `json:"vin"`
+	Vout          []*CoreTxOut `json:"vout"`
+	BlockHash     string       `json:"blockhash,omitempty"`
+	Confirmations int64        `json:"confirmations,omitempty"`
+	BlockTime     uint64       `json:"blocktime,omitempty"`
+}
+
+// CoreScriptSig struct
+type CoreScriptSig struct {
+	Asm string `json:"asm"`
+	Hex string `json:"hex"`
+}
+
+// CoreTxin struct
+type CoreTxin struct {
+	Coinbase  string         `json:"coinbase,omitempty"`
+	Txid      string         `json:"txid"`
+	Vout      uint32         `json:"vout"`
+	ScriptSig *CoreScriptSig `json:"scriptSig,omitempty"`
+	Sequence  uint32         `json:"sequence"`
+	Prevout   *CorePrevout   `json:"prevout,omitempty"`
+}
+
+// CorePrevout struct (available since bitcoin core v25 with verbosity 2)
+type CorePrevout struct {
+	Height       uint64            `json:"height"`
+	Value        float64           `json:"value"`
+	ScriptPubKey *CoreScriptPubKey `json:"scriptPubKey"`
+}
+
+// CoreTxOut struct
+type CoreTxOut struct {
+	Value        float64           `json:"value"`
+	N            uint32            `json:"n"`
+	ScriptPubKey *CoreScriptPubKey `json:"scriptPubKey"`
+}
+
+// CoreScriptPubKey struct
+type CoreScriptPubKey struct {
+	Asm       string   `json:"asm"`
+	Hex       string   `json:"hex"`
+	Type      string   `json:"type"`
+	Address   string   `json:"address,omitempty"`
+	Addresses []string `json:"addresses,omitempty"`
+}
+
+// CoreTxOutResult struct (gettxout)
+type CoreTxOutResult struct {
+	BestBlock     string  `json:"bestblock"`
+	Confirmations int64   `json:"confirmations"`
+	Value         float64 `json:"value"`
+	Coinbase      bool    `json:"coinbase"`
+}
+
+// CoreScanTxOutResult struct (scantxoutset)
+type CoreScanTxOutResult struct {
+	Success  bool               `json:"success"`
+	Height   uint64             `json:"height"`
+	Unspents []*CoreScanUnspent `json:"unspents"`
+}
+
+// CoreScanUnspent struct
+type CoreScanUnspent struct {
+	Txid   string  `json:"txid"`
+	Vout   uint32  `json:"vout"`
+	Amount float64 `json:"amount"`
+	Height uint64  `json:"height"`
+}
+
+// CoreWalletTx struct (listtransactions)
+type CoreWalletTx struct {
+	Address       string `json:"address"`
+	Category      string `json:"category"`
+	Txid          string `json:"txid"`
+	Confirmations int64  `json:"confirmations"`
+	BlockHeight   uint64 `json:"blockheight"`
+	Time          int64  `json:"time"`
+}
+
+// CoreSmartFee struct (estimatesmartfee)
+type CoreSmartFee struct {
+	FeeRate *float64 `json:"feerate,omitempty"`
+	Errors  []string `json:"errors,omitempty"`
+	Blocks  int64    `json:"blocks"`
+}
+
+// script types of bitcoin core and the corresponding electrs types
+var scriptTypeMap = map[string]string{
+	"pubkey":                "p2pk",
+	"pubkeyhash":            "p2pkh",
+	"scripthash":            "p2sh",
+	"multisig":              "multisig",
+	"nulldata":              "op_return",
+	"witness_v0_keyhash":    "v0_p2wpkh",
+	"witness_v0_scripthash": "v0_p2wsh",
+	"witness_v1_taproot":    "v1_p2tr",
+}
+
+// ConvertScriptType convert bitcoin core script type to electrs script type
+func ConvertScriptType(coreType string) string {
+	if electType, exist := scriptTypeMap[coreType]; exist {
+		return electType
+	}
+	return "unknown"
+}
+
+func toSatoshi(value float64) uint64 {
+	if value <= 0 {
+		return 0
+	}
+	return uint64(value*1e8 + 0.5)
+}
+
+// GetAddress get address from script pubkey
+func (s *CoreScriptPubKey) GetAddress() string {
+	if s.Address != "" {
+		return s.Address
+	}
+	if len(s.Addresses) == 1 {
+		return s.Addresses[0]
+	}
+	return ""
+}
+
+// HasAddress is address in outputs or prevouts of tx
+func (tx *CoreTx) HasAddress(addr string) bool {
+	for _, vout := range tx.Vout {
+		if vout.ScriptPubKey != nil && vout.ScriptPubKey.GetAddress() == addr {
+			return true
+		}
+	}
+	for _, vin := range tx.Vin {
+		if vin.Prevout != nil && vin.Prevout.ScriptPubKey != nil &&
+			vin.Prevout.ScriptPubKey.GetAddress() == addr {
+			return true
+		}
+	}
+	return false
+}
+
+// ToElectTx convert to electrs tx
+func (tx *CoreTx) ToElectTx(blockHeight uint64) *electrs.ElectTx {
+	etx := &electrs.ElectTx{
+		Txid:     new(string),
+		Version:  new(uint32),
+		Locktime: new(uint32),
+		Size:     new(uint32),
+		Weight:   new(uint32),
+		Fee:      new(uint64),
+		Vin:      make([]*electrs.ElectTxin, 0, len(tx.Vin)),
+		Vout:     make([]*electrs.ElectTxOut, 0, len(tx.Vout)),
+		Status:   tx.ToElectTxStatus(blockHeight),
+	}
+	*etx.Txid = tx.Txid
+	*etx.Version = tx.Version
+	*etx.Locktime = tx.Locktime
+	*etx.Size = tx.Size
+	*etx.Weight = tx.Weight
+	if tx.Fee != nil {
+		*etx.Fee = toSatoshi(*tx.Fee)
+	}
+	for _, vin := range tx.Vin {
+		etx.Vin = append(etx.Vin, vin.ToElectTxin())
+	}
+	for _, vout := range tx.Vout {
+		etx.Vout = append(etx.Vout, convertTxOut(vout.Value, vout.ScriptPubKey))
+	}
+	return etx
+}
+
+// ToElectTxStatus convert to electrs tx status
+func (tx *CoreTx) ToElectTxStatus(blockHeight uint64) *electrs.ElectTxStatus {
+	status := &electrs.ElectTxStatus{
+		Confirmed: new(bool),
+	}
+	if tx.BlockHash == "" || tx.Confirmations <= 0 {
+		return status
+	}
+	*status.Confirmed = true
+	status.BlockHash = new(string)
+	*status.BlockHash = tx.BlockHash
+	status.BlockTime = new(uint64)
+	*status.BlockTime = tx.BlockTime
+	if blockHeight > 0 {
+		status.BlockHeight = new(uint64)
+		*status.BlockHeight = blockHeight
+	}
+	return status
+}
+
+// ToElectTxin convert to electrs txin
+func (vin *CoreTxin) ToElectTxin() *electrs.ElectTxin {
+	evin := &electrs.ElectTxin{
+		Txid:         new(string),
+		Vout:         new(uint32),
+		Scriptsig:    new(string),
+		ScriptsigAsm: new(string),
+		IsCoinbase:   new(bool),
+		Sequence:     new(uint32),
+	}
+	*evin.Txid = vin.Txid
+	*evin.Vout = vin.Vout
+	*evin.Sequence = vin.Sequence
+	*evin.IsCoinbase = vin.Coinbase != ""
+	if vin.ScriptSig != nil {
+		*evin.Scriptsig = vin.ScriptSig.Hex
+		*evin.ScriptsigAsm = vin.ScriptSig.Asm
+	}
+	if vin.Prevout != nil {
+		evin.Prevout = convertTxOut(vin.Prevout.Value, vin.Prevout.ScriptPubKey)
+	}
+	return evin
+}
+
+func convertTxOut(value float64, script *CoreScriptPubKey) *electrs.ElectTxOut {
+	evout := &electrs.ElectTxOut{
+		Scriptpubkey:     new(string),
+		ScriptpubkeyAsm:  new(string),
+		ScriptpubkeyType: new(string),
+		Value:            new(uint64),
+	}
+	*evout.Value = toSatoshi(value)
+	if script == nil {
+		return evout
+	}
+	*evout.Scriptpubkey = script.Hex
+	*evout.ScriptpubkeyAsm = script.Asm
+	*evout.ScriptpubkeyType = ConvertScriptType(script.Type)
+	if addr := script.GetAddress(); addr != "" {
+		evout.ScriptpubkeyAddress = new(string)
+		*evout.ScriptpubkeyAddress = addr
+	}
+	return evout
+}
+
+// ToElectBlock convert to electrs block
+func (blk *CoreBlock) ToElectBlock() *electrs.ElectBlock {
+	eblk := &electrs.ElectBlock{
+		Hash:         new(string),
+		Height:       new(uint32),
+		Version:      new(uint32),
+		Timestamp:    new(uint32),
+		TxCount:      new(uint32),
+		Size:         new(uint32),
+		Weight:       new(uint32),
+		MerkleRoot:   new(string),
+		PreviousHash: new(string),
+		Nonce:        new(uint32),
+		Bits:         new(uint32),
+		Difficulty:   new(uint64),
+	}
+	*eblk.Hash = blk.Hash
+	*eblk.Height = blk.Height
+	*eblk.Version = blk.Version
+	*eblk.Timestamp = blk.Time
+	*eblk.TxCount = uint32(len(blk.Tx))
+	*eblk.Size = blk.Size
+	*eblk.Weight = blk.Weight
+	*eblk.MerkleRoot = blk.MerkleRoot
+	*eblk.PreviousHash = blk.PreviousHash
+	*eblk.Nonce = blk.Nonce
+	if bits, err := parseHexUint32(blk.Bits); err == nil {
+		*eblk.Bits = bits
+	}
+	*eblk.Difficulty = uint64(blk.Difficulty)
+	return eblk
+}
@@ -0,0 +1,130 @@
+package btc
+
+import (
+	"fmt"
+
+	"github.com/anyswap/CrossChain-Bridge/tokens/btc/electrs"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcutil"
+)
+
+// ExplorerBackend wraps a backend whose explorer shows addresses
+// in another chain's format (eg. bitcoin mainnet format),
+// it converts addresses in queries and results between the two formats.
+type ExplorerBackend struct {
+	Backend
+	bridge         *Bridge
+	explorerParams *chaincfg.Params
+}
+
+// NewExplorerBackend new explorer backend
+func NewExplorerBackend(b *Bridge, backend Backend, explorerParams *chaincfg.Params) *ExplorerBackend {
+	return &ExplorerBackend{
+		Backend:        backend,
+		bridge:         b,
+		explorerParams: explorerParams,
+	}
+}
+
+// ConvertAddress convert address from one chain params to another
+func ConvertAddress(addr string, from, to *chaincfg.Params) (btcutil.Address, error) {
+	address, err := btcutil.DecodeAddress(addr, from)
+	if err != nil {
+		return nil, err
+	}
+	switch address.(type) {
+	case *btcutil.AddressPubKeyHash:
+		return btcutil.NewAddressPubKeyHash(address.ScriptAddress(), to)
+	case *btcutil.AddressScriptHash:
+		return btcutil.NewAddressScriptHashFromHash(address.ScriptAddress(), to)
+	case *btcutil.AddressWitnessPubKeyHash:
+		return btcutil.NewAddressWitnessPubKeyHash(address.ScriptAddress(), to)
+	case *btcutil.AddressWitnessScriptHash:
+		return btcutil.NewAddressWitnessScriptHash(address.ScriptAddress(), to)
+	case *btcutil.AddressPubKey:
+		return btcutil.NewAddressPubKey(address.ScriptAddress(), to)
+	default:
+		return nil, fmt.Errorf("unsupported address type %T", address)
+	}
+}
+
+// toExplorerAddress convert address to explorer format, keep it if failed
+func (e *ExplorerBackend) toExplorerAddress(addr string) string {
+	address, err := ConvertAddress(addr, e.bridge.Inherit.GetChainParams(), e.explorerParams)
+	if err != nil {
+		return addr
+	}
+	return address.EncodeAddress()
+}
+
+// fromExplorerAddress convert address from explorer format, keep it if failed
+func (e *ExplorerBackend) fromExplorerAddress(addr string) string {
+	address, err := ConvertAddress(addr, e.explorerParams, e.bridge.Inherit.GetChainParams())
+	if err != nil {
+		return addr
+	}
+	return address.EncodeAddress()
+}
+
+func (e *ExplorerBackend) convertTxOut(vout *electrs.ElectTxOut) {
+	if vout == nil || vout.ScriptpubkeyAddress == nil {
+		return
+	}
+	*vout.ScriptpubkeyAddress = e.fromExplorerAddress(*vout.ScriptpubkeyAddress)
+}
+
+func (e *ExplorerBackend) convertTx(tx *electrs.ElectTx) {
+	for _, vin := range tx.Vin {
+		e.convertTxOut(vin.Prevout)
+	}
+	for _, vout := range tx.Vout {
+		e.convertTxOut(vout)
+	}
+}
+
+func (e *ExplorerBackend) convertTxs(txs []*electrs.ElectTx) {
+	for _, tx := range txs {
+		e.convertTx(tx)
+	}
+}
+
+// GetTransactionByHash impl
+func (e *ExplorerBackend) GetTransactionByHash(txHash string) (*electrs.ElectTx, error) {
+	result, err := e.Backend.GetTransactionByHash(txHash)
+	if err == nil {
+		e.convertTx(result)
+	}
+	return result, err
+}
+
+// FindUtxos impl
+func (e *ExplorerBackend) FindUtxos(addr string) ([]*electrs.ElectUtxo, error) {
+	return e.Backend.FindUtxos(e.toExplorerAddress(addr))
+}
+
+// GetPoolTransactions impl
+func (e *ExplorerBackend) GetPoolTransactions(addr string) ([]*electrs.ElectTx, error) {
+	results, err := e.Backend.GetPoolTransactions(e.toExplorerAddress(addr))
+	if err == nil {
+		e.convertTxs(results)
+	}
+	return results, err
+}
+
+// GetTransactionHistory impl
+func (e *ExplorerBackend) GetTransactionHistory(addr, lastSeenTxid string) ([]*electrs.ElectTx, error) {
+	results, err := e.Backend.GetTransactionHistory(e.toExplorerAddress(addr), lastSeenTxid)
+	if err == nil {
+		e.convertTxs(results)
+	}
+	return results, err
+}
+
+// GetBlockTransactions impl
+func (e *ExplorerBackend) GetBlockTransactions(blockHash string, startIndex uint32) ([]*electrs.ElectTx, error) {
+	results, err := e.Backend.GetBlockTransactions(blockHash, startIndex)
+	if err == nil {
+		e.convertTxs(results)
+	}
+	return results, err
+}
@@ -5,104 +5,97 @@ import (
 	"github.com/anyswap/CrossChain-Bridge/tokens"
 )
 
-var (
-	cfgMinRelayFee       int64 = 400
-	cfgMinRelayFeePerKb  int64 = 2000
-	cfgMaxRelayFeePerKb  int64 = 500000
-	cfgPlusFeePercentage uint64
-	cfgEstimateFeeBlocks = 6
-
-	cfgFromPublicKey string
-
-	cfgUtxoAggregateMinCount  = 20
-	cfgUtxoAggregateMinValue  = uint64(1000000)
-	cfgUtxoAggregateToAddress string
-)
-
 // Init init btc extra
 func Init(btcExtra *tokens.BtcExtraConfig) {
 	if BridgeInstance == nil {
 		return
 	}
+	BridgeInstance.InitExtra(btcExtra)
+}
 
+// InitExtra init btc extra of this bridge
+func (b *Bridge) InitExtra(btcExtra *tokens.BtcExtraConfig) {
 	if btcExtra == nil {
-		log.Fatal("Btc bridge must config 'BtcExtra'")
+		log.Fatal(b.Params.Name + " bridge must config 'BtcExtra'")
 	}
 
-	initFromPublicKey()
-	initRelayFee(btcExtra)
-	initAggregate(btcExtra)
+	b.initFromPublicKey()
+	b.initRelayFee(btcExtra)
+	b.initAggregate(btcExtra)
 }
 
-func initFromPublicKey() {
+func (b *Bridge) initFromPublicKey() {
+	chainName := b.Params.Name
 	if len(tokens.GetTokenPairsConfig()) != 1 {
-		log.Fatalf("Btc bridge does not support multiple tokens")
+		log.Fatalf("%v bridge does not support multiple tokens", chainName)
 	}
 
-	pairCfg, exist := tokens.GetTokenPairsConfig()[PairID]
+	pairCfg, exist := tokens.GetTokenPairsConfig()[b.Params.PairID]
 	if !exist {
-		log.Fatalf("Btc bridge must have pairID %v", PairID)
+		log.Fatalf("%v bridge must have pairID %v", chainName, b.Params.PairID)
 	}
 
-	cfgFromPublicKey = pairCfg.SrcToken.DcrmPubkey
-	_, err := BridgeInstance.GetCompressedPublicKey(cfgFromPublicKey, true)
+	b.fromPublicKey = pairCfg.SrcToken.DcrmPubkey
+	_, err := b.GetCompressedPublicKey(b.fromPublicKey, true)
 	if err != nil {
-		log.Fatal("wrong btc dcrm public key", "err", err)
+		log.Fatal("wrong "+chainName+" dcrm public key", "err", err)
 	}
 }
 
-func initRelayFee(btcExtra *tokens.BtcExtraConfig) {
+func (b *Bridge) initRelayFee(btcExtra *tokens.BtcExtraConfig) {
+	p := b.Params
 	if btcExtra.MinRelayFee > 0 {
-		cfgMinRelayFee = btcExtra.MinRelayFee
-		maxMinRelayFee, _ := newAmount(0.001)
-		minRelayFee := btcAmountType(cfgMinRelayFee)
+		p.MinRelayFee = btcExtra.MinRelayFee
+		maxMinRelayFee := btcAmountType(p.MaxMinRelayFee)
+		minRelayFee := btcAmountType(p.MinRelayFee)
 		if minRelayFee > maxMinRelayFee {
 			log.Fatal("BtcMinRelayFee is too large", "value", minRelayFee, "max", maxMinRelayFee)
 		}
 	}
 
 	if btcExtra.EstimateFeeBlocks > 0 {
-		cfgEstimateFeeBlocks = btcExtra.EstimateFeeBlocks
-		if cfgEstimateFeeBlocks > 25 {
+		p.EstimateFeeBlocks = btcExtra.EstimateFeeBlocks
+		if p.EstimateFeeBlocks > 25 {
 			log.Fatal("EstimateFeeBlocks is too large, must <= 25")
 		}
 	}
 
 	if btcExtra.PlusFeePercentage > 0 {
-		cfgPlusFeePercentage = btcExtra.PlusFeePercentage
-		if cfgPlusFeePercentage > 5000 {
+		p.PlusFeePercentage = btcExtra.PlusFeePercentage
+		if p.PlusFeePercentage > 5000 {
 			log.Fatal("PlusFeePercentage is too large, must <= 5000")
 		}
 	}
 
 	if btcExtra.MaxRelayFeePerKb > 0 {
-		cfgMaxRelayFeePerKb = btcExtra.MaxRelayFeePerKb
+		p.MaxRelayFeePerKb = btcExtra.MaxRelayFeePerKb
 	}
 
 	if btcExtra.MinRelayFeePerKb > 0 {
-		cfgMinRelayFeePerKb = btcExtra.MinRelayFeePerKb
+		p.MinRelayFeePerKb = btcExtra.MinRelayFeePerKb
 	}
 
-	if cfgMinRelayFeePerKb > cfgMaxRelayFeePerKb {
-		log.Fatal("MinRelayFeePerKb is larger than MaxRelayFeePerKb", "min", cfgMinRelayFeePerKb, "max", cfgMaxRelayFeePerKb)
+	if p.MinRelayFeePerKb > p.MaxRelayFeePerKb {
+		log.Fatal("MinRelayFeePerKb is larger than MaxRelayFeePerKb", "min", p.MinRelayFeePerKb, "max", p.MaxRelayFeePerKb)
 	}
 
-	log.Info("Init Btc extra", "MinRelayFee", cfgMinRelayFee, "MinRelayFeePerKb", cfgMinRelayFeePerKb, "MaxRelayFeePerKb", cfgMaxRelayFeePerKb, "PlusFeePercentage", cfgPlusFeePercentage)
+	log.Info("Init "+p.Name+" extra", "MinRelayFee", p.MinRelayFee, "MinRelayFeePerKb", p.MinRelayFeePerKb, "MaxRelayFeePerKb", p.MaxRelayFeePerKb, "PlusFeePercentage", p.PlusFeePercentage)
 }
 
-func initAggregate(btcExtra *tokens.BtcExtraConfig) {
+func (b *Bridge) initAggregate(btcExtra *tokens.BtcExtraConfig) {
+	p := b.Params
 	if btcExtra.UtxoAggregateMinCount > 0 {
-		cfgUtxoAggregateMinCount = btcExtra.UtxoAggregateMinCount
+		p.UtxoAggregateMinCount = btcExtra.UtxoAggregateMinCount
 	}
 
 	if btcExtra.UtxoAggregateMinValue > 0 {
-		cfgUtxoAggregateMinValue = btcExtra.UtxoAggregateMinValue
+		p.UtxoAggregateMinValue = btcExtra.UtxoAggregateMinValue
 	}
 
-	cfgUtxoAggregateToAddress = btcExtra.UtxoAggregateToAddress
-	if !BridgeInstance.IsValidAddress(cfgUtxoAggregateToAddress) {
-		log.Fatal("wrong utxo aggregate to address", "toAddress", cfgUtxoAggregateToAddress)
+	b.utxoAggregateToAddress = btcExtra.UtxoAggregateToAddress
+	if !b.IsValidAddress(b.utxoAggregateToAddress) {
+		log.Fatal("wrong utxo aggregate to address", "toAddress", b.utxoAggregateToAddress)
 	}
 
-	log.Info("Init Btc extra", "UtxoAggregateMinCount", cfgUtxoAggregateMinCount, "UtxoAggregateMinValue", cfgUtxoAggregateMinValue, "UtxoAggregateToAddress", cfgUtxoAggregateToAddress)
+	log.Info("Init "+p.Name+" extra", "UtxoAggregateMinCount", p.UtxoAggregateMinCount, "UtxoAggregateMinValue", p.UtxoAggregateMinValue, "UtxoAggregateToAddress", b.utxoAggregateToAddress)
 }
@@ -12,6 +12,8 @@ var BridgeInstance BridgeInterface
 type BridgeInterface interface {
 	tokens.CrossChainBridge
 
+	InitExtra(btcExtra *tokens.BtcExtraConfig)
+	GetPairID() string
 	GetCompressedPublicKey(fromPublicKey string, needVerify bool) (cPkData []byte, err error)
 	GetP2shAddress(bindAddr string) (p2shAddress string, redeemScript []byte, err error)
 	VerifyP2shTransaction(pairID, txHash, bindAddress string, allowUnstable bool) (*tokens.TxSwapInfo, error)
@@ -25,4 +27,12 @@ type BridgeInterface interface {
 	StartPoolTransactionScanJob()
 
 	ShouldAggregate(aggUtxoCount int, aggSumVal uint64) bool
+
+	IsUtxoReserved(txid string, vout uint32) bool
+	ReleaseReservedUtxos(swapKey string)
+	CheckUtxoReservations()
+
+	ExportPsbt(rawTx interface{}, args *tokens.BuildTxArgs) (string, error)
+	ExportAggregatePsbt(addrs []string, utxos []*electrs.ElectUtxo) (string, error)
+	ImportPsbt(psbtStr string) (signedTx interface{}, txHash string, args *tokens.BuildTxArgs, err error)
 }
@@ -17,7 +17,7 @@ func (b *Bridge) getP2shAddressWithMemo(memo, pubKeyHash []byte) (p2shAddress st
 	if err != nil {
 		return
 	}
-	p2shAddress = addressScriptHash.EncodeAddress()
+	p2shAddress = b.EncodeAddress(addressScriptHash)
 	return
 }
 
@@ -27,7 +27,7 @@ func (b *Bridge) GetP2shAddress(bindAddr string) (p2shAddress string, redeemScri
 		return "", nil, fmt.Errorf("invalid bind address %v", bindAddr)
 	}
 	memo := common.FromHex(bindAddr)
-	pairID := PairID
+	pairID := b.Params.PairID
 	tokenCfg := b.GetTokenConfig(pairID)
 	if tokenCfg == nil {
 		return "", nil, tokens.ErrUnknownPairID
@@ -51,7 +51,7 @@ func (b *Bridge) getRedeemScriptByOutputScrpit(preScript []byte) ([]byte, error)
 	if err != nil {
 		return nil, err
 	}
-	p2shAddr := p2shAddress.String()
+	p2shAddr := b.EncodeAddress(p2shAddress)
 	bindAddr := tools.GetP2shBindAddress(p2shAddr)
 	if bindAddr == "" {
 		return nil, fmt.Errorf("p2sh address %v is not registered", p2shAddr)
@@ -70,7 +70,7 @@ func (b *Bridge) GetP2shAddressByRedeemScript(redeemScript []byte) (string, erro
 	if err != nil {
 		return "", err
 	}
-	return addressScriptHash.EncodeAddress(), nil
+	return b.EncodeAddress(addressScriptHash), nil
 }
 
 // GetP2shSigScript get p2sh signature script
@@ -41,18 +41,18 @@ func (b *Bridge) processTransactionImpl(tx *electrs.ElectTx) {
 }
 
 func (b *Bridge) processSwapin(txid string) {
-	if tools.IsSwapExist(txid, PairID, "", true) {
+	if tools.IsSwapExist(txid, b.Params.PairID, "", true) {
 		return
 	}
-	swapInfo, err := b.verifySwapinTx(PairID, txid, true)
+	swapInfo, err := b.verifySwapinTx(b.Params.PairID, txid, true)
 	tools.RegisterSwapin(txid, []*tokens.TxSwapInfo{swapInfo}, []error{err})
 }
 
 func (b *Bridge) processP2shSwapin(txid, bindAddress string) {
-	if tools.IsSwapExist(txid, PairID, bindAddress, true) {
+	if tools.IsSwapExist(txid, b.Params.PairID, bindAddress, true) {
 		return
 	}
-	swapInfo, err := b.verifyP2shSwapinTx(PairID, txid, bindAddress, true)
+	swapInfo, err := b.verifyP2shSwapinTx(b.Params.PairID, txid, bindAddress, true)
 	tools.RegisterP2shSwapin(txid, swapInfo, err)
 }
 
@@ -75,9 +75,9 @@ func isP2pkhSwapinPrior(tx *electrs.ElectTx, depositAddress string) bool {
 
 // CheckSwapinTxType check swapin type
 func (b *Bridge) CheckSwapinTxType(tx *electrs.ElectTx) (p2shBindAddrs []string, err error) {
-	tokenCfg := b.GetTokenConfig(PairID)
+	tokenCfg := b.GetTokenConfig(b.Params.PairID)
 	if tokenCfg == nil {
-		return nil, fmt.Errorf("swap pair '%v' is not configed", PairID)
+		return nil, fmt.Errorf("swap pair '%v' is not configed", b.Params.PairID)
 	}
 	depositAddress := tokenCfg.DepositAddress
 	p2pkhSwapinPrior := isP2pkhSwapinPrior(tx, depositAddress)
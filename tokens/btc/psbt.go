@@ -0,0 +1,251 @@
+package btc
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil/psbt"
+	"github.com/btcsuite/btcwallet/wallet/txauthor"
+)
+
+// BIP174 proprietary key of metadata, format is
+// 0xFC <len(identifier)> <identifier> <subtype>
+// we store metadata in the first input, as global unknowns
+// are not serialized by the psbt package.
+const (
+	psbtProprietaryType   = 0xfc
+	psbtProprietaryPrefix = "anyswap"
+
+	psbtSubtypeBuildTxArgs = 0x00
+	psbtSubtypeMemo        = 0x01
+)
+
+var (
+	errPsbtNoInputs      = errors.New("psbt has no inputs")
+	errPsbtNoBuildTxArgs = errors.New("psbt has no build tx args")
+)
+
+func psbtProprietaryKey(subtype byte) []byte {
+	key := []byte{psbtProprietaryType, byte(len(psbtProprietaryPrefix))}
+	key = append(key, psbtProprietaryPrefix...)
+	return append(key, subtype)
+}
+
+func getPsbtProprietaryValue(pInput *psbt.PInput, subtype byte) []byte {
+	key := psbtProprietaryKey(subtype)
+	for _, kv := range pInput.Unknowns {
+		if bytes.Equal(kv.Key, key) {
+			return kv.Value
+		}
+	}
+	return nil
+}
+
+// ExportPsbt export unsigned swap or aggregate tx to base64 encoded psbt
+func (b *Bridge) ExportPsbt(rawTx interface{}, args *tokens.BuildTxArgs) (string, error) {
+	authoredTx, ok := rawTx.(*txauthor.AuthoredTx)
+	if !ok {
+		return "", tokens.ErrWrongRawTx
+	}
+	err := b.verifyTransactionWithArgs(authoredTx, args)
+	if err != nil {
+		return "", err
+	}
+	packet, err := psbt.NewFromUnsignedTx(authoredTx.Tx.Copy())
+	if err != nil {
+		return "", err
+	}
+	updater, err := psbt.NewUpdater(packet)
+	if err != nil {
+		return "", err
+	}
+	for i, prevScript := range authoredTx.PrevScripts {
+		// explorer backends do not provide raw prev tx, so we use witness utxo
+		// to carry prev script and value (which is also needed by forkid digest)
+		txOut := wire.NewTxOut(int64(authoredTx.PrevInputValues[i]), prevScript)
+		if err = updater.AddInWitnessUtxo(txOut, i); err != nil {
+			return "", err
+		}
+		if err = updater.AddInSighashType(b.GetSigHashType(), i); err != nil {
+			return "", err
+		}
+		if b.IsPayToScriptHash(prevScript) {
+			redeemScript, errf := b.getRedeemScriptByOutputScrpit(prevScript)
+			if errf != nil {
+				return "", errf
+			}
+			if err = updater.AddInRedeemScript(redeemScript, i); err != nil {
+				return "", err
+			}
+		}
+	}
+	if len(packet.Inputs) == 0 {
+		return "", errPsbtNoInputs
+	}
+	argsData, err := json.Marshal(args)
+	if err != nil {
+		return "", err
+	}
+	memo := tokens.AggregateMemo
+	if args.Identifier != tokens.AggregateIdentifier {
+		memo = tokens.UnlockMemoPrefix + args.SwapID
+	}
+	packet.Inputs[0].Unknowns = append(packet.Inputs[0].Unknowns,
+		&psbt.Unknown{Key: psbtProprietaryKey(psbtSubtypeBuildTxArgs), Value: argsData},
+		&psbt.Unknown{Key: psbtProprietaryKey(psbtSubtypeMemo), Value: []byte(memo)},
+	)
+	return packet.B64Encode()
+}
+
+// ImportPsbt import signed psbt, verify it with the rebuilt tx of build args,
+// then finalize and return the signed tx.
+func (b *Bridge) ImportPsbt(psbtStr string) (signedTx interface{}, txHash string, args *tokens.BuildTxArgs, err error) {
+	packet, err := psbt.NewFromRawBytes(strings.NewReader(strings.TrimSpace(psbtStr)), true)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	if len(packet.Inputs) == 0 {
+		return nil, "", nil, errPsbtNoInputs
+	}
+	argsData := getPsbtProprietaryValue(&packet.Inputs[0], psbtSubtypeBuildTxArgs)
+	if len(argsData) == 0 {
+		return nil, "", nil, errPsbtNoBuildTxArgs
+	}
+	args = &tokens.BuildTxArgs{}
+	if err = json.Unmarshal(argsData, args); err != nil {
+		return nil, "", nil, fmt.Errorf("wrong build tx args in psbt, %w", err)
+	}
+	if args.Extra == nil || args.Extra.BtcExtra == nil || len(args.Extra.BtcExtra.PreviousOutPoints) == 0 {
+		return nil, "", nil, tokens.ErrWrongExtraArgs
+	}
+
+	authoredTx := &txauthor.AuthoredTx{Tx: packet.UnsignedTx.Copy()}
+	for i, pInput := range packet.Inputs {
+		if pInput.WitnessUtxo == nil {
+			return nil, "", nil, fmt.Errorf("psbt input %v has no prev output", i)
+		}
+		authoredTx.PrevScripts = append(authoredTx.PrevScripts, pInput.WitnessUtxo.PkScript)
+		authoredTx.PrevInputValues = append(authoredTx.PrevInputValues, btcAmountType(pInput.WitnessUtxo.Value))
+		authoredTx.TotalInput += btcAmountType(pInput.WitnessUtxo.Value)
+	}
+
+	msgHashes, sigScripts, err := b.getPsbtMsgHashes(authoredTx)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	err = b.verifyPsbtMsgHashes(authoredTx, msgHashes, args)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	for i, pInput := range packet.Inputs {
+		var sigScript []byte
+		if len(pInput.FinalScriptSig) != 0 {
+			sigScript, err = b.verifyFinalScriptSig(authoredTx, i, pInput.FinalScriptSig)
+		} else {
+			sigScript, err = b.getSigScriptFromPartialSigs(authoredTx, i, &pInput, msgHashes[i], sigScripts)
+		}
+		if err != nil {
+			return nil, "", nil, fmt.Errorf("psbt input %v: %w", i, err)
+		}
+		authoredTx.Tx.TxIn[i].SignatureScript = sigScript
+	}
+	txHash = authoredTx.Tx.TxHash().String()
+	log.Info(b.ChainConfig.BlockChain+" ImportPsbt success", "txhash", txHash, "swapID", args.SwapID, "identifier", args.Identifier)
+	return authoredTx, txHash, args, nil
+}
+
+func (b *Bridge) getPsbtMsgHashes(authoredTx *txauthor.AuthoredTx) (msgHashes []string, sigScripts [][]byte, err error) {
+	hasP2shInput := false
+	for i, prevScript := range authoredTx.PrevScripts {
+		sigScript := prevScript
+		if b.IsPayToScriptHash(prevScript) {
+			sigScript, err = b.getRedeemScriptByOutputScrpit(prevScript)
+			if err != nil {
+				return nil, nil, err
+			}
+			hasP2shInput = true
+		}
+		sigHash, err := b.CalcSignatureHash(sigScript, authoredTx.Tx, i, authoredTx.PrevInputValues)
+		if err != nil {
+			return nil, nil, err
+		}
+		msgHashes = append(msgHashes, hex.EncodeToString(sigHash))
+		sigScripts = append(sigScripts, sigScript)
+	}
+	if !hasP2shInput {
+		sigScripts = nil
+	}
+	return msgHashes, sigScripts, nil
+}
+
+// verifyPsbtMsgHashes do the same verify as oracles do in accepting sign
+func (b *Bridge) verifyPsbtMsgHashes(authoredTx *txauthor.AuthoredTx, msgHashes []string, args *tokens.BuildTxArgs) error {
+	err := b.verifyTransactionWithArgs(authoredTx, args)
+	if err != nil {
+		return err
+	}
+	if args.Identifier == tokens.AggregateIdentifier {
+		return b.VerifyAggregateMsgHash(msgHashes, args)
+	}
+	rawTx, err := b.BuildRawTransaction(args)
+	if err != nil {
+		return err
+	}
+	return b.VerifyMsgHash(rawTx, msgHashes)
+}
+
+func (b *Bridge) getSigScriptFromPartialSigs(authoredTx *txauthor.AuthoredTx, i int, pInput *psbt.PInput, msgHash string, sigScripts [][]byte) ([]byte, error) {
+	if len(pInput.PartialSigs) != 1 {
+		return nil, fmt.Errorf("require 1 partial signature but have %v", len(pInput.PartialSigs))
+	}
+	partialSig := pInput.PartialSigs[0]
+	cPkData, err := b.GetCompressedPublicKey(hex.EncodeToString(partialSig.PubKey), true)
+	if err != nil {
+		return nil, err
+	}
+	sigData := partialSig.Signature
+	if len(sigData) == 0 || txscript.SigHashType(sigData[len(sigData)-1]) != b.GetSigHashType() {
+		return nil, errors.New("wrong signature hash type")
+	}
+	signature, err := btcec.ParseDERSignature(sigData[:len(sigData)-1], btcec.S256())
+	if err != nil {
+		return nil, err
+	}
+	pubKey, err := btcec.ParsePubKey(cPkData, btcec.S256())
+	if err != nil {
+		return nil, err
+	}
+	hashData, _ := hex.DecodeString(msgHash)
+	if !signature.Verify(hashData, pubKey) {
+		return nil, errors.New("verify signature failed")
+	}
+	return b.GetSigScript(sigScripts, authoredTx.PrevScripts[i], sigData, cPkData, i)
+}
+
+func (b *Bridge) verifyFinalScriptSig(authoredTx *txauthor.AuthoredTx, i int, sigScript []byte) ([]byte, error) {
+	if b.Params.SigHashForkID {
+		return nil, errors.New("finalized input is not supported, please provide partial signature")
+	}
+	tx := authoredTx.Tx.Copy()
+	tx.TxIn[i].SignatureScript = sigScript
+	prevScript := authoredTx.PrevScripts[i]
+	prevValue := int64(authoredTx.PrevInputValues[i])
+	vm, err := txscript.NewEngine(prevScript, tx, i, txscript.StandardVerifyFlags, nil, nil, prevValue)
+	if err != nil {
+		return nil, err
+	}
+	if err = vm.Execute(); err != nil {
+		return nil, err
+	}
+	return sigScript, nil
+}
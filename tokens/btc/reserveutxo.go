@@ -0,0 +1,162 @@
+package btc
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/mongodb"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+// utxos selected by swap server are reserved in mongodb with the swap key
+// before signing, so that concurrent swaps and aggregation won't double spend them.
+// reservation is only enabled when mongodb client exists (ie. swap server).
+
+var (
+	// check reservations which are reserved or refreshed longer than this seconds
+	utxoReservationTimeout = int64(3600)
+	// aggregate tx has no swap result to check, release its unspent utxos after this seconds
+	aggregateReservationTimeout = int64(24 * 3600)
+)
+
+// GetAggregateReserveKey get reserve key of aggregate tx
+func GetAggregateReserveKey(txins []*wireTxInType) string {
+	if len(txins) == 0 {
+		return tokens.AggregateIdentifier
+	}
+	point := txins[0].PreviousOutPoint
+	return fmt.Sprintf("%v:%v:%v", tokens.AggregateIdentifier, point.Hash.String(), point.Index)
+}
+
+// IsUtxoReserved is utxo reserved
+func (b *Bridge) IsUtxoReserved(txid string, vout uint32) bool {
+	if !mongodb.HasClient() {
+		return false
+	}
+	res, _ := mongodb.FindUtxoReservation(txid, vout)
+	return res != nil
+}
+
+// ReserveTxInputs reserve inputs of tx with swap key, release all if any failed
+func (b *Bridge) ReserveTxInputs(txins []*wireTxInType, swapKey string) error {
+	if !mongodb.HasClient() {
+		return nil
+	}
+	for i, txin := range txins {
+		point := txin.PreviousOutPoint
+		err := mongodb.ReserveUtxo(point.Hash.String(), point.Index, swapKey)
+		if err != nil {
+			b.ReleaseTxInputs(txins[:i])
+			return fmt.Errorf("reserve utxo (%v, %v) failed, %w", point.Hash, point.Index, err)
+		}
+	}
+	return nil
+}
+
+// ReleaseTxInputs release reserved inputs of tx
+func (b *Bridge) ReleaseTxInputs(txins []*wireTxInType) {
+	if !mongodb.HasClient() {
+		return
+	}
+	for _, txin := range txins {
+		point := txin.PreviousOutPoint
+		_ = mongodb.ReleaseUtxo(point.Hash.String(), point.Index)
+	}
+}
+
+// ReleaseReservedUtxos release utxos reserved by swap key
+func (b *Bridge) ReleaseReservedUtxos(swapKey string) {
+	if !mongodb.HasClient() {
+		return
+	}
+	_ = mongodb.ReleaseUtxosBySwapKey(swapKey)
+}
+
+// CheckUtxoReservations release reservations of spent utxos and of settled swaps,
+// and refresh reservations of pending swaps (signing, exported psbt or tx not stable)
+func (b *Bridge) CheckUtxoReservations() {
+	if !mongodb.HasClient() {
+		return
+	}
+	septime := common.NowMilli() - utxoReservationTimeout*1000
+	reservations, err := mongodb.FindUtxoReservations(septime)
+	if err != nil {
+		log.Warn("find utxo reservations failed", "err", err)
+		return
+	}
+	checkedSwapKeys := make(map[string]bool) // swap key -> is pending
+	for _, res := range reservations {
+		outspend, err := b.GetOutspend(res.TxID, res.Vout)
+		if err != nil || outspend.Spent == nil {
+			log.Debug("get outspend of reserved utxo failed", "txid", res.TxID, "vout", res.Vout, "err", err)
+			continue
+		}
+		if *outspend.Spent {
+			log.Info("release reservation of spent utxo", "txid", res.TxID, "vout", res.Vout, "swapKey", res.SwapKey)
+			_ = mongodb.ReleaseUtxo(res.TxID, res.Vout)
+			continue
+		}
+		if strings.HasPrefix(res.SwapKey, tokens.AggregateIdentifier) {
+			if res.Timestamp < common.NowMilli()-aggregateReservationTimeout*1000 {
+				log.Info("release reservation of dropped aggregate tx", "txid", res.TxID, "vout", res.Vout, "swapKey", res.SwapKey)
+				_ = mongodb.ReleaseUtxo(res.TxID, res.Vout)
+			}
+			continue
+		}
+		isPending, checked := checkedSwapKeys[res.SwapKey]
+		if !checked {
+			isPending, err = isReservingSwapPending(res.SwapKey)
+			if err != nil {
+				log.Debug("check swap of reserved utxo failed", "swapKey", res.SwapKey, "err", err)
+				continue
+			}
+			checkedSwapKeys[res.SwapKey] = isPending
+			if isPending {
+				_ = mongodb.RefreshUtxoReservations(res.SwapKey)
+			}
+		}
+		if !isPending {
+			log.Info("release reservation of settled swap", "txid", res.TxID, "vout", res.Vout, "swapKey", res.SwapKey)
+			_ = mongodb.ReleaseUtxo(res.TxID, res.Vout)
+		}
+	}
+}
+
+func isReservingSwapPending(swapKey string) (bool, error) {
+	swapRes, err := mongodb.FindSwapResultByKey(swapKey)
+	if err != nil {
+		if errors.Is(err, mongodb.ErrItemNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return swapRes.Status.IsResultPending(), nil
+}
+
+// IsTxDropped impl DroppedTxChecker
+func (b *Bridge) IsTxDropped(txHash, swapKey string, isTimeout bool) (bool, error) {
+	if _, err := b.GetTransactionByHash(txHash); err == nil {
+		return false, nil // on chain or in pool
+	}
+	if !mongodb.HasClient() {
+		return isTimeout, nil
+	}
+	reservations, err := mongodb.FindUtxoReservationsBySwapKey(swapKey)
+	if err != nil {
+		return false, err
+	}
+	for _, res := range reservations {
+		outspend, err := b.GetOutspend(res.TxID, res.Vout)
+		if err != nil || outspend.Spent == nil {
+			return false, fmt.Errorf("get outspend of reserved utxo (%v, %v) failed, %v", res.TxID, res.Vout, err)
+		}
+		if *outspend.Spent && outspend.Txid != nil && !strings.EqualFold(*outspend.Txid, txHash) {
+			log.Info("reserved utxo is spent by other tx", "spentTx", *outspend.Txid, "txHash", txHash, "txid", res.TxID, "vout", res.Vout, "swapKey", swapKey)
+			return true, nil
+		}
+	}
+	return isTimeout, nil
+}
@@ -34,7 +34,7 @@ func (b *Bridge) scanFirstLoop() {
 	chainName := b.ChainConfig.BlockChain
 	log.Infof("[scanFirstLoop] start %v first scan loop to min height %v", chainName, minHeight)
 
-	tokenCfg := b.GetTokenConfig(PairID)
+	tokenCfg := b.GetTokenConfig(b.Params.PairID)
 	lastSeenTxid := ""
 
 FIRST_LOOP:
@@ -85,7 +85,7 @@ func (b *Bridge) scanTransactionHistory() {
 	scanSubject := fmt.Sprintf("[scanhistory] scanned %v tx", chainName)
 	log.Infof("[scanhistory] start %v scan swap history loop from height %v", chainName, minHeight)
 
-	tokenCfg := b.GetTokenConfig(PairID)
+	tokenCfg := b.GetTokenConfig(b.Params.PairID)
 
 	for {
 		txHistory, err := b.GetTransactionHistory(tokenCfg.DepositAddress, lastSeenTxid)
@@ -10,8 +10,8 @@ import (
 	"math/big"
 
 	"github.com/anyswap/CrossChain-Bridge/common"
-	"github.com/anyswap/CrossChain-Bridge/dcrm"
 	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/signer"
 	"github.com/anyswap/CrossChain-Bridge/tokens"
 	"github.com/anyswap/CrossChain-Bridge/tools/crypto"
 	"github.com/btcsuite/btcwallet/wallet/txauthor"
@@ -19,8 +19,11 @@ import (
 
 func (b *Bridge) verifyTransactionWithArgs(tx *txauthor.AuthoredTx, args *tokens.BuildTxArgs) error {
 	checkReceiver := args.Bind
-	if args.Identifier == tokens.AggregateIdentifier {
-		checkReceiver = cfgUtxoAggregateToAddress
+	switch {
+	case args.Identifier == tokens.AggregateIdentifier:
+		checkReceiver = b.utxoAggregateToAddress
+	case args.SwapType == tokens.RefundType:
+		checkReceiver = args.RefundTo
 	}
 	payToReceiverScript, err := b.GetPayToAddrScript(checkReceiver)
 	if err != nil {
@@ -51,7 +54,7 @@ func (b *Bridge) DcrmSignTransaction(rawTx interface{}, args *tokens.BuildTxArgs
 		return nil, "", err
 	}
 
-	cPkData, err := b.GetCompressedPublicKey(cfgFromPublicKey, false)
+	cPkData, err := b.GetCompressedPublicKey(b.fromPublicKey, false)
 	if err != nil {
 		return nil, "", err
 	}
@@ -74,7 +77,7 @@ func (b *Bridge) DcrmSignTransaction(rawTx interface{}, args *tokens.BuildTxArgs
 			hasP2shInput = true
 		}
 
-		sigHash, err = b.CalcSignatureHash(sigScript, authoredTx.Tx, i)
+		sigHash, err = b.CalcSignatureHash(sigScript, authoredTx.Tx, i, authoredTx.PrevInputValues)
 		if err != nil {
 			return nil, "", err
 		}
@@ -168,7 +171,7 @@ func (b *Bridge) getSigDataFromRSV(rsv string) ([]byte, bool) {
 }
 
 func (b *Bridge) verifyPublickeyData(pkData []byte) error {
-	tokenCfg := b.GetTokenConfig(PairID)
+	tokenCfg := b.GetTokenConfig(b.Params.PairID)
 	if tokenCfg == nil {
 		return nil
 	}
@@ -180,7 +183,7 @@ func (b *Bridge) verifyPublickeyData(pkData []byte) error {
 	if err != nil {
 		return err
 	}
-	if address.EncodeAddress() != dcrmAddress {
+	if b.EncodeAddress(address) != dcrmAddress {
 		return fmt.Errorf("public key address %v is not the configed dcrm address %v", address, dcrmAddress)
 	}
 	return nil
@@ -226,22 +229,26 @@ func (b *Bridge) DcrmSignMsgHash(msgHash []string, args *tokens.BuildTxArgs) (rs
 	msgContext := []string{string(jsondata)}
 
 	log.Info(b.ChainConfig.BlockChain+" DcrmSignTransaction start", "msgContext", msgContext, "txid", args.SwapID)
-	keyID, rsv, err := dcrm.DoSign(cfgFromPublicKey, msgHash, msgContext)
+	txSigner, err := signer.GetSigner(args.PairID, b.IsSrc)
 	if err != nil {
 		return nil, err
 	}
-	log.Info(b.ChainConfig.BlockChain+" DcrmSignTransaction finished", "keyID", keyID, "msghash", msgHash, "txid", args.SwapID)
+	rsv, err = txSigner.Sign(b.fromPublicKey, msgHash, msgContext)
+	if err != nil {
+		return nil, err
+	}
+	log.Info(b.ChainConfig.BlockChain+" DcrmSignTransaction finished", "msghash", msgHash, "txid", args.SwapID)
 
 	if len(rsv) != len(msgHash) {
-		return nil, fmt.Errorf("get sign status require %v rsv but have %v (keyID = %v)", len(msgHash), len(rsv), keyID)
+		return nil, fmt.Errorf("sign require %v rsv but have %v", len(msgHash), len(rsv))
 	}
 
-	rsv, err = b.adjustRsvOrders(rsv, msgHash, cfgFromPublicKey)
+	rsv, err = b.adjustRsvOrders(rsv, msgHash, b.fromPublicKey)
 	if err != nil {
 		return nil, err
 	}
 
-	log.Trace(b.ChainConfig.BlockChain+" DcrmSignTransaction get rsv success", "keyID", keyID, "txid", args.SwapID, "rsv", rsv)
+	log.Trace(b.ChainConfig.BlockChain+" DcrmSignTransaction get rsv success", "txid", args.SwapID, "rsv", rsv)
 	return rsv, nil
 }
 
@@ -312,7 +319,7 @@ func (b *Bridge) SignTransactionWithPrivateKey(rawTx interface{}, privKey *ecdsa
 			hasP2shInput = true
 		}
 
-		sigHash, err := b.CalcSignatureHash(sigScript, authoredTx.Tx, i)
+		sigHash, err := b.CalcSignatureHash(sigScript, authoredTx.Tx, i, authoredTx.PrevInputValues)
 		if err != nil {
 			return nil, "", err
 		}
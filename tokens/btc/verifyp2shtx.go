@@ -27,7 +27,7 @@ func (b *Bridge) verifyP2shSwapinTx(pairID, txHash, bindAddress string, allowUns
 	if err != nil {
 		return swapInfo, tokens.ErrWrongP2shBindAddress
 	}
-	if !allowUnstable && !b.checkStable(txHash) {
+	if !allowUnstable && !b.checkStable(pairID, txHash) {
 		return swapInfo, tokens.ErrTxNotStable
 	}
 	tx, err := b.GetTransactionByHash(txHash)
@@ -59,6 +59,9 @@ func (b *Bridge) verifyP2shSwapinTx(pairID, txHash, bindAddress string, allowUns
 	}
 
 	if !allowUnstable {
+		if err = tokens.CheckTieredConfirmations(b, swapInfo); err != nil {
+			return swapInfo, err
+		}
 		log.Debug("verify p2sh swapin pass", "pairID", swapInfo.PairID, "from", swapInfo.From, "to", swapInfo.To, "bind", swapInfo.Bind, "value", swapInfo.Value, "txid", swapInfo.Hash, "height", swapInfo.Height, "timestamp", swapInfo.Timestamp)
 	}
 	return swapInfo, nil
@@ -64,7 +64,7 @@ func (b *Bridge) VerifyMsgHash(rawTx interface{}, msgHash []string) (err error)
 				return err
 			}
 		}
-		sigHash, err := b.CalcSignatureHash(sigScript, authoredTx.Tx, i)
+		sigHash, err := b.CalcSignatureHash(sigScript, authoredTx.Tx, i, authoredTx.PrevInputValues)
 		if err != nil {
 			return err
 		}
@@ -95,7 +95,7 @@ func (b *Bridge) verifySwapinTx(pairID, txHash string, allowUnstable bool) (*tok
 	swapInfo := &tokens.TxSwapInfo{}
 	swapInfo.PairID = pairID // PairID
 	swapInfo.Hash = txHash   // Hash
-	if !allowUnstable && !b.checkStable(txHash) {
+	if !allowUnstable && !b.checkStable(pairID, txHash) {
 		return swapInfo, tokens.ErrTxNotStable
 	}
 	tx, err := b.GetTransactionByHash(txHash)
@@ -135,6 +135,9 @@ func (b *Bridge) verifySwapinTx(pairID, txHash string, allowUnstable bool) (*tok
 	}
 
 	if !allowUnstable {
+		if err = tokens.CheckTieredConfirmations(b, swapInfo); err != nil {
+			return swapInfo, err
+		}
 		log.Info("verify swapin pass", "pairID", swapInfo.PairID, "from", swapInfo.From, "to", swapInfo.To, "bind", swapInfo.Bind, "value", swapInfo.Value, "txid", swapInfo.Hash, "height", swapInfo.Height, "timestamp", swapInfo.Timestamp)
 	}
 	return swapInfo, nil
@@ -154,12 +157,12 @@ func (b *Bridge) checkSwapinInfo(swapInfo *tokens.TxSwapInfo) error {
 	return nil
 }
 
-func (b *Bridge) checkStable(txHash string) bool {
+func (b *Bridge) checkStable(pairID, txHash string) bool {
 	txStatus, err := b.GetTransactionStatus(txHash)
 	if err != nil {
 		return false
 	}
-	confirmations := *b.GetChainConfig().Confirmations
+	confirmations := tokens.GetMinRequiredConfirmations(pairID, b.IsSrc)
 	return txStatus.BlockHeight > 0 && txStatus.Confirmations >= confirmations
 }
 
@@ -30,6 +30,7 @@ type BtcExtraConfig struct {
 type GatewayConfig struct {
 	APIAddress    []string
 	APIAddressExt []string
+	Backend       string         `toml:",omitempty" json:",omitempty"` // utxo chains: electrs (default) or corerpc
 	Extras        *GatewayExtras `json:",omitempty"`
 }
 
@@ -66,12 +67,22 @@ type ChainConfig struct {
 	EnableCheckTxBlockHash  bool
 	EnableCheckTxBlockIndex bool
 
+	// stable by finality instead of confirmations if not empty,
+	// the finalized block tag is 'finalized' or 'safe' (eth-like)
+	StableFinality string `toml:",omitempty" json:",omitempty"`
+
 	// judge by the 'to' chain (eg. dst for swapin)
 	EnableReplaceSwap  bool
 	EnableDynamicFeeTx bool
 
 	CallByContractWhitelist []string `json:",omitempty"`
 
+	// generic evm chain (BlockChain = "EVM") is defined by these items
+	ChainID      string `toml:",omitempty" json:",omitempty"` // declared chain ID
+	SignerType   string `toml:",omitempty" json:",omitempty"` // EIP155 (default) or London
+	NativeSymbol string `toml:",omitempty" json:",omitempty"` // native coin symbol (18 decimals)
+	NoFeeHistory bool   `toml:",omitempty" json:",omitempty"` // gateway has no 'eth_feeHistory' api
+
 	MinReserveFee              string
 	BaseFeePercent             int64
 	BaseGasPrice               string `json:",omitempty"`
@@ -94,10 +105,61 @@ type ChainConfig struct {
 	minReserveFee *big.Int
 	maxGasTipCap  *big.Int
 	maxGasFeeCap  *big.Int
+	chainID       *big.Int
 
 	callByContractWhitelist map[string]struct{}
 }
 
+// signer types of eth-like chain
+const (
+	EIP155SignerType = "EIP155"
+	LondonSignerType = "London"
+)
+
+func (c *ChainConfig) checkEvmConfig() error {
+	if c.ChainID != "" {
+		chainID, err := common.GetBigIntFromStr(c.ChainID)
+		if err != nil || chainID.Sign() <= 0 {
+			return fmt.Errorf("wrong 'ChainID' value '%v'", c.ChainID)
+		}
+		c.chainID = chainID
+	}
+	switch {
+	case c.SignerType == "":
+	case strings.EqualFold(c.SignerType, EIP155SignerType):
+		if c.EnableDynamicFeeTx {
+			return errors.New("'EIP155' signer does not support dynamic fee tx")
+		}
+		c.SignerType = EIP155SignerType
+	case strings.EqualFold(c.SignerType, LondonSignerType):
+		c.SignerType = LondonSignerType
+	default:
+		return fmt.Errorf("wrong 'SignerType' value '%v'", c.SignerType)
+	}
+	return nil
+}
+
+// GetChainID get declared chain ID
+func (c *ChainConfig) GetChainID() *big.Int {
+	return c.chainID
+}
+
+// GetSignerType get signer type, London if dynamic fee tx is enabled
+func (c *ChainConfig) GetSignerType() string {
+	if c.SignerType != "" {
+		return c.SignerType
+	}
+	if c.EnableDynamicFeeTx {
+		return LondonSignerType
+	}
+	return EIP155SignerType
+}
+
+// IsFinalityEnabled is stable by finality
+func (c *ChainConfig) IsFinalityEnabled() bool {
+	return c.StableFinality != ""
+}
+
 // TokenConfig struct
 type TokenConfig struct {
 	ID                     string `json:",omitempty"`
@@ -110,19 +172,24 @@ type TokenConfig struct {
 	DcrmPubkey             string   `json:"-"`
 	ContractAddress        string   `json:",omitempty"`
 	ContractCodeHash       string   `json:",omitempty"`
+	Denom                  string   `json:",omitempty"` // bank denom (cosmos)
 	MaximumSwap            *float64 // whole unit (eg. BTC, ETH, FSN), not Satoshi
 	MinimumSwap            *float64 // whole unit
 	BigValueThreshold      *float64
 	SwapFeeRate            *float64
 	MaximumSwapFee         *float64
 	MinimumSwapFee         *float64
-	PlusGasPricePercentage uint64 `json:",omitempty"`
+	RefundFee              *float64 `json:",omitempty"` // whole unit, defaults to MinimumSwapFee
+	PlusGasPricePercentage uint64   `json:",omitempty"`
 	DisableSwap            bool
 	IsDelegateContract     bool
 	DelegateToken          string `json:",omitempty"`
 	IsAnyswapAdapter       bool   `json:",omitempty"` // PRQ
 	IsMappingTokenProxy    bool   `json:",omitempty"` // VTX
 
+	// confirmations tiers by swap value, in ascending order of 'MaxValue'
+	ConfirmationTiers []*ConfirmationTier `json:",omitempty"`
+
 	DefaultGasLimit          uint64 `json:",omitempty"`
 	AllowSwapinFromContract  bool   `json:",omitempty"`
 	AllowSwapoutFromContract bool   `json:",omitempty"`
@@ -133,14 +200,96 @@ type TokenConfig struct {
 	DcrmAddressKeyFile  string `json:"-"`
 	dcrmAddressPriKey   *ecdsa.PrivateKey
 
+	// signer of swap tx, defaults to keystore if private key is provided, otherwise dcrm
+	Signer *SignerConfig `toml:",omitempty" json:"-"`
+
 	// calced value
 	maxSwap          *big.Int
 	minSwap          *big.Int
 	maxSwapFee       *big.Int
 	minSwapFee       *big.Int
+	refundFee        *big.Int
 	bigValThreshhold *big.Int
 }
 
+// signer types
+const (
+	DcrmSignerType     = "dcrm"
+	KeystoreSignerType = "keystore"
+	Web3SignerType     = "web3signer"
+	Pkcs11SignerType   = "pkcs11"
+)
+
+// SignerConfig signer config of token
+type SignerConfig struct {
+	Type string
+
+	// web3signer: remote http signer (Web3Signer compatible api), it signs
+	// keccak256 hash of message, so it's only supported by eth like chains
+	URL     string `toml:",omitempty"`
+	Timeout int    `toml:",omitempty"` // seconds
+
+	// pkcs11: HSM (eg. SoftHSM) private key with label 'KeyLabel' in token 'TokenLabel'
+	Module     string `toml:",omitempty"` // path of pkcs11 module library
+	TokenLabel string `toml:",omitempty"`
+	KeyLabel   string `toml:",omitempty"`
+	PinFile    string `toml:",omitempty"`
+}
+
+// CheckConfig check signer config
+func (c *SignerConfig) CheckConfig() error {
+	switch c.Type {
+	case DcrmSignerType, KeystoreSignerType:
+	case Web3SignerType:
+		if c.URL == "" {
+			return errors.New("web3signer must config 'URL'")
+		}
+	case Pkcs11SignerType:
+		if c.Module == "" || c.TokenLabel == "" || c.KeyLabel == "" {
+			return errors.New("pkcs11 signer must config 'Module', 'TokenLabel' and 'KeyLabel'")
+		}
+		if c.PinFile == "" {
+			return errors.New("pkcs11 signer must config 'PinFile'")
+		}
+	default:
+		return fmt.Errorf("unknown signer type '%v'", c.Type)
+	}
+	return nil
+}
+
+// GetSignerType get signer type of token
+func (c *TokenConfig) GetSignerType() string {
+	if c.Signer != nil && c.Signer.Type != "" {
+		return c.Signer.Type
+	}
+	if c.dcrmAddressPriKey != nil {
+		return KeystoreSignerType
+	}
+	return DcrmSignerType
+}
+
+// VerifySignerWithoutPreimage verify signer of token on bridge which signs msg hash without preimage,
+// web3signer is not supported as it signs keccak256 hash of preimage (eth like chains only)
+func (c *TokenConfig) VerifySignerWithoutPreimage() error {
+	if c.GetSignerType() == Web3SignerType {
+		return fmt.Errorf("signer type '%v' is only supported by eth like chains", Web3SignerType)
+	}
+	return nil
+}
+
+// IsSignedByDcrm is swap tx signed by dcrm
+func (c *TokenConfig) IsSignedByDcrm() bool {
+	return c.GetSignerType() == DcrmSignerType
+}
+
+// ConfirmationTier confirmations required for swap value less than 'MaxValue'
+type ConfirmationTier struct {
+	MaxValue      *float64 `json:",omitempty"` // whole unit, no upper bound if not specified
+	Confirmations uint64
+
+	maxValue *big.Int
+}
+
 // CheckConfig check chain config
 func (c *ChainConfig) CheckConfig(isServer bool) error {
 	if c.BlockChain == "" {
@@ -155,6 +304,15 @@ func (c *ChainConfig) CheckConfig(isServer bool) error {
 	if c.InitialHeight == nil {
 		return errors.New("token must config 'InitialHeight'")
 	}
+	switch c.StableFinality {
+	case "", FinalizedBlockTag, SafeBlockTag:
+	default:
+		return fmt.Errorf("wrong 'StableFinality' value '%v'", c.StableFinality)
+	}
+	err := c.checkEvmConfig()
+	if err != nil {
+		return err
+	}
 	if c.BaseFeePercent < -90 || c.BaseFeePercent > 500 {
 		return errors.New("'BaseFeePercent' must be in range [-90, 500]")
 	}
@@ -281,6 +439,9 @@ func (c *TokenConfig) CheckConfig(isSrc bool) error {
 	if *c.SwapFeeRate == 0.0 && *c.MinimumSwapFee > 0.0 {
 		return errors.New("wrong token config, MinimumSwapFee should be 0 if SwapFeeRate is 0")
 	}
+	if c.RefundFee != nil && *c.RefundFee < 0 {
+		return errors.New("wrong token config, negative 'RefundFee'")
+	}
 	if c.PlusGasPricePercentage > MaxPlusGasPricePercentage {
 		return errors.New("too large 'PlusGasPricePercentage' value")
 	}
@@ -333,13 +494,26 @@ func (c *TokenConfig) CheckConfig(isSrc bool) error {
 	} else if c.DelegateToken != "" {
 		return errors.New("token forbid config 'DelegateToken' if 'IsDelegateContract' is false")
 	}
-	// calc value and store
-	c.CalcAndStoreValue()
-	err := c.LoadDcrmAddressPrivateKey()
+	err := c.checkConfirmationTiers()
 	if err != nil {
 		return err
 	}
-	err = c.VerifyDcrmPublicKey()
+	// calc value and store
+	c.CalcAndStoreValue()
+	if c.Signer != nil {
+		err = c.Signer.CheckConfig()
+		if err != nil {
+			return err
+		}
+	}
+	if GetSignKeyType(isSrc) == KeyTypeED25519 {
+		err = c.VerifyED25519PublicKey()
+	} else {
+		err = c.LoadDcrmAddressPrivateKey()
+		if err == nil {
+			err = c.VerifyDcrmPublicKey()
+		}
+	}
 	if err != nil {
 		return err
 	}
@@ -352,6 +526,55 @@ func (c *TokenConfig) CheckConfig(isSrc bool) error {
 	return nil
 }
 
+func (c *TokenConfig) checkConfirmationTiers() error {
+	count := len(c.ConfirmationTiers)
+	for i, tier := range c.ConfirmationTiers {
+		if tier == nil {
+			return errors.New("empty confirmation tier")
+		}
+		if i == count-1 {
+			if tier.MaxValue != nil {
+				return errors.New("the last confirmation tier should not config 'MaxValue'")
+			}
+			break
+		}
+		if tier.MaxValue == nil || *tier.MaxValue <= 0 {
+			return errors.New("confirmation tier must config 'MaxValue' (positive) except the last one")
+		}
+		next := c.ConfirmationTiers[i+1]
+		if next != nil && next.MaxValue != nil && *next.MaxValue <= *tier.MaxValue {
+			return errors.New("confirmation tiers must be in ascending order of 'MaxValue'")
+		}
+		if next != nil && next.Confirmations < tier.Confirmations {
+			return errors.New("confirmation tiers must not decrease 'Confirmations'")
+		}
+	}
+	return nil
+}
+
+// GetRequiredConfirmations get required confirmations of swap value,
+// returns false if confirmation tiers are not configed
+func (c *TokenConfig) GetRequiredConfirmations(value *big.Int) (uint64, bool) {
+	if len(c.ConfirmationTiers) == 0 {
+		return 0, false
+	}
+	for _, tier := range c.ConfirmationTiers {
+		if tier.maxValue == nil || value.Cmp(tier.maxValue) < 0 {
+			return tier.Confirmations, true
+		}
+	}
+	return c.ConfirmationTiers[len(c.ConfirmationTiers)-1].Confirmations, true
+}
+
+// GetMinRequiredConfirmations get the minimum confirmations of all tiers,
+// returns false if confirmation tiers are not configed
+func (c *TokenConfig) GetMinRequiredConfirmations() (uint64, bool) {
+	if len(c.ConfirmationTiers) == 0 {
+		return 0, false
+	}
+	return c.ConfirmationTiers[0].Confirmations, true
+}
+
 // IsInCallByContractWhitelist is in call by contract whitelist
 func (c *ChainConfig) IsInCallByContractWhitelist(caller string) bool {
 	if c.callByContractWhitelist == nil {
@@ -409,7 +632,17 @@ func (c *TokenConfig) CalcAndStoreValue() {
 	c.minSwap = ToBits(*c.MinimumSwap-smallBiasValue, *c.Decimals)
 	c.maxSwapFee = ToBits(*c.MaximumSwapFee, *c.Decimals)
 	c.minSwapFee = ToBits(*c.MinimumSwapFee, *c.Decimals)
+	if c.RefundFee != nil {
+		c.refundFee = ToBits(*c.RefundFee, *c.Decimals)
+	} else {
+		c.refundFee = c.minSwapFee
+	}
 	c.bigValThreshhold = ToBits(*c.BigValueThreshold+smallBiasValue, *c.Decimals)
+	for _, tier := range c.ConfirmationTiers {
+		if tier.MaxValue != nil {
+			tier.maxValue = ToBits(*tier.MaxValue, *c.Decimals)
+		}
+	}
 }
 
 // GetDcrmAddressPrivateKey get private key
@@ -441,13 +674,35 @@ func (c *TokenConfig) LoadDcrmAddressPrivateKey() error {
 		if c.DcrmPubkey == "" {
 			return fmt.Errorf("token must config 'DcrmPubkey'")
 		}
-		if IsDcrmDisabled {
-			return fmt.Errorf("dcrm is disabled but no private key is provided")
+		switch c.GetSignerType() {
+		case KeystoreSignerType:
+			return fmt.Errorf("keystore signer but no private key is provided")
+		case DcrmSignerType:
+			if IsDcrmDisabled {
+				return fmt.Errorf("dcrm is disabled but no private key is provided")
+			}
 		}
 	}
 	return nil
 }
 
+// VerifyED25519PublicKey verify ed25519 public key (signed by dcrm only)
+func (c *TokenConfig) VerifyED25519PublicKey() error {
+	if c.DcrmAddressKeyFile != "" || c.DcrmAddressKeyStore != "" {
+		return fmt.Errorf("token with ED25519 key type does not support private key")
+	}
+	if IsDcrmDisabled || !c.IsSignedByDcrm() {
+		return fmt.Errorf("token with ED25519 key type must be signed by dcrm")
+	}
+	if c.DcrmPubkey == "" {
+		return fmt.Errorf("token must config 'DcrmPubkey'")
+	}
+	if len(common.FromHex(c.DcrmPubkey)) != ED25519PublicKeyLength {
+		return fmt.Errorf("wrong dcrm public key, should be %v bytes ed25519 public key", ED25519PublicKeyLength)
+	}
+	return nil
+}
+
 // VerifyDcrmPublicKey verify public key
 func (c *TokenConfig) VerifyDcrmPublicKey() error {
 	if !common.IsHexAddress(c.DcrmAddress) {
@@ -0,0 +1,85 @@
+package cosmos
+
+import (
+	"errors"
+
+	"github.com/anyswap/CrossChain-Bridge/tools/crypto"
+	"github.com/btcsuite/btcutil"
+	"github.com/btcsuite/btcutil/bech32"
+)
+
+const addressLength = 20
+
+var (
+	errInvalidAddress  = errors.New("invalid bech32 address")
+	errWrongAddrPrefix = errors.New("bech32 address prefix mismatch")
+)
+
+// IsValidAddress check address
+func (b *Bridge) IsValidAddress(address string) bool {
+	_, err := b.DecodeAddress(address)
+	return err == nil
+}
+
+// DecodeAddress decode bech32 address of current network
+func (b *Bridge) DecodeAddress(address string) ([]byte, error) {
+	prefix, addr, err := DecodeBech32Address(address)
+	if err != nil {
+		return nil, err
+	}
+	if prefix != b.NetParams.Bech32Prefix {
+		return nil, errWrongAddrPrefix
+	}
+	return addr, nil
+}
+
+// EncodeAddress encode address bytes to bech32 address of current network
+func (b *Bridge) EncodeAddress(addr []byte) string {
+	address, _ := bech32.EncodeFromBase256(b.NetParams.Bech32Prefix, addr)
+	return address
+}
+
+// PublicKeyToAddress convert ecdsa public key (compressed or not) to bech32 address
+func (b *Bridge) PublicKeyToAddress(pubkey []byte) (string, error) {
+	compressed, err := CompressPublicKey(pubkey)
+	if err != nil {
+		return "", err
+	}
+	return b.EncodeAddress(PublicKeyHash(compressed)), nil
+}
+
+// DecodeBech32Address decode canonical (lower case) bech32 address
+func DecodeBech32Address(address string) (prefix string, addr []byte, err error) {
+	prefix, addr, err = bech32.DecodeToBase256(address)
+	if err != nil || len(addr) != addressLength {
+		return "", nil, errInvalidAddress
+	}
+	if encoded, _ := bech32.EncodeFromBase256(prefix, addr); encoded != address {
+		return "", nil, errInvalidAddress
+	}
+	return prefix, addr, nil
+}
+
+// PublicKeyHash address of secp256k1 public key is ripemd160(sha256(compressedPubkey))
+func PublicKeyHash(compressed []byte) []byte {
+	return btcutil.Hash160(compressed)
+}
+
+// CompressPublicKey compress ecdsa public key
+func CompressPublicKey(pubkey []byte) ([]byte, error) {
+	switch len(pubkey) {
+	case 33:
+		if _, err := crypto.DecompressPubkey(pubkey); err != nil {
+			return nil, err
+		}
+		return pubkey, nil
+	case 65:
+		pubKey, err := crypto.UnmarshalPubkey(pubkey)
+		if err != nil {
+			return nil, err
+		}
+		return crypto.CompressPubkey(pubKey), nil
+	default:
+		return nil, errors.New("wrong length of ecdsa public key")
+	}
+}
@@ -0,0 +1,144 @@
+// Package cosmos implements the bridge interfaces for the bank tokens
+// of Cosmos-SDK based blockchains (eg. ATOM of cosmos hub).
+//
+// Swapin is a bank `MsgSend` to the deposit address with the bind address in
+// the tx memo. Swapout is paid by DCRM signed `MsgSend` in SIGN_MODE_DIRECT,
+// the account sequence is managed like the nonce of eth-like chains.
+// The chain is accessed through the LCD (gRPC-gateway) REST API, and
+// transactions are stable once included in blocks (Tendermint instant finality).
+package cosmos
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+var (
+	// ensure Bridge impl tokens.CrossChainBridge and tokens.NonceSetter
+	_ tokens.CrossChainBridge = &Bridge{}
+	_ tokens.NonceSetter      = &Bridge{}
+)
+
+// Bridge cosmos bridge
+type Bridge struct {
+	*tokens.CrossChainBridgeBase
+	*NonceSetterBase
+	NetParams *NetParams
+}
+
+// NewCrossChainBridge new cosmos bridge
+func NewCrossChainBridge(isSrc bool) *Bridge {
+	if !isSrc {
+		log.Fatalf("cosmos::NewCrossChainBridge error %v", tokens.ErrBridgeDestinationNotSupported)
+	}
+	return &Bridge{
+		CrossChainBridgeBase: tokens.NewCrossChainBridgeBase(isSrc),
+		NonceSetterBase:      NewNonceSetterBase(),
+	}
+}
+
+// SetChainAndGateway set chain and gateway config
+func (b *Bridge) SetChainAndGateway(chainCfg *tokens.ChainConfig, gatewayCfg *tokens.GatewayConfig) {
+	b.CrossChainBridgeBase.SetChainAndGateway(chainCfg, gatewayCfg)
+	switch chainCfg.StableFinality {
+	case "":
+		// tendermint has instant finality, txs in blocks are finalized
+		chainCfg.StableFinality = tokens.FinalizedBlockTag
+	case tokens.SafeBlockTag:
+		log.Fatalf("cosmos does not support '%v' stable finality", chainCfg.StableFinality)
+	}
+	b.VerifyChainConfig()
+	b.InitLatestBlockNumber()
+}
+
+// VerifyChainConfig verify chain config
+func (b *Bridge) VerifyChainConfig() {
+	networkID := strings.ToLower(b.ChainConfig.NetID)
+	netParams, exist := networks[networkID]
+	if !exist {
+		log.Fatalf("unsupported cosmos network: %v", b.ChainConfig.NetID)
+	}
+	b.NetParams = netParams
+
+	var (
+		block *Block
+		err   error
+	)
+	for {
+		block, err = b.GetLatestBlock()
+		if err == nil {
+			break
+		}
+		log.Errorf("can not get gateway chain id. %v", err)
+		log.Println("retry query gateway", b.GatewayConfig.APIAddress)
+		time.Sleep(3 * time.Second)
+	}
+
+	chainID := block.Block.Header.ChainID
+	if chainID != netParams.ChainID {
+		log.Fatalf("gateway chain id '%v' is not '%v'", chainID, netParams.ChainID)
+	}
+
+	log.Info("VerifyChainConfig succeed", "networkID", networkID, "chainID", chainID, "bech32Prefix", netParams.Bech32Prefix)
+}
+
+// VerifyTokenConfig verify token config
+func (b *Bridge) VerifyTokenConfig(tokenCfg *tokens.TokenConfig) error {
+	if err := tokenCfg.VerifySignerWithoutPreimage(); err != nil {
+		return err
+	}
+	if !b.IsValidAddress(tokenCfg.DcrmAddress) {
+		return fmt.Errorf("invalid dcrm address: %v", tokenCfg.DcrmAddress)
+	}
+	if !b.IsValidAddress(tokenCfg.DepositAddress) {
+		return fmt.Errorf("invalid deposit address: %v", tokenCfg.DepositAddress)
+	}
+	if tokenCfg.ContractAddress != "" {
+		return fmt.Errorf("cosmos token should not config contract address")
+	}
+	if tokenCfg.Denom == "" {
+		return fmt.Errorf("cosmos token must config 'Denom'")
+	}
+	if tokenCfg.Denom == b.NetParams.FeeDenom && *tokenCfg.Decimals != b.NetParams.Decimals {
+		return fmt.Errorf("invalid decimals for %v: want %v but have %v", tokenCfg.Denom, b.NetParams.Decimals, *tokenCfg.Decimals)
+	}
+	return b.verifyDcrmPublicKey(tokenCfg)
+}
+
+func (b *Bridge) verifyDcrmPublicKey(tokenCfg *tokens.TokenConfig) error {
+	if tokenCfg.DcrmPubkey == "" {
+		return fmt.Errorf("cosmos token must config 'DcrmPubkey'")
+	}
+	pubAddr, err := b.PublicKeyToAddress(common.FromHex(tokenCfg.DcrmPubkey))
+	if err != nil {
+		return fmt.Errorf("wrong dcrm public key, %w", err)
+	}
+	if pubAddr != tokenCfg.DcrmAddress {
+		return fmt.Errorf("dcrm address %v and public key address %v is not match", tokenCfg.DcrmAddress, pubAddr)
+	}
+	return nil
+}
+
+// InitLatestBlockNumber init latest block number
+func (b *Bridge) InitLatestBlockNumber() {
+	chainCfg := b.ChainConfig
+	gatewayCfg := b.GatewayConfig
+	var latest uint64
+	var err error
+	for {
+		latest, err = b.GetLatestBlockNumber()
+		if err == nil {
+			tokens.SetLatestBlockHeight(latest, b.IsSrc)
+			log.Info("get latst block number succeed.", "number", latest, "BlockChain", chainCfg.BlockChain, "NetID", chainCfg.NetID)
+			break
+		}
+		log.Error("get latst block number failed.", "BlockChain", chainCfg.BlockChain, "NetID", chainCfg.NetID, "err", err)
+		log.Println("retry query gateway", gatewayCfg.APIAddress)
+		time.Sleep(3 * time.Second)
+	}
+}
@@ -0,0 +1,151 @@
+package cosmos
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+var (
+	defaultGasLimit = uint64(200000)
+	maxGasLimit     = uint64(2000000)
+
+	// max fee is a multiple of the fee calculated by the minimum gas price
+	maxFeeMultiple = int64(10)
+
+	retryRPCCount    = 3
+	retryRPCInterval = 1 * time.Second
+)
+
+// BuildRawTransaction build raw tx
+func (b *Bridge) BuildRawTransaction(args *tokens.BuildTxArgs) (rawTx interface{}, err error) {
+	pairID := args.PairID
+	token := b.GetTokenConfig(pairID)
+	if token == nil {
+		return nil, fmt.Errorf("swap pair '%v' is not configed", pairID)
+	}
+
+	var (
+		to     string
+		amount *big.Int
+		memo   string
+	)
+	switch args.SwapType {
+	case tokens.SwapinType:
+		return nil, tokens.ErrSwapTypeNotSupported
+	case tokens.SwapoutType:
+		to = args.Bind                                                    // to
+		amount = tokens.CalcSwappedValue(pairID, args.OriginValue, false) // amount
+		memo = tokens.UnlockMemoPrefix + args.SwapID
+	default:
+		return nil, tokens.ErrUnknownSwapType
+	}
+	if amount.Sign() <= 0 {
+		return nil, tokens.ErrWrongSwapValue
+	}
+
+	args.From = token.DcrmAddress // from
+	if !b.IsValidAddress(to) {
+		return nil, fmt.Errorf("wrong receiver %v", to)
+	}
+	pubkey, err := CompressPublicKey(common.FromHex(token.DcrmPubkey))
+	if err != nil {
+		return nil, fmt.Errorf("wrong dcrm public key, %w", err)
+	}
+
+	extra, err := b.setDefaults(args, token.DefaultGasLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	rawTx = &UnsignedTx{
+		Msg: &MsgSend{
+			FromAddress: args.From,
+			ToAddress:   to,
+			Amount:      []*Coin{{Denom: token.Denom, Amount: amount.String()}},
+		},
+		Memo:          memo,
+		PubKey:        pubkey,
+		AccountNumber: *extra.AccountNumber,
+		Sequence:      *extra.Sequence,
+		ChainID:       b.NetParams.ChainID,
+		Fee:           []*Coin{{Denom: b.NetParams.FeeDenom, Amount: *extra.Fee}},
+		GasLimit:      *extra.Gas,
+	}
+	log.Info("build cosmos raw tx", "pairID", pairID, "swapID", args.SwapID, "from", args.From, "to", to, "amount", amount, "denom", token.Denom, "sequence", *extra.Sequence, "gas", *extra.Gas, "fee", *extra.Fee)
+	return rawTx, nil
+}
+
+// setDefaults set account number, sequence, gas and fee if not specified
+func (b *Bridge) setDefaults(args *tokens.BuildTxArgs, gasLimit uint64) (extra *tokens.CosmosExtraArgs, err error) {
+	if args.Extra == nil || args.Extra.CosmosExtra == nil {
+		extra = &tokens.CosmosExtraArgs{}
+		args.Extra = &tokens.AllExtras{CosmosExtra: extra}
+	} else {
+		extra = args.Extra.CosmosExtra
+	}
+	if extra.AccountNumber == nil || extra.Sequence == nil {
+		accountNumber, sequence, errf := b.getAccountNumberAndSequence(args.PairID, args.From, args.SwapType)
+		if errf != nil {
+			return nil, errf
+		}
+		if extra.AccountNumber == nil {
+			extra.AccountNumber = &accountNumber
+		}
+		if extra.Sequence == nil {
+			extra.Sequence = &sequence
+		}
+	}
+	if extra.Gas == nil {
+		if gasLimit == 0 {
+			gasLimit = defaultGasLimit
+		}
+		extra.Gas = &gasLimit
+	}
+	if *extra.Gas == 0 || *extra.Gas > maxGasLimit {
+		return nil, fmt.Errorf("%w: wrong gas limit %v", tokens.ErrWrongExtraArgs, *extra.Gas)
+	}
+	minFee := b.calcFee(*extra.Gas)
+	if extra.Fee == nil {
+		fee := minFee.String()
+		extra.Fee = &fee
+	}
+	fee, err := parseAmount(*extra.Fee)
+	if err != nil || fee.Cmp(minFee) < 0 || fee.Cmp(new(big.Int).Mul(minFee, big.NewInt(maxFeeMultiple))) > 0 {
+		return nil, fmt.Errorf("%w: wrong fee %v", tokens.ErrWrongExtraArgs, *extra.Fee)
+	}
+	return extra, nil
+}
+
+// calcFee fee = ceil(gas * gasPrice)
+func (b *Bridge) calcFee(gas uint64) *big.Int {
+	gasPrice := b.NetParams.GasPrice
+	fee := new(big.Int).Mul(new(big.Int).SetUint64(gas), gasPrice.Num())
+	denom := gasPrice.Denom()
+	fee.Add(fee, new(big.Int).Sub(denom, big.NewInt(1)))
+	return fee.Div(fee, denom)
+}
+
+func (b *Bridge) getAccountNumberAndSequence(pairID, from string, swapType tokens.SwapType) (accountNumber, sequence uint64, err error) {
+	for i := 0; i < retryRPCCount; i++ {
+		accountNumber, sequence, err = b.GetAccountNumberAndSequence(from)
+		if err == nil {
+			break
+		}
+		time.Sleep(retryRPCInterval)
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+	if swapType != tokens.NoSwapType {
+		tokenCfg := b.GetTokenConfig(pairID)
+		if tokenCfg != nil && from == tokenCfg.DcrmAddress {
+			sequence = b.AdjustNonce(pairID, sequence)
+		}
+	}
+	return accountNumber, sequence, nil
+}
@@ -0,0 +1,266 @@
+package cosmos
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/rpc/client"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+const broadcastModeSync = "BROADCAST_MODE_SYNC"
+
+var errNotFound = errors.New("not found")
+
+func wrapRPCQueryError(err error, method string, params ...interface{}) error {
+	if err == nil {
+		err = errNotFound
+	}
+	return fmt.Errorf("%w: call '%s %v' failed, err='%v'", tokens.ErrRPCQueryError, method, params, err)
+}
+
+// Block result of /cosmos/base/tendermint/v1beta1/blocks
+type Block struct {
+	Block struct {
+		Header struct {
+			ChainID string    `json:"chain_id"`
+			Height  string    `json:"height"`
+			Time    time.Time `json:"time"`
+		} `json:"header"`
+	} `json:"block"`
+}
+
+// TxResponse tx response
+type TxResponse struct {
+	Height    string    `json:"height"`
+	TxHash    string    `json:"txhash"`
+	Code      uint32    `json:"code"`
+	RawLog    string    `json:"raw_log"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// TxMessage tx message, only MsgSend fields are decoded
+type TxMessage struct {
+	Type string `json:"@type"`
+	MsgSend
+}
+
+// GetTxResult result of /cosmos/tx/v1beta1/txs/{hash}
+type GetTxResult struct {
+	Tx struct {
+		Body struct {
+			Messages []*TxMessage `json:"messages"`
+			Memo     string       `json:"memo"`
+		} `json:"body"`
+	} `json:"tx"`
+	TxResponse *TxResponse `json:"tx_response"`
+}
+
+// BaseAccount base account
+type BaseAccount struct {
+	Type          string `json:"@type"`
+	Address       string `json:"address"`
+	AccountNumber string `json:"account_number"`
+	Sequence      string `json:"sequence"`
+}
+
+// GetHeight get block height
+func (b *Block) GetHeight() uint64 {
+	height, _ := strconv.ParseUint(b.Block.Header.Height, 10, 64)
+	return height
+}
+
+// GetHeight get tx height
+func (r *TxResponse) GetHeight() uint64 {
+	height, _ := strconv.ParseUint(r.Height, 10, 64)
+	return height
+}
+
+func (b *Bridge) callAPI(result interface{}, path string) (err error) {
+	gateway := b.GatewayConfig
+	for _, urls := range [][]string{gateway.APIAddress, gateway.APIAddressExt} {
+		for _, apiAddress := range urls {
+			err = client.RPCGet(result, apiAddress+path)
+			if err == nil {
+				return nil
+			}
+		}
+	}
+	return wrapRPCQueryError(err, path)
+}
+
+// GetLatestBlock get latest block
+func (b *Bridge) GetLatestBlock() (*Block, error) {
+	var block Block
+	err := b.callAPI(&block, "/cosmos/base/tendermint/v1beta1/blocks/latest")
+	if err != nil {
+		return nil, err
+	}
+	return &block, nil
+}
+
+// GetLatestBlockNumber get latest block number
+func (b *Bridge) GetLatestBlockNumber() (maxHeight uint64, err error) {
+	var height uint64
+	for _, apiAddress := range b.GatewayConfig.APIAddress {
+		height, err = b.GetLatestBlockNumberOf(apiAddress)
+		if err == nil && height > maxHeight {
+			maxHeight = height
+		}
+	}
+	if maxHeight > 0 {
+		tokens.CmpAndSetLatestBlockHeight(maxHeight, b.IsSrc)
+		return maxHeight, nil
+	}
+	return 0, err
+}
+
+// GetLatestBlockNumberOf get latest block number of specified url
+func (b *Bridge) GetLatestBlockNumberOf(apiAddress string) (uint64, error) {
+	var block Block
+	path := "/cosmos/base/tendermint/v1beta1/blocks/latest"
+	err := client.RPCGet(&block, apiAddress+path)
+	if err != nil {
+		return 0, wrapRPCQueryError(err, path)
+	}
+	return block.GetHeight(), nil
+}
+
+// GetFinalizedBlockNumber latest block is finalized (instant finality)
+func (b *Bridge) GetFinalizedBlockNumber() (uint64, error) {
+	latest, err := b.GetLatestBlockNumber()
+	if err == nil {
+		tokens.CmpAndSetFinalizedHeight(latest, b.IsSrc)
+	}
+	return latest, err
+}
+
+// GetTxByHash get tx by hash
+func (b *Bridge) GetTxByHash(txHash string) (*GetTxResult, error) {
+	var result GetTxResult
+	err := b.callAPI(&result, "/cosmos/tx/v1beta1/txs/"+txHash)
+	if err != nil {
+		return nil, err
+	}
+	if result.TxResponse == nil || result.TxResponse.TxHash != txHash {
+		return nil, tokens.ErrTxNotFound
+	}
+	return &result, nil
+}
+
+// GetBaseAccount get base account (with account number and sequence)
+func (b *Bridge) GetBaseAccount(address string) (*BaseAccount, error) {
+	var result struct {
+		Account *BaseAccount `json:"account"`
+	}
+	err := b.callAPI(&result, "/cosmos/auth/v1beta1/accounts/"+address)
+	if err != nil {
+		return nil, err
+	}
+	if result.Account == nil || result.Account.Address != address {
+		return nil, wrapRPCQueryError(nil, "/cosmos/auth/v1beta1/accounts", address)
+	}
+	return result.Account, nil
+}
+
+// GetAccountNumberAndSequence get account number and sequence
+func (b *Bridge) GetAccountNumberAndSequence(address string) (accountNumber, sequence uint64, err error) {
+	account, err := b.GetBaseAccount(address)
+	if err != nil {
+		return 0, 0, err
+	}
+	accountNumber, err = strconv.ParseUint(account.AccountNumber, 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	sequence, err = strconv.ParseUint(account.Sequence, 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	return accountNumber, sequence, nil
+}
+
+// GetPoolNonce get account sequence of committed state (height is ignored)
+func (b *Bridge) GetPoolNonce(address, height string) (uint64, error) {
+	_, sequence, err := b.GetAccountNumberAndSequence(address)
+	return sequence, err
+}
+
+// GetTxBlockInfo impl
+func (b *Bridge) GetTxBlockInfo(txHash string) (blockHeight, blockTime uint64) {
+	result, err := b.GetTxByHash(txHash)
+	if err != nil {
+		return 0, 0
+	}
+	return result.TxResponse.GetHeight(), uint64(result.TxResponse.Timestamp.Unix())
+}
+
+// GetBalance get balance of fee denom
+func (b *Bridge) GetBalance(account string) (*big.Int, error) {
+	return b.GetTokenBalance("", b.NetParams.FeeDenom, account)
+}
+
+// GetTokenBalance get balance of denom
+func (b *Bridge) GetTokenBalance(tokenType, denom, accountAddress string) (*big.Int, error) {
+	var result struct {
+		Balance *Coin `json:"balance"`
+	}
+	err := b.callAPI(&result, "/cosmos/bank/v1beta1/balances/"+accountAddress+"/by_denom?denom="+url.QueryEscape(denom))
+	if err != nil {
+		return nil, err
+	}
+	if result.Balance == nil {
+		return big.NewInt(0), nil
+	}
+	return parseAmount(result.Balance.Amount)
+}
+
+// GetTokenSupply get total supply of denom
+func (b *Bridge) GetTokenSupply(tokenType, denom string) (*big.Int, error) {
+	var result struct {
+		Amount *Coin `json:"amount"`
+	}
+	err := b.callAPI(&result, "/cosmos/bank/v1beta1/supply/by_denom?denom="+url.QueryEscape(denom))
+	if err != nil {
+		return nil, err
+	}
+	if result.Amount == nil {
+		return big.NewInt(0), nil
+	}
+	return parseAmount(result.Amount.Amount)
+}
+
+// BroadcastTx broadcast signed tx to specified url
+func BroadcastTx(apiAddress string, txBytes []byte) (*TxResponse, error) {
+	var result struct {
+		TxResponse *TxResponse `json:"tx_response"`
+	}
+	path := "/cosmos/tx/v1beta1/txs"
+	err := client.RPCPostJSON(&result, apiAddress+path, map[string]interface{}{
+		"tx_bytes": base64.StdEncoding.EncodeToString(txBytes),
+		"mode":     broadcastModeSync,
+	})
+	if err != nil {
+		return nil, wrapRPCQueryError(err, path)
+	}
+	if result.TxResponse == nil {
+		return nil, wrapRPCQueryError(nil, path)
+	}
+	if result.TxResponse.Code != 0 {
+		return nil, wrapRPCQueryError(fmt.Errorf("code %v, %v", result.TxResponse.Code, result.TxResponse.RawLog), path)
+	}
+	return result.TxResponse, nil
+}
+
+func parseAmount(amount string) (*big.Int, error) {
+	value, ok := new(big.Int).SetString(amount, 10)
+	if !ok || value.Sign() < 0 {
+		return nil, fmt.Errorf("wrong amount '%v'", amount)
+	}
+	return value, nil
+}
@@ -0,0 +1,148 @@
+package cosmos
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tokens/tokenstest"
+	"github.com/anyswap/CrossChain-Bridge/tools/crypto"
+)
+
+const (
+	testTxHash = "0123456789ABCDEF0123456789ABCDEF0123456789ABCDEF0123456789ABCDEF"
+	testBind   = "0x1111111111111111111111111111111111111111"
+)
+
+var (
+	testBridge  = newTestBridge("")
+	testDeposit = testBridge.EncodeAddress(common.FromHex("0x9999999999999999999999999999999999999999"))
+	testSender  = testBridge.EncodeAddress(common.FromHex("0x2222222222222222222222222222222222222222"))
+)
+
+func newTestBridge(url string) *Bridge {
+	initialHeight := uint64(0)
+	b := NewCrossChainBridge(true)
+	b.NetParams = networks[netCosmosHub]
+	b.ChainConfig = &tokens.ChainConfig{
+		BlockChain:     "COSMOS",
+		InitialHeight:  &initialHeight,
+		StableFinality: tokens.FinalizedBlockTag,
+	}
+	b.GatewayConfig = &tokens.GatewayConfig{APIAddress: []string{url}}
+	return b
+}
+
+func TestAddress(t *testing.T) {
+	privKey, _ := crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	address, err := testBridge.PublicKeyToAddress(crypto.FromECDSAPub(&privKey.PublicKey))
+	if err != nil {
+		t.Fatalf("public key to address failed: %v", err)
+	}
+	if address != "cosmos1ekg395uj3zqrv8fh6z8wz76u9n6rcmwdg5v5dv" || !testBridge.IsValidAddress(address) {
+		t.Fatalf("wrong address %v", address)
+	}
+	for _, invalid := range []string{
+		strings.ToUpper(address),
+		address[:len(address)-1] + "q",
+		"osmo" + address[len("cosmos"):],
+	} {
+		if testBridge.IsValidAddress(invalid) {
+			t.Errorf("address %v should be invalid", invalid)
+		}
+	}
+}
+
+func TestSignTransaction(t *testing.T) {
+	privKey, _ := crypto.GenerateKey()
+	tx := &UnsignedTx{
+		Msg: &MsgSend{
+			FromAddress: testDeposit,
+			ToAddress:   testSender,
+			Amount:      []*Coin{{Denom: "uatom", Amount: "1000000"}},
+		},
+		Memo:          tokens.UnlockMemoPrefix + testTxHash,
+		PubKey:        crypto.CompressPubkey(&privKey.PublicKey),
+		AccountNumber: 12,
+		Sequence:      3,
+		ChainID:       "cosmoshub-4",
+		Fee:           []*Coin{{Denom: "uatom", Amount: "5000"}},
+		GasLimit:      defaultGasLimit,
+	}
+	signTx, txHash, err := testBridge.SignTransactionWithPrivateKey(tx, privKey)
+	if err != nil {
+		t.Fatalf("sign tx failed: %v", err)
+	}
+	signedTx := signTx.(*SignedTx)
+	if len(signedTx.Signature) != 64 || !IsCanonicalTxHash(txHash) {
+		t.Fatalf("wrong signed tx, signature %x hash %v", signedTx.Signature, txHash)
+	}
+	if new(big.Int).SetBytes(signedTx.Signature[32:]).Cmp(secp256k1HalfN) > 0 {
+		t.Fatalf("signature is not in low S form")
+	}
+	if !crypto.VerifySignature(tx.PubKey, tx.SigningHash(), signedTx.Signature) {
+		t.Fatalf("verify signature failed")
+	}
+
+	otherKey, _ := crypto.GenerateKey()
+	signature, _ := crypto.Sign(tx.SigningHash(), otherKey)
+	if _, err := signTxWithSignature(tx, signature); err == nil {
+		t.Fatalf("sign tx with wrong key should fail")
+	}
+}
+
+func newStubSwapin(to, denom, memo string, code uint32) tokenstest.StubAPI {
+	block := &Block{}
+	block.Block.Header.ChainID = "cosmoshub-4"
+	block.Block.Header.Height = "101"
+	txRes := &GetTxResult{
+		TxResponse: &TxResponse{
+			Height:    "100",
+			TxHash:    testTxHash,
+			Code:      code,
+			Timestamp: time.Unix(1600000000, 0),
+		},
+	}
+	txRes.Tx.Body.Memo = memo
+	txRes.Tx.Body.Messages = []*TxMessage{{
+		Type: MsgSendTypeURL,
+		MsgSend: MsgSend{
+			FromAddress: testSender,
+			ToAddress:   to,
+			Amount:      []*Coin{{Denom: denom, Amount: "100000000"}},
+		},
+	}}
+	return tokenstest.StubAPI{
+		"/cosmos/base/tendermint/v1beta1/blocks/latest": block,
+		"/cosmos/tx/v1beta1/txs/" + testTxHash:          txRes,
+	}
+}
+
+func TestVerifySwapin(t *testing.T) {
+	tokenstest.SetSwapinTokenPair(&tokens.TokenConfig{
+		Denom:          "uatom",
+		DepositAddress: testDeposit,
+		DcrmAddress:    testDeposit,
+	})
+	memo := tokens.LockMemoPrefix + testBind
+	checkSwapInfo := func(swapInfo *tokens.TxSwapInfo) bool {
+		return swapInfo.Bind == testBind && swapInfo.From == testSender &&
+			swapInfo.Value.String() == "100000000" && swapInfo.Height == 100
+	}
+
+	tokenstest.RunVerifySwapinTests(t, func(url string) tokens.CrossChainBridge { return newTestBridge(url) }, []*tokenstest.VerifySwapinTest{
+		{API: newStubSwapin(testDeposit, "uatom", memo, 0), TxHash: testTxHash, Check: checkSwapInfo},
+		{API: newStubSwapin(testSender, "uatom", memo, 0), TxHash: testTxHash, WantErr: tokens.ErrTxWithWrongReceiver},
+		{API: newStubSwapin(testDeposit, "uosmo", memo, 0), TxHash: testTxHash, WantErr: tokens.ErrTxWithWrongReceiver},
+		{API: newStubSwapin(testDeposit, "uatom", testBind, 0), TxHash: testTxHash, WantErr: tokens.ErrTxWithWrongMemo},
+		{API: newStubSwapin(testDeposit, "uatom", memo, 5), TxHash: testTxHash, WantErr: tokens.ErrTxWithWrongReceipt},
+	})
+
+	if _, err := testBridge.VerifyTransaction(tokenstest.TestPairID, strings.ToLower(testTxHash), false); !errors.Is(err, tokens.ErrTxNotFound) {
+		t.Errorf("verify non canonical tx hash should fail, err=%v", err)
+	}
+}
@@ -0,0 +1,57 @@
+package cosmos
+
+import (
+	"math/big"
+)
+
+const (
+	netCosmosHub      = "cosmoshub"
+	netThetaTestnet   = "theta-testnet"
+	netOsmosis        = "osmosis"
+	netOsmosisTestnet = "osmo-test"
+)
+
+// NetParams cosmos network params
+type NetParams struct {
+	Name         string
+	ChainID      string
+	Bech32Prefix string
+	FeeDenom     string
+	Decimals     uint8
+	GasPrice     *big.Rat // minimum gas price in fee denom
+}
+
+var networks = map[string]*NetParams{
+	netCosmosHub: {
+		Name:         netCosmosHub,
+		ChainID:      "cosmoshub-4",
+		Bech32Prefix: "cosmos",
+		FeeDenom:     "uatom",
+		Decimals:     6,
+		GasPrice:     big.NewRat(25, 1000),
+	},
+	netThetaTestnet: {
+		Name:         netThetaTestnet,
+		ChainID:      "theta-testnet-001",
+		Bech32Prefix: "cosmos",
+		FeeDenom:     "uatom",
+		Decimals:     6,
+		GasPrice:     big.NewRat(25, 1000),
+	},
+	netOsmosis: {
+		Name:         netOsmosis,
+		ChainID:      "osmosis-1",
+		Bech32Prefix: "osmo",
+		FeeDenom:     "uosmo",
+		Decimals:     6,
+		GasPrice:     big.NewRat(25, 1000),
+	},
+	netOsmosisTestnet: {
+		Name:         netOsmosisTestnet,
+		ChainID:      "osmo-test-5",
+		Bech32Prefix: "osmo",
+		FeeDenom:     "uosmo",
+		Decimals:     6,
+		GasPrice:     big.NewRat(25, 1000),
+	},
+}
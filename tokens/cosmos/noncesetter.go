@@ -0,0 +1,45 @@
+package cosmos
+
+import (
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/mongodb"
+)
+
+// NonceSetterBase base nonce setter, the nonce is the account sequence
+type NonceSetterBase struct {
+	SwapoutNonce map[string]uint64
+}
+
+// NewNonceSetterBase new base nonce setter
+func NewNonceSetterBase() *NonceSetterBase {
+	return &NonceSetterBase{
+		SwapoutNonce: make(map[string]uint64),
+	}
+}
+
+// SetNonce set nonce directly always increase
+func (b *Bridge) SetNonce(pairID string, value uint64) {
+	tokenCfg := b.GetTokenConfig(pairID)
+	account := tokenCfg.DcrmAddress
+	if b.SwapoutNonce[account] < value {
+		b.SwapoutNonce[account] = value
+		_ = mongodb.UpdateLatestSwapoutNonce(account, value)
+	}
+}
+
+// AdjustNonce adjust account sequence
+func (b *Bridge) AdjustNonce(pairID string, value uint64) (nonce uint64) {
+	tokenCfg := b.GetTokenConfig(pairID)
+	account := tokenCfg.DcrmAddress
+	nonce = value
+	if b.SwapoutNonce[account] > value {
+		nonce = b.SwapoutNonce[account]
+	}
+	return nonce
+}
+
+// InitNonces init nonces
+func (b *Bridge) InitNonces(nonces map[string]uint64) {
+	b.SwapoutNonce = nonces
+	log.Info("init swap nonces finished", "isSwapin", !b.IsSrcEndpoint(), "nonces", nonces)
+}
@@ -0,0 +1,167 @@
+package cosmos
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// type urls of protobuf Any
+const (
+	MsgSendTypeURL         = "/cosmos.bank.v1beta1.MsgSend"
+	Secp256k1PubKeyTypeURL = "/cosmos.crypto.secp256k1.PubKey"
+
+	signModeDirect = 1
+)
+
+// Coin bank coin
+type Coin struct {
+	Denom  string `json:"denom"`
+	Amount string `json:"amount"`
+}
+
+// MsgSend bank MsgSend
+type MsgSend struct {
+	FromAddress string  `json:"from_address"`
+	ToAddress   string  `json:"to_address"`
+	Amount      []*Coin `json:"amount"`
+}
+
+// UnsignedTx unsigned tx with one MsgSend in SIGN_MODE_DIRECT
+type UnsignedTx struct {
+	Msg           *MsgSend
+	Memo          string
+	PubKey        []byte // compressed secp256k1 public key
+	AccountNumber uint64
+	Sequence      uint64
+	ChainID       string
+	Fee           []*Coin
+	GasLimit      uint64
+}
+
+// SignedTx signed tx (TxRaw)
+type SignedTx struct {
+	BodyBytes     []byte
+	AuthInfoBytes []byte
+	Signature     []byte
+	Sequence      uint64
+	Hash          string
+}
+
+// BodyBytes encode TxBody
+func (tx *UnsignedTx) BodyBytes() []byte {
+	var msg []byte
+	msg = appendString(msg, 1, tx.Msg.FromAddress)
+	msg = appendString(msg, 2, tx.Msg.ToAddress)
+	for _, coin := range tx.Msg.Amount {
+		msg = appendBytes(msg, 3, encodeCoin(coin))
+	}
+
+	var b []byte
+	b = appendBytes(b, 1, encodeAny(MsgSendTypeURL, msg))
+	b = appendString(b, 2, tx.Memo)
+	return b
+}
+
+// AuthInfoBytes encode AuthInfo
+func (tx *UnsignedTx) AuthInfoBytes() []byte {
+	var pubkey []byte
+	pubkey = appendBytes(pubkey, 1, tx.PubKey)
+
+	var single []byte
+	single = appendVarint(single, 1, signModeDirect)
+	var modeInfo []byte
+	modeInfo = appendBytes(modeInfo, 1, single)
+
+	var signerInfo []byte
+	signerInfo = appendBytes(signerInfo, 1, encodeAny(Secp256k1PubKeyTypeURL, pubkey))
+	signerInfo = appendBytes(signerInfo, 2, modeInfo)
+	signerInfo = appendVarint(signerInfo, 3, tx.Sequence)
+
+	var fee []byte
+	for _, coin := range tx.Fee {
+		fee = appendBytes(fee, 1, encodeCoin(coin))
+	}
+	fee = appendVarint(fee, 2, tx.GasLimit)
+
+	var b []byte
+	b = appendBytes(b, 1, signerInfo)
+	b = appendBytes(b, 2, fee)
+	return b
+}
+
+// SignBytes encode SignDoc
+func (tx *UnsignedTx) SignBytes() []byte {
+	var b []byte
+	b = appendBytes(b, 1, tx.BodyBytes())
+	b = appendBytes(b, 2, tx.AuthInfoBytes())
+	b = appendString(b, 3, tx.ChainID)
+	b = appendVarint(b, 4, tx.AccountNumber)
+	return b
+}
+
+// SigningHash sha256 hash of SignDoc
+func (tx *UnsignedTx) SigningHash() []byte {
+	hash := sha256.Sum256(tx.SignBytes())
+	return hash[:]
+}
+
+// WithSignature make signed tx with 64 bytes signature (r || s)
+func (tx *UnsignedTx) WithSignature(signature []byte) *SignedTx {
+	signedTx := &SignedTx{
+		BodyBytes:     tx.BodyBytes(),
+		AuthInfoBytes: tx.AuthInfoBytes(),
+		Signature:     signature,
+		Sequence:      tx.Sequence,
+	}
+	hash := sha256.Sum256(signedTx.Marshal())
+	signedTx.Hash = strings.ToUpper(hex.EncodeToString(hash[:]))
+	return signedTx
+}
+
+// Marshal encode TxRaw
+func (tx *SignedTx) Marshal() []byte {
+	var b []byte
+	b = appendBytes(b, 1, tx.BodyBytes)
+	b = appendBytes(b, 2, tx.AuthInfoBytes)
+	b = appendBytes(b, 3, tx.Signature)
+	return b
+}
+
+func encodeCoin(coin *Coin) []byte {
+	var b []byte
+	b = appendString(b, 1, coin.Denom)
+	b = appendString(b, 2, coin.Amount)
+	return b
+}
+
+func encodeAny(typeURL string, value []byte) []byte {
+	var b []byte
+	b = appendString(b, 1, typeURL)
+	b = appendBytes(b, 2, value)
+	return b
+}
+
+// appendVarint append varint field, zero value is omitted as proto3
+func appendVarint(b []byte, num protowire.Number, v uint64) []byte {
+	if v == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, v)
+}
+
+// appendBytes append bytes field, empty value is omitted as proto3
+func appendBytes(b []byte, num protowire.Number, v []byte) []byte {
+	if len(v) == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, v)
+}
+
+func appendString(b []byte, num protowire.Number, v string) []byte {
+	return appendBytes(b, num, []byte(v))
+}
@@ -0,0 +1,50 @@
+package cosmos
+
+import (
+	"errors"
+
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/params"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+var errEmptyURLs = errors.New("empty URLs")
+
+// SendTransaction send signed tx
+func (b *Bridge) SendTransaction(signedTx interface{}) (txHash string, err error) {
+	tx, ok := signedTx.(*SignedTx)
+	if !ok {
+		return "", tokens.ErrWrongRawTx
+	}
+	txBytes := tx.Marshal()
+	gateway := b.GatewayConfig
+	_ = broadcastTransaction(txBytes, gateway.APIAddressExt)
+	err = broadcastTransaction(txBytes, gateway.APIAddress)
+	if err != nil {
+		return "", err
+	}
+	log.Info("Bridge send tx", "hash", tx.Hash, "sequence", tx.Sequence)
+	return tx.Hash, nil
+}
+
+func broadcastTransaction(txBytes []byte, urls []string) (err error) {
+	if len(urls) == 0 {
+		return errEmptyURLs
+	}
+	logFunc := log.GetPrintFuncOr(params.IsDebugMode, log.Info, log.Trace)
+	success := false
+	for _, url := range urls {
+		txRes, errf := BroadcastTx(url, txBytes)
+		if errf != nil {
+			logFunc("call broadcast tx failed", "url", url, "err", errf)
+			err = errf
+			continue
+		}
+		logFunc("call broadcast tx success", "txHash", txRes.TxHash, "url", url)
+		success = true
+	}
+	if success {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,147 @@
+package cosmos
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/dcrm"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/signer"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tools/crypto"
+)
+
+var (
+	secp256k1N     = crypto.S256().Params().N
+	secp256k1HalfN = new(big.Int).Rsh(secp256k1N, 1)
+)
+
+func (b *Bridge) verifyTransactionWithArgs(rawTx interface{}, args *tokens.BuildTxArgs) (*UnsignedTx, error) {
+	tx, ok := rawTx.(*UnsignedTx)
+	if !ok || tx.Msg == nil {
+		return nil, tokens.ErrWrongRawTx
+	}
+	tokenCfg := b.GetTokenConfig(args.PairID)
+	if tokenCfg == nil {
+		return nil, fmt.Errorf("[sign] verify tx with unknown pairID '%v'", args.PairID)
+	}
+	if tx.Msg.FromAddress != tokenCfg.DcrmAddress {
+		return nil, fmt.Errorf("[sign] verify tx sender failed")
+	}
+	return tx, nil
+}
+
+// DcrmSignTransaction dcrm sign raw tx
+func (b *Bridge) DcrmSignTransaction(rawTx interface{}, args *tokens.BuildTxArgs) (signTx interface{}, txHash string, err error) {
+	tx, err := b.verifyTransactionWithArgs(rawTx, args)
+	if err != nil {
+		return nil, "", err
+	}
+	msgHash := common.ToHex(tx.SigningHash())
+	jsondata, _ := json.Marshal(args)
+	msgContext := string(jsondata)
+
+	log.Info(b.ChainConfig.BlockChain+" DcrmSignTransaction start", "msghash", msgHash, "txid", args.SwapID)
+	rsv, err := signer.SignOne(args.PairID, b.IsSrc, b.GetDcrmPublicKey(args.PairID), msgHash, msgContext)
+	if err != nil {
+		return nil, "", err
+	}
+	log.Info(b.ChainConfig.BlockChain+" DcrmSignTransaction finished", "msghash", msgHash, "txid", args.SwapID)
+
+	log.Trace(b.ChainConfig.BlockChain+" DcrmSignTransaction get rsv success", "txid", args.SwapID, "rsv", rsv)
+	signature := common.FromHex(rsv)
+	if len(signature) != crypto.SignatureLength {
+		log.Error("DcrmSignTransaction wrong length of signature")
+		return nil, "", errors.New("wrong signature of txid " + args.SwapID)
+	}
+
+	signedTx, err := signTxWithSignature(tx, signature)
+	if err != nil {
+		return nil, "", err
+	}
+	log.Info(b.ChainConfig.BlockChain+" DcrmSignTransaction success", "txid", args.SwapID, "txhash", signedTx.Hash, "sequence", signedTx.Sequence)
+	return signedTx, signedTx.Hash, nil
+}
+
+// signTxWithSignature verify signer of signature and build signed tx
+// with 64 bytes signature in low S form
+func signTxWithSignature(tx *UnsignedTx, signature []byte) (*SignedTx, error) {
+	sigHash := tx.SigningHash()
+	sig := make([]byte, crypto.SignatureLength)
+	copy(sig, signature)
+	vPos := crypto.SignatureLength - 1
+	if sig[vPos] >= 27 {
+		sig[vPos] -= 27
+	}
+	pubkey, err := crypto.Ecrecover(sigHash, sig)
+	if err != nil {
+		return nil, err
+	}
+	compressed, err := CompressPublicKey(pubkey)
+	if err != nil || !bytes.Equal(compressed, tx.PubKey) {
+		return nil, errors.New("wrong signer public key")
+	}
+	s := new(big.Int).SetBytes(sig[32:64])
+	if s.Cmp(secp256k1HalfN) > 0 {
+		s.Sub(secp256k1N, s)
+		copy(sig[32:64], common.LeftPadBytes(s.Bytes(), 32))
+	}
+	return tx.WithSignature(sig[:64]), nil
+}
+
+// SignTransaction sign tx with pairID
+func (b *Bridge) SignTransaction(rawTx interface{}, pairID string) (signTx interface{}, txHash string, err error) {
+	privKey := b.GetTokenConfig(pairID).GetDcrmAddressPrivateKey()
+	return b.SignTransactionWithPrivateKey(rawTx, privKey)
+}
+
+// SignTransactionWithPrivateKey sign tx with ECDSA private key
+func (b *Bridge) SignTransactionWithPrivateKey(rawTx interface{}, privKey *ecdsa.PrivateKey) (signTx interface{}, txHash string, err error) {
+	tx, ok := rawTx.(*UnsignedTx)
+	if !ok {
+		return nil, "", tokens.ErrWrongRawTx
+	}
+	if privKey == nil {
+		return nil, "", errors.New("empty private key")
+	}
+	signature, err := crypto.Sign(tx.SigningHash(), privKey)
+	if err != nil {
+		return nil, "", fmt.Errorf("sign tx failed, %w", err)
+	}
+	signedTx, err := signTxWithSignature(tx, signature)
+	if err != nil {
+		return nil, "", err
+	}
+	log.Info(b.ChainConfig.BlockChain+" SignTransaction success", "txhash", signedTx.Hash, "sequence", signedTx.Sequence)
+	return signedTx, signedTx.Hash, nil
+}
+
+// GetSignedTxHashOfKeyID get signed tx hash by keyID (called by oracle)
+func (b *Bridge) GetSignedTxHashOfKeyID(keyID, pairID string, rawTx interface{}) (txHash string, err error) {
+	tx, ok := rawTx.(*UnsignedTx)
+	if !ok {
+		return "", errors.New("wrong raw tx of keyID " + keyID)
+	}
+	rsvs, err := dcrm.GetSignStatusByKeyID(keyID)
+	if err != nil {
+		return "", err
+	}
+	if len(rsvs) != 1 {
+		return "", errors.New("wrong number of rsvs of keyID " + keyID)
+	}
+
+	signature := common.FromHex(rsvs[0])
+	if len(signature) != crypto.SignatureLength {
+		return "", errors.New("wrong signature of keyID " + keyID)
+	}
+	signedTx, err := signTxWithSignature(tx, signature)
+	if err != nil {
+		return "", err
+	}
+	return signedTx.Hash, nil
+}
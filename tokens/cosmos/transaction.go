@@ -0,0 +1,59 @@
+package cosmos
+
+import (
+	"encoding/hex"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+// Receipt tx result, implements tokens.TxResultReceipt
+type Receipt struct {
+	TxHash string
+	Code   uint32
+}
+
+// IsTxFailed returns if tx is failed
+func (r *Receipt) IsTxFailed() bool {
+	return r.Code != 0
+}
+
+// IsCanonicalTxHash tx hash should be 64 upper case hex chars without 0x prefix
+func IsCanonicalTxHash(txHash string) bool {
+	if len(txHash) != 64 || strings.ToUpper(txHash) != txHash {
+		return false
+	}
+	_, err := hex.DecodeString(txHash)
+	return err == nil
+}
+
+// GetTransaction get tx by hash
+func (b *Bridge) GetTransaction(txHash string) (interface{}, error) {
+	if !IsCanonicalTxHash(txHash) {
+		return nil, tokens.ErrTxNotFound
+	}
+	return b.GetTxByHash(txHash)
+}
+
+// GetTransactionStatus impl, tx in block is finalized
+func (b *Bridge) GetTransactionStatus(txHash string) (*tokens.TxStatus, error) {
+	if !IsCanonicalTxHash(txHash) {
+		return nil, tokens.ErrTxNotFound
+	}
+	result, err := b.GetTxByHash(txHash)
+	if err != nil {
+		return nil, err
+	}
+	txRes := result.TxResponse
+	txStatus := &tokens.TxStatus{
+		Receipt:     &Receipt{TxHash: txHash, Code: txRes.Code},
+		BlockHeight: txRes.GetHeight(),
+		BlockTime:   uint64(txRes.Timestamp.Unix()),
+		Finalized:   txRes.GetHeight() > 0,
+	}
+	latest, err := b.GetLatestBlockNumber()
+	if err == nil && latest > txStatus.BlockHeight {
+		txStatus.Confirmations = latest - txStatus.BlockHeight
+	}
+	return txStatus, nil
+}
@@ -0,0 +1,152 @@
+package cosmos
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/params"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+// VerifyMsgHash verify msg hash
+func (b *Bridge) VerifyMsgHash(rawTx interface{}, msgHashes []string) error {
+	tx, ok := rawTx.(*UnsignedTx)
+	if !ok {
+		return tokens.ErrWrongRawTx
+	}
+	if len(msgHashes) != 1 {
+		return tokens.ErrWrongCountOfMsgHashes
+	}
+	sigHash := common.ToHex(tx.SigningHash())
+	if !strings.EqualFold(sigHash, msgHashes[0]) {
+		logFunc := log.GetPrintFuncOr(params.IsDebugMode, log.Info, log.Trace)
+		logFunc("message hash mismatch", "want", msgHashes[0], "have", sigHash)
+		return tokens.ErrMsgHashMismatch
+	}
+	return nil
+}
+
+// VerifyTransaction impl
+func (b *Bridge) VerifyTransaction(pairID, txHash string, allowUnstable bool) (*tokens.TxSwapInfo, error) {
+	if !b.IsSrc {
+		return nil, tokens.ErrBridgeDestinationNotSupported
+	}
+	return b.verifySwapinTx(pairID, txHash, allowUnstable)
+}
+
+func (b *Bridge) verifySwapinTx(pairID, txHash string, allowUnstable bool) (*tokens.TxSwapInfo, error) {
+	token := b.GetTokenConfig(pairID)
+	if token == nil {
+		return nil, tokens.ErrUnknownPairID
+	}
+	if token.DisableSwap {
+		return nil, tokens.ErrSwapIsClosed
+	}
+	swapInfo := &tokens.TxSwapInfo{}
+	swapInfo.PairID = pairID // PairID
+	swapInfo.Hash = txHash   // Hash
+
+	if !IsCanonicalTxHash(txHash) {
+		log.Debug("[verifySwapin] swapin tx hash should be 64 upper case hex chars", "tx", txHash)
+		return swapInfo, tokens.ErrTxNotFound
+	}
+	tx, err := b.GetTxByHash(txHash)
+	if err != nil {
+		log.Debug("[verifySwapin] "+b.ChainConfig.BlockChain+" Bridge::GetTransaction fail", "tx", txHash, "err", err)
+		return swapInfo, tokens.ErrTxNotFound
+	}
+	txRes := tx.TxResponse
+	swapInfo.Height = txRes.GetHeight()                 // Height
+	swapInfo.Timestamp = uint64(txRes.Timestamp.Unix()) // Timestamp
+	if swapInfo.Height == 0 {
+		return swapInfo, tokens.ErrTxNotFound
+	}
+	if swapInfo.Height < *b.ChainConfig.InitialHeight {
+		return swapInfo, tokens.ErrTxBeforeInitialHeight
+	}
+	if txRes.Code != 0 {
+		return swapInfo, tokens.ErrTxWithWrongReceipt
+	}
+
+	from, value, err := getReceivedValue(tx.Tx.Body.Messages, token.DepositAddress, token.Denom)
+	if err != nil {
+		return swapInfo, err
+	}
+	bindAddress, bindOk := GetBindAddressFromMemo(tx.Tx.Body.Memo)
+
+	swapInfo.TxTo = token.DepositAddress // TxTo
+	swapInfo.To = token.DepositAddress   // To
+	swapInfo.From = from                 // From
+	swapInfo.Bind = bindAddress          // Bind
+	swapInfo.Value = value               // Value
+
+	err = b.checkSwapinInfo(swapInfo)
+	if err != nil {
+		return swapInfo, err
+	}
+	if !bindOk {
+		log.Debug("wrong memo", "tx", txHash, "memo", tx.Tx.Body.Memo)
+		return swapInfo, tokens.ErrTxWithWrongMemo
+	}
+
+	if !allowUnstable {
+		log.Info("verify swapin pass", "pairID", swapInfo.PairID, "from", swapInfo.From, "to", swapInfo.To, "bind", swapInfo.Bind, "value", swapInfo.Value, "txid", swapInfo.Hash, "height", swapInfo.Height, "timestamp", swapInfo.Timestamp)
+	}
+	return swapInfo, nil
+}
+
+// getReceivedValue sum value of `MsgSend` of denom to receiver,
+// all these messages should be sent from the same sender
+func getReceivedValue(msgs []*TxMessage, receiver, denom string) (from string, value *big.Int, err error) {
+	value = big.NewInt(0)
+	for _, msg := range msgs {
+		if msg.Type != MsgSendTypeURL || msg.ToAddress != receiver {
+			continue
+		}
+		for _, coin := range msg.Amount {
+			if coin.Denom != denom {
+				continue
+			}
+			amount, errf := parseAmount(coin.Amount)
+			if errf != nil {
+				return "", nil, tokens.ErrTxWithWrongValue
+			}
+			if from != "" && from != msg.FromAddress {
+				return "", nil, tokens.ErrTxWithWrongSender
+			}
+			from = msg.FromAddress
+			value.Add(value, amount)
+		}
+	}
+	if value.Sign() == 0 {
+		return "", nil, tokens.ErrTxWithWrongReceiver
+	}
+	return from, value, nil
+}
+
+// GetBindAddressFromMemo get bind address from memo
+func GetBindAddressFromMemo(memo string) (bind string, ok bool) {
+	if len(memo) <= len(tokens.LockMemoPrefix) {
+		return "", false
+	}
+	if !strings.HasPrefix(memo, tokens.LockMemoPrefix) {
+		return "", false
+	}
+	return memo[len(tokens.LockMemoPrefix):], true
+}
+
+func (b *Bridge) checkSwapinInfo(swapInfo *tokens.TxSwapInfo) error {
+	if swapInfo.From == swapInfo.To {
+		return tokens.ErrTxWithWrongSender
+	}
+	if !tokens.CheckSwapValue(swapInfo.PairID, swapInfo.Value, b.IsSrc) {
+		return tokens.ErrTxWithWrongValue
+	}
+	if !tokens.DstBridge.IsValidAddress(swapInfo.Bind) {
+		log.Debug("wrong bind address in swapin", "bind", swapInfo.Bind)
+		return tokens.ErrTxWithWrongMemo
+	}
+	return nil
+}
@@ -18,6 +18,8 @@ var (
 	_ tokens.CrossChainBridge = &Bridge{}
 	// ensure Bridge impl tokens.NonceSetter
 	_ tokens.NonceSetter = &Bridge{}
+	// ensure Bridge impl tokens.Refunder
+	_ tokens.Refunder = &Bridge{}
 	// ensure Bridge impl InheritInterface
 	_ InheritInterface = &Bridge{}
 )
@@ -25,6 +27,7 @@ var (
 // InheritInterface inherit interface
 type InheritInterface interface {
 	GetLatestBlockNumberOf(apiAddress string) (uint64, error)
+	GetFinalizedBlockNumberOf(apiAddress string) (uint64, error)
 }
 
 // Bridge eth bridge
@@ -107,10 +110,7 @@ func (b *Bridge) VerifyChainID() {
 
 // MakeSigner make signer
 func (b *Bridge) MakeSigner(chainID *big.Int) types.Signer {
-	if b.ChainConfig.EnableDynamicFeeTx {
-		return types.MakeSigner("London", chainID)
-	}
-	return types.MakeSigner("EIP155", chainID)
+	return types.MakeSigner(b.ChainConfig.GetSignerType(), chainID)
 }
 
 // VerifyTokenConfig verify token config
@@ -142,7 +142,7 @@ func (b *Bridge) verifyDecimals(tokenCfg *tokens.TokenConfig) error {
 		checkToken = tokenCfg.DelegateToken
 	}
 	switch strings.ToUpper(tokenCfg.Symbol) {
-	case "ETH", "FSN":
+	case "ETH", "FSN", strings.ToUpper(b.ChainConfig.NativeSymbol):
 		if configedDecimals != 18 {
 			return fmt.Errorf("invalid decimals: want 18 but have %v", configedDecimals)
 		}
@@ -0,0 +1,45 @@
+package eth
+
+import (
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+// IsRefundSupported impl tokens.Refunder
+func (b *Bridge) IsRefundSupported() bool {
+	return b.IsSrc
+}
+
+// buildRefundTxInput build tx to return deposit (minus refund fee) to its sender
+func (b *Bridge) buildRefundTxInput(args *tokens.BuildTxArgs) (err error) {
+	token := b.GetTokenConfig(args.PairID)
+	if token == nil {
+		return tokens.ErrUnknownPairID
+	}
+
+	receiver := common.HexToAddress(args.RefundTo)
+	if receiver == (common.Address{}) || !common.IsHexAddress(args.RefundTo) {
+		log.Warn("refund to wrong address", "receiver", args.RefundTo)
+		return errInvalidReceiverAddress
+	}
+
+	refundValue := tokens.CalcRefundValue(args.PairID, args.OriginValue, b.IsSrc)
+	if refundValue.Sign() <= 0 {
+		return tokens.ErrRefundValueTooSmall
+	}
+	args.SwapValue = refundValue // refund value
+
+	if token.ContractAddress == "" {
+		args.To = args.RefundTo  // to
+		args.Value = refundValue // value
+		return nil
+	}
+
+	funcHash := erc20CodeParts["transfer"]
+	input := PackDataWithFuncHash(funcHash, receiver, refundValue)
+	args.Input = &input             // input
+	args.To = token.ContractAddress // to
+
+	return b.checkBalance(token.ContractAddress, token.DcrmAddress, refundValue)
+}
@@ -55,6 +55,8 @@ func (b *Bridge) BuildRawTransaction(args *tokens.BuildTxArgs) (rawTx interface{
 		err = b.buildSwapinTxInput(args)
 	case tokens.SwapoutType:
 		err = b.buildSwapoutTxInput(args)
+	case tokens.RefundType:
+		err = b.buildRefundTxInput(args)
 	default:
 		return nil, tokens.ErrUnknownSwapType
 	}
@@ -85,7 +87,7 @@ func (b *Bridge) checkBuildTxArgs(args *tokens.BuildTxArgs) error {
 		if b.IsSrc {
 			return tokens.ErrBuildSwapTxInWrongEndpoint
 		}
-	case tokens.SwapoutType:
+	case tokens.SwapoutType, tokens.RefundType:
 		if !b.IsSrc {
 			return tokens.ErrBuildSwapTxInWrongEndpoint
 		}
@@ -80,6 +80,44 @@ func getMaxLatestBlockNumber(urls []string) (maxHeight uint64, err error) {
 	return 0, wrapRPCQueryError(err, "eth_blockNumber")
 }
 
+// GetFinalizedBlockNumber get finalized block number, use the minimum
+// of all gateways to be conservative
+func (b *Bridge) GetFinalizedBlockNumber() (finalized uint64, err error) {
+	gateway := b.GatewayConfig
+	if len(gateway.APIAddress) == 0 {
+		return 0, errEmptyURLs
+	}
+	var height uint64
+	for _, url := range gateway.APIAddress {
+		height, err = b.Inherit.GetFinalizedBlockNumberOf(url)
+		if err != nil {
+			continue
+		}
+		if finalized == 0 || height < finalized {
+			finalized = height
+		}
+	}
+	if finalized > 0 {
+		tokens.CmpAndSetFinalizedHeight(finalized, b.IsSrcEndpoint())
+		return finalized, nil
+	}
+	return 0, err
+}
+
+// GetFinalizedBlockNumberOf call eth_getBlockByNumber with finalized block tag
+func (b *Bridge) GetFinalizedBlockNumberOf(url string) (uint64, error) {
+	blockTag := b.ChainConfig.StableFinality
+	if blockTag == "" {
+		blockTag = tokens.FinalizedBlockTag
+	}
+	var result *types.RPCBlock
+	err := client.RPCPost(&result, url, "eth_getBlockByNumber", blockTag, false)
+	if err != nil || result == nil {
+		return 0, wrapRPCQueryError(err, "eth_getBlockByNumber", blockTag)
+	}
+	return result.Number.ToInt().Uint64(), nil
+}
+
 // GetBlockByHash call eth_getBlockByHash
 func (b *Bridge) GetBlockByHash(blockHash string) (*types.RPCBlock, error) {
 	gateway := b.GatewayConfig
@@ -608,7 +646,7 @@ func getFeeHistory(urls []string, blockCount int, rewardPercentiles []float64) (
 
 // GetBaseFee get base fee
 func (b *Bridge) GetBaseFee(blockCount int) (*big.Int, error) {
-	if blockCount == 0 { // from lastest block header
+	if blockCount == 0 || b.ChainConfig.NoFeeHistory { // from lastest block header
 		block, err := b.GetBlockByNumber(nil)
 		if err != nil {
 			return nil, err
@@ -10,6 +10,7 @@ import (
 	"github.com/anyswap/CrossChain-Bridge/common"
 	"github.com/anyswap/CrossChain-Bridge/dcrm"
 	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/signer"
 	"github.com/anyswap/CrossChain-Bridge/tokens"
 	"github.com/anyswap/CrossChain-Bridge/tools/crypto"
 	"github.com/anyswap/CrossChain-Bridge/types"
@@ -39,10 +40,109 @@ func (b *Bridge) verifyTransactionWithArgs(rawTx interface{}, args *tokens.Build
 
 // DcrmSignTransaction dcrm sign raw tx
 func (b *Bridge) DcrmSignTransaction(rawTx interface{}, args *tokens.BuildTxArgs) (signTx interface{}, txHash string, err error) {
-	tx, err := b.verifyTransactionWithArgs(rawTx, args)
+	tx, msgHash, msgContext, err := b.prepareDcrmSign(rawTx, args)
 	if err != nil {
 		return nil, "", err
 	}
+
+	log.Info(b.ChainConfig.BlockChain+" DcrmSignTransaction start", "msghash", msgHash, "txid", args.SwapID)
+	rsv, err := signer.SignOneWithPreimage(args.PairID, b.IsSrc, b.GetDcrmPublicKey(args.PairID), msgHash, msgContext, b.Signer.HashPreimage(tx))
+	if err != nil {
+		return nil, "", err
+	}
+	log.Info(b.ChainConfig.BlockChain+" DcrmSignTransaction finished", "msghash", msgHash, "txid", args.SwapID)
+
+	signedTx, txHash, err := b.signTxWithRsv(tx, rsv, args)
+	if err != nil {
+		return nil, "", err
+	}
+	log.Info(b.ChainConfig.BlockChain+" DcrmSignTransaction success", "txid", args.SwapID, "txhash", txHash, "nonce", signedTx.Nonce())
+	return signedTx, txHash, nil
+}
+
+// PrepareSignTransactions verify raw txs to be signed in one sign round and get their msg hashes and contexts
+func (b *Bridge) PrepareSignTransactions(rawTxs []interface{}, argsList []*tokens.BuildTxArgs) (msgHashes, msgContexts []string, err error) {
+	if len(rawTxs) == 0 || len(rawTxs) != len(argsList) {
+		return nil, nil, errors.New("wrong number of raw txs to batch sign")
+	}
+	pubkey := b.GetDcrmPublicKey(argsList[0].PairID)
+
+	msgHashes = make([]string, len(rawTxs))
+	msgContexts = make([]string, len(rawTxs))
+	var prevNonce uint64
+	for i, rawTx := range rawTxs {
+		args := argsList[i]
+		if !strings.EqualFold(b.GetDcrmPublicKey(args.PairID), pubkey) {
+			return nil, nil, fmt.Errorf("batch sign with different public key of pairID '%v'", args.PairID)
+		}
+		tx, msgHash, msgContext, errp := b.prepareDcrmSign(rawTx, args)
+		if errp != nil {
+			return nil, nil, errp
+		}
+		if i > 0 && tx.Nonce() != prevNonce+1 {
+			return nil, nil, fmt.Errorf("batch sign with non consecutive nonce %v after %v", tx.Nonce(), prevNonce)
+		}
+		prevNonce = tx.Nonce()
+		msgHashes[i] = msgHash
+		msgContexts[i] = msgContext
+	}
+	return msgHashes, msgContexts, nil
+}
+
+// MakeSignedTransactions make signed txs from raw txs and rsvs of the sign round
+func (b *Bridge) MakeSignedTransactions(rawTxs []interface{}, rsvs []string, argsList []*tokens.BuildTxArgs) (signedTxs []interface{}, txHashes []string, err error) {
+	if len(rsvs) != len(rawTxs) || len(argsList) != len(rawTxs) {
+		return nil, nil, fmt.Errorf("batch sign require %v rsvs but have %v", len(rawTxs), len(rsvs))
+	}
+	signedTxs = make([]interface{}, len(rawTxs))
+	txHashes = make([]string, len(rawTxs))
+	swapIDs := make([]string, len(rawTxs))
+	for i, rawTx := range rawTxs {
+		tx, ok := rawTx.(*types.Transaction)
+		if !ok {
+			return nil, nil, errors.New("wrong raw tx param")
+		}
+		signedTx, txHash, errs := b.signTxWithRsv(tx, rsvs[i], argsList[i])
+		if errs != nil {
+			return nil, nil, errs
+		}
+		signedTxs[i] = signedTx
+		txHashes[i] = txHash
+		swapIDs[i] = argsList[i].SwapID
+	}
+	log.Info(b.ChainConfig.BlockChain+" MakeSignedTransactions success", "txids", swapIDs, "txhashes", txHashes)
+	return signedTxs, txHashes, nil
+}
+
+// MarshalRawTransaction marshal raw tx to hex string
+func (b *Bridge) MarshalRawTransaction(rawTx interface{}) (string, error) {
+	tx, ok := rawTx.(*types.Transaction)
+	if !ok {
+		return "", errors.New("wrong raw tx param")
+	}
+	data, err := tx.MarshalBinary()
+	if err != nil {
+		return "", err
+	}
+	return common.ToHex(data), nil
+}
+
+// UnmarshalRawTransaction unmarshal raw tx from hex string
+func (b *Bridge) UnmarshalRawTransaction(data string) (interface{}, error) {
+	tx := new(types.Transaction)
+	err := tx.UnmarshalBinary(common.FromHex(data))
+	if err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// prepareDcrmSign verify raw tx and get its msg hash and msg context
+func (b *Bridge) prepareDcrmSign(rawTx interface{}, args *tokens.BuildTxArgs) (tx *types.Transaction, msgHash, msgContext string, err error) {
+	tx, err = b.verifyTransactionWithArgs(rawTx, args)
+	if err != nil {
+		return nil, "", "", err
+	}
 	if !b.ChainConfig.EnableDynamicFeeTx {
 		gasPrice, errt := b.getGasPrice(args)
 		if errt == nil && args.Extra.EthExtra.GasPrice.Cmp(gasPrice) < 0 {
@@ -51,32 +151,21 @@ func (b *Bridge) DcrmSignTransaction(rawTx interface{}, args *tokens.BuildTxArgs
 			tx.SetGasPrice(gasPrice)
 		}
 	}
-	signer := b.Signer
-	msgHash := signer.Hash(tx)
 	jsondata, _ := json.Marshal(args)
-	msgContext := string(jsondata)
-
-	log.Info(b.ChainConfig.BlockChain+" DcrmSignTransaction start", "msghash", msgHash.String(), "txid", args.SwapID)
-	keyID, rsvs, err := dcrm.DoSignOne(b.GetDcrmPublicKey(args.PairID), msgHash.String(), msgContext)
-	if err != nil {
-		return nil, "", err
-	}
-	log.Info(b.ChainConfig.BlockChain+" DcrmSignTransaction finished", "keyID", keyID, "msghash", msgHash.String(), "txid", args.SwapID)
-
-	if len(rsvs) != 1 {
-		return nil, "", fmt.Errorf("get sign status require one rsv but have %v (keyID = %v)", len(rsvs), keyID)
-	}
+	return tx, b.Signer.Hash(tx).String(), string(jsondata), nil
+}
 
-	rsv := rsvs[0]
-	log.Trace(b.ChainConfig.BlockChain+" DcrmSignTransaction get rsv success", "keyID", keyID, "txid", args.SwapID, "rsv", rsv)
+// signTxWithRsv make signed tx from raw tx and rsv
+func (b *Bridge) signTxWithRsv(tx *types.Transaction, rsv string, args *tokens.BuildTxArgs) (signedTx *types.Transaction, txHash string, err error) {
+	log.Trace(b.ChainConfig.BlockChain+" DcrmSignTransaction get rsv success", "txid", args.SwapID, "rsv", rsv)
 	signature := common.FromHex(rsv)
 	if len(signature) != crypto.SignatureLength {
 		log.Error("DcrmSignTransaction wrong length of signature")
-		return nil, "", errors.New("wrong signature of keyID " + keyID)
+		return nil, "", errors.New("wrong signature of txid " + args.SwapID)
 	}
 
 	token := b.GetTokenConfig(args.PairID)
-	signedTx, err := b.signTxWithSignature(tx, signature, common.HexToAddress(token.DcrmAddress))
+	signedTx, err = b.signTxWithSignature(tx, signature, common.HexToAddress(token.DcrmAddress))
 	if err != nil {
 		return nil, "", err
 	}
@@ -84,7 +173,6 @@ func (b *Bridge) DcrmSignTransaction(rawTx interface{}, args *tokens.BuildTxArgs
 	if err != nil {
 		return nil, "", fmt.Errorf("calc signed tx hash failed, %w", err)
 	}
-	log.Info(b.ChainConfig.BlockChain+" DcrmSignTransaction success", "keyID", keyID, "txid", args.SwapID, "txhash", txHash, "nonce", signedTx.Nonce())
 	return signedTx, txHash, nil
 }
 
@@ -149,6 +237,15 @@ func (b *Bridge) CalcTransactionHash(tx *types.Transaction) (txHash string, err
 
 // GetSignedTxHashOfKeyID get signed tx hash by keyID (called by oracle)
 func (b *Bridge) GetSignedTxHashOfKeyID(keyID, pairID string, rawTx interface{}) (txHash string, err error) {
+	return b.getSignedTxHashOfKeyID(keyID, pairID, rawTx, 0, 1)
+}
+
+// GetSignedTxHashOfKeyIDAt get signed tx hash by keyID and msg index in batch sign (called by oracle)
+func (b *Bridge) GetSignedTxHashOfKeyIDAt(keyID, pairID string, rawTx interface{}, msgIndex, msgCount int) (txHash string, err error) {
+	return b.getSignedTxHashOfKeyID(keyID, pairID, rawTx, msgIndex, msgCount)
+}
+
+func (b *Bridge) getSignedTxHashOfKeyID(keyID, pairID string, rawTx interface{}, msgIndex, msgCount int) (txHash string, err error) {
 	tx, ok := rawTx.(*types.Transaction)
 	if !ok {
 		return "", errors.New("wrong raw tx of keyID " + keyID)
@@ -157,11 +254,11 @@ func (b *Bridge) GetSignedTxHashOfKeyID(keyID, pairID string, rawTx interface{})
 	if err != nil {
 		return "", err
 	}
-	if len(rsvs) != 1 {
+	if len(rsvs) != msgCount || msgIndex < 0 || msgIndex >= msgCount {
 		return "", errors.New("wrong number of rsvs of keyID " + keyID)
 	}
 
-	rsv := rsvs[0]
+	rsv := rsvs[msgIndex]
 	signature := common.FromHex(rsv)
 	if len(signature) != crypto.SignatureLength {
 		return "", errors.New("wrong signature of keyID " + keyID)
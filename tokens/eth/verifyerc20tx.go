@@ -27,6 +27,9 @@ func (b *Bridge) verifyErc20SwapinTx(swapInfo *tokens.TxSwapInfo, allowUnstable
 	}
 
 	if !allowUnstable {
+		if err = tokens.CheckTieredConfirmations(b, swapInfo); err != nil {
+			return swapInfo, err
+		}
 		log.Info("verify erc20 swapin stable pass",
 			"identifier", params.GetIdentifier(), "pairID", swapInfo.PairID,
 			"from", swapInfo.From, "to", swapInfo.To, "bind", swapInfo.Bind,
@@ -26,6 +26,9 @@ func (b *Bridge) verifySwapoutTx(swapInfo *tokens.TxSwapInfo, allowUnstable bool
 	}
 
 	if !allowUnstable {
+		if err = tokens.CheckTieredConfirmations(b, swapInfo); err != nil {
+			return swapInfo, err
+		}
 		log.Info("verify swapout stable pass",
 			"identifier", params.GetIdentifier(), "pairID", swapInfo.PairID,
 			"from", swapInfo.From, "to", swapInfo.To, "bind", swapInfo.Bind,
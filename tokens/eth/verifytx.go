@@ -35,6 +35,10 @@ func (b *Bridge) GetTransactionStatus(txHash string) (*tokens.TxStatus, error) {
 			}
 			time.Sleep(1 * time.Second)
 		}
+		if b.ChainConfig.IsFinalityEnabled() {
+			finalized, errf := b.GetFinalizedBlockNumber()
+			txStatus.Finalized = errf == nil && finalized >= txStatus.BlockHeight
+		}
 	}
 	return txStatus, nil
 }
@@ -127,6 +131,9 @@ func (b *Bridge) verifyNativeSwapinTx(swapInfo *tokens.TxSwapInfo, allowUnstable
 	}
 
 	if !allowUnstable {
+		if err = tokens.CheckTieredConfirmations(b, swapInfo); err != nil {
+			return swapInfo, err
+		}
 		log.Info("verify native swapin stable pass",
 			"identifier", params.GetIdentifier(), "pairID", swapInfo.PairID,
 			"from", swapInfo.From, "to", swapInfo.To, "bind", swapInfo.Bind,
@@ -168,7 +175,7 @@ func (b *Bridge) getStableReceipt(swapInfo *tokens.TxSwapInfo) (*types.RPCTxRece
 			"blockHeight", txStatus.BlockHeight)
 		return nil, tokens.ErrTxBeforeInitialHeight
 	}
-	if txStatus.Confirmations < *b.GetChainConfig().Confirmations {
+	if !txStatus.IsStable(b.GetChainConfig(), tokens.GetMinRequiredConfirmations(swapInfo.PairID, b.IsSrc)) {
 		return nil, tokens.ErrTxNotStable
 	}
 	receipt, ok := txStatus.Receipt.(*types.RPCTxReceipt)
@@ -0,0 +1,63 @@
+// Package evm implements the bridge interfaces for generic evm blockchain,
+// which is defined by chain config alone (chain ID, signer type etc.)
+package evm
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tokens/eth"
+)
+
+// Bridge generic evm bridge inherit from eth bridge
+type Bridge struct {
+	*eth.Bridge
+}
+
+// NewCrossChainBridge new evm bridge
+func NewCrossChainBridge(isSrc bool) *Bridge {
+	bridge := &Bridge{Bridge: eth.NewCrossChainBridge(isSrc)}
+	bridge.Inherit = bridge
+	return bridge
+}
+
+// SetChainAndGateway set token and gateway config
+func (b *Bridge) SetChainAndGateway(chainCfg *tokens.ChainConfig, gatewayCfg *tokens.GatewayConfig) {
+	b.CrossChainBridgeBase.SetChainAndGateway(chainCfg, gatewayCfg)
+	b.VerifyChainID()
+	b.Init()
+}
+
+// VerifyChainID verify gateway chain id is the declared 'ChainID' in chain config
+func (b *Bridge) VerifyChainID() {
+	targetChainID := b.ChainConfig.GetChainID()
+	if targetChainID == nil {
+		log.Fatalf("evm chain %v must config 'ChainID'", b.ChainConfig.NetID)
+	}
+
+	var (
+		chainID *big.Int
+		err     error
+	)
+
+	for {
+		chainID, err = b.GetSignerChainID()
+		if err == nil {
+			break
+		}
+		log.Errorf("can not get gateway chainID. %v", err)
+		log.Println("retry query gateway", b.GatewayConfig.APIAddress)
+		time.Sleep(3 * time.Second)
+	}
+
+	if chainID.Cmp(targetChainID) != 0 {
+		log.Fatalf("gateway chainID '%v' is not the configed '%v'", chainID, targetChainID)
+	}
+
+	b.SignerChainID = chainID
+	b.Signer = b.MakeSigner(chainID)
+
+	log.Info("VerifyChainID succeed", "networkID", b.ChainConfig.NetID, "chainID", chainID, "signer", b.ChainConfig.GetSignerType())
+}
@@ -22,6 +22,8 @@ var (
 	ErrTxBeforeInitialHeight         = errors.New("transaction before initial block height")
 	ErrAddressIsInBlacklist          = errors.New("address is in black list")
 	ErrSwapIsClosed                  = errors.New("swap is closed")
+	ErrRefundNotSupported            = errors.New("refund not supported in this endpoint")
+	ErrRefundValueTooSmall           = errors.New("refund value is too small")
 
 	ErrTodo = errors.New("developing: TODO")
 
@@ -107,6 +109,40 @@ type NonceSetter interface {
 	InitNonces(nonces map[string]uint64)
 }
 
+// DroppedTxChecker interface of bridge without nonce (for btc-like),
+// to check if the sent tx of swap is dropped and will never be on chain.
+// The tx is dropped if its inputs reserved by the swap key are spent by other tx,
+// or if it's neither on chain nor in pool after timeout.
+type DroppedTxChecker interface {
+	IsTxDropped(txHash, swapKey string, isTimeout bool) (bool, error)
+}
+
+// BatchSigner interface of bridge which can sign several raw txs in one sign round,
+// the signed txs and tx hashes are in the same order of raw txs.
+// The caller signs the prepared msg hashes, so that it can persist and resume in-flight sign sessions.
+type BatchSigner interface {
+	PrepareSignTransactions(rawTxs []interface{}, args []*BuildTxArgs) (msgHashes, msgContexts []string, err error)
+	MakeSignedTransactions(rawTxs []interface{}, rsvs []string, args []*BuildTxArgs) (signedTxs []interface{}, txHashes []string, err error)
+	MarshalRawTransaction(rawTx interface{}) (string, error)
+	UnmarshalRawTransaction(data string) (interface{}, error)
+}
+
+// FinalityChecker finality checker interface
+type FinalityChecker interface {
+	GetFinalizedBlockNumber() (uint64, error)
+}
+
+// SignKeyTyper interface of bridge which declares its dcrm sign key type
+type SignKeyTyper interface {
+	GetSignKeyType() string
+}
+
+// Refunder interface of bridge which can build refund tx (RefundType)
+// to return deposits to the sender
+type Refunder interface {
+	IsRefundSupported() bool
+}
+
 // ForkChecker fork checker interface
 type ForkChecker interface {
 	GetBlockHashOf(urls []string, height uint64) (hash string, err error)
@@ -27,6 +27,9 @@ func NewCrossChainBridge(isSrc bool) *Bridge {
 // SetChainAndGateway set token and gateway config
 func (b *Bridge) SetChainAndGateway(chainCfg *tokens.ChainConfig, gatewayCfg *tokens.GatewayConfig) {
 	b.CrossChainBridgeBase.SetChainAndGateway(chainCfg, gatewayCfg)
+	if chainCfg.StableFinality == tokens.SafeBlockTag {
+		log.Fatalf("kusama does not support '%v' stable finality", chainCfg.StableFinality)
+	}
 	b.VerifyChainID()
 	b.Init()
 }
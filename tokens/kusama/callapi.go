@@ -36,6 +36,11 @@ func (b *Bridge) GetLatestBlockNumberOf(url string) (latest uint64, err error) {
 	return header.Number.ToInt().Uint64(), nil
 }
 
+// GetFinalizedBlockNumberOf call chain_getFinalizedHead
+func (b *Bridge) GetFinalizedBlockNumberOf(url string) (uint64, error) {
+	return b.GetLatestBlockNumberOf(url)
+}
+
 // ------------------------ kusama specific apis -----------------------------
 
 // KsmGetFinalizedHead call chain_getFinalizedHead
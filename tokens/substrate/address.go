@@ -0,0 +1,147 @@
+package substrate
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/anyswap/CrossChain-Bridge/tools/crypto"
+	"github.com/btcsuite/btcutil/base58"
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	accountIDLength = 32
+	ss58ChecksumLen = 2
+)
+
+var (
+	ss58Prefix = []byte("SS58PRE")
+
+	errInvalidSS58Address = errors.New("invalid ss58 address")
+	errWrongSS58Prefix    = errors.New("ss58 address prefix mismatch")
+)
+
+// IsValidAddress check address
+func (b *Bridge) IsValidAddress(address string) bool {
+	_, err := b.DecodeAddress(address)
+	return err == nil
+}
+
+// DecodeAddress decode ss58 address of current network to account id
+func (b *Bridge) DecodeAddress(address string) ([]byte, error) {
+	prefix, accountID, err := DecodeSS58Address(address)
+	if err != nil {
+		return nil, err
+	}
+	if prefix != b.NetParams.SS58Prefix {
+		return nil, errWrongSS58Prefix
+	}
+	return accountID, nil
+}
+
+// EncodeAddress encode account id to ss58 address of current network
+func (b *Bridge) EncodeAddress(accountID []byte) string {
+	return EncodeSS58Address(b.NetParams.SS58Prefix, accountID)
+}
+
+// PublicKeyToAddress convert ecdsa public key (compressed or not) to ss58 address
+func (b *Bridge) PublicKeyToAddress(pubkey []byte) (string, error) {
+	accountID, err := EcdsaPublicKeyToAccountID(pubkey)
+	if err != nil {
+		return "", err
+	}
+	return b.EncodeAddress(accountID), nil
+}
+
+// EcdsaPublicKeyToAccountID account id of ecdsa public key is
+// blake2_256 hash of the compressed public key (MultiSigner::Ecdsa)
+func EcdsaPublicKeyToAccountID(pubkey []byte) ([]byte, error) {
+	compressed, err := compressPublicKey(pubkey)
+	if err != nil {
+		return nil, err
+	}
+	return blake2b256(compressed), nil
+}
+
+func compressPublicKey(pubkey []byte) ([]byte, error) {
+	switch len(pubkey) {
+	case 33:
+		if _, err := crypto.DecompressPubkey(pubkey); err != nil {
+			return nil, err
+		}
+		return pubkey, nil
+	case 65:
+		pubKey, err := crypto.UnmarshalPubkey(pubkey)
+		if err != nil {
+			return nil, err
+		}
+		return crypto.CompressPubkey(pubKey), nil
+	default:
+		return nil, errors.New("wrong length of ecdsa public key")
+	}
+}
+
+// EncodeSS58Address encode account id to ss58 address
+func EncodeSS58Address(prefix uint16, accountID []byte) string {
+	data := encodeSS58Prefix(prefix)
+	data = append(data, accountID...)
+	data = append(data, ss58Checksum(data)...)
+	return base58.Encode(data)
+}
+
+// DecodeSS58Address decode ss58 address to prefix and account id
+func DecodeSS58Address(address string) (prefix uint16, accountID []byte, err error) {
+	data := base58.Decode(address)
+	if len(data) == 0 {
+		return 0, nil, errInvalidSS58Address
+	}
+	prefixLen := 1
+	switch {
+	case data[0] < 64:
+		prefix = uint16(data[0])
+	case data[0] < 128:
+		if len(data) < 2 {
+			return 0, nil, errInvalidSS58Address
+		}
+		prefixLen = 2
+		lower := (data[0] << 2) | (data[1] >> 6)
+		upper := data[1] & 0x3f
+		prefix = uint16(lower) | uint16(upper)<<8
+	default:
+		return 0, nil, errInvalidSS58Address
+	}
+	if len(data) != prefixLen+accountIDLength+ss58ChecksumLen {
+		return 0, nil, errInvalidSS58Address
+	}
+	payload := data[:len(data)-ss58ChecksumLen]
+	if !bytes.Equal(ss58Checksum(payload), data[len(payload):]) {
+		return 0, nil, errInvalidSS58Address
+	}
+	return prefix, payload[prefixLen:], nil
+}
+
+func encodeSS58Prefix(prefix uint16) []byte {
+	if prefix < 64 {
+		return []byte{byte(prefix)}
+	}
+	return []byte{
+		byte((prefix&0xfc)>>2) | 0x40,
+		byte(prefix>>8) | byte(prefix&0x03)<<6,
+	}
+}
+
+func ss58Checksum(payload []byte) []byte {
+	hash := blake2b.Sum512(append(append([]byte{}, ss58Prefix...), payload...))
+	return hash[:ss58ChecksumLen]
+}
+
+func blake2b256(data []byte) []byte {
+	hash := blake2b.Sum256(data)
+	return hash[:]
+}
+
+func blake2b128(data []byte) []byte {
+	hasher, _ := blake2b.New(16, nil)
+	_, _ = hasher.Write(data)
+	return hasher.Sum(nil)
+}
@@ -0,0 +1,28 @@
+package substrate
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+)
+
+func TestSS58Address(t *testing.T) {
+	accountID := common.FromHex("0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d")
+	want := "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"
+
+	address := EncodeSS58Address(42, accountID)
+	if address != want {
+		t.Fatalf("encode ss58 address failed, have %v want %v", address, want)
+	}
+	prefix, decoded, err := DecodeSS58Address(address)
+	if err != nil {
+		t.Fatalf("decode ss58 address failed, %v", err)
+	}
+	if prefix != 42 || !bytes.Equal(decoded, accountID) {
+		t.Fatalf("decode ss58 address mismatch, prefix %v account %x", prefix, decoded)
+	}
+	if _, _, err = DecodeSS58Address(want[:len(want)-1] + "Z"); err == nil {
+		t.Fatalf("decode ss58 address with wrong checksum should fail")
+	}
+}
@@ -0,0 +1,157 @@
+// Package substrate implements the bridge interfaces for the native coin
+// of substrate based blockchains (eg. DOT of polkadot, KSM of kusama).
+//
+// Blocks, extrinsics and events are decoded through SCALE with the runtime
+// metadata (v14). Swapin is a transfer to the deposit address with the bind
+// address in a `system.remark` memo (batched by `utility.batchAll`), and the
+// tx ID of swapin is the extrinsic ID in the form of `blockNumber-index`.
+// Swapout is paid by DCRM signed extrinsic of ecdsa account, and transactions
+// are treated as stable only after they are finalized by GRANDPA.
+package substrate
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+// Bridge substrate bridge
+type Bridge struct {
+	*tokens.CrossChainBridgeBase
+	NetParams   *NetParams
+	GenesisHash string
+
+	metadatas    map[uint32]*Metadata
+	metadataLock sync.RWMutex
+
+	trackedTxs map[string]*txSearchRange
+	trackLock  sync.Mutex
+}
+
+// NewCrossChainBridge new substrate bridge
+func NewCrossChainBridge(isSrc bool) *Bridge {
+	if !isSrc {
+		log.Fatalf("substrate::NewCrossChainBridge error %v", tokens.ErrBridgeDestinationNotSupported)
+	}
+	return &Bridge{
+		CrossChainBridgeBase: tokens.NewCrossChainBridgeBase(isSrc),
+		metadatas:            make(map[uint32]*Metadata),
+		trackedTxs:           make(map[string]*txSearchRange),
+	}
+}
+
+// SetChainAndGateway set chain and gateway config
+func (b *Bridge) SetChainAndGateway(chainCfg *tokens.ChainConfig, gatewayCfg *tokens.GatewayConfig) {
+	b.CrossChainBridgeBase.SetChainAndGateway(chainCfg, gatewayCfg)
+	b.VerifyChainConfig()
+	b.InitLatestBlockNumber()
+}
+
+// VerifyChainConfig verify chain config
+func (b *Bridge) VerifyChainConfig() {
+	networkID := strings.ToLower(b.ChainConfig.NetID)
+	netParams, exist := networks[networkID]
+	if !exist {
+		log.Fatalf("unsupported substrate network: %v", b.ChainConfig.NetID)
+	}
+	b.NetParams = netParams
+
+	var (
+		genesisHash string
+		err         error
+	)
+	for {
+		genesisHash, err = b.GetBlockHash(0)
+		if err == nil && networkID == netCustom {
+			err = b.initCustomNetParams()
+		}
+		if err == nil {
+			break
+		}
+		log.Errorf("can not get gateway genesis hash. %v", err)
+		log.Println("retry query gateway", b.GatewayConfig.APIAddress)
+		time.Sleep(3 * time.Second)
+	}
+
+	if netParams.GenesisHash != "" && !strings.EqualFold(genesisHash, netParams.GenesisHash) {
+		log.Fatalf("gateway genesis hash '%v' is not '%v'", genesisHash, b.ChainConfig.NetID)
+	}
+	b.GenesisHash = genesisHash
+
+	log.Info("VerifyChainConfig succeed", "networkID", networkID, "genesisHash", genesisHash, "symbol", b.NetParams.Symbol, "ss58Prefix", b.NetParams.SS58Prefix)
+}
+
+func (b *Bridge) initCustomNetParams() error {
+	props, err := b.GetSystemProperties()
+	if err != nil {
+		return err
+	}
+	b.NetParams = &NetParams{
+		Name:       netCustom,
+		Symbol:     props.getTokenSymbol(),
+		Decimals:   props.getTokenDecimals(),
+		SS58Prefix: props.SS58Format,
+	}
+	return nil
+}
+
+// VerifyTokenConfig verify token config
+func (b *Bridge) VerifyTokenConfig(tokenCfg *tokens.TokenConfig) error {
+	if err := tokenCfg.VerifySignerWithoutPreimage(); err != nil {
+		return err
+	}
+	if !b.IsValidAddress(tokenCfg.DcrmAddress) {
+		return fmt.Errorf("invalid dcrm address: %v", tokenCfg.DcrmAddress)
+	}
+	if !b.IsValidAddress(tokenCfg.DepositAddress) {
+		return fmt.Errorf("invalid deposit address: %v", tokenCfg.DepositAddress)
+	}
+	if tokenCfg.ContractAddress != "" {
+		return fmt.Errorf("substrate token should not config contract address")
+	}
+	if !strings.EqualFold(tokenCfg.Symbol, b.NetParams.Symbol) {
+		return fmt.Errorf("invalid symbol: want %v but have %v", b.NetParams.Symbol, tokenCfg.Symbol)
+	}
+	if *tokenCfg.Decimals != b.NetParams.Decimals {
+		return fmt.Errorf("invalid decimals for %v: want %v but have %v", b.NetParams.Symbol, b.NetParams.Decimals, *tokenCfg.Decimals)
+	}
+	return b.verifyDcrmPublicKey(tokenCfg)
+}
+
+func (b *Bridge) verifyDcrmPublicKey(tokenCfg *tokens.TokenConfig) error {
+	if tokenCfg.DcrmPubkey == "" {
+		return fmt.Errorf("substrate token must config 'DcrmPubkey'")
+	}
+	pubAddr, err := b.PublicKeyToAddress(common.FromHex(tokenCfg.DcrmPubkey))
+	if err != nil {
+		return fmt.Errorf("wrong dcrm public key, %w", err)
+	}
+	if pubAddr != tokenCfg.DcrmAddress {
+		return fmt.Errorf("dcrm address %v and public key address %v is not match", tokenCfg.DcrmAddress, pubAddr)
+	}
+	return nil
+}
+
+// InitLatestBlockNumber init latest block number
+func (b *Bridge) InitLatestBlockNumber() {
+	chainCfg := b.ChainConfig
+	gatewayCfg := b.GatewayConfig
+	var latest uint64
+	var err error
+	for {
+		latest, err = b.GetLatestBlockNumber()
+		if err == nil {
+			tokens.SetLatestBlockHeight(latest, b.IsSrc)
+			log.Info("get latst block number succeed.", "number", latest, "BlockChain", chainCfg.BlockChain, "NetID", chainCfg.NetID)
+			break
+		}
+		log.Error("get latst block number failed.", "BlockChain", chainCfg.BlockChain, "NetID", chainCfg.NetID, "err", err)
+		log.Println("retry query gateway", gatewayCfg.APIAddress)
+		time.Sleep(3 * time.Second)
+	}
+}
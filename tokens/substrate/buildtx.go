@@ -0,0 +1,163 @@
+package substrate
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tokens/substrate/scale"
+)
+
+// BuildRawTransaction build raw tx
+func (b *Bridge) BuildRawTransaction(args *tokens.BuildTxArgs) (rawTx interface{}, err error) {
+	pairID := args.PairID
+	token := b.GetTokenConfig(pairID)
+	if token == nil {
+		return nil, fmt.Errorf("swap pair '%v' is not configed", pairID)
+	}
+
+	var (
+		to     string
+		amount *big.Int
+		memo   string
+	)
+	switch args.SwapType {
+	case tokens.SwapinType:
+		return nil, tokens.ErrSwapTypeNotSupported
+	case tokens.SwapoutType:
+		to = args.Bind                                                    // to
+		amount = tokens.CalcSwappedValue(pairID, args.OriginValue, false) // amount
+		memo = tokens.UnlockMemoPrefix + args.SwapID
+	default:
+		return nil, tokens.ErrUnknownSwapType
+	}
+	if amount.Sign() <= 0 {
+		return nil, tokens.ErrWrongSwapValue
+	}
+
+	args.From = token.DcrmAddress // from
+	signer, err := b.DecodeAddress(args.From)
+	if err != nil {
+		return nil, fmt.Errorf("wrong sender %v, %w", args.From, err)
+	}
+	dest, err := b.DecodeAddress(to)
+	if err != nil {
+		return nil, fmt.Errorf("wrong receiver %v, %w", to, err)
+	}
+
+	extra, err := b.setDefaults(args)
+	if err != nil {
+		return nil, err
+	}
+
+	blockHash := *extra.BlockHash
+	meta, err := b.GetMetadata(blockHash)
+	if err != nil {
+		return nil, err
+	}
+	if meta.SpecVersion != *extra.SpecVersion {
+		return nil, fmt.Errorf("%w: spec version mismatch, have %v want %v", tokens.ErrWrongExtraArgs, *extra.SpecVersion, meta.SpecVersion)
+	}
+	call, err := buildSwapoutCall(meta, dest, amount, memo)
+	if err != nil {
+		return nil, err
+	}
+
+	rawTx = &UnsignedExtrinsic{
+		meta:        meta,
+		Call:        call,
+		Signer:      signer,
+		BlockNumber: *extra.BlockNumber,
+		BlockHash:   common.HexToHash(blockHash),
+		Nonce:       *extra.Nonce,
+		Tip:         extra.Tip,
+		SpecVersion: *extra.SpecVersion,
+		TxVersion:   *extra.TxVersion,
+		GenesisHash: common.HexToHash(b.GenesisHash),
+	}
+	log.Info("build substrate raw tx", "pairID", pairID, "swapID", args.SwapID, "from", args.From, "to", to, "amount", amount, "nonce", *extra.Nonce, "blockNumber", *extra.BlockNumber)
+	return rawTx, nil
+}
+
+// setDefaults set nonce and era checkpoint if not specified, the
+// checkpoint is the latest finalized block
+func (b *Bridge) setDefaults(args *tokens.BuildTxArgs) (extra *tokens.SubstrateExtraArgs, err error) {
+	if args.Extra == nil || args.Extra.SubstrateExtra == nil {
+		extra = &tokens.SubstrateExtraArgs{}
+		args.Extra = &tokens.AllExtras{SubstrateExtra: extra}
+	} else {
+		extra = args.Extra.SubstrateExtra
+	}
+	if extra.BlockHash == nil || extra.BlockNumber == nil {
+		blockHash, errf := b.GetFinalizedHead()
+		if errf != nil {
+			return nil, errf
+		}
+		header, errf := b.GetHeader(blockHash)
+		if errf != nil {
+			return nil, errf
+		}
+		blockNumber := uint64(*header.Number)
+		extra.BlockHash = &blockHash
+		extra.BlockNumber = &blockNumber
+	}
+	if extra.SpecVersion == nil || extra.TxVersion == nil {
+		version, errf := b.GetRuntimeVersion(*extra.BlockHash)
+		if errf != nil {
+			return nil, errf
+		}
+		extra.SpecVersion = &version.SpecVersion
+		extra.TxVersion = &version.TransactionVersion
+	}
+	if extra.Nonce == nil {
+		nonce, errf := b.GetAccountNextIndex(args.From)
+		if errf != nil {
+			return nil, errf
+		}
+		extra.Nonce = &nonce
+	}
+	if extra.Tip == nil {
+		extra.Tip = big.NewInt(0)
+	}
+	return extra, nil
+}
+
+// buildSwapoutCall build `utility.batchAll([balances.transferKeepAlive(dest, value), system.remark(memo)])`
+func buildSwapoutCall(meta *Metadata, dest []byte, value *big.Int, memo string) ([]byte, error) {
+	transfer, transferIndex, err := meta.getCallIndex("Balances", "transfer_keep_alive")
+	if err != nil {
+		return nil, err
+	}
+	_, remarkIndex, err := meta.getCallIndex("System", "remark")
+	if err != nil {
+		return nil, err
+	}
+	_, batchIndex, err := meta.getCallIndex("Utility", "batch_all")
+	if err != nil {
+		return nil, err
+	}
+	if len(transfer.Fields) != 2 {
+		return nil, errors.New("unsupported call of balances.transfer_keep_alive")
+	}
+
+	enc := scale.NewEncoder()
+	enc.WriteBytes(batchIndex)
+	enc.WriteCompactUint64(2)
+
+	enc.WriteBytes(transferIndex)
+	if index, ok := meta.getVariantIndex(transfer.Fields[0].Type, "Id"); ok {
+		enc.WriteUint8(index) // MultiAddress::Id
+	}
+	enc.WriteBytes(dest)
+	if err = enc.WriteCompact(value); err != nil {
+		return nil, err
+	}
+
+	enc.WriteBytes(remarkIndex)
+	enc.WriteVecBytes([]byte(memo))
+
+	return enc.Bytes(), nil
+}
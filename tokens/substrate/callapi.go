@@ -0,0 +1,269 @@
+package substrate
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/common/hexutil"
+	"github.com/anyswap/CrossChain-Bridge/rpc/client"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+// twox128("System") ++ twox128("Events")
+const systemEventsStorageKey = "0x26aa394eea5630e07c48ae0c9558cef780d41e5e16056765bc8461851072c9d7"
+
+// twox128("System") ++ twox128("Account"), the key is blake2_128_concat(accountID)
+const systemAccountStoragePrefix = "0x26aa394eea5630e07c48ae0c9558cef7b99d880ec681799c0cf30e8886371da9"
+
+var (
+	errNotFound = errors.New("not found")
+)
+
+func wrapRPCQueryError(err error, method string, params ...interface{}) error {
+	if err == nil {
+		err = errNotFound
+	}
+	return fmt.Errorf("%w: call '%s %v' failed, err='%v'", tokens.ErrRPCQueryError, method, params, err)
+}
+
+// Header block header
+type Header struct {
+	ParentHash *common.Hash    `json:"parentHash"`
+	Number     *hexutil.Uint64 `json:"number"`
+}
+
+// SignedBlock result of chain_getBlock
+type SignedBlock struct {
+	Block struct {
+		Header     *Header         `json:"header"`
+		Extrinsics []hexutil.Bytes `json:"extrinsics"`
+	} `json:"block"`
+}
+
+// RuntimeVersion result of state_getRuntimeVersion
+type RuntimeVersion struct {
+	SpecName           string `json:"specName"`
+	SpecVersion        uint32 `json:"specVersion"`
+	TransactionVersion uint32 `json:"transactionVersion"`
+}
+
+func (b *Bridge) callRPC(result interface{}, method string, params ...interface{}) (err error) {
+	gateway := b.GatewayConfig
+	for _, urls := range [][]string{gateway.APIAddress, gateway.APIAddressExt} {
+		for _, url := range urls {
+			err = client.RPCPost(result, url, method, params...)
+			if err == nil {
+				return nil
+			}
+		}
+	}
+	return wrapRPCQueryError(err, method, params...)
+}
+
+func blockHashParams(blockHash string) []interface{} {
+	if blockHash == "" {
+		return nil
+	}
+	return []interface{}{blockHash}
+}
+
+// GetLatestBlockNumber get latest block number finalized by GRANDPA
+func (b *Bridge) GetLatestBlockNumber() (uint64, error) {
+	blockHash, err := b.GetFinalizedHead()
+	if err != nil {
+		return 0, err
+	}
+	header, err := b.GetHeader(blockHash)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(*header.Number), nil
+}
+
+// GetFinalizedBlockNumber get finalized block number
+func (b *Bridge) GetFinalizedBlockNumber() (uint64, error) {
+	return b.GetLatestBlockNumber()
+}
+
+// GetLatestBlockNumberOf get latest finalized block number of specified url
+func (b *Bridge) GetLatestBlockNumberOf(url string) (uint64, error) {
+	var blockHash string
+	err := client.RPCPost(&blockHash, url, "chain_getFinalizedHead")
+	if err != nil || blockHash == "" {
+		return 0, wrapRPCQueryError(err, "chain_getFinalizedHead")
+	}
+	var header *Header
+	err = client.RPCPost(&header, url, "chain_getHeader", blockHash)
+	if err != nil || header == nil || header.Number == nil {
+		return 0, wrapRPCQueryError(err, "chain_getHeader", blockHash)
+	}
+	return uint64(*header.Number), nil
+}
+
+// GetBestBlockNumber get best (unfinalized) block number
+func (b *Bridge) GetBestBlockNumber() (uint64, error) {
+	header, err := b.GetHeader("")
+	if err != nil {
+		return 0, err
+	}
+	return uint64(*header.Number), nil
+}
+
+// GetFinalizedHead call chain_getFinalizedHead
+func (b *Bridge) GetFinalizedHead() (blockHash string, err error) {
+	err = b.callRPC(&blockHash, "chain_getFinalizedHead")
+	if err == nil && blockHash == "" {
+		err = wrapRPCQueryError(nil, "chain_getFinalizedHead")
+	}
+	return blockHash, err
+}
+
+// GetHeader call chain_getHeader (get best header if block hash is empty)
+func (b *Bridge) GetHeader(blockHash string) (header *Header, err error) {
+	err = b.callRPC(&header, "chain_getHeader", blockHashParams(blockHash)...)
+	if err == nil && (header == nil || header.Number == nil) {
+		err = wrapRPCQueryError(nil, "chain_getHeader", blockHash)
+	}
+	return header, err
+}
+
+// GetBlockHash call chain_getBlockHash
+func (b *Bridge) GetBlockHash(number uint64) (blockHash string, err error) {
+	err = b.callRPC(&blockHash, "chain_getBlockHash", number)
+	if err == nil && blockHash == "" {
+		err = wrapRPCQueryError(nil, "chain_getBlockHash", number)
+	}
+	return blockHash, err
+}
+
+// GetBlock call chain_getBlock
+func (b *Bridge) GetBlock(blockHash string) (block *SignedBlock, err error) {
+	err = b.callRPC(&block, "chain_getBlock", blockHash)
+	if err == nil && (block == nil || block.Block.Header == nil) {
+		err = wrapRPCQueryError(nil, "chain_getBlock", blockHash)
+	}
+	return block, err
+}
+
+// GetRuntimeVersion call state_getRuntimeVersion
+func (b *Bridge) GetRuntimeVersion(blockHash string) (version *RuntimeVersion, err error) {
+	err = b.callRPC(&version, "state_getRuntimeVersion", blockHashParams(blockHash)...)
+	if err == nil && version == nil {
+		err = wrapRPCQueryError(nil, "state_getRuntimeVersion", blockHash)
+	}
+	return version, err
+}
+
+// GetMetadata get metadata of runtime at block (cached by spec version)
+func (b *Bridge) GetMetadata(blockHash string) (*Metadata, error) {
+	version, err := b.GetRuntimeVersion(blockHash)
+	if err != nil {
+		return nil, err
+	}
+	b.metadataLock.RLock()
+	meta, exist := b.metadatas[version.SpecVersion]
+	b.metadataLock.RUnlock()
+	if exist {
+		return meta, nil
+	}
+
+	var data hexutil.Bytes
+	err = b.callRPC(&data, "state_getMetadata", blockHashParams(blockHash)...)
+	if err != nil {
+		return nil, err
+	}
+	meta, err = DecodeMetadata(data)
+	if err != nil {
+		return nil, err
+	}
+	meta.SpecVersion = version.SpecVersion
+
+	b.metadataLock.Lock()
+	b.metadatas[version.SpecVersion] = meta
+	b.metadataLock.Unlock()
+	return meta, nil
+}
+
+// GetStorage call state_getStorage, returns nil if storage not exist
+func (b *Bridge) GetStorage(key, blockHash string) (data []byte, err error) {
+	var result *hexutil.Bytes
+	params := append([]interface{}{key}, blockHashParams(blockHash)...)
+	err = b.callRPC(&result, "state_getStorage", params...)
+	if err != nil || result == nil {
+		return nil, err
+	}
+	return *result, nil
+}
+
+// GetSystemProperties call system_properties
+func (b *Bridge) GetSystemProperties() (props *SystemProperties, err error) {
+	err = b.callRPC(&props, "system_properties")
+	if err == nil && props == nil {
+		err = wrapRPCQueryError(nil, "system_properties")
+	}
+	return props, err
+}
+
+// GetAccountNextIndex call system_accountNextIndex (nonce including pool txs)
+func (b *Bridge) GetAccountNextIndex(address string) (nonce uint64, err error) {
+	err = b.callRPC(&nonce, "system_accountNextIndex", address)
+	return nonce, err
+}
+
+// GetPendingExtrinsics call author_pendingExtrinsics
+func (b *Bridge) GetPendingExtrinsics() (result []hexutil.Bytes, err error) {
+	err = b.callRPC(&result, "author_pendingExtrinsics")
+	return result, err
+}
+
+// GetBalance get free balance of account
+func (b *Bridge) GetBalance(account string) (*big.Int, error) {
+	accountID, err := b.DecodeAddress(account)
+	if err != nil {
+		return nil, err
+	}
+	blockHash, err := b.GetFinalizedHead()
+	if err != nil {
+		return nil, err
+	}
+	meta, err := b.GetMetadata(blockHash)
+	if err != nil {
+		return nil, err
+	}
+	entry, err := meta.getStorageEntry("System", "Account")
+	if err != nil {
+		return nil, err
+	}
+	if !entry.IsMap || len(entry.Hashers) != 1 || entry.Hashers[0] != hasherBlake2128Concat {
+		return nil, errors.New("unsupported storage of System.Account")
+	}
+	key := common.ToHex(append(append(common.FromHex(systemAccountStoragePrefix), blake2b128(accountID)...), accountID...))
+	data, err := b.GetStorage(key, blockHash)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return big.NewInt(0), nil
+	}
+	accountInfo, err := meta.DecodeValue(entry.ValueType, data)
+	if err != nil {
+		return nil, err
+	}
+	free, ok := getBigInt(getField(getField(accountInfo, "data"), "free"))
+	if !ok {
+		return nil, errors.New("can not get free balance from account info")
+	}
+	return free, nil
+}
+
+// GetTokenBalance impl
+func (b *Bridge) GetTokenBalance(tokenType, tokenAddress, accountAddress string) (*big.Int, error) {
+	return nil, fmt.Errorf("[%v] can not get token balance of token with type '%v'", b.ChainConfig.BlockChain, tokenType)
+}
+
+// GetTokenSupply impl
+func (b *Bridge) GetTokenSupply(tokenType, tokenAddress string) (*big.Int, error) {
+	return nil, fmt.Errorf("[%v] can not get token supply of token with type '%v'", b.ChainConfig.BlockChain, tokenType)
+}
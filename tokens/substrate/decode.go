@@ -0,0 +1,302 @@
+package substrate
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/anyswap/CrossChain-Bridge/tokens/substrate/scale"
+)
+
+const maxDecodeDepth = 64
+
+var errDecodeTooDeep = errors.New("decode value nested too deep")
+
+// VariantValue decoded value of variant type (enum)
+type VariantValue struct {
+	Name   string
+	Index  uint8
+	Fields interface{}
+}
+
+// Field get named field of variant value
+func (v *VariantValue) Field(name string) interface{} {
+	if fields, ok := v.Fields.(map[string]interface{}); ok {
+		return fields[name]
+	}
+	return nil
+}
+
+// DecodeValue decode data of specified type completely
+func (m *Metadata) DecodeValue(typeID uint32, data []byte) (interface{}, error) {
+	d := scale.NewDecoder(data)
+	value, err := m.decodeValue(d, typeID, 0)
+	if err != nil {
+		return nil, err
+	}
+	if d.Remaining() != 0 {
+		return nil, fmt.Errorf("decode value of type %v has %v bytes left", typeID, d.Remaining())
+	}
+	return value, nil
+}
+
+// decodeValue decode value of type to go values, which are:
+// composite with named fields -> map[string]interface{},
+// composite with one unnamed field -> the field value,
+// composite with unnamed fields and tuple -> []interface{},
+// variant -> *VariantValue,
+// sequence and array of u8 -> []byte, other sequence and array -> []interface{},
+// bool, str, char -> bool, string, rune,
+// unsigned integers up to 64 bits -> uint64, signed ones -> int64,
+// compact and integers of 128 and 256 bits -> *big.Int,
+// bit sequence -> []byte of the underlying store.
+func (m *Metadata) decodeValue(d *scale.Decoder, typeID uint32, depth int) (interface{}, error) {
+	if depth > maxDecodeDepth {
+		return nil, errDecodeTooDeep
+	}
+	ty, err := m.getType(typeID)
+	if err != nil {
+		return nil, err
+	}
+	switch ty.Kind {
+	case typeDefComposite:
+		return m.decodeFields(d, ty.Fields, depth)
+	case typeDefVariant:
+		index, err := d.ReadUint8()
+		if err != nil {
+			return nil, err
+		}
+		for _, variant := range ty.Variants {
+			if variant.Index != index {
+				continue
+			}
+			fields, err := m.decodeFields(d, variant.Fields, depth)
+			if err != nil {
+				return nil, err
+			}
+			return &VariantValue{Name: variant.Name, Index: index, Fields: fields}, nil
+		}
+		return nil, fmt.Errorf("unknown variant index %v of type %v", index, typeID)
+	case typeDefSequence:
+		count, err := d.ReadLength()
+		if err != nil {
+			return nil, err
+		}
+		return m.decodeElems(d, ty.Elem, count, depth)
+	case typeDefArray:
+		return m.decodeElems(d, ty.Elem, int(ty.Len), depth)
+	case typeDefTuple:
+		if len(ty.Tuple) == 0 {
+			return nil, nil
+		}
+		values := make([]interface{}, len(ty.Tuple))
+		for i, elem := range ty.Tuple {
+			if values[i], err = m.decodeValue(d, elem, depth+1); err != nil {
+				return nil, err
+			}
+		}
+		return values, nil
+	case typeDefPrimitive:
+		return decodePrimitive(d, ty.Primitive)
+	case typeDefCompact:
+		return d.ReadCompact()
+	case typeDefBitSequence:
+		return m.decodeBitSequence(d, ty.BitStore)
+	default:
+		return nil, fmt.Errorf("unknown type definition kind %v", ty.Kind)
+	}
+}
+
+func (m *Metadata) decodeFields(d *scale.Decoder, fields []*typeField, depth int) (interface{}, error) {
+	switch {
+	case len(fields) == 0:
+		return nil, nil
+	case fields[0].Name == "":
+		if len(fields) == 1 {
+			return m.decodeValue(d, fields[0].Type, depth+1)
+		}
+		values := make([]interface{}, len(fields))
+		for i, field := range fields {
+			value, err := m.decodeValue(d, field.Type, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = value
+		}
+		return values, nil
+	default:
+		values := make(map[string]interface{}, len(fields))
+		for _, field := range fields {
+			value, err := m.decodeValue(d, field.Type, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			values[field.Name] = value
+		}
+		return values, nil
+	}
+}
+
+func (m *Metadata) decodeElems(d *scale.Decoder, elemType uint32, count, depth int) (interface{}, error) {
+	elem, err := m.getType(elemType)
+	if err != nil {
+		return nil, err
+	}
+	if elem.Kind == typeDefPrimitive && elem.Primitive == primitiveU8 {
+		return d.ReadBytes(count)
+	}
+	if count > d.Remaining() && !m.isEmptyType(elemType) {
+		return nil, scale.ErrUnexpectedEOF
+	}
+	values := make([]interface{}, count)
+	for i := 0; i < count; i++ {
+		if values[i], err = m.decodeValue(d, elemType, depth+1); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+func (m *Metadata) decodeBitSequence(d *scale.Decoder, storeType uint32) (interface{}, error) {
+	store, err := m.getType(storeType)
+	if err != nil {
+		return nil, err
+	}
+	var storeSize uint64
+	switch {
+	case store.Kind != typeDefPrimitive:
+		return nil, fmt.Errorf("unsupported bit store type %v", storeType)
+	case store.Primitive == primitiveU8:
+		storeSize = 1
+	case store.Primitive == primitiveU16:
+		storeSize = 2
+	case store.Primitive == primitiveU32:
+		storeSize = 4
+	case store.Primitive == primitiveU64:
+		storeSize = 8
+	default:
+		return nil, fmt.Errorf("unsupported bit store type %v", storeType)
+	}
+	bits, err := d.ReadCompactUint64()
+	if err != nil {
+		return nil, err
+	}
+	storeBits := storeSize * 8
+	length := (bits + storeBits - 1) / storeBits * storeSize
+	if length > uint64(d.Remaining()) {
+		return nil, scale.ErrUnexpectedEOF
+	}
+	return d.ReadBytes(int(length))
+}
+
+func decodePrimitive(d *scale.Decoder, primitive int) (interface{}, error) {
+	switch primitive {
+	case primitiveBool:
+		return d.ReadBool()
+	case primitiveChar:
+		value, err := d.ReadUint32()
+		return rune(value), err
+	case primitiveStr:
+		return d.ReadString()
+	case primitiveU8:
+		value, err := d.ReadUint8()
+		return uint64(value), err
+	case primitiveU16:
+		value, err := d.ReadUint16()
+		return uint64(value), err
+	case primitiveU32:
+		value, err := d.ReadUint32()
+		return uint64(value), err
+	case primitiveU64:
+		return d.ReadUint64()
+	case primitiveU128:
+		return d.ReadBigUint(16)
+	case primitiveU256:
+		return d.ReadBigUint(32)
+	case primitiveI8:
+		value, err := d.ReadUint8()
+		return int64(int8(value)), err
+	case primitiveI16:
+		value, err := d.ReadUint16()
+		return int64(int16(value)), err
+	case primitiveI32:
+		value, err := d.ReadUint32()
+		return int64(int32(value)), err
+	case primitiveI64:
+		value, err := d.ReadUint64()
+		return int64(value), err
+	case primitiveI128:
+		return readBigInt(d, 16)
+	case primitiveI256:
+		return readBigInt(d, 32)
+	default:
+		return nil, fmt.Errorf("unknown primitive type %v", primitive)
+	}
+}
+
+func readBigInt(d *scale.Decoder, length int) (*big.Int, error) {
+	value, err := d.ReadBigUint(length)
+	if err != nil {
+		return nil, err
+	}
+	if value.Bit(length*8-1) == 1 {
+		value.Sub(value, new(big.Int).Lsh(big.NewInt(1), uint(length*8)))
+	}
+	return value, nil
+}
+
+// helpers to access decoded values
+
+func getVariant(value interface{}) (*VariantValue, bool) {
+	variant, ok := value.(*VariantValue)
+	return variant, ok && variant != nil
+}
+
+func getField(value interface{}, name string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return v[name]
+	case *VariantValue:
+		return v.Field(name)
+	default:
+		return nil
+	}
+}
+
+func getBigInt(value interface{}) (*big.Int, bool) {
+	switch v := value.(type) {
+	case *big.Int:
+		return v, v != nil
+	case uint64:
+		return new(big.Int).SetUint64(v), true
+	default:
+		return nil, false
+	}
+}
+
+func getUint64(value interface{}) (uint64, bool) {
+	switch v := value.(type) {
+	case uint64:
+		return v, true
+	case *big.Int:
+		if v != nil && v.IsUint64() {
+			return v.Uint64(), true
+		}
+	}
+	return 0, false
+}
+
+// getAccountID get account id from AccountId32 or MultiAddress::Id
+func getAccountID(value interface{}) ([]byte, bool) {
+	if variant, ok := getVariant(value); ok {
+		if variant.Name != "Id" && variant.Name != "Address32" {
+			return nil, false
+		}
+		value = variant.Fields
+	}
+	accountID, ok := value.([]byte)
+	if !ok || len(accountID) != accountIDLength {
+		return nil, false
+	}
+	return accountID, true
+}
@@ -0,0 +1,100 @@
+package substrate
+
+import (
+	"errors"
+)
+
+// EventRecord decoded event record
+type EventRecord struct {
+	ExtrinsicIndex *uint64 // nil if not in phase of applying extrinsic
+	Pallet         string
+	Name           string
+	Fields         interface{}
+}
+
+// GetEvents get all events of block
+func (b *Bridge) GetEvents(blockHash string, meta *Metadata) ([]*EventRecord, error) {
+	entry, err := meta.getStorageEntry("System", "Events")
+	if err != nil {
+		return nil, err
+	}
+	data, err := b.GetStorage(systemEventsStorageKey, blockHash)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+	value, err := meta.DecodeValue(entry.ValueType, data)
+	if err != nil {
+		return nil, err
+	}
+	records, ok := value.([]interface{})
+	if !ok {
+		return nil, errors.New("wrong events storage")
+	}
+	events := make([]*EventRecord, 0, len(records))
+	for _, record := range records {
+		event, err := parseEventRecord(record)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+func parseEventRecord(record interface{}) (*EventRecord, error) {
+	phase, ok := getVariant(getField(record, "phase"))
+	if !ok {
+		return nil, errors.New("event record without phase")
+	}
+	event, ok := getVariant(getField(record, "event"))
+	if !ok {
+		return nil, errors.New("event record without event")
+	}
+	inner, ok := getVariant(event.Fields)
+	if !ok {
+		return nil, errors.New("wrong event of event record")
+	}
+	result := &EventRecord{
+		Pallet: event.Name,
+		Name:   inner.Name,
+		Fields: inner.Fields,
+	}
+	if phase.Name == "ApplyExtrinsic" {
+		index, ok := getUint64(phase.Fields)
+		if !ok {
+			return nil, errors.New("wrong phase of event record")
+		}
+		result.ExtrinsicIndex = &index
+	}
+	return result, nil
+}
+
+// filterExtrinsicEvents filter events emitted when applying extrinsic of index
+func filterExtrinsicEvents(events []*EventRecord, index uint64) (result []*EventRecord) {
+	for _, event := range events {
+		if event.ExtrinsicIndex != nil && *event.ExtrinsicIndex == index {
+			result = append(result, event)
+		}
+	}
+	return result
+}
+
+// isExtrinsicSuccess check result of extrinsic, the batch call is also
+// treated as failed if it is interrupted
+func isExtrinsicSuccess(events []*EventRecord) bool {
+	success := false
+	for _, event := range events {
+		switch {
+		case event.Pallet == "System" && event.Name == "ExtrinsicSuccess":
+			success = true
+		case event.Pallet == "System" && event.Name == "ExtrinsicFailed":
+			return false
+		case event.Pallet == "Utility" && event.Name == "BatchInterrupted":
+			return false
+		}
+	}
+	return success
+}
@@ -0,0 +1,239 @@
+package substrate
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"math/bits"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/tokens/substrate/scale"
+)
+
+const (
+	extrinsicVersion4 = 4
+	signedBit         = 0x80
+
+	// mortal era period of signed extrinsics (in blocks)
+	eraPeriod = uint64(64)
+
+	// payload longer than this is hashed before signing
+	maxUnhashedPayloadLength = 256
+
+	ecdsaSignatureLength = 65
+)
+
+var (
+	errUnsupportedExtrinsic = errors.New("unsupported extrinsic version")
+	errWrongSignatureLength = errors.New("wrong ecdsa signature length")
+)
+
+// Extrinsic decoded extrinsic
+type Extrinsic struct {
+	Hash   string
+	Signer []byte // account id, nil if unsigned
+	Call   *VariantValue
+}
+
+// DecodeExtrinsic decode extrinsic (with compact length prefix)
+func (m *Metadata) DecodeExtrinsic(data []byte) (*Extrinsic, error) {
+	d := scale.NewDecoder(data)
+	length, err := d.ReadLength()
+	if err != nil {
+		return nil, err
+	}
+	if length != d.Remaining() {
+		return nil, errors.New("wrong extrinsic length")
+	}
+	version, err := d.ReadUint8()
+	if err != nil {
+		return nil, err
+	}
+	if version&^signedBit != extrinsicVersion4 {
+		return nil, errUnsupportedExtrinsic
+	}
+	extrinsic := &Extrinsic{Hash: common.ToHex(blake2b256(data))}
+	if version&signedBit != 0 {
+		address, err := m.decodeValue(d, m.addressType, 0)
+		if err != nil {
+			return nil, fmt.Errorf("decode extrinsic address failed, %w", err)
+		}
+		if _, err = m.decodeValue(d, m.signatureType, 0); err != nil {
+			return nil, fmt.Errorf("decode extrinsic signature failed, %w", err)
+		}
+		if _, err = m.decodeValue(d, m.extraType, 0); err != nil {
+			return nil, fmt.Errorf("decode extrinsic extra failed, %w", err)
+		}
+		if accountID, ok := getAccountID(address); ok {
+			extrinsic.Signer = accountID
+		}
+	}
+	call, err := m.decodeValue(d, m.callType, 0)
+	if err != nil {
+		return nil, fmt.Errorf("decode extrinsic call failed, %w", err)
+	}
+	if d.Remaining() != 0 {
+		return nil, errors.New("extrinsic has extra data after call")
+	}
+	var ok bool
+	if extrinsic.Call, ok = getVariant(call); !ok {
+		return nil, errors.New("wrong extrinsic call")
+	}
+	return extrinsic, nil
+}
+
+// CallName returns pallet name and call name of a decoded runtime call
+func CallName(call *VariantValue) (pallet, name string, args interface{}) {
+	inner, ok := getVariant(call.Fields)
+	if !ok {
+		return call.Name, "", nil
+	}
+	return call.Name, inner.Name, inner.Fields
+}
+
+// UnsignedExtrinsic extrinsic to be signed
+type UnsignedExtrinsic struct {
+	meta *Metadata
+
+	Call        []byte
+	Signer      []byte // account id
+	BlockNumber uint64 // era checkpoint
+	BlockHash   common.Hash
+	Nonce       uint64
+	Tip         *big.Int
+	SpecVersion uint32
+	TxVersion   uint32
+	GenesisHash common.Hash
+}
+
+// SignedExtrinsic signed extrinsic
+type SignedExtrinsic struct {
+	Data  []byte
+	Hash  string
+	Nonce uint64
+}
+
+// encodeExtensions encode the extra and additional signed data of signed extensions
+func (tx *UnsignedExtrinsic) encodeExtensions() (extra, additional []byte, err error) {
+	extraEnc := scale.NewEncoder()
+	additionalEnc := scale.NewEncoder()
+	for _, ext := range tx.meta.signedExtensions {
+		switch ext.Identifier {
+		case "CheckNonZeroSender", "CheckWeight", "PrevalidateAttests":
+		case "CheckSpecVersion":
+			additionalEnc.WriteUint32(tx.SpecVersion)
+		case "CheckTxVersion":
+			additionalEnc.WriteUint32(tx.TxVersion)
+		case "CheckGenesis":
+			additionalEnc.WriteBytes(tx.GenesisHash.Bytes())
+		case "CheckMortality", "CheckEra":
+			extraEnc.WriteBytes(encodeMortalEra(eraPeriod, tx.BlockNumber))
+			additionalEnc.WriteBytes(tx.BlockHash.Bytes())
+		case "CheckNonce":
+			extraEnc.WriteCompactUint64(tx.Nonce)
+		case "ChargeTransactionPayment":
+			if err = extraEnc.WriteCompact(tx.Tip); err != nil {
+				return nil, nil, err
+			}
+		case "ChargeAssetTxPayment":
+			if err = extraEnc.WriteCompact(tx.Tip); err != nil {
+				return nil, nil, err
+			}
+			extraEnc.WriteUint8(0) // no asset id
+		case "CheckMetadataHash":
+			extraEnc.WriteUint8(0)      // mode disabled
+			additionalEnc.WriteUint8(0) // no metadata hash
+		default:
+			if !tx.meta.isEmptyType(ext.Type) || !tx.meta.isEmptyType(ext.AdditionalType) {
+				return nil, nil, fmt.Errorf("unsupported signed extension '%v'", ext.Identifier)
+			}
+		}
+	}
+	return extraEnc.Bytes(), additionalEnc.Bytes(), nil
+}
+
+// SigningPayload get the payload to be signed
+func (tx *UnsignedExtrinsic) SigningPayload() ([]byte, error) {
+	extra, additional, err := tx.encodeExtensions()
+	if err != nil {
+		return nil, err
+	}
+	payload := make([]byte, 0, len(tx.Call)+len(extra)+len(additional))
+	payload = append(payload, tx.Call...)
+	payload = append(payload, extra...)
+	payload = append(payload, additional...)
+	if len(payload) > maxUnhashedPayloadLength {
+		payload = blake2b256(payload)
+	}
+	return payload, nil
+}
+
+// SigningHash get the message hash to be signed by ecdsa,
+// which is the blake2_256 hash of the signing payload
+func (tx *UnsignedExtrinsic) SigningHash() ([]byte, error) {
+	payload, err := tx.SigningPayload()
+	if err != nil {
+		return nil, err
+	}
+	return blake2b256(payload), nil
+}
+
+// WithSignature build signed extrinsic with ecdsa signature (r || s || v)
+func (tx *UnsignedExtrinsic) WithSignature(signature []byte) (*SignedExtrinsic, error) {
+	if len(signature) != ecdsaSignatureLength {
+		return nil, errWrongSignatureLength
+	}
+	extra, _, err := tx.encodeExtensions()
+	if err != nil {
+		return nil, err
+	}
+	enc := scale.NewEncoder()
+	enc.WriteUint8(signedBit | extrinsicVersion4)
+	if index, ok := tx.meta.getVariantIndex(tx.meta.addressType, "Id"); ok {
+		enc.WriteUint8(index) // MultiAddress::Id
+	}
+	enc.WriteBytes(tx.Signer)
+	index, ok := tx.meta.getVariantIndex(tx.meta.signatureType, "Ecdsa")
+	if !ok {
+		return nil, errors.New("ecdsa signature is not supported")
+	}
+	enc.WriteUint8(index) // MultiSignature::Ecdsa
+	enc.WriteBytes(signature)
+	enc.WriteBytes(extra)
+	enc.WriteBytes(tx.Call)
+
+	data := scale.EncodeVecBytes(enc.Bytes())
+	return &SignedExtrinsic{
+		Data:  data,
+		Hash:  common.ToHex(blake2b256(data)),
+		Nonce: tx.Nonce,
+	}, nil
+}
+
+// encodeMortalEra encode mortal era which begins at current block
+func encodeMortalEra(period, current uint64) []byte {
+	if period < 4 {
+		period = 4
+	}
+	if period > 1<<16 {
+		period = 1 << 16
+	}
+	period = 1 << bits.Len64(period-1) // next power of two
+	phase := current % period
+	quantizeFactor := period >> 12
+	if quantizeFactor == 0 {
+		quantizeFactor = 1
+	}
+	quantizedPhase := phase / quantizeFactor * quantizeFactor
+
+	trailingZeros := uint64(bits.TrailingZeros64(period))
+	low := trailingZeros - 1
+	if low < 1 {
+		low = 1
+	}
+	if low > 15 {
+		low = 15
+	}
+	encoded := uint16(low) | uint16(quantizedPhase/quantizeFactor)<<4
+	return []byte{byte(encoded), byte(encoded >> 8)}
+}
@@ -0,0 +1,597 @@
+package substrate
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/anyswap/CrossChain-Bridge/tokens/substrate/scale"
+)
+
+const (
+	metadataMagic     = 0x6174656d // "meta"
+	metadataVersion14 = 14
+)
+
+// type definition kinds of portable registry
+const (
+	typeDefComposite = iota
+	typeDefVariant
+	typeDefSequence
+	typeDefArray
+	typeDefTuple
+	typeDefPrimitive
+	typeDefCompact
+	typeDefBitSequence
+)
+
+// primitive kinds of portable registry
+const (
+	primitiveBool = iota
+	primitiveChar
+	primitiveStr
+	primitiveU8
+	primitiveU16
+	primitiveU32
+	primitiveU64
+	primitiveU128
+	primitiveU256
+	primitiveI8
+	primitiveI16
+	primitiveI32
+	primitiveI64
+	primitiveI128
+	primitiveI256
+)
+
+// storage entry hasher of blake2_128 concat key
+const hasherBlake2128Concat = 2
+
+var (
+	errUnsupportedMetadata = errors.New("unsupported metadata version, require v14")
+	errUnknownTypeID       = errors.New("unknown type id in metadata")
+)
+
+// Metadata runtime metadata (v14)
+type Metadata struct {
+	SpecVersion uint32
+
+	types   map[uint32]*portableType
+	pallets []*palletMetadata
+
+	extrinsicType    uint32
+	extrinsicVersion uint8
+	signedExtensions []*signedExtension
+
+	// type params of UncheckedExtrinsic
+	addressType   uint32
+	callType      uint32
+	signatureType uint32
+	extraType     uint32
+}
+
+type portableType struct {
+	ID     uint32
+	Path   []string
+	Params map[string]uint32
+
+	Kind      int
+	Fields    []*typeField   // composite
+	Variants  []*typeVariant // variant
+	Elem      uint32         // sequence, array, compact
+	Len       uint32         // array
+	Tuple     []uint32       // tuple
+	Primitive int            // primitive
+	BitStore  uint32         // bit sequence
+}
+
+type typeField struct {
+	Name     string
+	Type     uint32
+	TypeName string
+}
+
+type typeVariant struct {
+	Name   string
+	Index  uint8
+	Fields []*typeField
+}
+
+type palletMetadata struct {
+	Name      string
+	Index     uint8
+	Storage   map[string]*storageEntry
+	CallType  *uint32
+	EventType *uint32
+	Constants map[string]*palletConstant
+}
+
+type storageEntry struct {
+	Name      string
+	IsMap     bool
+	Hashers   []uint8
+	KeyType   uint32
+	ValueType uint32
+}
+
+type palletConstant struct {
+	Type  uint32
+	Value []byte
+}
+
+type signedExtension struct {
+	Identifier     string
+	Type           uint32
+	AdditionalType uint32
+}
+
+// DecodeMetadata decode runtime metadata v14
+func DecodeMetadata(data []byte) (*Metadata, error) {
+	d := scale.NewDecoder(data)
+	magic, err := d.ReadUint32()
+	if err != nil {
+		return nil, err
+	}
+	if magic != metadataMagic {
+		return nil, errors.New("wrong metadata magic number")
+	}
+	version, err := d.ReadUint8()
+	if err != nil {
+		return nil, err
+	}
+	if version != metadataVersion14 {
+		return nil, errUnsupportedMetadata
+	}
+	meta := &Metadata{types: make(map[uint32]*portableType)}
+	if err = meta.decodeTypes(d); err != nil {
+		return nil, fmt.Errorf("decode metadata types failed, %w", err)
+	}
+	if err = meta.decodePallets(d); err != nil {
+		return nil, fmt.Errorf("decode metadata pallets failed, %w", err)
+	}
+	if err = meta.decodeExtrinsic(d); err != nil {
+		return nil, fmt.Errorf("decode metadata extrinsic failed, %w", err)
+	}
+	return meta, nil
+}
+
+func (m *Metadata) decodeTypes(d *scale.Decoder) error {
+	count, err := d.ReadLength()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < count; i++ {
+		id, err := d.ReadCompactUint64()
+		if err != nil {
+			return err
+		}
+		ty, err := decodePortableType(d)
+		if err != nil {
+			return err
+		}
+		ty.ID = uint32(id)
+		m.types[ty.ID] = ty
+	}
+	return nil
+}
+
+func decodePortableType(d *scale.Decoder) (ty *portableType, err error) {
+	ty = &portableType{Params: make(map[string]uint32)}
+	if ty.Path, err = d.ReadStrings(); err != nil {
+		return nil, err
+	}
+	paramCount, err := d.ReadLength()
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < paramCount; i++ {
+		name, err := d.ReadString()
+		if err != nil {
+			return nil, err
+		}
+		paramType, hasType, err := readOptionCompact(d)
+		if err != nil {
+			return nil, err
+		}
+		if hasType {
+			ty.Params[name] = paramType
+		}
+	}
+	kind, err := d.ReadUint8()
+	if err != nil {
+		return nil, err
+	}
+	ty.Kind = int(kind)
+	switch ty.Kind {
+	case typeDefComposite:
+		ty.Fields, err = decodeTypeFields(d)
+	case typeDefVariant:
+		ty.Variants, err = decodeTypeVariants(d)
+	case typeDefSequence, typeDefCompact:
+		ty.Elem, err = readCompactUint32(d)
+	case typeDefArray:
+		if ty.Len, err = d.ReadUint32(); err == nil {
+			ty.Elem, err = readCompactUint32(d)
+		}
+	case typeDefTuple:
+		ty.Tuple, err = readCompactUint32s(d)
+	case typeDefPrimitive:
+		var primitive byte
+		primitive, err = d.ReadUint8()
+		ty.Primitive = int(primitive)
+	case typeDefBitSequence:
+		if ty.BitStore, err = readCompactUint32(d); err == nil {
+			_, err = readCompactUint32(d) // bit order type
+		}
+	default:
+		err = fmt.Errorf("unknown type definition kind %v", kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if _, err = d.ReadStrings(); err != nil { // docs
+		return nil, err
+	}
+	return ty, nil
+}
+
+func decodeTypeFields(d *scale.Decoder) ([]*typeField, error) {
+	count, err := d.ReadLength()
+	if err != nil {
+		return nil, err
+	}
+	fields := make([]*typeField, count)
+	for i := 0; i < count; i++ {
+		field := &typeField{}
+		if field.Name, err = readOptionString(d); err != nil {
+			return nil, err
+		}
+		if field.Type, err = readCompactUint32(d); err != nil {
+			return nil, err
+		}
+		if field.TypeName, err = readOptionString(d); err != nil {
+			return nil, err
+		}
+		if _, err = d.ReadStrings(); err != nil { // docs
+			return nil, err
+		}
+		fields[i] = field
+	}
+	return fields, nil
+}
+
+func decodeTypeVariants(d *scale.Decoder) ([]*typeVariant, error) {
+	count, err := d.ReadLength()
+	if err != nil {
+		return nil, err
+	}
+	variants := make([]*typeVariant, count)
+	for i := 0; i < count; i++ {
+		variant := &typeVariant{}
+		if variant.Name, err = d.ReadString(); err != nil {
+			return nil, err
+		}
+		if variant.Fields, err = decodeTypeFields(d); err != nil {
+			return nil, err
+		}
+		if variant.Index, err = d.ReadUint8(); err != nil {
+			return nil, err
+		}
+		if _, err = d.ReadStrings(); err != nil { // docs
+			return nil, err
+		}
+		variants[i] = variant
+	}
+	return variants, nil
+}
+
+func (m *Metadata) decodePallets(d *scale.Decoder) error {
+	count, err := d.ReadLength()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < count; i++ {
+		pallet := &palletMetadata{
+			Storage:   make(map[string]*storageEntry),
+			Constants: make(map[string]*palletConstant),
+		}
+		if pallet.Name, err = d.ReadString(); err != nil {
+			return err
+		}
+		if err = pallet.decodeStorage(d); err != nil {
+			return err
+		}
+		if pallet.CallType, err = readOptionType(d); err != nil {
+			return err
+		}
+		if pallet.EventType, err = readOptionType(d); err != nil {
+			return err
+		}
+		if err = pallet.decodeConstants(d); err != nil {
+			return err
+		}
+		if _, err = readOptionType(d); err != nil { // error type
+			return err
+		}
+		if pallet.Index, err = d.ReadUint8(); err != nil {
+			return err
+		}
+		m.pallets = append(m.pallets, pallet)
+	}
+	return nil
+}
+
+func (p *palletMetadata) decodeStorage(d *scale.Decoder) error {
+	hasStorage, err := d.ReadOption()
+	if err != nil || !hasStorage {
+		return err
+	}
+	if _, err = d.ReadString(); err != nil { // prefix
+		return err
+	}
+	count, err := d.ReadLength()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < count; i++ {
+		entry := &storageEntry{}
+		if entry.Name, err = d.ReadString(); err != nil {
+			return err
+		}
+		if _, err = d.ReadUint8(); err != nil { // modifier
+			return err
+		}
+		entryKind, err := d.ReadUint8()
+		if err != nil {
+			return err
+		}
+		switch entryKind {
+		case 0: // plain
+			entry.ValueType, err = readCompactUint32(d)
+		case 1: // map
+			entry.IsMap = true
+			if entry.Hashers, err = d.ReadVecBytes(); err != nil {
+				return err
+			}
+			if entry.KeyType, err = readCompactUint32(d); err != nil {
+				return err
+			}
+			entry.ValueType, err = readCompactUint32(d)
+		default:
+			err = fmt.Errorf("unknown storage entry type %v", entryKind)
+		}
+		if err != nil {
+			return err
+		}
+		if _, err = d.ReadVecBytes(); err != nil { // default
+			return err
+		}
+		if _, err = d.ReadStrings(); err != nil { // docs
+			return err
+		}
+		p.Storage[entry.Name] = entry
+	}
+	return nil
+}
+
+func (p *palletMetadata) decodeConstants(d *scale.Decoder) error {
+	count, err := d.ReadLength()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < count; i++ {
+		name, err := d.ReadString()
+		if err != nil {
+			return err
+		}
+		constant := &palletConstant{}
+		if constant.Type, err = readCompactUint32(d); err != nil {
+			return err
+		}
+		if constant.Value, err = d.ReadVecBytes(); err != nil {
+			return err
+		}
+		if _, err = d.ReadStrings(); err != nil { // docs
+			return err
+		}
+		p.Constants[name] = constant
+	}
+	return nil
+}
+
+func (m *Metadata) decodeExtrinsic(d *scale.Decoder) (err error) {
+	if m.extrinsicType, err = readCompactUint32(d); err != nil {
+		return err
+	}
+	if m.extrinsicVersion, err = d.ReadUint8(); err != nil {
+		return err
+	}
+	count, err := d.ReadLength()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < count; i++ {
+		ext := &signedExtension{}
+		if ext.Identifier, err = d.ReadString(); err != nil {
+			return err
+		}
+		if ext.Type, err = readCompactUint32(d); err != nil {
+			return err
+		}
+		if ext.AdditionalType, err = readCompactUint32(d); err != nil {
+			return err
+		}
+		m.signedExtensions = append(m.signedExtensions, ext)
+	}
+	extrinsicType, err := m.getType(m.extrinsicType)
+	if err != nil {
+		return err
+	}
+	for name, target := range map[string]*uint32{
+		"Address":   &m.addressType,
+		"Call":      &m.callType,
+		"Signature": &m.signatureType,
+		"Extra":     &m.extraType,
+	} {
+		paramType, exist := extrinsicType.Params[name]
+		if !exist {
+			return fmt.Errorf("extrinsic type has no '%v' param", name)
+		}
+		*target = paramType
+	}
+	return nil
+}
+
+func (m *Metadata) getType(id uint32) (*portableType, error) {
+	ty, exist := m.types[id]
+	if !exist {
+		return nil, fmt.Errorf("%w: %v", errUnknownTypeID, id)
+	}
+	return ty, nil
+}
+
+func (m *Metadata) getPallet(name string) (*palletMetadata, error) {
+	for _, pallet := range m.pallets {
+		if pallet.Name == name {
+			return pallet, nil
+		}
+	}
+	return nil, fmt.Errorf("pallet '%v' not found in metadata", name)
+}
+
+// getCallIndex get [pallet index, call index] of call
+func (m *Metadata) getCallIndex(palletName, callName string) (*typeVariant, []byte, error) {
+	pallet, err := m.getPallet(palletName)
+	if err != nil {
+		return nil, nil, err
+	}
+	if pallet.CallType == nil {
+		return nil, nil, fmt.Errorf("pallet '%v' has no calls", palletName)
+	}
+	callType, err := m.getType(*pallet.CallType)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, variant := range callType.Variants {
+		if variant.Name == callName {
+			return variant, []byte{pallet.Index, variant.Index}, nil
+		}
+	}
+	return nil, nil, fmt.Errorf("call '%v.%v' not found in metadata", palletName, callName)
+}
+
+func (m *Metadata) getStorageEntry(palletName, entryName string) (*storageEntry, error) {
+	pallet, err := m.getPallet(palletName)
+	if err != nil {
+		return nil, err
+	}
+	entry, exist := pallet.Storage[entryName]
+	if !exist {
+		return nil, fmt.Errorf("storage '%v.%v' not found in metadata", palletName, entryName)
+	}
+	return entry, nil
+}
+
+// getConstant get decoded constant value
+func (m *Metadata) getConstant(palletName, constantName string) (interface{}, error) {
+	pallet, err := m.getPallet(palletName)
+	if err != nil {
+		return nil, err
+	}
+	constant, exist := pallet.Constants[constantName]
+	if !exist {
+		return nil, fmt.Errorf("constant '%v.%v' not found in metadata", palletName, constantName)
+	}
+	return m.DecodeValue(constant.Type, constant.Value)
+}
+
+// getVariantIndex get variant index by name of a variant type
+func (m *Metadata) getVariantIndex(typeID uint32, name string) (uint8, bool) {
+	ty, err := m.getType(typeID)
+	if err != nil || ty.Kind != typeDefVariant {
+		return 0, false
+	}
+	for _, variant := range ty.Variants {
+		if variant.Name == name {
+			return variant.Index, true
+		}
+	}
+	return 0, false
+}
+
+// isEmptyType returns if type is encoded with zero size
+func (m *Metadata) isEmptyType(typeID uint32) bool {
+	ty, err := m.getType(typeID)
+	if err != nil {
+		return false
+	}
+	switch ty.Kind {
+	case typeDefComposite:
+		for _, field := range ty.Fields {
+			if !m.isEmptyType(field.Type) {
+				return false
+			}
+		}
+		return true
+	case typeDefTuple:
+		for _, elem := range ty.Tuple {
+			if !m.isEmptyType(elem) {
+				return false
+			}
+		}
+		return true
+	case typeDefArray:
+		return ty.Len == 0 || m.isEmptyType(ty.Elem)
+	default:
+		return false
+	}
+}
+
+func readCompactUint32(d *scale.Decoder) (uint32, error) {
+	value, err := d.ReadCompactUint64()
+	if err != nil {
+		return 0, err
+	}
+	if value > uint64(^uint32(0)) {
+		return 0, errors.New("compact value overflows uint32")
+	}
+	return uint32(value), nil
+}
+
+func readCompactUint32s(d *scale.Decoder) ([]uint32, error) {
+	count, err := d.ReadLength()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]uint32, count)
+	for i := 0; i < count; i++ {
+		if result[i], err = readCompactUint32(d); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func readOptionCompact(d *scale.Decoder) (uint32, bool, error) {
+	hasValue, err := d.ReadOption()
+	if err != nil || !hasValue {
+		return 0, false, err
+	}
+	value, err := readCompactUint32(d)
+	return value, err == nil, err
+}
+
+func readOptionType(d *scale.Decoder) (*uint32, error) {
+	value, hasValue, err := readOptionCompact(d)
+	if err != nil || !hasValue {
+		return nil, err
+	}
+	return &value, nil
+}
+
+func readOptionString(d *scale.Decoder) (string, error) {
+	hasValue, err := d.ReadOption()
+	if err != nil || !hasValue {
+		return "", err
+	}
+	return d.ReadString()
+}
@@ -0,0 +1,81 @@
+package substrate
+
+import (
+	"encoding/json"
+)
+
+const (
+	netPolkadot = "polkadot"
+	netKusama   = "kusama"
+	netWestend  = "westend"
+	netCustom   = "custom"
+)
+
+// NetParams substrate network params
+type NetParams struct {
+	Name        string
+	Symbol      string
+	Decimals    uint8
+	SS58Prefix  uint16
+	GenesisHash string
+}
+
+var networks = map[string]*NetParams{
+	netPolkadot: {
+		Name:        netPolkadot,
+		Symbol:      "DOT",
+		Decimals:    10,
+		SS58Prefix:  0,
+		GenesisHash: "0x91b171bb158e2d3848fa23a9f1c25182fb8e20313b2c1eb49219da7a70ce90c3",
+	},
+	netKusama: {
+		Name:        netKusama,
+		Symbol:      "KSM",
+		Decimals:    12,
+		SS58Prefix:  2,
+		GenesisHash: "0xb0a8d493285c2df73290dfb7e61f870f17b41801197a149ca93654499ea3dafe",
+	},
+	netWestend: {
+		Name:        netWestend,
+		Symbol:      "WND",
+		Decimals:    12,
+		SS58Prefix:  42,
+		GenesisHash: "0xe143f23803ac50e8f6f8e62695d1ce9e4e1d68aa36c1cd2cfd15340213f3423e",
+	},
+	netCustom: {
+		Name: netCustom,
+	},
+}
+
+// SystemProperties result of system_properties
+type SystemProperties struct {
+	SS58Format    uint16          `json:"ss58Format"`
+	TokenDecimals json.RawMessage `json:"tokenDecimals"`
+	TokenSymbol   json.RawMessage `json:"tokenSymbol"`
+}
+
+// token decimals and symbol are arrays if the chain has multiple tokens,
+// the first one is the native token.
+func (p *SystemProperties) getTokenDecimals() uint8 {
+	var decimals uint8
+	if json.Unmarshal(p.TokenDecimals, &decimals) == nil {
+		return decimals
+	}
+	var decimalsSlice []uint8
+	if json.Unmarshal(p.TokenDecimals, &decimalsSlice) == nil && len(decimalsSlice) > 0 {
+		return decimalsSlice[0]
+	}
+	return 0
+}
+
+func (p *SystemProperties) getTokenSymbol() string {
+	var symbol string
+	if json.Unmarshal(p.TokenSymbol, &symbol) == nil {
+		return symbol
+	}
+	var symbolSlice []string
+	if json.Unmarshal(p.TokenSymbol, &symbolSlice) == nil && len(symbolSlice) > 0 {
+		return symbolSlice[0]
+	}
+	return ""
+}
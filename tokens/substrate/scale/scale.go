@@ -0,0 +1,350 @@
+// Package scale implements the SCALE codec used by substrate based blockchains.
+package scale
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// errors
+var (
+	ErrUnexpectedEOF  = errors.New("scale: unexpected end of data")
+	ErrInvalidCompact = errors.New("scale: invalid compact encoding")
+	ErrInvalidBool    = errors.New("scale: invalid bool encoding")
+	ErrInvalidOption  = errors.New("scale: invalid option encoding")
+	ErrNegativeValue  = errors.New("scale: negative value")
+)
+
+var (
+	maxCompactSingle = uint64(1<<6 - 1)
+	maxCompactTwo    = uint64(1<<14 - 1)
+	maxCompactFour   = uint64(1<<30 - 1)
+)
+
+// Decoder scale decoder
+type Decoder struct {
+	data []byte
+	pos  int
+}
+
+// NewDecoder new decoder
+func NewDecoder(data []byte) *Decoder {
+	return &Decoder{data: data}
+}
+
+// Remaining returns the count of undecoded bytes
+func (d *Decoder) Remaining() int {
+	return len(d.data) - d.pos
+}
+
+// Offset returns the current decoding offset
+func (d *Decoder) Offset() int {
+	return d.pos
+}
+
+// ReadBytes read fixed length bytes
+func (d *Decoder) ReadBytes(length int) ([]byte, error) {
+	if length < 0 || d.Remaining() < length {
+		return nil, ErrUnexpectedEOF
+	}
+	result := d.data[d.pos : d.pos+length]
+	d.pos += length
+	return result, nil
+}
+
+// ReadUint8 read one byte
+func (d *Decoder) ReadUint8() (byte, error) {
+	data, err := d.ReadBytes(1)
+	if err != nil {
+		return 0, err
+	}
+	return data[0], nil
+}
+
+// ReadBool read bool
+func (d *Decoder) ReadBool() (bool, error) {
+	b, err := d.ReadUint8()
+	if err != nil {
+		return false, err
+	}
+	switch b {
+	case 0:
+		return false, nil
+	case 1:
+		return true, nil
+	default:
+		return false, ErrInvalidBool
+	}
+}
+
+// ReadOption read option flag, returns if the option has some value
+func (d *Decoder) ReadOption() (bool, error) {
+	b, err := d.ReadUint8()
+	if err != nil {
+		return false, err
+	}
+	switch b {
+	case 0:
+		return false, nil
+	case 1:
+		return true, nil
+	default:
+		return false, ErrInvalidOption
+	}
+}
+
+// ReadUint16 read little endian uint16
+func (d *Decoder) ReadUint16() (uint16, error) {
+	data, err := d.ReadBytes(2)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint16(data), nil
+}
+
+// ReadUint32 read little endian uint32
+func (d *Decoder) ReadUint32() (uint32, error) {
+	data, err := d.ReadBytes(4)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint32(data), nil
+}
+
+// ReadUint64 read little endian uint64
+func (d *Decoder) ReadUint64() (uint64, error) {
+	data, err := d.ReadBytes(8)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint64(data), nil
+}
+
+// ReadBigUint read little endian unsigned integer of specified bytes length
+func (d *Decoder) ReadBigUint(length int) (*big.Int, error) {
+	data, err := d.ReadBytes(length)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(reverseBytes(data)), nil
+}
+
+// ReadCompact read compact encoded unsigned integer
+func (d *Decoder) ReadCompact() (*big.Int, error) {
+	b, err := d.ReadUint8()
+	if err != nil {
+		return nil, err
+	}
+	switch b & 0x03 {
+	case 0x00:
+		return big.NewInt(int64(b >> 2)), nil
+	case 0x01:
+		next, err := d.ReadUint8()
+		if err != nil {
+			return nil, err
+		}
+		value := (uint64(next)<<8 | uint64(b)) >> 2
+		if value <= maxCompactSingle {
+			return nil, ErrInvalidCompact
+		}
+		return new(big.Int).SetUint64(value), nil
+	case 0x02:
+		data, err := d.ReadBytes(3)
+		if err != nil {
+			return nil, err
+		}
+		value := (uint64(data[2])<<24 | uint64(data[1])<<16 | uint64(data[0])<<8 | uint64(b)) >> 2
+		if value <= maxCompactTwo {
+			return nil, ErrInvalidCompact
+		}
+		return new(big.Int).SetUint64(value), nil
+	default:
+		length := int(b>>2) + 4
+		value, err := d.ReadBigUint(length)
+		if err != nil {
+			return nil, err
+		}
+		if value.BitLen() <= (length-1)*8 || (length == 4 && value.Uint64() <= maxCompactFour) {
+			return nil, ErrInvalidCompact
+		}
+		return value, nil
+	}
+}
+
+// ReadCompactUint64 read compact encoded integer which fits in uint64
+func (d *Decoder) ReadCompactUint64() (uint64, error) {
+	value, err := d.ReadCompact()
+	if err != nil {
+		return 0, err
+	}
+	if !value.IsUint64() {
+		return 0, fmt.Errorf("scale: compact value %v overflows uint64", value)
+	}
+	return value.Uint64(), nil
+}
+
+// ReadLength read compact encoded length prefix
+func (d *Decoder) ReadLength() (int, error) {
+	length, err := d.ReadCompactUint64()
+	if err != nil {
+		return 0, err
+	}
+	if length > uint64(d.Remaining()) {
+		return 0, ErrUnexpectedEOF
+	}
+	return int(length), nil
+}
+
+// ReadVecBytes read length prefixed bytes
+func (d *Decoder) ReadVecBytes() ([]byte, error) {
+	length, err := d.ReadLength()
+	if err != nil {
+		return nil, err
+	}
+	return d.ReadBytes(length)
+}
+
+// ReadString read length prefixed string
+func (d *Decoder) ReadString() (string, error) {
+	data, err := d.ReadVecBytes()
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// ReadStrings read vector of strings
+func (d *Decoder) ReadStrings() ([]string, error) {
+	count, err := d.ReadLength()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]string, count)
+	for i := 0; i < count; i++ {
+		if result[i], err = d.ReadString(); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// Encoder scale encoder
+type Encoder struct {
+	data []byte
+}
+
+// NewEncoder new encoder
+func NewEncoder() *Encoder {
+	return &Encoder{}
+}
+
+// Bytes returns the encoded bytes
+func (e *Encoder) Bytes() []byte {
+	return e.data
+}
+
+// WriteBytes write fixed length bytes
+func (e *Encoder) WriteBytes(data []byte) *Encoder {
+	e.data = append(e.data, data...)
+	return e
+}
+
+// WriteUint8 write one byte
+func (e *Encoder) WriteUint8(b byte) *Encoder {
+	e.data = append(e.data, b)
+	return e
+}
+
+// WriteBool write bool
+func (e *Encoder) WriteBool(b bool) *Encoder {
+	if b {
+		return e.WriteUint8(1)
+	}
+	return e.WriteUint8(0)
+}
+
+// WriteUint16 write little endian uint16
+func (e *Encoder) WriteUint16(value uint16) *Encoder {
+	var buf [2]byte
+	binary.LittleEndian.PutUint16(buf[:], value)
+	return e.WriteBytes(buf[:])
+}
+
+// WriteUint32 write little endian uint32
+func (e *Encoder) WriteUint32(value uint32) *Encoder {
+	var buf [4]byte
+	binary.LittleEndian.PutUint32(buf[:], value)
+	return e.WriteBytes(buf[:])
+}
+
+// WriteUint64 write little endian uint64
+func (e *Encoder) WriteUint64(value uint64) *Encoder {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], value)
+	return e.WriteBytes(buf[:])
+}
+
+// WriteCompact write compact encoded unsigned integer
+func (e *Encoder) WriteCompact(value *big.Int) error {
+	if value.Sign() < 0 {
+		return ErrNegativeValue
+	}
+	if value.IsUint64() {
+		e.WriteCompactUint64(value.Uint64())
+		return nil
+	}
+	data := reverseBytes(value.Bytes())
+	if len(data) > 67 {
+		return fmt.Errorf("scale: compact value %v is too large", value)
+	}
+	e.WriteUint8(byte(len(data)-4)<<2 | 0x03)
+	e.WriteBytes(data)
+	return nil
+}
+
+// WriteCompactUint64 write compact encoded uint64
+func (e *Encoder) WriteCompactUint64(value uint64) *Encoder {
+	switch {
+	case value <= maxCompactSingle:
+		return e.WriteUint8(byte(value << 2))
+	case value <= maxCompactTwo:
+		return e.WriteUint16(uint16(value<<2 | 0x01))
+	case value <= maxCompactFour:
+		return e.WriteUint32(uint32(value<<2 | 0x02))
+	default:
+		var buf [8]byte
+		binary.LittleEndian.PutUint64(buf[:], value)
+		length := 8
+		for length > 4 && buf[length-1] == 0 {
+			length--
+		}
+		e.WriteUint8(byte(length-4)<<2 | 0x03)
+		return e.WriteBytes(buf[:length])
+	}
+}
+
+// WriteVecBytes write length prefixed bytes
+func (e *Encoder) WriteVecBytes(data []byte) *Encoder {
+	e.WriteCompactUint64(uint64(len(data)))
+	return e.WriteBytes(data)
+}
+
+// EncodeCompact encode uint64 in compact format
+func EncodeCompact(value uint64) []byte {
+	return NewEncoder().WriteCompactUint64(value).Bytes()
+}
+
+// EncodeVecBytes encode length prefixed bytes
+func EncodeVecBytes(data []byte) []byte {
+	return NewEncoder().WriteVecBytes(data).Bytes()
+}
+
+func reverseBytes(data []byte) []byte {
+	result := make([]byte, len(data))
+	for i, b := range data {
+		result[len(data)-1-i] = b
+	}
+	return result
+}
@@ -0,0 +1,75 @@
+package scale
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"testing"
+)
+
+var compactTestCases = []struct {
+	value   string
+	encoded string
+}{
+	{"0", "00"},
+	{"1", "04"},
+	{"63", "fc"},
+	{"64", "0101"},
+	{"16383", "fdff"},
+	{"16384", "02000100"},
+	{"1073741823", "feffffff"},
+	{"1073741824", "0300000040"},
+	{"4294967295", "03ffffffff"},
+	{"4294967296", "070000000001"},
+	{"18446744073709551615", "13ffffffffffffffff"},
+	{"340282366920938463463374607431768211455", "33ffffffffffffffffffffffffffffffff"},
+}
+
+func TestCompact(t *testing.T) {
+	for _, tc := range compactTestCases {
+		value, _ := new(big.Int).SetString(tc.value, 10)
+		want, _ := hex.DecodeString(tc.encoded)
+
+		enc := NewEncoder()
+		if err := enc.WriteCompact(value); err != nil {
+			t.Fatalf("encode compact %v failed: %v", tc.value, err)
+		}
+		if !bytes.Equal(enc.Bytes(), want) {
+			t.Errorf("encode compact %v mismatch: have %x want %x", tc.value, enc.Bytes(), want)
+		}
+
+		dec := NewDecoder(want)
+		decoded, err := dec.ReadCompact()
+		if err != nil {
+			t.Fatalf("decode compact %v failed: %v", tc.encoded, err)
+		}
+		if decoded.Cmp(value) != 0 || dec.Remaining() != 0 {
+			t.Errorf("decode compact %v mismatch: have %v want %v", tc.encoded, decoded, tc.value)
+		}
+	}
+}
+
+func TestNonCanonicalCompact(t *testing.T) {
+	for _, encoded := range []string{"0100", "02000000", "03ffffff3f", "070000000100"} {
+		data, _ := hex.DecodeString(encoded)
+		if _, err := NewDecoder(data).ReadCompact(); err == nil {
+			t.Errorf("decode non canonical compact %v should fail", encoded)
+		}
+	}
+}
+
+func TestVecBytes(t *testing.T) {
+	data := []byte("SWAPTO:0x1111111111111111111111111111111111111111")
+	encoded := EncodeVecBytes(data)
+	dec := NewDecoder(encoded)
+	decoded, err := dec.ReadVecBytes()
+	if err != nil {
+		t.Fatalf("decode vec bytes failed: %v", err)
+	}
+	if !bytes.Equal(decoded, data) || dec.Remaining() != 0 {
+		t.Errorf("decode vec bytes mismatch: have %x want %x", decoded, data)
+	}
+	if _, err = NewDecoder(encoded[:len(encoded)-1]).ReadVecBytes(); err == nil {
+		t.Errorf("decode truncated vec bytes should fail")
+	}
+}
@@ -1,10 +1,11 @@
-package colx
+package substrate
 
 import (
 	"fmt"
 	"time"
 
 	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
 	"github.com/anyswap/CrossChain-Bridge/tokens/tools"
 )
 
@@ -44,10 +45,8 @@ func (b *Bridge) getStartAndLatestHeight() (start, latest uint64) {
 	return start, latest
 }
 
-// StartChainTransactionScanJob scan job
+// StartChainTransactionScanJob scan job, only finalized blocks are scanned
 func (b *Bridge) StartChainTransactionScanJob() {
-	go b.StartPoolTransactionScanJob()
-
 	chainName := b.ChainConfig.BlockChain
 	log.Infof("[scanchain] start %v scan chain job", chainName)
 
@@ -55,9 +54,6 @@ func (b *Bridge) StartChainTransactionScanJob() {
 	_ = tools.UpdateLatestScanInfo(b.IsSrc, start)
 	log.Infof("[scanchain] start %v scan chain loop from %v latest=%v", chainName, start, latest)
 
-	chainCfg := b.GetChainConfig()
-	confirmations := *chainCfg.Confirmations
-
 	stable := start
 	errorSubject := fmt.Sprintf("[scanchain] get %v block failed", chainName)
 	scanSubject := fmt.Sprintf("[scanchain] scanned %v block", chainName)
@@ -74,23 +70,62 @@ func (b *Bridge) StartChainTransactionScanJob() {
 				h++
 				continue
 			}
-			txids, err := b.GetBlockTxids(blockHash)
+			count, err := b.scanBlock(h, blockHash)
 			if err != nil {
 				log.Error(errorSubject, "height", h, "blockHash", blockHash, "err", err)
 				time.Sleep(retryIntervalInScanJob)
 				continue
 			}
-			for _, txid := range txids {
-				b.processTransaction(txid)
-			}
 			scannedBlocks.CacheScannedBlock(blockHash, h)
-			log.Info(scanSubject, "blockHash", blockHash, "height", h, "txs", len(txids))
+			log.Info(scanSubject, "blockHash", blockHash, "height", h, "swapins", count)
 			h++
 		}
-		if stable+confirmations < latest {
-			stable = latest - confirmations
+		if stable < latest {
+			stable = latest
 			_ = tools.UpdateLatestScanInfo(b.IsSrc, stable)
 		}
 		time.Sleep(restIntervalInScanJob)
 	}
 }
+
+// scanBlock find swapins by `balances.Transfer` events to deposit addresses
+func (b *Bridge) scanBlock(height uint64, blockHash string) (count int, err error) {
+	meta, err := b.GetMetadata(blockHash)
+	if err != nil {
+		return 0, err
+	}
+	events, err := b.GetEvents(blockHash, meta)
+	if err != nil {
+		return 0, err
+	}
+	processed := make(map[string]struct{})
+	for _, event := range events {
+		if event.ExtrinsicIndex == nil || event.Pallet != "Balances" || event.Name != "Transfer" {
+			continue
+		}
+		to, ok := getAccountID(getField(event.Fields, "to"))
+		if !ok {
+			continue
+		}
+		_, pairIDs := tokens.FindTokenConfig(b.EncodeAddress(to), true)
+		for _, pairID := range pairIDs {
+			txid := MakeExtrinsicID(height, *event.ExtrinsicIndex)
+			key := txid + ":" + pairID
+			if _, exist := processed[key]; exist {
+				continue
+			}
+			processed[key] = struct{}{}
+			b.processSwapin(txid, pairID)
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (b *Bridge) processSwapin(txid, pairID string) {
+	if tools.IsSwapExist(txid, pairID, "", true) {
+		return
+	}
+	swapInfo, err := b.verifySwapinTx(pairID, txid, true)
+	tools.RegisterSwapin(txid, []*tokens.TxSwapInfo{swapInfo}, []error{err})
+}
@@ -0,0 +1,59 @@
+package substrate
+
+import (
+	"errors"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/params"
+	"github.com/anyswap/CrossChain-Bridge/rpc/client"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+var errEmptyURLs = errors.New("empty URLs")
+
+// SendTransaction send signed tx
+func (b *Bridge) SendTransaction(signedTx interface{}) (txHash string, err error) {
+	tx, ok := signedTx.(*SignedExtrinsic)
+	if !ok {
+		return "", tokens.ErrWrongRawTx
+	}
+	submitHeight, _ := b.GetBestBlockNumber()
+
+	hexData := common.ToHex(tx.Data)
+	gateway := b.GatewayConfig
+	txHash, _ = submitExtrinsic(hexData, gateway.APIAddressExt)
+	txHash2, err := submitExtrinsic(hexData, gateway.APIAddress)
+	if txHash == "" {
+		txHash = txHash2
+	}
+	if txHash == "" {
+		return "", err
+	}
+	b.trackTransaction(txHash, submitHeight)
+	log.Info("Bridge send tx", "hash", txHash, "nonce", tx.Nonce, "submitHeight", submitHeight)
+	return txHash, nil
+}
+
+func submitExtrinsic(hexData string, urls []string) (txHash string, err error) {
+	if len(urls) == 0 {
+		return "", errEmptyURLs
+	}
+	logFunc := log.GetPrintFuncOr(params.IsDebugMode, log.Info, log.Trace)
+	var result string
+	for _, url := range urls {
+		err = client.RPCPost(&result, url, "author_submitExtrinsic", hexData)
+		if err != nil {
+			logFunc("call author_submitExtrinsic failed", "txHash", result, "url", url, "err", err)
+			continue
+		}
+		logFunc("call author_submitExtrinsic success", "txHash", result, "url", url)
+		if txHash == "" {
+			txHash = result
+		}
+	}
+	if txHash != "" {
+		return txHash, nil
+	}
+	return "", wrapRPCQueryError(err, "author_submitExtrinsic")
+}
@@ -0,0 +1,147 @@
+package substrate
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/dcrm"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/signer"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tools/crypto"
+)
+
+func (b *Bridge) verifyTransactionWithArgs(rawTx interface{}, args *tokens.BuildTxArgs) (*UnsignedExtrinsic, error) {
+	tx, ok := rawTx.(*UnsignedExtrinsic)
+	if !ok {
+		return nil, tokens.ErrWrongRawTx
+	}
+	tokenCfg := b.GetTokenConfig(args.PairID)
+	if tokenCfg == nil {
+		return nil, fmt.Errorf("[sign] verify tx with unknown pairID '%v'", args.PairID)
+	}
+	dcrmAccount, err := b.DecodeAddress(tokenCfg.DcrmAddress)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(tx.Signer, dcrmAccount) {
+		return nil, fmt.Errorf("[sign] verify tx signer failed")
+	}
+	return tx, nil
+}
+
+// DcrmSignTransaction dcrm sign raw tx
+func (b *Bridge) DcrmSignTransaction(rawTx interface{}, args *tokens.BuildTxArgs) (signTx interface{}, txHash string, err error) {
+	tx, err := b.verifyTransactionWithArgs(rawTx, args)
+	if err != nil {
+		return nil, "", err
+	}
+	sigHash, err := tx.SigningHash()
+	if err != nil {
+		return nil, "", err
+	}
+	msgHash := common.ToHex(sigHash)
+	jsondata, _ := json.Marshal(args)
+	msgContext := string(jsondata)
+
+	log.Info(b.ChainConfig.BlockChain+" DcrmSignTransaction start", "msghash", msgHash, "txid", args.SwapID)
+	rsv, err := signer.SignOne(args.PairID, b.IsSrc, b.GetDcrmPublicKey(args.PairID), msgHash, msgContext)
+	if err != nil {
+		return nil, "", err
+	}
+	log.Info(b.ChainConfig.BlockChain+" DcrmSignTransaction finished", "msghash", msgHash, "txid", args.SwapID)
+
+	log.Trace(b.ChainConfig.BlockChain+" DcrmSignTransaction get rsv success", "txid", args.SwapID, "rsv", rsv)
+	signature := common.FromHex(rsv)
+	if len(signature) != crypto.SignatureLength {
+		log.Error("DcrmSignTransaction wrong length of signature")
+		return nil, "", errors.New("wrong signature of txid " + args.SwapID)
+	}
+
+	signedTx, err := signTxWithSignature(tx, sigHash, signature)
+	if err != nil {
+		return nil, "", err
+	}
+	log.Info(b.ChainConfig.BlockChain+" DcrmSignTransaction success", "txid", args.SwapID, "txhash", signedTx.Hash, "nonce", signedTx.Nonce)
+	return signedTx, signedTx.Hash, nil
+}
+
+// signTxWithSignature fix recovery id of signature and build signed extrinsic
+func signTxWithSignature(tx *UnsignedExtrinsic, sigHash, signature []byte) (*SignedExtrinsic, error) {
+	vPos := crypto.SignatureLength - 1
+	for i := 0; i < 2; i++ {
+		pubkey, err := crypto.Ecrecover(sigHash, signature)
+		if err == nil {
+			accountID, errp := EcdsaPublicKeyToAccountID(pubkey)
+			if errp == nil && bytes.Equal(accountID, tx.Signer) {
+				return tx.WithSignature(signature)
+			}
+		}
+		signature[vPos] ^= 0x1 // v can only be 0 or 1
+	}
+	return nil, errors.New("wrong signer account")
+}
+
+// SignTransaction sign tx with pairID
+func (b *Bridge) SignTransaction(rawTx interface{}, pairID string) (signTx interface{}, txHash string, err error) {
+	privKey := b.GetTokenConfig(pairID).GetDcrmAddressPrivateKey()
+	return b.SignTransactionWithPrivateKey(rawTx, privKey)
+}
+
+// SignTransactionWithPrivateKey sign tx with ECDSA private key
+func (b *Bridge) SignTransactionWithPrivateKey(rawTx interface{}, privKey *ecdsa.PrivateKey) (signTx interface{}, txHash string, err error) {
+	tx, ok := rawTx.(*UnsignedExtrinsic)
+	if !ok {
+		return nil, "", tokens.ErrWrongRawTx
+	}
+	if privKey == nil {
+		return nil, "", errors.New("empty private key")
+	}
+	sigHash, err := tx.SigningHash()
+	if err != nil {
+		return nil, "", err
+	}
+	signature, err := crypto.Sign(sigHash, privKey)
+	if err != nil {
+		return nil, "", fmt.Errorf("sign tx failed, %w", err)
+	}
+	signedTx, err := signTxWithSignature(tx, sigHash, signature)
+	if err != nil {
+		return nil, "", err
+	}
+	log.Info(b.ChainConfig.BlockChain+" SignTransaction success", "txhash", signedTx.Hash, "nonce", signedTx.Nonce)
+	return signedTx, signedTx.Hash, nil
+}
+
+// GetSignedTxHashOfKeyID get signed tx hash by keyID (called by oracle)
+func (b *Bridge) GetSignedTxHashOfKeyID(keyID, pairID string, rawTx interface{}) (txHash string, err error) {
+	tx, ok := rawTx.(*UnsignedExtrinsic)
+	if !ok {
+		return "", errors.New("wrong raw tx of keyID " + keyID)
+	}
+	rsvs, err := dcrm.GetSignStatusByKeyID(keyID)
+	if err != nil {
+		return "", err
+	}
+	if len(rsvs) != 1 {
+		return "", errors.New("wrong number of rsvs of keyID " + keyID)
+	}
+
+	signature := common.FromHex(rsvs[0])
+	if len(signature) != crypto.SignatureLength {
+		return "", errors.New("wrong signature of keyID " + keyID)
+	}
+	sigHash, err := tx.SigningHash()
+	if err != nil {
+		return "", err
+	}
+	signedTx, err := signTxWithSignature(tx, sigHash, signature)
+	if err != nil {
+		return "", err
+	}
+	return signedTx.Hash, nil
+}
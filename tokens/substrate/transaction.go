@@ -0,0 +1,286 @@
+package substrate
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+const (
+	// search depth back from the best block for untracked tx hash
+	untrackedSearchDepth = 2 * eraPeriod
+	// max blocks to search in one locating call
+	maxSearchBlocksPerCall = uint64(20)
+	// max extrinsics to search for the timestamp inherent
+	maxInherentExtrinsics = 3
+)
+
+// Transaction extrinsic located in block
+type Transaction struct {
+	Hash        string
+	ExtrinsicID string
+	BlockNumber uint64
+	BlockHash   string
+	BlockTime   uint64
+	Index       uint64
+	Extrinsic   *Extrinsic
+	Events      []*EventRecord
+	Success     bool
+}
+
+// PendingTransaction extrinsic in tx pool
+type PendingTransaction struct {
+	Hash string
+}
+
+// Receipt extrinsic result, implements tokens.TxResultReceipt
+type Receipt struct {
+	ExtrinsicID string
+	Success     bool
+}
+
+// IsTxFailed returns if extrinsic is failed
+func (r *Receipt) IsTxFailed() bool {
+	return !r.Success
+}
+
+type txLocation struct {
+	blockNumber uint64
+	blockHash   string
+	index       uint64
+}
+
+type txSearchRange struct {
+	next     uint64
+	end      uint64
+	location *txLocation
+}
+
+// MakeExtrinsicID make extrinsic ID in the form of `blockNumber-index`
+func MakeExtrinsicID(blockNumber, index uint64) string {
+	return fmt.Sprintf("%d-%d", blockNumber, index)
+}
+
+// ParseExtrinsicID parse canonical extrinsic ID in the form of `blockNumber-index`
+func ParseExtrinsicID(extrinsicID string) (blockNumber, index uint64, ok bool) {
+	parts := strings.Split(extrinsicID, "-")
+	if len(parts) != 2 {
+		return 0, 0, false
+	}
+	blockNumber, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	index, err = strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	if MakeExtrinsicID(blockNumber, index) != extrinsicID {
+		return 0, 0, false // not canonical
+	}
+	return blockNumber, index, true
+}
+
+// GetTransaction get tx by extrinsic ID or extrinsic hash
+func (b *Bridge) GetTransaction(txHash string) (interface{}, error) {
+	tx, err := b.GetTransactionByHash(txHash)
+	if err == nil {
+		return tx, nil
+	}
+	if _, _, isExtrinsicID := ParseExtrinsicID(txHash); isExtrinsicID {
+		return nil, err
+	}
+	pendings, errp := b.GetPendingExtrinsics()
+	if errp == nil {
+		for _, pending := range pendings {
+			if strings.EqualFold(common.ToHex(blake2b256(pending)), txHash) {
+				return &PendingTransaction{Hash: txHash}, nil
+			}
+		}
+	}
+	return nil, err
+}
+
+// GetTransactionByHash get on chain tx by extrinsic ID or extrinsic hash
+func (b *Bridge) GetTransactionByHash(txHash string) (*Transaction, error) {
+	if blockNumber, index, ok := ParseExtrinsicID(txHash); ok {
+		return b.GetTransactionAt(blockNumber, index)
+	}
+	location, err := b.locateTransaction(txHash)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := b.GetTransactionAt(location.blockNumber, location.index)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(tx.Hash, txHash) || tx.BlockHash != location.blockHash {
+		return nil, tokens.ErrTxNotFound
+	}
+	return tx, nil
+}
+
+// GetTransactionAt get tx of extrinsic index in block
+func (b *Bridge) GetTransactionAt(blockNumber, index uint64) (*Transaction, error) {
+	blockHash, err := b.GetBlockHash(blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	block, err := b.GetBlock(blockHash)
+	if err != nil {
+		return nil, err
+	}
+	if index >= uint64(len(block.Block.Extrinsics)) {
+		return nil, tokens.ErrTxNotFound
+	}
+	meta, err := b.GetMetadata(blockHash)
+	if err != nil {
+		return nil, err
+	}
+	extrinsic, err := meta.DecodeExtrinsic(block.Block.Extrinsics[index])
+	if err != nil {
+		return nil, err
+	}
+	events, err := b.GetEvents(blockHash, meta)
+	if err != nil {
+		return nil, err
+	}
+	extrinsicEvents := filterExtrinsicEvents(events, index)
+	return &Transaction{
+		Hash:        extrinsic.Hash,
+		ExtrinsicID: MakeExtrinsicID(blockNumber, index),
+		BlockNumber: blockNumber,
+		BlockHash:   blockHash,
+		BlockTime:   getBlockTimestamp(meta, block),
+		Index:       index,
+		Extrinsic:   extrinsic,
+		Events:      extrinsicEvents,
+		Success:     isExtrinsicSuccess(extrinsicEvents),
+	}, nil
+}
+
+// getBlockTimestamp get block time (in seconds) from `timestamp.set` inherent
+func getBlockTimestamp(meta *Metadata, block *SignedBlock) uint64 {
+	for i, data := range block.Block.Extrinsics {
+		if i >= maxInherentExtrinsics {
+			break
+		}
+		extrinsic, err := meta.DecodeExtrinsic(data)
+		if err != nil || extrinsic.Signer != nil {
+			continue
+		}
+		pallet, name, args := CallName(extrinsic.Call)
+		if pallet == "Timestamp" && name == "set" {
+			if now, ok := getUint64(getField(args, "now")); ok {
+				return now / 1000
+			}
+		}
+	}
+	return 0
+}
+
+// GetTransactionStatus impl, confirmations are counted by finalized blocks,
+// a tx in the latest finalized block has 1 confirmation.
+func (b *Bridge) GetTransactionStatus(txHash string) (*tokens.TxStatus, error) {
+	tx, err := b.GetTransactionByHash(txHash)
+	if err != nil {
+		return nil, err
+	}
+	return b.getTxStatus(tx), nil
+}
+
+// getTxStatus get status of tx, latest block number is of finalized head
+func (b *Bridge) getTxStatus(tx *Transaction) *tokens.TxStatus {
+	txStatus := &tokens.TxStatus{
+		Receipt:     &Receipt{ExtrinsicID: tx.ExtrinsicID, Success: tx.Success},
+		BlockHeight: tx.BlockNumber,
+		BlockHash:   tx.BlockHash,
+		BlockTime:   tx.BlockTime,
+	}
+	latest, err := b.GetLatestBlockNumber()
+	if err == nil && latest >= tx.BlockNumber {
+		txStatus.Confirmations = latest - tx.BlockNumber + 1
+		txStatus.Finalized = true
+	}
+	return txStatus
+}
+
+// trackTransaction track sent tx, it can only be included
+// in blocks of its mortal era after submit height
+func (b *Bridge) trackTransaction(txHash string, submitHeight uint64) {
+	b.trackLock.Lock()
+	defer b.trackLock.Unlock()
+	b.trackedTxs[strings.ToLower(txHash)] = &txSearchRange{
+		next: submitHeight,
+		end:  submitHeight + eraPeriod,
+	}
+}
+
+// locateTransaction find block and index of tx hash by searching blocks
+func (b *Bridge) locateTransaction(txHash string) (*txLocation, error) {
+	txHash = strings.ToLower(txHash)
+
+	b.trackLock.Lock()
+	defer b.trackLock.Unlock()
+
+	best, err := b.GetBestBlockNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	searchRange := b.trackedTxs[txHash]
+	if searchRange == nil {
+		start := uint64(0)
+		if best > untrackedSearchDepth {
+			start = best - untrackedSearchDepth
+		}
+		searchRange = &txSearchRange{next: start, end: best + eraPeriod}
+		b.trackedTxs[txHash] = searchRange
+	}
+
+	if location := searchRange.location; location != nil {
+		blockHash, errh := b.GetBlockHash(location.blockNumber)
+		if errh != nil {
+			return nil, errh
+		}
+		if blockHash == location.blockHash {
+			return location, nil
+		}
+		// reorged, search again from here
+		searchRange.next = location.blockNumber
+		searchRange.location = nil
+	}
+
+	end := searchRange.end
+	if end > best {
+		end = best
+	}
+	for count := uint64(0); searchRange.next <= end && count < maxSearchBlocksPerCall; count++ {
+		height := searchRange.next
+		blockHash, errh := b.GetBlockHash(height)
+		if errh != nil {
+			return nil, errh
+		}
+		block, errb := b.GetBlock(blockHash)
+		if errb != nil {
+			return nil, errb
+		}
+		for i, data := range block.Block.Extrinsics {
+			if common.ToHex(blake2b256(data)) == txHash {
+				searchRange.location = &txLocation{
+					blockNumber: height,
+					blockHash:   blockHash,
+					index:       uint64(i),
+				}
+				log.Trace("[substrate] locate transaction success", "txHash", txHash, "height", height, "index", i)
+				return searchRange.location, nil
+			}
+		}
+		searchRange.next++
+	}
+	return nil, tokens.ErrTxNotFound
+}
@@ -0,0 +1,185 @@
+package substrate
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/params"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+const maxCallNestedDepth = 4
+
+// VerifyMsgHash verify msg hash
+func (b *Bridge) VerifyMsgHash(rawTx interface{}, msgHashes []string) error {
+	tx, ok := rawTx.(*UnsignedExtrinsic)
+	if !ok {
+		return tokens.ErrWrongRawTx
+	}
+	if len(msgHashes) != 1 {
+		return tokens.ErrWrongCountOfMsgHashes
+	}
+	sigHash, err := tx.SigningHash()
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(common.ToHex(sigHash), msgHashes[0]) {
+		logFunc := log.GetPrintFuncOr(params.IsDebugMode, log.Info, log.Trace)
+		logFunc("message hash mismatch", "want", msgHashes[0], "have", common.ToHex(sigHash), "call", common.ToHex(tx.Call))
+		return tokens.ErrMsgHashMismatch
+	}
+	return nil
+}
+
+// VerifyTransaction impl
+func (b *Bridge) VerifyTransaction(pairID, txHash string, allowUnstable bool) (*tokens.TxSwapInfo, error) {
+	if !b.IsSrc {
+		return nil, tokens.ErrBridgeDestinationNotSupported
+	}
+	return b.verifySwapinTx(pairID, txHash, allowUnstable)
+}
+
+func (b *Bridge) verifySwapinTx(pairID, txHash string, allowUnstable bool) (*tokens.TxSwapInfo, error) {
+	tokenCfg := b.GetTokenConfig(pairID)
+	if tokenCfg == nil {
+		return nil, tokens.ErrUnknownPairID
+	}
+	if tokenCfg.DisableSwap {
+		return nil, tokens.ErrSwapIsClosed
+	}
+	swapInfo := &tokens.TxSwapInfo{}
+	swapInfo.PairID = pairID // PairID
+	swapInfo.Hash = txHash   // Hash
+
+	blockNumber, index, ok := ParseExtrinsicID(txHash)
+	if !ok {
+		log.Debug("[verifySwapin] swapin tx id should be extrinsic ID 'blockNumber-index'", "tx", txHash)
+		return swapInfo, tokens.ErrTxNotFound
+	}
+	if blockNumber < *b.ChainConfig.InitialHeight {
+		return swapInfo, tokens.ErrTxBeforeInitialHeight
+	}
+	tx, err := b.GetTransactionAt(blockNumber, index)
+	if err != nil {
+		log.Debug("[verifySwapin] "+b.ChainConfig.BlockChain+" Bridge::GetTransaction fail", "tx", txHash, "err", err)
+		return swapInfo, tokens.ErrTxNotFound
+	}
+	if !allowUnstable && !b.getTxStatus(tx).IsStable(b.GetChainConfig(), tokens.GetMinRequiredConfirmations(pairID, b.IsSrc)) {
+		return swapInfo, tokens.ErrTxNotStable
+	}
+	swapInfo.Height = tx.BlockNumber  // Height
+	swapInfo.Timestamp = tx.BlockTime // Timestamp
+
+	if tx.Extrinsic.Signer == nil {
+		return swapInfo, tokens.ErrTxWithWrongSender
+	}
+	if !tx.Success {
+		return swapInfo, tokens.ErrTxWithWrongReceipt
+	}
+
+	depositAddress := tokenCfg.DepositAddress
+	depositAccount, err := b.DecodeAddress(depositAddress)
+	if err != nil {
+		return swapInfo, err
+	}
+	value := getReceivedValue(tx.Events, depositAccount)
+	if value.Sign() == 0 {
+		return swapInfo, tokens.ErrTxWithWrongReceiver
+	}
+	bindAddress, bindOk := GetBindAddressFromMemos(getRemarks(tx.Extrinsic.Call, 0))
+
+	swapInfo.TxTo = depositAddress                       // TxTo
+	swapInfo.To = depositAddress                         // To
+	swapInfo.Value = value                               // Value
+	swapInfo.Bind = bindAddress                          // Bind
+	swapInfo.From = b.EncodeAddress(tx.Extrinsic.Signer) // From
+
+	err = b.checkSwapinInfo(swapInfo)
+	if err != nil {
+		return swapInfo, err
+	}
+	if !bindOk {
+		log.Debug("wrong memo", "tx", txHash)
+		return swapInfo, tokens.ErrTxWithWrongMemo
+	}
+
+	if !allowUnstable {
+		if err = tokens.CheckTieredConfirmations(b, swapInfo); err != nil {
+			return swapInfo, err
+		}
+		log.Info("verify swapin pass", "pairID", swapInfo.PairID, "from", swapInfo.From, "to", swapInfo.To, "bind", swapInfo.Bind, "value", swapInfo.Value, "txid", swapInfo.Hash, "height", swapInfo.Height, "timestamp", swapInfo.Timestamp)
+	}
+	return swapInfo, nil
+}
+
+func (b *Bridge) checkSwapinInfo(swapInfo *tokens.TxSwapInfo) error {
+	if swapInfo.From == swapInfo.To {
+		return tokens.ErrTxWithWrongSender
+	}
+	if !tokens.CheckSwapValue(swapInfo.PairID, swapInfo.Value, b.IsSrc) {
+		return tokens.ErrTxWithWrongValue
+	}
+	if !tokens.DstBridge.IsValidAddress(swapInfo.Bind) {
+		log.Debug("wrong bind address in swapin", "bind", swapInfo.Bind)
+		return tokens.ErrTxWithWrongMemo
+	}
+	return nil
+}
+
+// getReceivedValue sum value of `balances.Transfer` events to receiver
+func getReceivedValue(events []*EventRecord, receiver []byte) *big.Int {
+	value := big.NewInt(0)
+	for _, event := range events {
+		if event.Pallet != "Balances" || event.Name != "Transfer" {
+			continue
+		}
+		to, ok := getAccountID(getField(event.Fields, "to"))
+		if !ok || !bytes.Equal(to, receiver) {
+			continue
+		}
+		if amount, ok := getBigInt(getField(event.Fields, "amount")); ok {
+			value.Add(value, amount)
+		}
+	}
+	return value
+}
+
+// getRemarks get remarks of `system.remark` calls (may be in batch calls)
+func getRemarks(call *VariantValue, depth int) (remarks [][]byte) {
+	if call == nil || depth > maxCallNestedDepth {
+		return nil
+	}
+	pallet, name, args := CallName(call)
+	switch {
+	case pallet == "System" && (name == "remark" || name == "remark_with_event"):
+		if remark, ok := getField(args, "remark").([]byte); ok {
+			remarks = append(remarks, remark)
+		}
+	case pallet == "Utility" && (name == "batch" || name == "batch_all" || name == "force_batch"):
+		calls, _ := getField(args, "calls").([]interface{})
+		for _, subCall := range calls {
+			if subVariant, ok := getVariant(subCall); ok {
+				remarks = append(remarks, getRemarks(subVariant, depth+1)...)
+			}
+		}
+	}
+	return remarks
+}
+
+// GetBindAddressFromMemos get bind address from the first swapin memo
+func GetBindAddressFromMemos(memos [][]byte) (bind string, ok bool) {
+	for _, memo := range memos {
+		memoStr := string(memo)
+		if len(memoStr) <= len(tokens.LockMemoPrefix) {
+			continue
+		}
+		if !strings.HasPrefix(memoStr, tokens.LockMemoPrefix) {
+			continue
+		}
+		return memoStr[len(tokens.LockMemoPrefix):], true
+	}
+	return "", false
+}
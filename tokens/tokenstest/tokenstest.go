@@ -0,0 +1,82 @@
+// Package tokenstest provides utilities for testing bridges against stub gateways.
+package tokenstest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tokens/eth"
+)
+
+// TestPairID pair ID used in tests
+const TestPairID = "testpairid"
+
+// StubAPI stub gateway api, responds the json encoded result of url path
+type StubAPI map[string]interface{}
+
+// ServeHTTP impl http.Handler
+func (s StubAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	result, exist := s[r.URL.Path]
+	if !exist {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(result)
+}
+
+// SetSwapinTokenPair set token pairs config of `TestPairID` with the source token,
+// whose decimals, swap limits and fees are defaulted if not set,
+// and set an eth destination bridge to verify bind addresses.
+func SetSwapinTokenPair(srcToken *tokens.TokenConfig) {
+	decimals := uint8(6)
+	maxSwap, minSwap, bigValue := 1e6, 1.0, 1e5
+	feeRate, maxFee, minFee := 0.0, 0.0, 0.0
+	srcToken.ID = TestPairID
+	if srcToken.Decimals == nil {
+		srcToken.Decimals = &decimals
+	}
+	srcToken.MaximumSwap = &maxSwap
+	srcToken.MinimumSwap = &minSwap
+	srcToken.BigValueThreshold = &bigValue
+	srcToken.SwapFeeRate = &feeRate
+	srcToken.MaximumSwapFee = &maxFee
+	srcToken.MinimumSwapFee = &minFee
+	srcToken.CalcAndStoreValue()
+	tokens.SetTokenPairsConfig(map[string]*tokens.TokenPairConfig{
+		TestPairID: {PairID: TestPairID, SrcToken: srcToken, DestToken: &tokens.TokenConfig{}},
+	}, false)
+	tokens.DstBridge = eth.NewCrossChainBridge(false)
+}
+
+// VerifySwapinTest verify swapin test case
+type VerifySwapinTest struct {
+	API     StubAPI
+	TxHash  string
+	WantErr error
+	Check   func(swapInfo *tokens.TxSwapInfo) bool // check swap info if verify succeed
+}
+
+// RunVerifySwapinTests verify swapin of every test case with a new source bridge
+// connected to the stub api of the test case
+func RunVerifySwapinTests(t *testing.T, newBridge func(url string) tokens.CrossChainBridge, tests []*VerifySwapinTest) {
+	t.Helper()
+	for i, test := range tests {
+		server := httptest.NewServer(test.API)
+		b := newBridge(server.URL)
+		tokens.SrcBridge = b
+
+		swapInfo, err := b.VerifyTransaction(TestPairID, test.TxHash, false)
+		server.Close()
+		if !errors.Is(err, test.WantErr) {
+			t.Errorf("test %v: verify swapin error mismatch: have %v want %v", i, err, test.WantErr)
+			continue
+		}
+		if err == nil && test.Check != nil && !test.Check(swapInfo) {
+			t.Errorf("test %v: wrong swap info %+v", i, swapInfo)
+		}
+	}
+}
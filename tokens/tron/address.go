@@ -0,0 +1,87 @@
+package tron
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/tools/crypto"
+	"github.com/btcsuite/btcutil/base58"
+)
+
+const (
+	// AddressPrefix prefix byte of tron address
+	AddressPrefix = byte(0x41)
+	// AddressLength length of tron address with prefix
+	AddressLength = 21
+)
+
+var errInvalidAddress = errors.New("invalid tron address")
+
+// IsValidAddress check address (base58 or hex form with prefix 41)
+func (b *Bridge) IsValidAddress(address string) bool {
+	_, err := DecodeAddress(address)
+	return err == nil
+}
+
+// DecodeAddress decode base58 or hex (41 prefixed) address to 21 bytes
+func DecodeAddress(address string) ([]byte, error) {
+	if len(address) == 2*AddressLength && strings.HasPrefix(address, "41") {
+		addr, err := hex.DecodeString(address)
+		if err != nil {
+			return nil, errInvalidAddress
+		}
+		return addr, nil
+	}
+	payload, version, err := base58.CheckDecode(address)
+	if err != nil || version != AddressPrefix || len(payload) != common.AddressLength {
+		return nil, errInvalidAddress
+	}
+	return append([]byte{version}, payload...), nil
+}
+
+// EncodeAddress encode 21 bytes address (or 20 bytes without prefix) to base58 form
+func EncodeAddress(addr []byte) string {
+	if len(addr) == AddressLength {
+		addr = addr[1:]
+	}
+	return base58.CheckEncode(addr, AddressPrefix)
+}
+
+// ToHexAddress convert address to hex form with prefix 41 (used by http api)
+func ToHexAddress(address string) (string, error) {
+	addr, err := DecodeAddress(address)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(addr), nil
+}
+
+// ToEthAddress convert address to eth compatible address
+func ToEthAddress(addr []byte) string {
+	if len(addr) == AddressLength {
+		addr = addr[1:]
+	}
+	return strings.ToLower(common.BytesToAddress(addr).String())
+}
+
+// PublicKeyToAddress convert ecdsa public key (compressed or not) to base58 address
+func PublicKeyToAddress(pubkey []byte) (string, error) {
+	switch len(pubkey) {
+	case 33:
+		pubKey, err := crypto.DecompressPubkey(pubkey)
+		if err != nil {
+			return "", err
+		}
+		return EncodeAddress(crypto.PubkeyToAddress(*pubKey).Bytes()), nil
+	case 65:
+		pubKey, err := crypto.UnmarshalPubkey(pubkey)
+		if err != nil {
+			return "", err
+		}
+		return EncodeAddress(crypto.PubkeyToAddress(*pubKey).Bytes()), nil
+	default:
+		return "", errors.New("wrong length of ecdsa public key")
+	}
+}
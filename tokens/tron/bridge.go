@@ -0,0 +1,139 @@
+// Package tron implements the bridge interfaces for TRX and TRC20 tokens
+// of tron blockchain.
+//
+// Transactions are encoded in protobuf and the tx ID is the sha256 hash of
+// the raw data. Swapin is a TRX transfer or a TRC20 `Transfer` to the deposit
+// address, and the bind address is the (eth compatible) hex form of the
+// sender. Swapout is paid by DCRM signed `TransferContract` (TRX) or
+// `TriggerSmartContract` calling `transfer` (TRC20). The chain is accessed
+// through the HTTP API of java-tron compatible nodes.
+package tron
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+// Bridge tron bridge
+type Bridge struct {
+	*tokens.CrossChainBridgeBase
+	NetParams *NetParams
+}
+
+// NewCrossChainBridge new tron bridge
+func NewCrossChainBridge(isSrc bool) *Bridge {
+	if !isSrc {
+		log.Fatalf("tron::NewCrossChainBridge error %v", tokens.ErrBridgeDestinationNotSupported)
+	}
+	return &Bridge{CrossChainBridgeBase: tokens.NewCrossChainBridgeBase(isSrc)}
+}
+
+// SetChainAndGateway set chain and gateway config
+func (b *Bridge) SetChainAndGateway(chainCfg *tokens.ChainConfig, gatewayCfg *tokens.GatewayConfig) {
+	b.CrossChainBridgeBase.SetChainAndGateway(chainCfg, gatewayCfg)
+	if chainCfg.StableFinality == tokens.SafeBlockTag {
+		log.Fatalf("tron does not support '%v' stable finality", chainCfg.StableFinality)
+	}
+	b.VerifyChainConfig()
+	b.InitLatestBlockNumber()
+}
+
+// VerifyChainConfig verify chain config
+func (b *Bridge) VerifyChainConfig() {
+	networkID := strings.ToLower(b.ChainConfig.NetID)
+	netParams, exist := networks[networkID]
+	if !exist {
+		log.Fatalf("unsupported tron network: %v", b.ChainConfig.NetID)
+	}
+	b.NetParams = netParams
+
+	var (
+		genesis *Block
+		err     error
+	)
+	for {
+		genesis, err = b.GetBlockByNumber(0)
+		if err == nil {
+			break
+		}
+		log.Errorf("can not get gateway genesis block. %v", err)
+		log.Println("retry query gateway", b.GatewayConfig.APIAddress)
+		time.Sleep(3 * time.Second)
+	}
+
+	if netParams.GenesisBlockID != "" && !strings.EqualFold(genesis.BlockID, netParams.GenesisBlockID) {
+		log.Fatalf("gateway genesis block '%v' is not '%v'", genesis.BlockID, b.ChainConfig.NetID)
+	}
+
+	log.Info("VerifyChainConfig succeed", "networkID", networkID, "genesisBlockID", genesis.BlockID)
+}
+
+// VerifyTokenConfig verify token config
+func (b *Bridge) VerifyTokenConfig(tokenCfg *tokens.TokenConfig) error {
+	if err := tokenCfg.VerifySignerWithoutPreimage(); err != nil {
+		return err
+	}
+	if !isBase58Address(tokenCfg.DcrmAddress) {
+		return fmt.Errorf("invalid dcrm address: %v", tokenCfg.DcrmAddress)
+	}
+	if !isBase58Address(tokenCfg.DepositAddress) {
+		return fmt.Errorf("invalid deposit address: %v", tokenCfg.DepositAddress)
+	}
+	if tokenCfg.ContractAddress != "" {
+		if !isBase58Address(tokenCfg.ContractAddress) {
+			return fmt.Errorf("invalid contract address: %v", tokenCfg.ContractAddress)
+		}
+	} else {
+		if !strings.EqualFold(tokenCfg.Symbol, trxSymbol) {
+			return fmt.Errorf("invalid symbol: want %v but have %v", trxSymbol, tokenCfg.Symbol)
+		}
+		if *tokenCfg.Decimals != trxDecimals {
+			return fmt.Errorf("invalid decimals for %v: want %v but have %v", trxSymbol, trxDecimals, *tokenCfg.Decimals)
+		}
+	}
+	return b.verifyDcrmPublicKey(tokenCfg)
+}
+
+// isBase58Address addresses in token config should be in base58 form
+func isBase58Address(address string) bool {
+	addr, err := DecodeAddress(address)
+	return err == nil && EncodeAddress(addr) == address
+}
+
+func (b *Bridge) verifyDcrmPublicKey(tokenCfg *tokens.TokenConfig) error {
+	if tokenCfg.DcrmPubkey == "" {
+		return fmt.Errorf("tron token must config 'DcrmPubkey'")
+	}
+	pubAddr, err := PublicKeyToAddress(common.FromHex(tokenCfg.DcrmPubkey))
+	if err != nil {
+		return fmt.Errorf("wrong dcrm public key, %w", err)
+	}
+	if pubAddr != tokenCfg.DcrmAddress {
+		return fmt.Errorf("dcrm address %v and public key address %v is not match", tokenCfg.DcrmAddress, pubAddr)
+	}
+	return nil
+}
+
+// InitLatestBlockNumber init latest block number
+func (b *Bridge) InitLatestBlockNumber() {
+	chainCfg := b.ChainConfig
+	gatewayCfg := b.GatewayConfig
+	var latest uint64
+	var err error
+	for {
+		latest, err = b.GetLatestBlockNumber()
+		if err == nil {
+			tokens.SetLatestBlockHeight(latest, b.IsSrc)
+			log.Info("get latst block number succeed.", "number", latest, "BlockChain", chainCfg.BlockChain, "NetID", chainCfg.NetID)
+			break
+		}
+		log.Error("get latst block number failed.", "BlockChain", chainCfg.BlockChain, "NetID", chainCfg.NetID, "err", err)
+		log.Println("retry query gateway", gatewayCfg.APIAddress)
+		time.Sleep(3 * time.Second)
+	}
+}
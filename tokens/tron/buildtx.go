@@ -0,0 +1,157 @@
+package tron
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+const (
+	defaultFeeLimit = int64(100000000)  // 100 TRX
+	maxFeeLimit     = int64(1000000000) // 1000 TRX
+
+	// expiration interval (milliseconds) from the ref block time
+	txExpirationInterval = int64(10 * 60 * 1000)
+)
+
+var trc20TransferSelector = common.FromHex("0xa9059cbb")
+
+// BuildRawTransaction build raw tx
+func (b *Bridge) BuildRawTransaction(args *tokens.BuildTxArgs) (rawTx interface{}, err error) {
+	pairID := args.PairID
+	token := b.GetTokenConfig(pairID)
+	if token == nil {
+		return nil, fmt.Errorf("swap pair '%v' is not configed", pairID)
+	}
+
+	var (
+		to     string
+		amount *big.Int
+		memo   string
+	)
+	switch args.SwapType {
+	case tokens.SwapinType:
+		return nil, tokens.ErrSwapTypeNotSupported
+	case tokens.SwapoutType:
+		to = args.Bind                                                    // to
+		amount = tokens.CalcSwappedValue(pairID, args.OriginValue, false) // amount
+		memo = tokens.UnlockMemoPrefix + args.SwapID
+	default:
+		return nil, tokens.ErrUnknownSwapType
+	}
+	if amount.Sign() <= 0 {
+		return nil, tokens.ErrWrongSwapValue
+	}
+
+	args.From = token.DcrmAddress // from
+	owner, err := DecodeAddress(args.From)
+	if err != nil {
+		return nil, fmt.Errorf("wrong sender %v, %w", args.From, err)
+	}
+	receiver, err := DecodeAddress(to)
+	if err != nil {
+		return nil, fmt.Errorf("wrong receiver %v, %w", to, err)
+	}
+
+	isTrc20 := token.ContractAddress != ""
+	extra, err := b.setDefaults(args, isTrc20)
+	if err != nil {
+		return nil, err
+	}
+	refBlockID, err := hex.DecodeString(*extra.RefBlockHash)
+	if err != nil || len(refBlockID) != 32 || binary.BigEndian.Uint64(refBlockID[:8]) != *extra.RefBlockNum {
+		return nil, fmt.Errorf("%w: wrong ref block %v %v", tokens.ErrWrongExtraArgs, *extra.RefBlockNum, *extra.RefBlockHash)
+	}
+
+	tx := &RawTransaction{
+		RefBlockBytes: refBlockID[6:8],
+		RefBlockHash:  refBlockID[8:16],
+		Expiration:    *extra.Expiration,
+		Data:          []byte(memo),
+		Timestamp:     *extra.Timestamp,
+	}
+	if isTrc20 {
+		contract, errf := DecodeAddress(token.ContractAddress)
+		if errf != nil {
+			return nil, errf
+		}
+		data := make([]byte, 0, 68)
+		data = append(data, trc20TransferSelector...)
+		data = append(data, common.LeftPadBytes(receiver[1:], 32)...)
+		data = append(data, common.LeftPadBytes(amount.Bytes(), 32)...)
+		tx.Contract = &Contract{
+			Type:         TriggerSmartContractType,
+			OwnerAddress: owner,
+			ToAddress:    contract,
+			Data:         data,
+		}
+		tx.FeeLimit = *extra.FeeLimit
+	} else {
+		if !amount.IsInt64() {
+			return nil, tokens.ErrWrongSwapValue
+		}
+		tx.Contract = &Contract{
+			Type:         TransferContractType,
+			OwnerAddress: owner,
+			ToAddress:    receiver,
+			Amount:       amount.Int64(),
+		}
+	}
+	log.Info("build tron raw tx", "pairID", pairID, "swapID", args.SwapID, "from", args.From, "to", to, "amount", amount, "refBlock", *extra.RefBlockNum, "expiration", *extra.Expiration)
+	return tx, nil
+}
+
+// setDefaults set ref block, timestamp, expiration and fee limit if not specified,
+// the tx expiration is restricted so that the accept record can prevent replaying.
+func (b *Bridge) setDefaults(args *tokens.BuildTxArgs, isTrc20 bool) (extra *tokens.TronExtraArgs, err error) {
+	if args.Extra == nil || args.Extra.TronExtra == nil {
+		extra = &tokens.TronExtraArgs{}
+		args.Extra = &tokens.AllExtras{TronExtra: extra}
+	} else {
+		extra = args.Extra.TronExtra
+	}
+	if extra.RefBlockNum == nil || extra.RefBlockHash == nil {
+		block, errf := b.GetNowBlock()
+		if errf != nil {
+			return nil, errf
+		}
+		refBlockNum := block.GetNumber()
+		refBlockHash := block.BlockID
+		extra.RefBlockNum = &refBlockNum
+		extra.RefBlockHash = &refBlockHash
+		if extra.Timestamp == nil {
+			timestamp := block.GetTimestamp()
+			extra.Timestamp = &timestamp
+		}
+	}
+	if extra.Timestamp == nil {
+		timestamp := time.Now().UnixNano() / 1e6
+		extra.Timestamp = &timestamp
+	}
+	if extra.Expiration == nil {
+		expiration := *extra.Timestamp + txExpirationInterval
+		extra.Expiration = &expiration
+	}
+	if *extra.Expiration <= *extra.Timestamp ||
+		*extra.Expiration/1000 > time.Now().Unix()+tokens.MaxTxExpirationInterval {
+		return nil, fmt.Errorf("%w: wrong expiration %v", tokens.ErrWrongExtraArgs, *extra.Expiration)
+	}
+	if !isTrc20 {
+		extra.FeeLimit = nil
+		return extra, nil
+	}
+	if extra.FeeLimit == nil {
+		feeLimit := defaultFeeLimit
+		extra.FeeLimit = &feeLimit
+	}
+	if *extra.FeeLimit <= 0 || *extra.FeeLimit > maxFeeLimit {
+		return nil, fmt.Errorf("%w: wrong fee limit %v", tokens.ErrWrongExtraArgs, *extra.FeeLimit)
+	}
+	return extra, nil
+}
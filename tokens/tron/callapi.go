@@ -0,0 +1,301 @@
+package tron
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/rpc/client"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+var (
+	errNotFound = errors.New("not found")
+
+	balanceOfSelector   = common.FromHex("0x70a08231")
+	totalSupplySelector = common.FromHex("0x18160ddd")
+)
+
+func wrapRPCQueryError(err error, method string, params ...interface{}) error {
+	if err == nil {
+		err = errNotFound
+	}
+	return fmt.Errorf("%w: call '%s %v' failed, err='%v'", tokens.ErrRPCQueryError, method, params, err)
+}
+
+// BlockHeader block header
+type BlockHeader struct {
+	RawData struct {
+		Number    uint64 `json:"number"`
+		Timestamp int64  `json:"timestamp"`
+	} `json:"raw_data"`
+}
+
+// Block block with transactions
+type Block struct {
+	BlockID      string               `json:"blockID"`
+	BlockHeader  *BlockHeader         `json:"block_header"`
+	Transactions []*TransactionResult `json:"transactions"`
+}
+
+// TransactionResult result of /wallet/gettransactionbyid
+type TransactionResult struct {
+	TxID       string   `json:"txID"`
+	RawDataHex string   `json:"raw_data_hex"`
+	Signature  []string `json:"signature"`
+	Ret        []struct {
+		ContractRet string `json:"contractRet"`
+	} `json:"ret"`
+}
+
+// TransactionLog log of smart contract
+type TransactionLog struct {
+	Address string   `json:"address"`
+	Topics  []string `json:"topics"`
+	Data    string   `json:"data"`
+}
+
+// TransactionInfo result of /wallet/gettransactioninfobyid
+type TransactionInfo struct {
+	ID             string `json:"id"`
+	BlockNumber    uint64 `json:"blockNumber"`
+	BlockTimeStamp int64  `json:"blockTimeStamp"`
+	Result         string `json:"result"`
+	Receipt        struct {
+		Result string `json:"result"`
+	} `json:"receipt"`
+	Log []*TransactionLog `json:"log"`
+}
+
+// BroadcastResult result of /wallet/broadcasthex
+type BroadcastResult struct {
+	Result  bool   `json:"result"`
+	TxID    string `json:"txid"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// GetNumber get block number
+func (b *Block) GetNumber() uint64 {
+	if b.BlockHeader == nil {
+		return 0
+	}
+	return b.BlockHeader.RawData.Number
+}
+
+// GetTimestamp get block timestamp in milliseconds
+func (b *Block) GetTimestamp() int64 {
+	if b.BlockHeader == nil {
+		return 0
+	}
+	return b.BlockHeader.RawData.Timestamp
+}
+
+func (b *Bridge) getURLs(withExt bool) []string {
+	gateway := b.GatewayConfig
+	urls := gateway.APIAddress
+	if withExt && len(gateway.APIAddressExt) > 0 {
+		urls = append(append([]string{}, urls...), gateway.APIAddressExt...)
+	}
+	return urls
+}
+
+func (b *Bridge) callAPI(result interface{}, path string, body interface{}) (err error) {
+	for _, url := range b.getURLs(true) {
+		err = client.RPCPostJSON(result, url+path, body)
+		if err == nil {
+			return nil
+		}
+	}
+	return wrapRPCQueryError(err, path, body)
+}
+
+// GetLatestBlockNumber get latest block number
+func (b *Bridge) GetLatestBlockNumber() (maxHeight uint64, err error) {
+	var height uint64
+	for _, url := range b.GatewayConfig.APIAddress {
+		height, err = b.GetLatestBlockNumberOf(url)
+		if err == nil && height > maxHeight {
+			maxHeight = height
+		}
+	}
+	if maxHeight > 0 {
+		tokens.CmpAndSetLatestBlockHeight(maxHeight, b.IsSrc)
+		return maxHeight, nil
+	}
+	return 0, err
+}
+
+// GetLatestBlockNumberOf get latest block number of specified url
+func (b *Bridge) GetLatestBlockNumberOf(url string) (uint64, error) {
+	block, err := getNowBlock(url, "/wallet/getnowblock")
+	if err != nil {
+		return 0, err
+	}
+	return block.GetNumber(), nil
+}
+
+// GetFinalizedBlockNumber get latest solidified block number, which is
+// confirmed by more than 2/3 super representatives
+func (b *Bridge) GetFinalizedBlockNumber() (finalized uint64, err error) {
+	var block *Block
+	for _, url := range b.GatewayConfig.APIAddress {
+		block, err = getNowBlock(url, "/walletsolidity/getnowblock")
+		if err != nil {
+			continue
+		}
+		if height := block.GetNumber(); finalized == 0 || height < finalized {
+			finalized = height
+		}
+	}
+	if finalized > 0 {
+		tokens.CmpAndSetFinalizedHeight(finalized, b.IsSrc)
+		return finalized, nil
+	}
+	return 0, err
+}
+
+// GetNowBlock get latest block
+func (b *Bridge) GetNowBlock() (block *Block, err error) {
+	for _, url := range b.GatewayConfig.APIAddress {
+		block, err = getNowBlock(url, "/wallet/getnowblock")
+		if err == nil {
+			return block, nil
+		}
+	}
+	return nil, err
+}
+
+func getNowBlock(url, path string) (*Block, error) {
+	var block Block
+	err := client.RPCPostJSON(&block, url+path, nil)
+	if err != nil {
+		return nil, wrapRPCQueryError(err, path)
+	}
+	if block.BlockID == "" || block.BlockHeader == nil {
+		return nil, wrapRPCQueryError(nil, path)
+	}
+	return &block, nil
+}
+
+// GetBlockByNumber get block by number
+func (b *Bridge) GetBlockByNumber(number uint64) (*Block, error) {
+	var block Block
+	path := "/wallet/getblockbynum"
+	err := b.callAPI(&block, path, map[string]interface{}{"num": number})
+	if err != nil {
+		return nil, err
+	}
+	if block.BlockID == "" {
+		return nil, wrapRPCQueryError(nil, path, number)
+	}
+	return &block, nil
+}
+
+// GetTransactionByID get transaction by tx ID
+func (b *Bridge) GetTransactionByID(txID string) (*TransactionResult, error) {
+	var tx TransactionResult
+	path := "/wallet/gettransactionbyid"
+	err := b.callAPI(&tx, path, map[string]interface{}{"value": txID})
+	if err != nil {
+		return nil, err
+	}
+	if tx.TxID == "" {
+		return nil, tokens.ErrTxNotFound
+	}
+	return &tx, nil
+}
+
+// GetTransactionInfoByID get transaction info (receipt) by tx ID
+func (b *Bridge) GetTransactionInfoByID(txID string) (*TransactionInfo, error) {
+	var info TransactionInfo
+	path := "/wallet/gettransactioninfobyid"
+	err := b.callAPI(&info, path, map[string]interface{}{"value": txID})
+	if err != nil {
+		return nil, err
+	}
+	if info.ID == "" {
+		return nil, tokens.ErrTxNotFound
+	}
+	return &info, nil
+}
+
+// GetBalance get TRX balance (in sun)
+func (b *Bridge) GetBalance(account string) (*big.Int, error) {
+	address, err := ToHexAddress(account)
+	if err != nil {
+		return nil, err
+	}
+	var result struct {
+		Balance int64 `json:"balance"`
+	}
+	err = b.callAPI(&result, "/wallet/getaccount", map[string]interface{}{"address": address})
+	if err != nil {
+		return nil, err
+	}
+	return big.NewInt(result.Balance), nil // account not exist if result is empty
+}
+
+// GetTokenBalance get TRC20 token balance
+func (b *Bridge) GetTokenBalance(tokenType, tokenAddress, accountAddress string) (*big.Int, error) {
+	account, err := DecodeAddress(accountAddress)
+	if err != nil {
+		return nil, err
+	}
+	data := append(append([]byte{}, balanceOfSelector...), common.LeftPadBytes(account[1:], 32)...)
+	return b.callConstantContract(accountAddress, tokenAddress, data)
+}
+
+// GetTokenSupply get TRC20 token total supply
+func (b *Bridge) GetTokenSupply(tokenType, tokenAddress string) (*big.Int, error) {
+	return b.callConstantContract(tokenAddress, tokenAddress, totalSupplySelector)
+}
+
+func (b *Bridge) callConstantContract(caller, contract string, data []byte) (*big.Int, error) {
+	owner, err := ToHexAddress(caller)
+	if err != nil {
+		return nil, err
+	}
+	contractAddr, err := ToHexAddress(contract)
+	if err != nil {
+		return nil, err
+	}
+	var result struct {
+		ConstantResult []string `json:"constant_result"`
+		Result         struct {
+			Result  bool   `json:"result"`
+			Message string `json:"message"`
+		} `json:"result"`
+	}
+	path := "/wallet/triggerconstantcontract"
+	err = b.callAPI(&result, path, map[string]interface{}{
+		"owner_address":    owner,
+		"contract_address": contractAddr,
+		"data":             hex.EncodeToString(data),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !result.Result.Result || len(result.ConstantResult) == 0 {
+		return nil, wrapRPCQueryError(errors.New(result.Result.Message), path, contract)
+	}
+	return common.GetBigInt(common.FromHex(result.ConstantResult[0]), 0, 32), nil
+}
+
+// BroadcastHex broadcast signed transaction to specified url
+func BroadcastHex(url, txHex string) (string, error) {
+	var result BroadcastResult
+	path := "/wallet/broadcasthex"
+	err := client.RPCPostJSON(&result, url+path, map[string]interface{}{"transaction": txHex})
+	if err != nil {
+		return "", wrapRPCQueryError(err, path)
+	}
+	if !result.Result {
+		message, _ := hex.DecodeString(result.Message)
+		return "", wrapRPCQueryError(fmt.Errorf("%v %v", result.Code, string(message)), path)
+	}
+	return strings.ToLower(result.TxID), nil
+}
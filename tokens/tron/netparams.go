@@ -0,0 +1,35 @@
+package tron
+
+const (
+	netMainnet = "mainnet"
+	netShasta  = "shasta"
+	netNile    = "nile"
+	netCustom  = "custom"
+
+	trxSymbol   = "TRX"
+	trxDecimals = uint8(6)
+)
+
+// NetParams tron network params
+type NetParams struct {
+	Name           string
+	GenesisBlockID string
+}
+
+var networks = map[string]*NetParams{
+	netMainnet: {
+		Name:           netMainnet,
+		GenesisBlockID: "00000000000000001ebf88508a03865c71d452e25f4d51194196a1d22b6653dc",
+	},
+	netShasta: {
+		Name:           netShasta,
+		GenesisBlockID: "0000000000000000de1aa88295e1fcf982742f773e0419c5a9c134c994a9059e",
+	},
+	netNile: {
+		Name:           netNile,
+		GenesisBlockID: "0000000000000000d698d4192c56cb6be724a558448e2684802de4d6cd8690dc",
+	},
+	netCustom: {
+		Name: netCustom,
+	},
+}
@@ -0,0 +1,309 @@
+package tron
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// ContractType tron contract type
+type ContractType int32
+
+// supported contract types
+const (
+	TransferContractType     ContractType = 1
+	TriggerSmartContractType ContractType = 31
+)
+
+const typeURLPrefix = "type.googleapis.com/protocol."
+
+var contractTypeNames = map[ContractType]string{
+	TransferContractType:     "TransferContract",
+	TriggerSmartContractType: "TriggerSmartContract",
+}
+
+// field numbers of protocol messages
+const (
+	// Transaction
+	fieldTxRawData   = 1
+	fieldTxSignature = 2
+
+	// Transaction.raw
+	fieldRawRefBlockBytes = 1
+	fieldRawRefBlockHash  = 4
+	fieldRawExpiration    = 8
+	fieldRawData          = 10
+	fieldRawContract      = 11
+	fieldRawTimestamp     = 14
+	fieldRawFeeLimit      = 18
+
+	// Transaction.Contract
+	fieldContractType      = 1
+	fieldContractParameter = 2
+
+	// google.protobuf.Any
+	fieldAnyTypeURL = 1
+	fieldAnyValue   = 2
+
+	// TransferContract and TriggerSmartContract
+	fieldOwnerAddress = 1
+	fieldToAddress    = 2 // to_address or contract_address
+	fieldAmount       = 3 // amount or call_value
+	fieldCallData     = 4
+)
+
+var (
+	errUnsupportedContract = errors.New("unsupported contract type")
+	errWrongContractCount  = errors.New("transaction should have only one contract")
+)
+
+// Contract is the only contract in transaction, either `TransferContract`
+// or `TriggerSmartContract`
+type Contract struct {
+	Type         ContractType
+	OwnerAddress []byte
+	ToAddress    []byte // receiver of TRX or the called contract
+	Amount       int64  // TRX amount or call value (in sun)
+	Data         []byte // call data of smart contract
+}
+
+// RawTransaction raw data of transaction
+type RawTransaction struct {
+	RefBlockBytes []byte
+	RefBlockHash  []byte
+	Expiration    int64 // milliseconds
+	Data          []byte
+	Contract      *Contract
+	Timestamp     int64 // milliseconds
+	FeeLimit      int64
+}
+
+// SignedTransaction signed transaction
+type SignedTransaction struct {
+	Raw       *RawTransaction
+	RawData   []byte
+	Signature []byte
+	TxID      string
+}
+
+// Marshal encode raw transaction
+func (tx *RawTransaction) Marshal() ([]byte, error) {
+	contract, err := tx.Contract.marshal()
+	if err != nil {
+		return nil, err
+	}
+	var b []byte
+	b = appendBytes(b, fieldRawRefBlockBytes, tx.RefBlockBytes)
+	b = appendBytes(b, fieldRawRefBlockHash, tx.RefBlockHash)
+	b = appendVarint(b, fieldRawExpiration, uint64(tx.Expiration))
+	b = appendBytes(b, fieldRawData, tx.Data)
+	b = appendBytes(b, fieldRawContract, contract)
+	b = appendVarint(b, fieldRawTimestamp, uint64(tx.Timestamp))
+	b = appendVarint(b, fieldRawFeeLimit, uint64(tx.FeeLimit))
+	return b, nil
+}
+
+// TxID tx ID is the sha256 hash of raw data
+func (tx *RawTransaction) TxID() (string, error) {
+	data, err := tx.Marshal()
+	if err != nil {
+		return "", err
+	}
+	return CalcTxID(data), nil
+}
+
+// CalcTxID calc tx ID of raw data
+func CalcTxID(rawData []byte) string {
+	hash := sha256.Sum256(rawData)
+	return fmt.Sprintf("%x", hash[:])
+}
+
+// WithSignature make signed transaction
+func (tx *RawTransaction) WithSignature(signature []byte) (*SignedTransaction, error) {
+	data, err := tx.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return &SignedTransaction{
+		Raw:       tx,
+		RawData:   data,
+		Signature: signature,
+		TxID:      CalcTxID(data),
+	}, nil
+}
+
+// Marshal encode signed transaction
+func (tx *SignedTransaction) Marshal() []byte {
+	var b []byte
+	b = appendBytes(b, fieldTxRawData, tx.RawData)
+	b = appendBytes(b, fieldTxSignature, tx.Signature)
+	return b
+}
+
+func (c *Contract) marshal() ([]byte, error) {
+	if c == nil {
+		return nil, errWrongContractCount
+	}
+	name, ok := contractTypeNames[c.Type]
+	if !ok {
+		return nil, errUnsupportedContract
+	}
+	var param []byte
+	param = appendBytes(param, fieldOwnerAddress, c.OwnerAddress)
+	param = appendBytes(param, fieldToAddress, c.ToAddress)
+	param = appendVarint(param, fieldAmount, uint64(c.Amount))
+	if c.Type == TriggerSmartContractType {
+		param = appendBytes(param, fieldCallData, c.Data)
+	}
+
+	var anyMsg []byte
+	anyMsg = appendBytes(anyMsg, fieldAnyTypeURL, []byte(typeURLPrefix+name))
+	anyMsg = appendBytes(anyMsg, fieldAnyValue, param)
+
+	var b []byte
+	b = appendVarint(b, fieldContractType, uint64(c.Type))
+	b = appendBytes(b, fieldContractParameter, anyMsg)
+	return b, nil
+}
+
+// UnmarshalRawTransaction decode raw transaction, only transaction
+// with one supported contract is accepted
+func UnmarshalRawTransaction(data []byte) (*RawTransaction, error) {
+	tx := &RawTransaction{}
+	contracts := 0
+	err := walkFields(data, func(num protowire.Number, v uint64, bs []byte) (err error) {
+		switch num {
+		case fieldRawRefBlockBytes:
+			tx.RefBlockBytes = bs
+		case fieldRawRefBlockHash:
+			tx.RefBlockHash = bs
+		case fieldRawExpiration:
+			tx.Expiration = int64(v)
+		case fieldRawData:
+			tx.Data = bs
+		case fieldRawContract:
+			contracts++
+			tx.Contract, err = unmarshalContract(bs)
+		case fieldRawTimestamp:
+			tx.Timestamp = int64(v)
+		case fieldRawFeeLimit:
+			tx.FeeLimit = int64(v)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if contracts != 1 {
+		return nil, errWrongContractCount
+	}
+	return tx, nil
+}
+
+func unmarshalContract(data []byte) (*Contract, error) {
+	c := &Contract{}
+	var anyMsg []byte
+	err := walkFields(data, func(num protowire.Number, v uint64, bs []byte) error {
+		switch num {
+		case fieldContractType:
+			c.Type = ContractType(v)
+		case fieldContractParameter:
+			anyMsg = bs
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	name, ok := contractTypeNames[c.Type]
+	if !ok {
+		return nil, errUnsupportedContract
+	}
+	var typeURL string
+	var param []byte
+	err = walkFields(anyMsg, func(num protowire.Number, v uint64, bs []byte) error {
+		switch num {
+		case fieldAnyTypeURL:
+			typeURL = string(bs)
+		case fieldAnyValue:
+			param = bs
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if typeURL != typeURLPrefix+name {
+		return nil, errUnsupportedContract
+	}
+	err = walkFields(param, func(num protowire.Number, v uint64, bs []byte) error {
+		switch num {
+		case fieldOwnerAddress:
+			c.OwnerAddress = bs
+		case fieldToAddress:
+			c.ToAddress = bs
+		case fieldAmount:
+			c.Amount = int64(v)
+		case fieldCallData:
+			if c.Type == TriggerSmartContractType {
+				c.Data = bs
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// walkFields iterate varint and bytes fields, other wire types are skipped
+func walkFields(data []byte, fn func(num protowire.Number, v uint64, bs []byte) error) error {
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		data = data[n:]
+		var (
+			v  uint64
+			bs []byte
+		)
+		switch typ {
+		case protowire.VarintType:
+			v, n = protowire.ConsumeVarint(data)
+		case protowire.BytesType:
+			bs, n = protowire.ConsumeBytes(data)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, data)
+		}
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		data = data[n:]
+		if err := fn(num, v, bs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// appendVarint append varint field, zero value is omitted as proto3
+func appendVarint(b []byte, num protowire.Number, v uint64) []byte {
+	if v == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, v)
+}
+
+// appendBytes append bytes field, empty value is omitted as proto3
+func appendBytes(b []byte, num protowire.Number, v []byte) []byte {
+	if len(v) == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, v)
+}
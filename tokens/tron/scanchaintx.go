@@ -0,0 +1,141 @@
+package tron
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tokens/tools"
+)
+
+var (
+	scannedBlocks = tools.NewCachedScannedBlocks(67)
+
+	maxScanHeight          = uint64(100)
+	retryIntervalInScanJob = 3 * time.Second
+	restIntervalInScanJob  = 3 * time.Second
+)
+
+func (b *Bridge) getStartAndLatestHeight() (start, latest uint64) {
+	startHeight := tools.GetLatestScanHeight(b.IsSrc)
+
+	chainCfg := b.GetChainConfig()
+	confirmations := *chainCfg.Confirmations
+	initialHeight := *chainCfg.InitialHeight
+
+	latest = tools.LoopGetLatestBlockNumber(b)
+
+	switch {
+	case startHeight != 0:
+		start = startHeight
+	case initialHeight != 0:
+		start = initialHeight
+	default:
+		if latest > confirmations {
+			start = latest - confirmations
+		}
+	}
+	if start < initialHeight {
+		start = initialHeight
+	}
+	if start+maxScanHeight < latest {
+		start = latest - maxScanHeight
+	}
+	return start, latest
+}
+
+// StartChainTransactionScanJob scan job
+func (b *Bridge) StartChainTransactionScanJob() {
+	chainName := b.ChainConfig.BlockChain
+	log.Infof("[scanchain] start %v scan chain job", chainName)
+
+	start, latest := b.getStartAndLatestHeight()
+	_ = tools.UpdateLatestScanInfo(b.IsSrc, start)
+	log.Infof("[scanchain] start %v scan chain loop from %v latest=%v", chainName, start, latest)
+
+	stable := start
+	errorSubject := fmt.Sprintf("[scanchain] get %v block failed", chainName)
+	scanSubject := fmt.Sprintf("[scanchain] scanned %v block", chainName)
+	for {
+		latest := tools.LoopGetLatestBlockNumber(b)
+		for h := stable + 1; h <= latest; {
+			block, err := b.GetBlockByNumber(h)
+			if err != nil {
+				log.Error(errorSubject, "height", h, "err", err)
+				time.Sleep(retryIntervalInScanJob)
+				continue
+			}
+			if scannedBlocks.IsBlockScanned(block.BlockID) {
+				h++
+				continue
+			}
+			count := b.scanBlock(block)
+			scannedBlocks.CacheScannedBlock(block.BlockID, h)
+			log.Info(scanSubject, "blockID", block.BlockID, "height", h, "swapins", count)
+			h++
+		}
+		if stable < latest {
+			stable = latest
+			_ = tools.UpdateLatestScanInfo(b.IsSrc, stable)
+		}
+		time.Sleep(restIntervalInScanJob)
+	}
+}
+
+// scanBlock find swapins by TRX transfers to deposit addresses
+// and TRC20 `transfer` calls to deposit addresses
+func (b *Bridge) scanBlock(block *Block) (count int) {
+	for _, tx := range block.Transactions {
+		rawData, err := hex.DecodeString(tx.RawDataHex)
+		if err != nil {
+			continue
+		}
+		raw, err := UnmarshalRawTransaction(rawData)
+		if err != nil {
+			continue
+		}
+		var address string
+		contract := raw.Contract
+		switch contract.Type {
+		case TransferContractType:
+			address = EncodeAddress(contract.ToAddress)
+		case TriggerSmartContractType:
+			if !isTrc20TransferCall(contract.Data) {
+				continue
+			}
+			address = EncodeAddress(contract.ToAddress)
+		default:
+			continue
+		}
+		tokenCfgs, pairIDs := tokens.FindTokenConfig(address, true)
+		for i, pairID := range pairIDs {
+			if contract.Type == TriggerSmartContractType &&
+				!isTransferTo(contract.Data, tokenCfgs[i].DepositAddress) {
+				continue
+			}
+			b.processSwapin(tx.TxID, pairID)
+			count++
+		}
+	}
+	return count
+}
+
+func isTrc20TransferCall(data []byte) bool {
+	return len(data) == 68 && bytes.Equal(data[:4], trc20TransferSelector)
+}
+
+func isTransferTo(data []byte, address string) bool {
+	addr, err := DecodeAddress(address)
+	return err == nil && bytes.Equal(data[16:36], addr[1:])
+}
+
+func (b *Bridge) processSwapin(txid, pairID string) {
+	if tools.IsSwapExist(txid, pairID, "", true) {
+		return
+	}
+	swapInfo, err := b.verifySwapinTx(pairID, txid, true)
+	tools.RegisterSwapin(txid, []*tokens.TxSwapInfo{swapInfo}, []error{err})
+}
@@ -0,0 +1,50 @@
+package tron
+
+import (
+	"encoding/hex"
+	"errors"
+
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/params"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+var errEmptyURLs = errors.New("empty URLs")
+
+// SendTransaction send signed tx
+func (b *Bridge) SendTransaction(signedTx interface{}) (txHash string, err error) {
+	tx, ok := signedTx.(*SignedTransaction)
+	if !ok {
+		return "", tokens.ErrWrongRawTx
+	}
+	txHex := hex.EncodeToString(tx.Marshal())
+	gateway := b.GatewayConfig
+	_, _ = broadcastTransaction(txHex, gateway.APIAddressExt)
+	_, err = broadcastTransaction(txHex, gateway.APIAddress)
+	if err != nil {
+		return "", err
+	}
+	log.Info("Bridge send tx", "hash", tx.TxID, "expiration", tx.Raw.Expiration)
+	return tx.TxID, nil
+}
+
+func broadcastTransaction(txHex string, urls []string) (success bool, err error) {
+	if len(urls) == 0 {
+		return false, errEmptyURLs
+	}
+	logFunc := log.GetPrintFuncOr(params.IsDebugMode, log.Info, log.Trace)
+	for _, url := range urls {
+		txHash, errf := BroadcastHex(url, txHex)
+		if errf != nil {
+			logFunc("call broadcasthex failed", "url", url, "err", errf)
+			err = errf
+			continue
+		}
+		logFunc("call broadcasthex success", "txHash", txHash, "url", url)
+		success = true
+	}
+	if success {
+		return true, nil
+	}
+	return false, err
+}
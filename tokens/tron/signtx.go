@@ -0,0 +1,141 @@
+package tron
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/signer"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tools/crypto"
+)
+
+func (b *Bridge) verifyTransactionWithArgs(rawTx interface{}, args *tokens.BuildTxArgs) (*RawTransaction, error) {
+	tx, ok := rawTx.(*RawTransaction)
+	if !ok || tx.Contract == nil {
+		return nil, tokens.ErrWrongRawTx
+	}
+	tokenCfg := b.GetTokenConfig(args.PairID)
+	if tokenCfg == nil {
+		return nil, fmt.Errorf("[sign] verify tx with unknown pairID '%v'", args.PairID)
+	}
+	dcrmAddress, err := DecodeAddress(tokenCfg.DcrmAddress)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(tx.Contract.OwnerAddress, dcrmAddress) {
+		return nil, fmt.Errorf("[sign] verify tx owner failed")
+	}
+	return tx, nil
+}
+
+// DcrmSignTransaction dcrm sign raw tx
+func (b *Bridge) DcrmSignTransaction(rawTx interface{}, args *tokens.BuildTxArgs) (signTx interface{}, txHash string, err error) {
+	tx, err := b.verifyTransactionWithArgs(rawTx, args)
+	if err != nil {
+		return nil, "", err
+	}
+	txID, err := tx.TxID()
+	if err != nil {
+		return nil, "", err
+	}
+	msgHash := "0x" + txID
+	jsondata, _ := json.Marshal(args)
+	msgContext := string(jsondata)
+
+	log.Info(b.ChainConfig.BlockChain+" DcrmSignTransaction start", "msghash", msgHash, "txid", args.SwapID)
+	rsv, err := signer.SignOne(args.PairID, b.IsSrc, b.GetDcrmPublicKey(args.PairID), msgHash, msgContext)
+	if err != nil {
+		return nil, "", err
+	}
+	log.Info(b.ChainConfig.BlockChain+" DcrmSignTransaction finished", "msghash", msgHash, "txid", args.SwapID)
+
+	log.Trace(b.ChainConfig.BlockChain+" DcrmSignTransaction get rsv success", "txid", args.SwapID, "rsv", rsv)
+	signature := common.FromHex(rsv)
+	if len(signature) != crypto.SignatureLength {
+		log.Error("DcrmSignTransaction wrong length of signature")
+		return nil, "", errors.New("wrong signature of txid " + args.SwapID)
+	}
+
+	signedTx, err := signTxWithSignature(tx, signature)
+	if err != nil {
+		return nil, "", err
+	}
+	log.Info(b.ChainConfig.BlockChain+" DcrmSignTransaction success", "txid", args.SwapID, "txhash", signedTx.TxID)
+	return signedTx, signedTx.TxID, nil
+}
+
+// signTxWithSignature fix recovery id of signature and build signed tx,
+// the recovery id in tron signature is 27 or 28
+func signTxWithSignature(tx *RawTransaction, signature []byte) (*SignedTransaction, error) {
+	txID, err := tx.TxID()
+	if err != nil {
+		return nil, err
+	}
+	sigHash, _ := hex.DecodeString(txID)
+	sig := make([]byte, crypto.SignatureLength)
+	copy(sig, signature)
+	vPos := crypto.SignatureLength - 1
+	if sig[vPos] >= 27 {
+		sig[vPos] -= 27
+	}
+	for i := 0; i < 2; i++ {
+		pubkey, errr := crypto.Ecrecover(sigHash, sig)
+		if errr == nil {
+			pubAddr, errp := PublicKeyToAddress(pubkey)
+			if errp == nil && pubAddr == EncodeAddress(tx.Contract.OwnerAddress) {
+				sig[vPos] += 27
+				return tx.WithSignature(sig)
+			}
+		}
+		sig[vPos] ^= 0x1 // v can only be 0 or 1
+	}
+	return nil, errors.New("wrong signer account")
+}
+
+// SignTransaction sign tx with pairID
+func (b *Bridge) SignTransaction(rawTx interface{}, pairID string) (signTx interface{}, txHash string, err error) {
+	privKey := b.GetTokenConfig(pairID).GetDcrmAddressPrivateKey()
+	return b.SignTransactionWithPrivateKey(rawTx, privKey)
+}
+
+// SignTransactionWithPrivateKey sign tx with ECDSA private key
+func (b *Bridge) SignTransactionWithPrivateKey(rawTx interface{}, privKey *ecdsa.PrivateKey) (signTx interface{}, txHash string, err error) {
+	tx, ok := rawTx.(*RawTransaction)
+	if !ok || tx.Contract == nil {
+		return nil, "", tokens.ErrWrongRawTx
+	}
+	if privKey == nil {
+		return nil, "", errors.New("empty private key")
+	}
+	txID, err := tx.TxID()
+	if err != nil {
+		return nil, "", err
+	}
+	sigHash, _ := hex.DecodeString(txID)
+	signature, err := crypto.Sign(sigHash, privKey)
+	if err != nil {
+		return nil, "", fmt.Errorf("sign tx failed, %w", err)
+	}
+	signedTx, err := signTxWithSignature(tx, signature)
+	if err != nil {
+		return nil, "", err
+	}
+	log.Info(b.ChainConfig.BlockChain+" SignTransaction success", "txhash", signedTx.TxID)
+	return signedTx, signedTx.TxID, nil
+}
+
+// GetSignedTxHashOfKeyID get signed tx hash by keyID (called by oracle),
+// the tx ID does not depend on signature
+func (b *Bridge) GetSignedTxHashOfKeyID(keyID, pairID string, rawTx interface{}) (txHash string, err error) {
+	tx, ok := rawTx.(*RawTransaction)
+	if !ok {
+		return "", errors.New("wrong raw tx of keyID " + keyID)
+	}
+	return tx.TxID()
+}
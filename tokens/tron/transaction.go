@@ -0,0 +1,113 @@
+package tron
+
+import (
+	"encoding/hex"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+const (
+	contractRetSuccess = "SUCCESS"
+	txInfoResultFailed = "FAILED"
+)
+
+// Transaction decoded transaction with execution result
+type Transaction struct {
+	TxID        string
+	Raw         *RawTransaction
+	Signature   []string
+	ContractRet string
+}
+
+// Receipt tx result, implements tokens.TxResultReceipt
+type Receipt struct {
+	TxID    string
+	Success bool
+	Logs    []*TransactionLog
+}
+
+// IsTxFailed returns if tx is failed
+func (r *Receipt) IsTxFailed() bool {
+	return !r.Success
+}
+
+// IsCanonicalTxID tx ID should be 64 lower case hex chars without 0x prefix
+func IsCanonicalTxID(txID string) bool {
+	if len(txID) != 64 || strings.ToLower(txID) != txID {
+		return false
+	}
+	_, err := hex.DecodeString(txID)
+	return err == nil
+}
+
+// GetTransaction get decoded tx by tx ID
+func (b *Bridge) GetTransaction(txHash string) (interface{}, error) {
+	return b.GetTransactionByHash(txHash)
+}
+
+// GetTransactionByHash get decoded tx by tx ID, the tx ID is checked
+// against the raw data
+func (b *Bridge) GetTransactionByHash(txHash string) (*Transaction, error) {
+	if !IsCanonicalTxID(txHash) {
+		return nil, tokens.ErrTxNotFound
+	}
+	result, err := b.GetTransactionByID(txHash)
+	if err != nil {
+		return nil, err
+	}
+	rawData, err := hex.DecodeString(result.RawDataHex)
+	if err != nil || CalcTxID(rawData) != txHash {
+		return nil, tokens.ErrTxIncompatible
+	}
+	raw, err := UnmarshalRawTransaction(rawData)
+	if err != nil {
+		return nil, tokens.ErrTxIncompatible
+	}
+	tx := &Transaction{
+		TxID:      txHash,
+		Raw:       raw,
+		Signature: result.Signature,
+	}
+	if len(result.Ret) > 0 {
+		tx.ContractRet = result.Ret[0].ContractRet
+	}
+	return tx, nil
+}
+
+// GetTransactionStatus impl
+func (b *Bridge) GetTransactionStatus(txHash string) (*tokens.TxStatus, error) {
+	if !IsCanonicalTxID(txHash) {
+		return nil, tokens.ErrTxNotFound
+	}
+	info, err := b.GetTransactionInfoByID(txHash)
+	if err != nil {
+		return nil, err
+	}
+	txStatus := &tokens.TxStatus{
+		Receipt:     &Receipt{TxID: txHash, Success: isTxInfoSuccess(info), Logs: info.Log},
+		BlockHeight: info.BlockNumber,
+		BlockTime:   uint64(info.BlockTimeStamp / 1000),
+	}
+	if info.BlockNumber == 0 {
+		return txStatus, nil
+	}
+	latest, err := b.GetLatestBlockNumber()
+	if err == nil && latest > info.BlockNumber {
+		txStatus.Confirmations = latest - info.BlockNumber
+	}
+	if b.ChainConfig.IsFinalityEnabled() {
+		finalized, errf := b.GetFinalizedBlockNumber()
+		txStatus.Finalized = errf == nil && finalized >= info.BlockNumber
+	}
+	return txStatus, nil
+}
+
+// isTxInfoSuccess check execution result, the receipt result of
+// TRX transfer is empty
+func isTxInfoSuccess(info *TransactionInfo) bool {
+	if info.Result == txInfoResultFailed {
+		return false
+	}
+	return info.Receipt.Result == "" || info.Receipt.Result == contractRetSuccess
+}
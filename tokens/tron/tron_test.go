@@ -0,0 +1,180 @@
+package tron
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tokens/tokenstest"
+	"github.com/anyswap/CrossChain-Bridge/tools/crypto"
+)
+
+var (
+	testOwner    = common.HexToAddress("0x1111111111111111111111111111111111111111").Bytes()
+	testDeposit  = common.HexToAddress("0x9999999999999999999999999999999999999999").Bytes()
+	testContract = common.HexToAddress("0x6666666666666666666666666666666666666666").Bytes()
+	testOther    = common.HexToAddress("0x2222222222222222222222222222222222222222").Bytes()
+)
+
+func TestAddress(t *testing.T) {
+	base58Addr := "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+	hexAddr := "41a614f803b6fd780986a42c78ec9c7f77e6ded13c"
+
+	addr, err := DecodeAddress(base58Addr)
+	if err != nil {
+		t.Fatalf("decode address failed: %v", err)
+	}
+	if hex.EncodeToString(addr) != hexAddr {
+		t.Fatalf("decode address mismatch: have %x want %v", addr, hexAddr)
+	}
+	if EncodeAddress(addr) != base58Addr {
+		t.Fatalf("encode address mismatch: have %v want %v", EncodeAddress(addr), base58Addr)
+	}
+	if toHex, _ := ToHexAddress(base58Addr); toHex != hexAddr {
+		t.Fatalf("to hex address mismatch: have %v want %v", toHex, hexAddr)
+	}
+	if ToEthAddress(addr) != "0xa614f803b6fd780986a42c78ec9c7f77e6ded13c" {
+		t.Fatalf("to eth address mismatch: have %v", ToEthAddress(addr))
+	}
+	for _, invalid := range []string{
+		"TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6u", // wrong checksum
+		"0xa614f803b6fd780986a42c78ec9c7f77e6ded13c",
+		"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2",
+	} {
+		if _, err := DecodeAddress(invalid); err == nil {
+			t.Errorf("decode invalid address %v should fail", invalid)
+		}
+	}
+}
+
+func TestTransactionEncoding(t *testing.T) {
+	tx := &RawTransaction{
+		RefBlockBytes: []byte{0x12, 0x34},
+		RefBlockHash:  []byte{1, 2, 3, 4, 5, 6, 7, 8},
+		Expiration:    1600000600000,
+		Data:          []byte("SWAPTX:0x1234"),
+		Contract: &Contract{
+			Type:         TriggerSmartContractType,
+			OwnerAddress: append([]byte{AddressPrefix}, testOwner...),
+			ToAddress:    append([]byte{AddressPrefix}, testContract...),
+			Data:         common.FromHex("0xa9059cbb"),
+		},
+		Timestamp: 1600000000000,
+		FeeLimit:  defaultFeeLimit,
+	}
+	data, err := tx.Marshal()
+	if err != nil {
+		t.Fatalf("marshal tx failed: %v", err)
+	}
+	decoded, err := UnmarshalRawTransaction(data)
+	if err != nil {
+		t.Fatalf("unmarshal tx failed: %v", err)
+	}
+	data2, _ := decoded.Marshal()
+	if !bytes.Equal(data, data2) {
+		t.Fatalf("tx encoding round trip mismatch")
+	}
+
+	privKey, _ := crypto.GenerateKey()
+	tx.Contract.OwnerAddress = append([]byte{AddressPrefix}, crypto.PubkeyToAddress(privKey.PublicKey).Bytes()...)
+	txID, _ := tx.TxID()
+	sigHash, _ := hex.DecodeString(txID)
+	signature, _ := crypto.Sign(sigHash, privKey)
+	signature[crypto.SignatureLength-1] ^= 0x1 // wrong recovery id should be fixed
+	signedTx, err := signTxWithSignature(tx, signature)
+	if err != nil {
+		t.Fatalf("sign tx failed: %v", err)
+	}
+	if signedTx.TxID != txID {
+		t.Fatalf("signed tx id mismatch: have %v want %v", signedTx.TxID, txID)
+	}
+	if v := signedTx.Signature[crypto.SignatureLength-1]; v != 27 && v != 28 {
+		t.Fatalf("wrong recovery id %v", v)
+	}
+}
+
+func newStubTrc20Swapin(t *testing.T, logTo []byte, value *big.Int, latest uint64) (api tokenstest.StubAPI, txID string) {
+	tx := &RawTransaction{
+		RefBlockBytes: []byte{0x12, 0x34},
+		RefBlockHash:  []byte{1, 2, 3, 4, 5, 6, 7, 8},
+		Expiration:    1600000600000,
+		Contract: &Contract{
+			Type:         TriggerSmartContractType,
+			OwnerAddress: append([]byte{AddressPrefix}, testOwner...),
+			ToAddress:    append([]byte{AddressPrefix}, testContract...),
+		},
+		Timestamp: 1600000000000,
+		FeeLimit:  defaultFeeLimit,
+	}
+	data, err := tx.Marshal()
+	if err != nil {
+		t.Fatalf("marshal tx failed: %v", err)
+	}
+	txID = CalcTxID(data)
+	block := &Block{BlockID: "00000000000000ff", BlockHeader: &BlockHeader{}}
+	block.BlockHeader.RawData.Number = latest
+	txInfo := &TransactionInfo{
+		ID:             txID,
+		BlockNumber:    100,
+		BlockTimeStamp: 1600000003000,
+		Log: []*TransactionLog{{
+			Address: hex.EncodeToString(testContract),
+			Topics: []string{
+				hex.EncodeToString(transferTopic),
+				hex.EncodeToString(common.LeftPadBytes(testOwner, 32)),
+				hex.EncodeToString(common.LeftPadBytes(logTo, 32)),
+			},
+			Data: hex.EncodeToString(common.LeftPadBytes(value.Bytes(), 32)),
+		}},
+	}
+	txInfo.Receipt.Result = contractRetSuccess
+	return tokenstest.StubAPI{
+		"/wallet/getnowblock":            block,
+		"/wallet/gettransactionbyid":     &TransactionResult{TxID: txID, RawDataHex: hex.EncodeToString(data)},
+		"/wallet/gettransactioninfobyid": txInfo,
+	}, txID
+}
+
+func newTestBridge(url string) *Bridge {
+	confirmations := uint64(20)
+	initialHeight := uint64(0)
+	b := NewCrossChainBridge(true)
+	b.ChainConfig = &tokens.ChainConfig{
+		BlockChain:    "TRON",
+		Confirmations: &confirmations,
+		InitialHeight: &initialHeight,
+	}
+	b.GatewayConfig = &tokens.GatewayConfig{APIAddress: []string{url}}
+	return b
+}
+
+func TestVerifyTrc20Swapin(t *testing.T) {
+	tokenstest.SetSwapinTokenPair(&tokens.TokenConfig{
+		DepositAddress:  EncodeAddress(testDeposit),
+		DcrmAddress:     EncodeAddress(testDeposit),
+		ContractAddress: EncodeAddress(testContract),
+	})
+	value := big.NewInt(100000000) // 100 tokens
+	checkSwapInfo := func(swapInfo *tokens.TxSwapInfo) bool {
+		return swapInfo.Bind == ToEthAddress(testOwner) && swapInfo.Value.Cmp(value) == 0 &&
+			swapInfo.To == EncodeAddress(testDeposit) && swapInfo.Height == 100
+	}
+
+	var tests []*tokenstest.VerifySwapinTest
+	for _, test := range []struct {
+		logTo   []byte
+		latest  uint64
+		wantErr error
+	}{
+		{logTo: testDeposit, latest: 200},
+		{logTo: testOther, latest: 200, wantErr: tokens.ErrTxWithWrongReceiver},
+		{logTo: testDeposit, latest: 110, wantErr: tokens.ErrTxNotStable},
+	} {
+		api, txID := newStubTrc20Swapin(t, test.logTo, value, test.latest)
+		tests = append(tests, &tokenstest.VerifySwapinTest{API: api, TxHash: txID, WantErr: test.wantErr, Check: checkSwapInfo})
+	}
+	tokenstest.RunVerifySwapinTests(t, func(url string) tokens.CrossChainBridge { return newTestBridge(url) }, tests)
+}
@@ -0,0 +1,217 @@
+package tron
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/params"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+// topic of `Transfer(address,address,uint256)`
+var transferTopic = common.FromHex("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+
+// VerifyMsgHash verify msg hash
+func (b *Bridge) VerifyMsgHash(rawTx interface{}, msgHashes []string) error {
+	tx, ok := rawTx.(*RawTransaction)
+	if !ok {
+		return tokens.ErrWrongRawTx
+	}
+	if len(msgHashes) != 1 {
+		return tokens.ErrWrongCountOfMsgHashes
+	}
+	txID, err := tx.TxID()
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold("0x"+txID, msgHashes[0]) {
+		logFunc := log.GetPrintFuncOr(params.IsDebugMode, log.Info, log.Trace)
+		logFunc("message hash mismatch", "want", msgHashes[0], "have", txID)
+		return tokens.ErrMsgHashMismatch
+	}
+	return nil
+}
+
+// VerifyTransaction impl
+func (b *Bridge) VerifyTransaction(pairID, txHash string, allowUnstable bool) (*tokens.TxSwapInfo, error) {
+	if !b.IsSrc {
+		return nil, tokens.ErrBridgeDestinationNotSupported
+	}
+	return b.verifySwapinTx(pairID, txHash, allowUnstable)
+}
+
+func (b *Bridge) verifySwapinTx(pairID, txHash string, allowUnstable bool) (*tokens.TxSwapInfo, error) {
+	token := b.GetTokenConfig(pairID)
+	if token == nil {
+		return nil, tokens.ErrUnknownPairID
+	}
+	if token.DisableSwap {
+		return nil, tokens.ErrSwapIsClosed
+	}
+	swapInfo := &tokens.TxSwapInfo{}
+	swapInfo.PairID = pairID // PairID
+	swapInfo.Hash = txHash   // Hash
+
+	if !IsCanonicalTxID(txHash) {
+		log.Debug("[verifySwapin] swapin tx id should be 64 lower case hex chars", "tx", txHash)
+		return swapInfo, tokens.ErrTxNotFound
+	}
+	txStatus, err := b.GetTransactionStatus(txHash)
+	if err != nil || txStatus.BlockHeight == 0 {
+		log.Debug("[verifySwapin] "+b.ChainConfig.BlockChain+" Bridge::GetTransactionStatus fail", "tx", txHash, "err", err)
+		return swapInfo, tokens.ErrTxNotFound
+	}
+	swapInfo.Height = txStatus.BlockHeight  // Height
+	swapInfo.Timestamp = txStatus.BlockTime // Timestamp
+	if txStatus.BlockHeight < *b.ChainConfig.InitialHeight {
+		return swapInfo, tokens.ErrTxBeforeInitialHeight
+	}
+	if !allowUnstable && !txStatus.IsStable(b.GetChainConfig(), tokens.GetMinRequiredConfirmations(pairID, b.IsSrc)) {
+		return swapInfo, tokens.ErrTxNotStable
+	}
+	receipt, ok := txStatus.Receipt.(*Receipt)
+	if !ok || !receipt.Success {
+		return swapInfo, tokens.ErrTxWithWrongReceipt
+	}
+	tx, err := b.GetTransactionByHash(txHash)
+	if err != nil {
+		log.Debug("[verifySwapin] "+b.ChainConfig.BlockChain+" Bridge::GetTransaction fail", "tx", txHash, "err", err)
+		return swapInfo, tokens.ErrTxNotFound
+	}
+
+	if token.ContractAddress != "" {
+		err = b.verifyTrc20SwapinTx(swapInfo, token, tx, receipt)
+	} else {
+		err = b.verifyTrxSwapinTx(swapInfo, token, tx)
+	}
+	if err != nil {
+		return swapInfo, err
+	}
+
+	err = b.checkSwapinInfo(swapInfo)
+	if err != nil {
+		return swapInfo, err
+	}
+
+	if !allowUnstable {
+		if err = tokens.CheckTieredConfirmations(b, swapInfo); err != nil {
+			return swapInfo, err
+		}
+		log.Info("verify swapin pass", "pairID", swapInfo.PairID, "from", swapInfo.From, "to", swapInfo.To, "bind", swapInfo.Bind, "value", swapInfo.Value, "txid", swapInfo.Hash, "height", swapInfo.Height, "timestamp", swapInfo.Timestamp)
+	}
+	return swapInfo, nil
+}
+
+func (b *Bridge) verifyTrxSwapinTx(swapInfo *tokens.TxSwapInfo, token *tokens.TokenConfig, tx *Transaction) error {
+	contract := tx.Raw.Contract
+	if contract.Type != TransferContractType {
+		return tokens.ErrWrongSwapinTxType
+	}
+	if tx.ContractRet != contractRetSuccess {
+		return tokens.ErrTxWithWrongReceipt
+	}
+	txTo := EncodeAddress(contract.ToAddress)
+	if txTo != token.DepositAddress {
+		return tokens.ErrTxWithWrongReceiver
+	}
+	swapInfo.TxTo = txTo                                 // TxTo
+	swapInfo.To = txTo                                   // To
+	swapInfo.From = EncodeAddress(contract.OwnerAddress) // From
+	swapInfo.Bind = ToEthAddress(contract.OwnerAddress)  // Bind
+	swapInfo.Value = big.NewInt(contract.Amount)         // Value
+	return nil
+}
+
+func (b *Bridge) verifyTrc20SwapinTx(swapInfo *tokens.TxSwapInfo, token *tokens.TokenConfig, tx *Transaction, receipt *Receipt) error {
+	contract := tx.Raw.Contract
+	swapInfo.TxTo = EncodeAddress(contract.ToAddress)    // TxTo
+	swapInfo.From = EncodeAddress(contract.OwnerAddress) // From
+
+	if !token.AllowSwapinFromContract &&
+		(contract.Type != TriggerSmartContractType || swapInfo.TxTo != token.ContractAddress) {
+		return tokens.ErrTxWithWrongContract
+	}
+
+	from, value, err := ParseTrc20SwapinTxLogs(receipt.Logs, token.ContractAddress, token.DepositAddress)
+	if err != nil {
+		if err != tokens.ErrTxWithWrongReceiver {
+			log.Debug(b.ChainConfig.BlockChain+" ParseTrc20SwapinTxLogs failed", "tx", swapInfo.Hash, "err", err)
+		}
+		return err
+	}
+	swapInfo.To = token.DepositAddress // To
+	swapInfo.Value = value             // Value
+	swapInfo.Bind = ToEthAddress(from) // Bind
+	return nil
+}
+
+// ParseTrc20SwapinTxLogs parse trc20 `Transfer` log to deposit address
+func ParseTrc20SwapinTxLogs(logs []*TransactionLog, contractAddress, depositAddress string) (from []byte, value *big.Int, err error) {
+	contract, err := DecodeAddress(contractAddress)
+	if err != nil {
+		return nil, nil, err
+	}
+	deposit, err := DecodeAddress(depositAddress)
+	if err != nil {
+		return nil, nil, err
+	}
+	transferLogExist := false
+	for _, log := range logs {
+		if !bytes.Equal(parseLogAddress(log.Address), contract[1:]) {
+			continue
+		}
+		if len(log.Topics) != 3 || !bytes.Equal(common.FromHex(log.Topics[0]), transferTopic) {
+			continue
+		}
+		transferLogExist = true
+		to := common.FromHex(log.Topics[2])
+		if len(to) != 32 || !bytes.Equal(to[12:], deposit[1:]) {
+			continue
+		}
+		fromTopic := common.FromHex(log.Topics[1])
+		if len(fromTopic) != 32 {
+			continue
+		}
+		data := common.FromHex(log.Data)
+		if len(data) != 32 {
+			continue
+		}
+		return fromTopic[12:], new(big.Int).SetBytes(data), nil
+	}
+	if transferLogExist {
+		err = tokens.ErrTxWithWrongReceiver
+	} else {
+		err = tokens.ErrDepositLogNotFound
+	}
+	return nil, nil, err
+}
+
+// parseLogAddress log address is in hex form with or without prefix 41
+func parseLogAddress(address string) []byte {
+	addr, err := hex.DecodeString(strings.TrimPrefix(address, "0x"))
+	if err != nil {
+		return nil
+	}
+	if len(addr) == AddressLength && addr[0] == AddressPrefix {
+		return addr[1:]
+	}
+	return addr
+}
+
+func (b *Bridge) checkSwapinInfo(swapInfo *tokens.TxSwapInfo) error {
+	if swapInfo.From == swapInfo.To {
+		return tokens.ErrTxWithWrongSender
+	}
+	if !tokens.CheckSwapValue(swapInfo.PairID, swapInfo.Value, b.IsSrc) {
+		return tokens.ErrTxWithWrongValue
+	}
+	if !tokens.DstBridge.IsValidAddress(swapInfo.Bind) {
+		log.Debug("wrong bind address in swapin", "bind", swapInfo.Bind)
+		return tokens.ErrTxWithWrongMemo
+	}
+	return nil
+}
@@ -13,6 +13,7 @@ const (
 	NoSwapType SwapType = iota
 	SwapinType
 	SwapoutType
+	RefundType // refund deposit to sender on the deposit chain
 )
 
 func (s SwapType) String() string {
@@ -23,6 +24,8 @@ func (s SwapType) String() string {
 		return "swapin"
 	case SwapoutType:
 		return "swapout"
+	case RefundType:
+		return "refund"
 	default:
 		return fmt.Sprintf("unknown swap type %d", s)
 	}
@@ -71,6 +74,7 @@ type TxStatus struct {
 	BlockHeight   uint64      `json:"block_height"`
 	BlockHash     string      `json:"block_hash"`
 	BlockTime     uint64      `json:"block_time"`
+	Finalized     bool        `json:"finalized,omitempty"`
 }
 
 // SwapInfo struct
@@ -96,6 +100,7 @@ type BuildTxArgs struct {
 	Input       *[]byte    `json:"input,omitempty"`
 	Extra       *AllExtras `json:"extra,omitempty"`
 	ReplaceNum  uint64     `json:"replaceNum,omitempty"`
+	RefundTo    string     `json:"refundTo,omitempty"` // sender of deposit (refund only)
 }
 
 // GetExtraArgs get extra args
@@ -111,6 +116,20 @@ func (args *BuildTxArgs) GetTxNonce() uint64 {
 	if args.Extra != nil && args.Extra.EthExtra != nil && args.Extra.EthExtra.Nonce != nil {
 		return *args.Extra.EthExtra.Nonce
 	}
+	if args.Extra != nil && args.Extra.SubstrateExtra != nil && args.Extra.SubstrateExtra.Nonce != nil {
+		return *args.Extra.SubstrateExtra.Nonce
+	}
+	if args.Extra != nil && args.Extra.CosmosExtra != nil && args.Extra.CosmosExtra.Sequence != nil {
+		return *args.Extra.CosmosExtra.Sequence
+	}
+	return 0
+}
+
+// GetTxExpiration get tx expiration in seconds (for tx without nonce)
+func (args *BuildTxArgs) GetTxExpiration() int64 {
+	if args.Extra != nil && args.Extra.TronExtra != nil && args.Extra.TronExtra.Expiration != nil {
+		return *args.Extra.TronExtra.Expiration / 1000
+	}
 	return 0
 }
 
@@ -136,8 +155,11 @@ func (args *BuildTxArgs) GetTxGasPrice() *big.Int {
 
 // AllExtras struct
 type AllExtras struct {
-	BtcExtra *BtcExtraArgs `json:"btcExtra,omitempty"`
-	EthExtra *EthExtraArgs `json:"ethExtra,omitempty"`
+	BtcExtra       *BtcExtraArgs       `json:"btcExtra,omitempty"`
+	EthExtra       *EthExtraArgs       `json:"ethExtra,omitempty"`
+	SubstrateExtra *SubstrateExtraArgs `json:"substrateExtra,omitempty"`
+	TronExtra      *TronExtraArgs      `json:"tronExtra,omitempty"`
+	CosmosExtra    *CosmosExtraArgs    `json:"cosmosExtra,omitempty"`
 }
 
 // EthExtraArgs struct
@@ -149,6 +171,33 @@ type EthExtraArgs struct {
 	Nonce     *uint64  `json:"nonce,omitempty"`
 }
 
+// SubstrateExtraArgs struct
+type SubstrateExtraArgs struct {
+	Nonce       *uint64  `json:"nonce,omitempty"`
+	Tip         *big.Int `json:"tip,omitempty"`
+	BlockNumber *uint64  `json:"blockNumber,omitempty"` // era checkpoint
+	BlockHash   *string  `json:"blockHash,omitempty"`   // era checkpoint
+	SpecVersion *uint32  `json:"specVersion,omitempty"`
+	TxVersion   *uint32  `json:"txVersion,omitempty"`
+}
+
+// TronExtraArgs struct
+type TronExtraArgs struct {
+	RefBlockNum  *uint64 `json:"refBlockNum,omitempty"`
+	RefBlockHash *string `json:"refBlockHash,omitempty"`
+	Timestamp    *int64  `json:"timestamp,omitempty"`  // milliseconds
+	Expiration   *int64  `json:"expiration,omitempty"` // milliseconds
+	FeeLimit     *int64  `json:"feeLimit,omitempty"`
+}
+
+// CosmosExtraArgs struct
+type CosmosExtraArgs struct {
+	AccountNumber *uint64 `json:"accountNumber,omitempty"`
+	Sequence      *uint64 `json:"sequence,omitempty"`
+	Gas           *uint64 `json:"gas,omitempty"`
+	Fee           *string `json:"fee,omitempty"` // amount in fee denom
+}
+
 // BtcOutPoint struct
 type BtcOutPoint struct {
 	Hash  string `json:"hash"`
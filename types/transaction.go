@@ -233,6 +233,25 @@ func prefixedRlpHash(prefix byte, x interface{}) (h common.Hash) {
 	return h
 }
 
+// rlpEncode rlp encode x, the encoding error is ignored as in rlpHash.
+func rlpEncode(x interface{}) []byte {
+	data, _ := rlp.EncodeToBytes(x)
+	return data
+}
+
+// prefixedRlpEncode rlp encode x with prefix, it's used for typed transactions.
+func prefixedRlpEncode(prefix byte, x interface{}) []byte {
+	return append([]byte{prefix}, rlpEncode(x)...)
+}
+
+// preimageHash keccak256 hash of preimage, and empty hash of empty preimage
+func preimageHash(preimage []byte) (h common.Hash) {
+	if len(preimage) == 0 {
+		return h
+	}
+	return crypto.Keccak256Hash(preimage)
+}
+
 type writeCounter StorageSize
 
 func (c *writeCounter) Write(b []byte) (int, error) {
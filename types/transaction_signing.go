@@ -82,6 +82,8 @@ type Signer interface {
 	SignatureValues(tx *Transaction, sig []byte) (r, s, v *big.Int, err error)
 	// Hash returns the hash to be signed.
 	Hash(tx *Transaction) common.Hash
+	// HashPreimage returns the data whose keccak256 hash is the hash to be signed.
+	HashPreimage(tx *Transaction) []byte
 	// Equal returns true if the given signer is the same as the receiver.
 	Equal(Signer) bool
 }
@@ -133,10 +135,15 @@ func (s londonSigner) SignatureValues(tx *Transaction, sig []byte) (R, S, V *big
 // Hash returns the hash to be signed by the sender.
 // It does not uniquely identify the transaction.
 func (s londonSigner) Hash(tx *Transaction) common.Hash {
+	return preimageHash(s.HashPreimage(tx))
+}
+
+// HashPreimage returns the data whose keccak256 hash is the hash to be signed.
+func (s londonSigner) HashPreimage(tx *Transaction) []byte {
 	if tx.Type() != DynamicFeeTxType {
-		return s.eip2930Signer.Hash(tx)
+		return s.eip2930Signer.HashPreimage(tx)
 	}
-	return prefixedRlpHash(
+	return prefixedRlpEncode(
 		tx.Type(),
 		[]interface{}{
 			s.chainID,
@@ -211,11 +218,16 @@ func (s eip2930Signer) SignatureValues(tx *Transaction, sig []byte) (R, S, V *bi
 // Hash returns the hash to be signed by the sender.
 // It does not uniquely identify the transaction.
 func (s eip2930Signer) Hash(tx *Transaction) common.Hash {
+	return preimageHash(s.HashPreimage(tx))
+}
+
+// HashPreimage returns the data whose keccak256 hash is the hash to be signed.
+func (s eip2930Signer) HashPreimage(tx *Transaction) []byte {
 	switch tx.Type() {
 	case LegacyTxType:
-		return s.EIP155Signer.Hash(tx)
+		return s.EIP155Signer.HashPreimage(tx)
 	case AccessListTxType:
-		return prefixedRlpHash(
+		return prefixedRlpEncode(
 			tx.Type(),
 			[]interface{}{
 				s.chainID,
@@ -232,7 +244,7 @@ func (s eip2930Signer) Hash(tx *Transaction) common.Hash {
 		// json struct via RPC, it's probably more prudent to return an
 		// empty hash instead of killing the node with a panic
 		//panic("Unsupported transaction type: %d", tx.typ)
-		return common.Hash{}
+		return nil
 	}
 }
 
@@ -290,7 +302,12 @@ func (s EIP155Signer) SignatureValues(tx *Transaction, sig []byte) (rsvR, rsvS,
 // Hash returns the hash to be signed by the sender.
 // It does not uniquely identify the transaction.
 func (s EIP155Signer) Hash(tx *Transaction) common.Hash {
-	return rlpHash([]interface{}{
+	return preimageHash(s.HashPreimage(tx))
+}
+
+// HashPreimage returns the data whose keccak256 hash is the hash to be signed.
+func (s EIP155Signer) HashPreimage(tx *Transaction) []byte {
+	return rlpEncode([]interface{}{
 		tx.data.AccountNonce,
 		tx.data.Price,
 		tx.data.GasLimit,
@@ -344,7 +361,12 @@ func (fs FrontierSigner) SignatureValues(tx *Transaction, sig []byte) (r, s, v *
 // Hash returns the hash to be signed by the sender.
 // It does not uniquely identify the transaction.
 func (fs FrontierSigner) Hash(tx *Transaction) common.Hash {
-	return rlpHash([]interface{}{
+	return preimageHash(fs.HashPreimage(tx))
+}
+
+// HashPreimage returns the data whose keccak256 hash is the hash to be signed.
+func (fs FrontierSigner) HashPreimage(tx *Transaction) []byte {
+	return rlpEncode([]interface{}{
 		tx.data.AccountNonce,
 		tx.data.Price,
 		tx.data.GasLimit,
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/anyswap/CrossChain-Bridge/common"
@@ -40,3 +41,20 @@ func TestTxHash(t *testing.T) {
 		}
 	}
 }
+
+func TestHashPreimage(t *testing.T) {
+	// example of EIP-155
+	tx := NewTransaction(9, common.HexToAddress("0x3535353535353535353535353535353535353535"),
+		big.NewInt(1e18), 21000, big.NewInt(20e9), nil)
+	wantPreimage := "0xec098504a817c800825208943535353535353535353535353535353535353535880de0b6b3a764000080018080"
+	wantHash := "0xdaf5a779ae972f972197303d7b574746c7ef83eadac0f2791ad23db92e4c8e53"
+
+	for _, signer := range []Signer{NewEIP155Signer(big.NewInt(1)), NewLondonSigner(big.NewInt(1))} {
+		if preimage := common.ToHex(signer.HashPreimage(tx)); preimage != wantPreimage {
+			t.Errorf("preimage mismatch, have %s, want %s", preimage, wantPreimage)
+		}
+		if hash := signer.Hash(tx).Hex(); hash != wantHash {
+			t.Errorf("hash mismatch, have %s, want %s", hash, wantHash)
+		}
+	}
+}
@@ -4,10 +4,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	"github.com/anyswap/CrossChain-Bridge/acceptpolicy"
 	"github.com/anyswap/CrossChain-Bridge/cmd/utils"
 	"github.com/anyswap/CrossChain-Bridge/common"
 	"github.com/anyswap/CrossChain-Bridge/dcrm"
@@ -20,6 +22,7 @@ import (
 const (
 	acceptAgree    = "AGREE"
 	acceptDisagree = "DISAGREE"
+	acceptDiscard  = "DISCARD" // not accepted as sign info is invalid
 )
 
 var (
@@ -43,6 +46,8 @@ var (
 	errWrongMsgContext    = errors.New("wrong msg context")
 	errInvalidSignInfo    = errors.New("invalid sign info")
 	errExpiredSignInfo    = errors.New("expired sign info")
+	errKeyTypeMismatch    = errors.New("sign key type mismatch")
+	errWrongBatchSign     = errors.New("wrong batch sign info")
 )
 
 // StartAcceptSignJob accept job
@@ -58,6 +63,12 @@ func StartAcceptSignJob() {
 	acceptSignStarter.Do(func() {
 		logWorker("accept", "start accept sign job")
 		openLeveldb()
+		if params.IsOracleShadowMode() {
+			initShadowSource()
+		}
+		initAcceptPolicy()
+		startAcceptAuditAPI()
+		go startAcceptAuditPruneJob()
 		go startAcceptProducer()
 
 		utils.TopWaitGroup.Add(1)
@@ -68,15 +79,15 @@ func StartAcceptSignJob() {
 func startAcceptProducer() {
 	i := 0
 	for {
-		signInfo, err := dcrm.GetCurNodeSignInfo()
+		signInfo, err := getAcceptSignInfos()
 		if err != nil {
-			logWorkerError("accept", "getCurNodeSignInfo failed", err)
+			logWorkerError("accept", "getAcceptSignInfos failed", err)
 			time.Sleep(retryInterval)
 			continue
 		}
 		i++
 		if i%7 == 0 {
-			logWorker("accept", "getCurNodeSignInfo", "count", len(signInfo))
+			logWorker("accept", "getAcceptSignInfos", "count", len(signInfo))
 		}
 		for _, info := range signInfo {
 			if utils.IsCleanuping() {
@@ -151,12 +162,31 @@ func processAcceptInfo(info *dcrm.SignInfoData) {
 		}
 	}()
 
-	args, err := verifySignInfo(info)
+	var (
+		argsList  []*tokens.BuildTxArgs
+		swapInfos []*tokens.TxSwapInfo
+		decision  *acceptpolicy.Decision
+		err       error
+	)
+	startTime := time.Now()
+	if held := getHeldAcceptSign(keyID); held != nil {
+		// held sign request is only waiting for manual decision
+		decision = getManualDecision(keyID, held.decision.Swaps)
+		if decision == nil {
+			logWorkerTrace("accept", "sign is still held", "keyID", keyID)
+			return
+		}
+		argsList, swapInfos = held.argsList, held.swapInfos
+	} else {
+		argsList, swapInfos, err = verifySignInfo(info)
+	}
+	audit := newAcceptAuditRecord(info, argsList, swapInfos, startTime)
 
 	ctx := []interface{}{
 		"keyID", keyID,
 	}
-	if args != nil {
+	if len(argsList) > 0 {
+		args := argsList[0]
 		ctx = append(ctx,
 			"identifier", args.Identifier,
 			"swaptype", args.SwapType.String(),
@@ -164,6 +194,9 @@ func processAcceptInfo(info *dcrm.SignInfoData) {
 			"swapID", args.SwapID,
 			"bind", args.Bind,
 		)
+		if len(argsList) > 1 {
+			ctx = append(ctx, "batchSize", len(argsList))
+		}
 	}
 
 	switch {
@@ -182,61 +215,116 @@ func processAcceptInfo(info *dcrm.SignInfoData) {
 		errors.Is(err, errWrongMsgContext),
 		errors.Is(err, errExpiredSignInfo),
 		errors.Is(err, errInvalidSignInfo),
+		errors.Is(err, errKeyTypeMismatch),
+		errors.Is(err, errWrongBatchSign),
 		errors.Is(err, tokens.ErrUnknownPairID),
 		errors.Is(err, tokens.ErrNoBtcBridge):
 		ctx = append(ctx, "err", err)
 		logWorker("accept", "discard sign", ctx...)
 		isProcessed = true
+		if !errors.Is(err, errInitiatorMismatch) && !errors.Is(err, errExpiredSignInfo) {
+			audit.setDecision(acceptDiscard, err.Error())
+			saveAcceptAuditRecord(audit)
+		}
 		return
 	}
 
 	agreeResult := acceptAgree
+	reason := ""
 	if err != nil {
 		logWorkerError("accept", "DISAGREE sign", err, ctx...)
 		agreeResult = acceptDisagree
+		reason = err.Error()
+		audit.VerifyError = reason
+	} else if decision == nil {
+		decision = evaluateAcceptPolicy(keyID, argsList, swapInfos)
+	}
+	if decision != nil {
+		ctx = append(ctx, "rule", decision.Rule, "reason", decision.Reason)
+		audit.Policy = decision
+		reason = decision.Reason
+		switch decision.Result {
+		case acceptpolicy.ResultHold:
+			audit.setDecision(acceptpolicy.ResultHold, reason)
+			if audit.Shadow {
+				logWorker("accept", "shadow HOLD sign", ctx...)
+				isProcessed = true
+			} else {
+				holdAcceptSign(audit.SignTime/1000, argsList, swapInfos, decision)
+				logWorker("accept", "HOLD sign", ctx...)
+			}
+			saveAcceptAuditRecord(audit)
+			return
+		case acceptpolicy.ResultDisagree:
+			logWorkerWarn("accept", "DISAGREE sign by accept policy", ctx...)
+			agreeResult = acceptDisagree
+		}
 	}
 	ctx = append(ctx, "result", agreeResult)
+	audit.setDecision(agreeResult, reason)
+
+	if audit.Shadow {
+		logWorker("accept", "shadow accept sign finish", ctx...)
+		isProcessed = true
+		finishAcceptDecision(decision)
+		saveAcceptAuditRecord(audit)
+		return
+	}
 
+	acceptStart := time.Now()
 	res, err := dcrm.DoAcceptSign(keyID, agreeResult, info.MsgHash, info.MsgContext)
+	audit.AcceptMillis = time.Since(acceptStart).Milliseconds()
 	if err != nil {
 		ctx = append(ctx, "rpcResult", res)
 		logWorkerError("accept", "accept sign job failed", err, ctx...)
+		audit.AcceptError = err.Error()
 	} else {
 		logWorker("accept", "accept sign job finish", ctx...)
 		isProcessed = true
+		finishAcceptDecision(decision)
 	}
+	saveAcceptAuditRecord(audit)
 }
 
-func getBuildTxArgsFromMsgContext(signInfo *dcrm.SignInfoData) (*tokens.BuildTxArgs, error) {
+// getBuildTxArgsFromMsgContext get build tx args from msg context,
+// batch sign has one msg context for each msg hash
+func getBuildTxArgsFromMsgContext(signInfo *dcrm.SignInfoData) ([]*tokens.BuildTxArgs, error) {
 	msgContext := signInfo.MsgContext
-	if len(msgContext) != 1 {
+	if len(msgContext) == 0 || len(msgContext) > params.MaxSignBatchSizeLimit {
 		return nil, errWrongMsgContext
 	}
-	var args tokens.BuildTxArgs
-	err := json.Unmarshal([]byte(msgContext[0]), &args)
-	if err != nil {
-		return nil, errWrongMsgContext
+	argsList := make([]*tokens.BuildTxArgs, 0, len(msgContext))
+	for _, context := range msgContext {
+		var args tokens.BuildTxArgs
+		err := json.Unmarshal([]byte(context), &args)
+		if err != nil {
+			return nil, errWrongMsgContext
+		}
+		argsList = append(argsList, &args)
 	}
-	return &args, nil
+	return argsList, nil
 }
 
-func verifySignInfo(signInfo *dcrm.SignInfoData) (args *tokens.BuildTxArgs, err error) {
+// verifySignInfo verify sign info, the verified swap infos are returned
+// to be evaluated by accept policy (not for aggregate sign)
+func verifySignInfo(signInfo *dcrm.SignInfoData) (argsList []*tokens.BuildTxArgs, swapInfos []*tokens.TxSwapInfo, err error) {
 	timestamp, err := common.GetUint64FromStr(signInfo.TimeStamp)
 	if err != nil || int64(timestamp/1000)+maxAcceptSignTimeInterval < time.Now().Unix() {
 		logWorkerTrace("accept", "expired accept sign info", "signInfo", signInfo)
-		return nil, errExpiredSignInfo
+		return nil, nil, errExpiredSignInfo
 	}
 	if signInfo.Key == "" || signInfo.Account == "" || signInfo.GroupID == "" {
 		logWorkerWarn("accept", "invalid accept sign info", "signInfo", signInfo)
-		return nil, errInvalidSignInfo
+		return nil, nil, errInvalidSignInfo
 	}
 	if !params.IsDcrmInitiator(signInfo.Account) {
-		return nil, errInitiatorMismatch
+		return nil, nil, errInitiatorMismatch
 	}
-	args, err = getBuildTxArgsFromMsgContext(signInfo)
+	argsList, err = getBuildTxArgsFromMsgContext(signInfo)
 	if err != nil {
-		return args, err
+		return argsList, nil, err
 	}
+	args := argsList[0]
 	msgHash := signInfo.MsgHash
 	msgContext := signInfo.MsgContext
 	switch args.Identifier {
@@ -244,32 +332,117 @@ func verifySignInfo(signInfo *dcrm.SignInfoData) (args *tokens.BuildTxArgs, err
 	case params.GetReplaceIdentifier():
 	case tokens.AggregateIdentifier:
 		if btc.BridgeInstance == nil {
-			return args, tokens.ErrNoBtcBridge
+			return argsList, nil, tokens.ErrNoBtcBridge
 		}
 		logWorker("accept", "verifySignInfo", "msgHash", msgHash, "msgContext", msgContext)
+		if len(argsList) != 1 {
+			return argsList, nil, errWrongBatchSign
+		}
+		if !isSignKeyTypeMatch(signInfo.KeyType, tokens.KeyTypeECDSA) {
+			return argsList, nil, errKeyTypeMismatch
+		}
 		err = btc.BridgeInstance.VerifyAggregateMsgHash(msgHash, args)
 		if err != nil {
-			return args, err
+			return argsList, nil, err
 		}
-		return args, nil
+		return argsList, nil, nil
 	default:
-		return args, errIdentifierMismatch
+		return argsList, nil, errIdentifierMismatch
 	}
 	logWorker("accept", "verifySignInfo", "keyID", signInfo.Key, "msgHash", msgHash, "msgContext", msgContext)
-	if lvldbHandle != nil && args.GetTxNonce() > 0 { // only for eth like chain
-		err = CheckAcceptRecord(args)
+	isBatch := len(argsList) > 1
+	if isBatch {
+		err = checkBatchSignInfo(signInfo, argsList)
 		if err != nil {
-			return args, err
+			return argsList, nil, err
 		}
 	}
-	err = rebuildAndVerifyMsgHash(signInfo.Key, msgHash, args)
-	if err != nil {
-		return args, err
+	swapInfos = make([]*tokens.TxSwapInfo, len(argsList))
+	for i, args := range argsList {
+		err = checkSignKeyType(signInfo, args)
+		if err != nil {
+			return argsList, nil, err
+		}
+		if lvldbHandle != nil && (args.GetTxNonce() > 0 || args.GetTxExpiration() > 0) { // only for chain with nonce or tx expiration
+			err = CheckAcceptRecord(args)
+			if err != nil {
+				return argsList, nil, err
+			}
+		}
+		if lvldbHandle != nil { // refund on chain without nonce must be checked too
+			err = checkRefundConflict(args)
+			if err != nil {
+				return argsList, nil, err
+			}
+		}
+		argsMsgHash := msgHash
+		if isBatch {
+			argsMsgHash = msgHash[i : i+1]
+		}
+		swapInfos[i], err = rebuildAndVerifyMsgHash(signInfo.Key, argsMsgHash, args, i, len(argsList))
+		if err != nil {
+			return argsList, nil, err
+		}
 	}
-	return args, nil
+	return argsList, swapInfos, nil
 }
 
-func rebuildAndVerifyMsgHash(keyID string, msgHash []string, args *tokens.BuildTxArgs) error {
+// checkBatchSignInfo check batch sign info, every msg hash has its own msg context,
+// all swaps are of the same swap type and the tx nonces are consecutive
+func checkBatchSignInfo(signInfo *dcrm.SignInfoData, argsList []*tokens.BuildTxArgs) error {
+	if len(signInfo.MsgHash) != len(argsList) {
+		logWorkerWarn("accept", "batch sign msg hash and context mismatch", "keyID", signInfo.Key, "msgHashes", len(signInfo.MsgHash), "msgContexts", len(argsList))
+		return errWrongBatchSign
+	}
+	first := argsList[0]
+	swaps := make(map[string]struct{}, len(argsList))
+	for i, args := range argsList {
+		if args.Identifier != params.GetIdentifier() || args.SwapType != first.SwapType {
+			logWorkerWarn("accept", "batch sign with different identifier or swap type", "keyID", signInfo.Key, "index", i)
+			return errWrongBatchSign
+		}
+		if args.GetTxNonce() != first.GetTxNonce()+uint64(i) {
+			logWorkerWarn("accept", "batch sign with non consecutive nonces", "keyID", signInfo.Key, "index", i, "nonce", args.GetTxNonce(), "firstNonce", first.GetTxNonce())
+			return errWrongBatchSign
+		}
+		swapKey := strings.ToLower(fmt.Sprintf("%v:%v:%v", args.PairID, args.SwapID, args.Bind))
+		if _, exist := swaps[swapKey]; exist {
+			logWorkerWarn("accept", "batch sign with duplicate swap", "keyID", signInfo.Key, "index", i, "swapID", args.SwapID)
+			return errWrongBatchSign
+		}
+		swaps[swapKey] = struct{}{}
+	}
+	return nil
+}
+
+// checkSignKeyType check key type of sign info is the one declared by the signing chain
+func checkSignKeyType(signInfo *dcrm.SignInfoData, args *tokens.BuildTxArgs) error {
+	var isSrc bool
+	switch args.SwapType {
+	case tokens.SwapinType:
+		isSrc = false
+	case tokens.SwapoutType, tokens.RefundType:
+		isSrc = true
+	default:
+		return fmt.Errorf("unknown swap type %v", args.SwapType)
+	}
+	wantKeyType := tokens.GetSignKeyType(isSrc)
+	if !isSignKeyTypeMatch(signInfo.KeyType, wantKeyType) {
+		logWorkerWarn("accept", "sign key type mismatch", "keyID", signInfo.Key, "have", signInfo.KeyType, "want", wantKeyType)
+		return errKeyTypeMismatch
+	}
+	return nil
+}
+
+// empty key type is treated as ECDSA for compatibility
+func isSignKeyTypeMatch(keyType, wantKeyType string) bool {
+	if keyType == "" {
+		keyType = tokens.KeyTypeECDSA
+	}
+	return strings.EqualFold(keyType, wantKeyType)
+}
+
+func rebuildAndVerifyMsgHash(keyID string, msgHash []string, args *tokens.BuildTxArgs, msgIndex, msgCount int) (*tokens.TxSwapInfo, error) {
 	var srcBridge, dstBridge tokens.CrossChainBridge
 	switch args.SwapType {
 	case tokens.SwapinType:
@@ -278,13 +451,16 @@ func rebuildAndVerifyMsgHash(keyID string, msgHash []string, args *tokens.BuildT
 	case tokens.SwapoutType:
 		srcBridge = tokens.DstBridge
 		dstBridge = tokens.SrcBridge
+	case tokens.RefundType: // refund on the deposit chain
+		srcBridge = tokens.SrcBridge
+		dstBridge = tokens.SrcBridge
 	default:
-		return fmt.Errorf("unknown swap type %v", args.SwapType)
+		return nil, fmt.Errorf("unknown swap type %v", args.SwapType)
 	}
 
 	tokenCfg := dstBridge.GetTokenConfig(args.PairID)
 	if tokenCfg == nil {
-		return tokens.ErrUnknownPairID
+		return nil, tokens.ErrUnknownPairID
 	}
 
 	ctx := []interface{}{
@@ -296,10 +472,16 @@ func rebuildAndVerifyMsgHash(keyID string, msgHash []string, args *tokens.BuildT
 		"bind", args.Bind,
 	}
 
-	swapInfo, err := verifySwapTransaction(srcBridge, args.PairID, args.SwapID, args.Bind, args.TxType)
+	var swapInfo *tokens.TxSwapInfo
+	var err error
+	if args.SwapType == tokens.RefundType {
+		swapInfo, err = verifyRefundDeposit(args.PairID, args.SwapID, args.Bind, args.TxType)
+	} else {
+		swapInfo, err = verifySwapTransaction(srcBridge, args.PairID, args.SwapID, args.Bind, args.TxType)
+	}
 	if err != nil {
 		logWorkerError("accept", "verifySignInfo failed", err, ctx...)
-		return err
+		return nil, err
 	}
 
 	buildTxArgs := &tokens.BuildTxArgs{
@@ -308,29 +490,49 @@ func rebuildAndVerifyMsgHash(keyID string, msgHash []string, args *tokens.BuildT
 		OriginValue: swapInfo.Value,
 		Extra:       args.Extra,
 	}
+	if args.SwapType == tokens.RefundType {
+		buildTxArgs.RefundTo = swapInfo.From // always refund to the deposit sender
+	}
 	rawTx, err := dstBridge.BuildRawTransaction(buildTxArgs)
 	if err != nil {
 		logWorkerError("accept", "build raw tx failed", err, ctx...)
-		return err
+		return nil, err
 	}
 	err = dstBridge.VerifyMsgHash(rawTx, msgHash)
 	if err != nil {
 		logWorkerError("accept", "verify message hash failed", err, ctx...)
-		return err
+		return nil, err
 	}
-	if lvldbHandle != nil && args.GetTxNonce() > 0 { // only for eth like chain
-		go saveAcceptRecord(dstBridge, keyID, buildTxArgs, rawTx)
+	if lvldbHandle != nil && !params.IsOracleShadowMode() &&
+		(args.GetTxNonce() > 0 || args.GetTxExpiration() > 0) { // only for chain with nonce or tx expiration
+		go saveAcceptRecord(dstBridge, keyID, buildTxArgs, rawTx, msgIndex, msgCount)
 	}
 	logWorker("accept", "verify message hash success", ctx...)
-	return nil
+	return swapInfo, nil
 }
 
-func saveAcceptRecord(bridge tokens.CrossChainBridge, keyID string, args *tokens.BuildTxArgs, rawTx interface{}) {
-	impl, ok := bridge.(interface {
-		GetSignedTxHashOfKeyID(keyID, pairID string, rawTx interface{}) (txHash string, err error)
-	})
-	if !ok {
-		return
+func saveAcceptRecord(bridge tokens.CrossChainBridge, keyID string, args *tokens.BuildTxArgs, rawTx interface{}, msgIndex, msgCount int) {
+	var getSignedTxHash func() (string, error)
+	if msgCount > 1 {
+		impl, ok := bridge.(interface {
+			GetSignedTxHashOfKeyIDAt(keyID, pairID string, rawTx interface{}, msgIndex, msgCount int) (txHash string, err error)
+		})
+		if !ok {
+			return
+		}
+		getSignedTxHash = func() (string, error) {
+			return impl.GetSignedTxHashOfKeyIDAt(keyID, args.PairID, rawTx, msgIndex, msgCount)
+		}
+	} else {
+		impl, ok := bridge.(interface {
+			GetSignedTxHashOfKeyID(keyID, pairID string, rawTx interface{}) (txHash string, err error)
+		})
+		if !ok {
+			return
+		}
+		getSignedTxHash = func() (string, error) {
+			return impl.GetSignedTxHashOfKeyID(keyID, args.PairID, rawTx)
+		}
 	}
 
 	ctx := []interface{}{
@@ -342,7 +544,7 @@ func saveAcceptRecord(bridge tokens.CrossChainBridge, keyID string, args *tokens
 		"bind", args.Bind,
 	}
 
-	swapTx, err := impl.GetSignedTxHashOfKeyID(keyID, args.PairID, rawTx)
+	swapTx, err := getSignedTxHash()
 	if err != nil {
 		logWorkerError("accept", "get signed tx hash failed", err, ctx...)
 		return
@@ -0,0 +1,467 @@
+package worker
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/acceptpolicy"
+	"github.com/anyswap/CrossChain-Bridge/admin"
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/dcrm"
+	"github.com/anyswap/CrossChain-Bridge/leveldb"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/params"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+const (
+	acceptAuditKeyPrefix       = "acceptaudit:"
+	acceptAuditKeyIndexPrefix  = "acceptauditkey:"
+	acceptAuditSwapIndexPrefix = "acceptauditswap:"
+
+	defaultAuditQueryLimit = 100
+	maxAuditQueryLimit     = 10000
+
+	defaultAuditRetentionDays = 90
+	acceptAuditPruneInterval  = time.Hour
+
+	acceptAuditAdminMethod = "acceptaudit"
+)
+
+// AcceptAuditRecord accept audit record, one record per sign request (keyID),
+// it is updated if the decision is changed (eg. from HOLD to AGREE)
+type AcceptAuditRecord struct {
+	KeyID         string                 `json:"keyID"`
+	Initiator     string                 `json:"initiator"`
+	GroupID       string                 `json:"groupID"`
+	KeyType       string                 `json:"keyType,omitempty"`
+	MsgHashes     []string               `json:"msgHashes"`
+	Identifier    string                 `json:"identifier,omitempty"`
+	SwapType      string                 `json:"swapType,omitempty"`
+	Swaps         []*AcceptAuditSwap     `json:"swaps,omitempty"`
+	Decision      string                 `json:"decision"`
+	Reason        string                 `json:"reason,omitempty"`
+	VerifyError   string                 `json:"verifyError,omitempty"`
+	Policy        *acceptpolicy.Decision `json:"policy,omitempty"`
+	AcceptError   string                 `json:"acceptError,omitempty"`
+	SignTime      int64                  `json:"signTime"`      // sign request timestamp (milliseconds)
+	FirstSeenTime int64                  `json:"firstSeenTime"` // milliseconds
+	DecisionTime  int64                  `json:"decisionTime"`  // milliseconds
+	VerifyMillis  int64                  `json:"verifyMillis"`
+	AcceptMillis  int64                  `json:"acceptMillis,omitempty"`
+	Shadow        bool                   `json:"shadow,omitempty"` // decided in shadow mode without accepting
+}
+
+// AcceptAuditSwap swap of sign request, value and tx info are filled if verified
+type AcceptAuditSwap struct {
+	PairID    string `json:"pairID"`
+	SwapID    string `json:"swapID"`
+	Bind      string `json:"bind"`
+	Nonce     uint64 `json:"nonce,omitempty"`
+	Value     string `json:"value,omitempty"`
+	Height    uint64 `json:"height,omitempty"`
+	Timestamp uint64 `json:"timestamp,omitempty"`
+}
+
+// AcceptAuditFilter filter of querying accept audit records, time range is of decision time
+type AcceptAuditFilter struct {
+	TxID      string // swap ID or keyID
+	PairID    string
+	Decision  string
+	StartTime int64 // unix seconds, inclusive
+	EndTime   int64 // unix seconds, exclusive
+	Limit     int   // 0 means default limit, negative means no limit
+}
+
+func newAcceptAuditRecord(info *dcrm.SignInfoData, argsList []*tokens.BuildTxArgs, swapInfos []*tokens.TxSwapInfo, startTime time.Time) *AcceptAuditRecord {
+	signTime, _ := common.GetUint64FromStr(info.TimeStamp)
+	record := &AcceptAuditRecord{
+		KeyID:         info.Key,
+		Initiator:     info.Account,
+		GroupID:       info.GroupID,
+		KeyType:       info.KeyType,
+		MsgHashes:     info.MsgHash,
+		SignTime:      int64(signTime),
+		FirstSeenTime: startTime.UnixNano() / 1e6,
+		VerifyMillis:  time.Since(startTime).Milliseconds(),
+		Shadow:        params.IsOracleShadowMode(),
+	}
+	if len(argsList) > 0 {
+		record.Identifier = argsList[0].Identifier
+		record.SwapType = argsList[0].SwapType.String()
+	}
+	for i, args := range argsList {
+		swap := &AcceptAuditSwap{
+			PairID: args.PairID,
+			SwapID: args.SwapID,
+			Bind:   args.Bind,
+			Nonce:  args.GetTxNonce(),
+		}
+		if i < len(swapInfos) && swapInfos[i] != nil {
+			swapInfo := swapInfos[i]
+			if swapInfo.Value != nil {
+				swap.Value = swapInfo.Value.String()
+			}
+			swap.Height = swapInfo.Height
+			swap.Timestamp = swapInfo.Timestamp
+		}
+		record.Swaps = append(record.Swaps, swap)
+	}
+	return record
+}
+
+func (r *AcceptAuditRecord) setDecision(decision, reason string) {
+	r.Decision = decision
+	r.Reason = reason
+	r.DecisionTime = common.NowMilli()
+}
+
+// accept audit records are keyed by decision time to query by time range,
+// with index of keyID (the latest record) and index of swapID
+func getAcceptAuditKey(decisionTime int64, keyID string) []byte {
+	return []byte(fmt.Sprintf("%s%016d:%s", acceptAuditKeyPrefix, decisionTime, strings.ToLower(keyID)))
+}
+
+func getAcceptAuditKeyIndex(keyID string) []byte {
+	return []byte(acceptAuditKeyIndexPrefix + strings.ToLower(keyID))
+}
+
+func getAcceptAuditSwapIndexPrefix(swapID string) string {
+	return acceptAuditSwapIndexPrefix + strings.ToLower(swapID) + ":"
+}
+
+func getAcceptAuditSwapIndex(swapID string, decisionTime int64, keyID string) []byte {
+	return []byte(fmt.Sprintf("%s%016d:%s", getAcceptAuditSwapIndexPrefix(swapID), decisionTime, strings.ToLower(keyID)))
+}
+
+// getAcceptAuditKeyTime get decision time from record key
+func getAcceptAuditKeyTime(key []byte) int64 {
+	timeStr := strings.SplitN(string(key[len(acceptAuditKeyPrefix):]), ":", 2)[0]
+	decisionTime, _ := strconv.ParseInt(timeStr, 10, 64)
+	return decisionTime
+}
+
+func getAcceptAuditRecord(key []byte) *AcceptAuditRecord {
+	data, err := lvldbHandle.Get(key)
+	if err != nil {
+		return nil
+	}
+	record := &AcceptAuditRecord{}
+	if json.Unmarshal(data, record) != nil {
+		return nil
+	}
+	return record
+}
+
+func deleteAcceptAuditRecord(batch leveldb.Batch, key []byte, record *AcceptAuditRecord) {
+	_ = batch.Delete(key)
+	for _, swap := range record.Swaps {
+		_ = batch.Delete(getAcceptAuditSwapIndex(swap.SwapID, record.DecisionTime, record.KeyID))
+	}
+}
+
+// saveAcceptAuditRecord save accept audit record, the old record of the same keyID
+// is replaced and its first seen time is kept
+func saveAcceptAuditRecord(record *AcceptAuditRecord) {
+	if lvldbHandle == nil {
+		return
+	}
+	batch := lvldbHandle.NewBatch()
+	keyIndex := getAcceptAuditKeyIndex(record.KeyID)
+	if oldKey, err := lvldbHandle.Get(keyIndex); err == nil {
+		if old := getAcceptAuditRecord(oldKey); old != nil {
+			if old.FirstSeenTime > 0 && old.FirstSeenTime < record.FirstSeenTime {
+				record.FirstSeenTime = old.FirstSeenTime
+			}
+			deleteAcceptAuditRecord(batch, oldKey, old)
+		}
+	} else if !leveldb.IsNotFoundErr(err) {
+		logWorkerError("accept", "get accept audit record failed", err, "keyID", record.KeyID)
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return
+	}
+	key := getAcceptAuditKey(record.DecisionTime, record.KeyID)
+	_ = batch.Put(key, data)
+	_ = batch.Put(keyIndex, key)
+	for _, swap := range record.Swaps {
+		_ = batch.Put(getAcceptAuditSwapIndex(swap.SwapID, record.DecisionTime, record.KeyID), key)
+	}
+	err = batch.Write()
+	if err != nil {
+		logWorkerError("accept", "save accept audit record failed", err, "keyID", record.KeyID)
+	}
+}
+
+// iterateAcceptAuditRecords iterate accept audit records ordered by decision time
+// from 'startTime' (milliseconds) until 'fn' returns false
+func iterateAcceptAuditRecords(startTime int64, fn func(*AcceptAuditRecord) bool) {
+	if lvldbHandle == nil {
+		return
+	}
+	var start []byte
+	if startTime > 0 {
+		start = []byte(fmt.Sprintf("%016d", startTime))
+	}
+	iter := lvldbHandle.NewIterator([]byte(acceptAuditKeyPrefix), start)
+	defer iter.Release()
+	for iter.Next() {
+		record := &AcceptAuditRecord{}
+		if json.Unmarshal(iter.Value(), record) != nil {
+			continue
+		}
+		if !fn(record) {
+			return
+		}
+	}
+}
+
+// findAcceptAuditRecordsByTxID find accept audit records by swapID or keyID
+func findAcceptAuditRecordsByTxID(txid string) []*AcceptAuditRecord {
+	if lvldbHandle == nil {
+		return nil
+	}
+	var result []*AcceptAuditRecord
+	if key, err := lvldbHandle.Get(getAcceptAuditKeyIndex(txid)); err == nil {
+		if record := getAcceptAuditRecord(key); record != nil {
+			result = append(result, record)
+		}
+	}
+	iter := lvldbHandle.NewIterator([]byte(getAcceptAuditSwapIndexPrefix(txid)), nil)
+	defer iter.Release()
+	for iter.Next() {
+		if record := getAcceptAuditRecord(iter.Value()); record != nil {
+			result = append(result, record)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].DecisionTime < result[j].DecisionTime
+	})
+	return result
+}
+
+func (f *AcceptAuditFilter) match(record *AcceptAuditRecord) bool {
+	if f.Decision != "" && !strings.EqualFold(f.Decision, record.Decision) {
+		return false
+	}
+	if f.StartTime > 0 && record.DecisionTime < f.StartTime*1000 {
+		return false
+	}
+	if f.EndTime > 0 && record.DecisionTime >= f.EndTime*1000 {
+		return false
+	}
+	if f.TxID == "" && f.PairID == "" {
+		return true
+	}
+	if f.PairID == "" && strings.EqualFold(f.TxID, record.KeyID) {
+		return true
+	}
+	for _, swap := range record.Swaps {
+		if (f.TxID == "" || strings.EqualFold(f.TxID, swap.SwapID)) &&
+			(f.PairID == "" || strings.EqualFold(f.PairID, swap.PairID)) {
+			return true
+		}
+	}
+	return false
+}
+
+// FindAcceptAuditRecords find accept audit records ordered by decision time
+func FindAcceptAuditRecords(filter *AcceptAuditFilter) []*AcceptAuditRecord {
+	limit := filter.Limit
+	if limit == 0 {
+		limit = defaultAuditQueryLimit
+	} else if limit > maxAuditQueryLimit {
+		limit = maxAuditQueryLimit
+	}
+	var result []*AcceptAuditRecord
+	addRecord := func(record *AcceptAuditRecord) {
+		if !filter.match(record) {
+			return
+		}
+		result = append(result, record)
+		if limit > 0 && len(result) > limit {
+			result = result[1:] // keep the latest ones
+		}
+	}
+	if filter.TxID != "" {
+		for _, record := range findAcceptAuditRecordsByTxID(filter.TxID) {
+			addRecord(record)
+		}
+		return result
+	}
+	iterateAcceptAuditRecords(filter.StartTime*1000, func(record *AcceptAuditRecord) bool {
+		if filter.EndTime > 0 && record.DecisionTime >= filter.EndTime*1000 {
+			return false
+		}
+		addRecord(record)
+		return true
+	})
+	return result
+}
+
+func getAcceptAuditRetention() time.Duration {
+	retentionDays := params.GetOracleConfig().AuditRetentionDays
+	if retentionDays <= 0 {
+		retentionDays = defaultAuditRetentionDays
+	}
+	return time.Duration(retentionDays) * 24 * time.Hour
+}
+
+// pruneAcceptAuditRecords remove accept audit records beyond retention
+func pruneAcceptAuditRecords() {
+	if lvldbHandle == nil {
+		return
+	}
+	expireTime := time.Now().Add(-getAcceptAuditRetention()).UnixNano() / 1e6
+	batch := lvldbHandle.NewBatch()
+	count := 0
+	iter := lvldbHandle.NewIterator([]byte(acceptAuditKeyPrefix), nil)
+	for iter.Next() {
+		if getAcceptAuditKeyTime(iter.Key()) >= expireTime {
+			break
+		}
+		key := common.CopyBytes(iter.Key())
+		record := &AcceptAuditRecord{}
+		if json.Unmarshal(iter.Value(), record) == nil {
+			deleteAcceptAuditRecord(batch, key, record)
+			keyIndex := getAcceptAuditKeyIndex(record.KeyID)
+			if latestKey, err := lvldbHandle.Get(keyIndex); err == nil && bytes.Equal(latestKey, key) {
+				_ = batch.Delete(keyIndex)
+			}
+		} else {
+			_ = batch.Delete(key)
+		}
+		count++
+	}
+	iter.Release()
+	if count == 0 {
+		return
+	}
+	if err := batch.Write(); err != nil {
+		logWorkerError("accept", "prune accept audit records failed", err)
+		return
+	}
+	logWorker("accept", "prune accept audit records", "count", count)
+}
+
+// startAcceptAuditPruneJob prune accept audit records periodically
+func startAcceptAuditPruneJob() {
+	if lvldbHandle == nil {
+		return
+	}
+	for {
+		pruneAcceptAuditRecords()
+		restInJob(acceptAuditPruneInterval)
+	}
+}
+
+// startAcceptAuditAPI start local accept audit API if configured
+func startAcceptAuditAPI() {
+	address := params.GetOracleConfig().AuditAPIAddress
+	if address == "" || lvldbHandle == nil {
+		return
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/accept/audit", acceptAuditHandler)
+	if !params.IsOracleShadowMode() {
+		mux.HandleFunc("/accept/signinfo", acceptSignInfoHandler)
+	}
+	go func() {
+		log.Info("start accept audit api", "address", address)
+		err := http.ListenAndServe(address, mux)
+		if err != nil {
+			log.Error("accept audit api stopped", "address", address, "err", err)
+		}
+	}()
+}
+
+// verifyAdminQuery verify admin signed request of calling 'method' of local accept API,
+// the signed call params is the encoded query
+func verifyAdminQuery(r *http.Request, method string) (url.Values, error) {
+	tx, err := admin.DecodeTransaction(r.URL.Query().Get("rawtx"))
+	if err != nil {
+		return nil, err
+	}
+	sender, args, err := admin.VerifyTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+	if !params.IsOracleAdmin(sender.String()) {
+		return nil, fmt.Errorf("sender %v is not admin", sender.String())
+	}
+	if args.Method != method || len(args.Params) != 1 {
+		return nil, fmt.Errorf("wrong admin call of method '%v'", args.Method)
+	}
+	return url.ParseQuery(args.Params[0])
+}
+
+// acceptAuditHandler query accept audit records, the request is signed by admin,
+// query params: txid, pairid, decision, start, end, limit, format (json or jsonl),
+// jsonl format without limit exports all the matched records
+func acceptAuditHandler(w http.ResponseWriter, r *http.Request) {
+	query, err := verifyAdminQuery(r, acceptAuditAdminMethod)
+	if err != nil {
+		http.Error(w, "unauthorized: "+err.Error(), http.StatusUnauthorized)
+		return
+	}
+	filter := &AcceptAuditFilter{
+		TxID:     query.Get("txid"),
+		PairID:   query.Get("pairid"),
+		Decision: query.Get("decision"),
+	}
+	if filter.StartTime, err = parseAuditTime(query.Get("start")); err != nil {
+		http.Error(w, "wrong start time: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if filter.EndTime, err = parseAuditTime(query.Get("end")); err != nil {
+		http.Error(w, "wrong end time: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	isJSONL := query.Get("format") == "jsonl"
+	if limit := query.Get("limit"); limit != "" {
+		if filter.Limit, err = strconv.Atoi(limit); err != nil {
+			http.Error(w, "wrong limit: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	} else if isJSONL {
+		filter.Limit = -1
+	}
+
+	records := FindAcceptAuditRecords(filter)
+	if isJSONL {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		encoder := json.NewEncoder(w)
+		for _, record := range records {
+			_ = encoder.Encode(record)
+		}
+		return
+	}
+	if records == nil {
+		records = []*AcceptAuditRecord{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(records)
+}
+
+// parseAuditTime parse unix seconds or RFC3339 time
+func parseAuditTime(s string) (int64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	if seconds, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return seconds, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return 0, err
+	}
+	return t.Unix(), nil
+}
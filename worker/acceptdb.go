@@ -80,7 +80,13 @@ func CheckAcceptRecord(args *tokens.BuildTxArgs) (err error) {
 		log.Info("[accept] check saved record", "key", key, "value", value)
 		txStatus, errt := resBridge.GetTransactionStatus(oldSwapTx)
 		if errt == nil && txStatus != nil && txStatus.BlockHeight > 0 { // on chain
-			if txStatus.Receipt != nil { // for eth like chain
+			if receipt, ok := txStatus.Receipt.(tokens.TxResultReceipt); ok {
+				if !receipt.IsTxFailed() {
+					log.Warn("[accept] found already swapped tx", "key", key, "value", value)
+					alreadySwapped = true
+					break
+				}
+			} else if txStatus.Receipt != nil { // for eth like chain
 				receipt, ok := txStatus.Receipt.(*types.RPCTxReceipt)
 				if ok && *receipt.Status == 1 {
 					log.Warn("[accept] found already swapped tx", "key", key, "value", value)
@@ -113,6 +119,11 @@ func CheckAcceptRecord(args *tokens.BuildTxArgs) (err error) {
 			log.Warn("[accept] find already swapped tx in pool", "key", key, "value", value, "txNonce", txNonce, "argNonce", argNonce)
 			alreadySwapped = true
 			break
+		} else if args.GetTxExpiration() > 0 && value+tokens.MaxTxExpirationInterval > nowTime {
+			// tx without nonce can not be replaced, wait until the old tx is expired
+			log.Warn("[accept] found not expired tx", "key", key, "value", value)
+			alreadySwapped = true
+			break
 		}
 	}
 	iter.Release()
@@ -122,6 +133,27 @@ func CheckAcceptRecord(args *tokens.BuildTxArgs) (err error) {
 	return nil
 }
 
+// checkRefundConflict forbid to both swap and refund the same deposit
+func checkRefundConflict(args *tokens.BuildTxArgs) error {
+	var counterType tokens.SwapType
+	switch args.SwapType {
+	case tokens.SwapinType:
+		counterType = tokens.RefundType
+	case tokens.RefundType:
+		counterType = tokens.SwapinType
+	default:
+		return nil
+	}
+	counterArgs := &tokens.BuildTxArgs{SwapInfo: args.SwapInfo}
+	counterArgs.SwapType = counterType
+	counterArgs.Reswapping = false
+	if err := CheckAcceptRecord(counterArgs); err != nil {
+		log.Warn("[accept] found conflict of swap and refund", "swapID", args.SwapID, "pairID", args.PairID, "bind", args.Bind, "swapType", args.SwapType.String())
+		return errSwapRefundConflict
+	}
+	return nil
+}
+
 func getLeveldbPath() string {
 	dataDir := params.GetDataDir()
 	identifier := params.GetIdentifier()
@@ -0,0 +1,172 @@
+package worker
+
+import (
+	"sync"
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/acceptpolicy"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/params"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+var (
+	acceptPolicyEngine *acceptpolicy.Engine
+	acceptHoldDir      string
+
+	// holds are kept after the held sign request is expired,
+	// as the swap is signed again with new keyID
+	acceptHoldExpiration = 24 * time.Hour
+
+	// held sign requests are not verified again, only wait for manual decision
+	heldAcceptSigns     = make(map[string]*heldAcceptSign)
+	heldAcceptSignsLock sync.Mutex
+)
+
+type heldAcceptSign struct {
+	argsList   []*tokens.BuildTxArgs
+	swapInfos  []*tokens.TxSwapInfo
+	decision   *acceptpolicy.Decision
+	expireTime int64
+}
+
+func initAcceptPolicy() {
+	policyFile := params.GetOracleConfig().AcceptPolicyFile
+	if policyFile == "" {
+		return
+	}
+	if params.GetDataDir() == "" {
+		log.Fatal("accept policy requires data dir to save decisions")
+	}
+	policy, err := acceptpolicy.LoadPolicy(policyFile)
+	if err != nil {
+		log.Fatal("init accept policy failed", "err", err)
+	}
+	acceptPolicyEngine = acceptpolicy.NewEngine(policy)
+	acceptHoldDir = acceptpolicy.GetHoldDir(params.GetDataDir())
+	restoreAgreedDecisions()
+}
+
+// restoreAgreedDecisions restore agreed values of last hour from audit records after restart
+func restoreAgreedDecisions() {
+	count := 0
+	iterateAcceptAuditRecords((now()-3600)*1000, func(record *AcceptAuditRecord) bool {
+		decision := record.Policy
+		if record.Decision == acceptAgree && record.AcceptError == "" &&
+			decision != nil && decision.Result == acceptpolicy.ResultAgree &&
+			decision.Timestamp+3600 > now() {
+			acceptPolicyEngine.RecordAgreed(decision)
+			count++
+		}
+		return true
+	})
+	logWorker("accept", "restore agreed accept decisions", "count", count)
+}
+
+func getDepositBridge(swapType tokens.SwapType) tokens.CrossChainBridge {
+	return tokens.GetCrossChainBridge(swapType == tokens.SwapinType || swapType == tokens.RefundType)
+}
+
+// evaluateAcceptPolicy evaluate accept policy on verified swaps,
+// manual decision of held swaps has the priority
+func evaluateAcceptPolicy(keyID string, argsList []*tokens.BuildTxArgs, swapInfos []*tokens.TxSwapInfo) *acceptpolicy.Decision {
+	if acceptPolicyEngine == nil || len(swapInfos) != len(argsList) {
+		return nil
+	}
+	swaps := make([]*acceptpolicy.SwapInfo, len(argsList))
+	for i, args := range argsList {
+		swapInfo := swapInfos[i]
+		depositBridge := getDepositBridge(args.SwapType)
+		swap := &acceptpolicy.SwapInfo{
+			PairID:      args.PairID,
+			SwapID:      args.SwapID,
+			SwapType:    args.SwapType.String(),
+			Bind:        args.Bind,
+			DepositTime: int64(swapInfo.Timestamp),
+		}
+		tokenCfg := depositBridge.GetTokenConfig(args.PairID)
+		if tokenCfg != nil && tokenCfg.Decimals != nil && swapInfo.Value != nil {
+			swap.Value = tokens.FromBits(swapInfo.Value, *tokenCfg.Decimals)
+		}
+		if swap.DepositTime == 0 {
+			if txStatus, err := depositBridge.GetTransactionStatus(args.SwapID); err == nil && txStatus != nil {
+				swap.DepositTime = int64(txStatus.BlockTime)
+			}
+		}
+		swaps[i] = swap
+	}
+
+	if decision := getManualDecision(keyID, swaps); decision != nil {
+		return decision
+	}
+	return acceptPolicyEngine.Evaluate(keyID, swaps, time.Now())
+}
+
+// getManualDecision get manual decision of swaps, return nil if not decided
+func getManualDecision(keyID string, swaps []*acceptpolicy.SwapInfo) *acceptpolicy.Decision {
+	manualResult := acceptpolicy.GetSwapsManualResult(acceptHoldDir, swaps)
+	if manualResult == "" {
+		return nil
+	}
+	return &acceptpolicy.Decision{
+		KeyID:     keyID,
+		Result:    manualResult,
+		Rule:      acceptpolicy.RuleManual,
+		Swaps:     swaps,
+		Timestamp: now(),
+	}
+}
+
+// holdAcceptSign leave the sign request pending until manual decision
+// is made or it is expired, the verified swaps are kept in memory
+// to not verify and evaluate again in the next accept rounds
+func holdAcceptSign(signTime int64, argsList []*tokens.BuildTxArgs, swapInfos []*tokens.TxSwapInfo, decision *acceptpolicy.Decision) {
+	err := acceptpolicy.SaveHold(acceptHoldDir, decision)
+	if err != nil {
+		logWorkerError("accept", "save held swaps failed", err, "keyID", decision.KeyID)
+	}
+	acceptpolicy.RemoveExpiredHolds(acceptHoldDir, acceptHoldExpiration)
+
+	heldAcceptSignsLock.Lock()
+	defer heldAcceptSignsLock.Unlock()
+	nowTime := now()
+	for keyID, held := range heldAcceptSigns {
+		if held.expireTime < nowTime {
+			delete(heldAcceptSigns, keyID)
+		}
+	}
+	heldAcceptSigns[decision.KeyID] = &heldAcceptSign{
+		argsList:   argsList,
+		swapInfos:  swapInfos,
+		decision:   decision,
+		expireTime: signTime + maxAcceptSignTimeInterval,
+	}
+}
+
+// getHeldAcceptSign get held sign request which is not expired
+func getHeldAcceptSign(keyID string) *heldAcceptSign {
+	heldAcceptSignsLock.Lock()
+	defer heldAcceptSignsLock.Unlock()
+	held, exist := heldAcceptSigns[keyID]
+	if !exist {
+		return nil
+	}
+	if held.expireTime < now() {
+		delete(heldAcceptSigns, keyID)
+		return nil
+	}
+	return held
+}
+
+// finishAcceptDecision record agreed value and remove holds after accepted
+func finishAcceptDecision(decision *acceptpolicy.Decision) {
+	if decision == nil {
+		return
+	}
+	acceptPolicyEngine.RecordAgreed(decision)
+	acceptpolicy.RemoveSwapsHolds(acceptHoldDir, decision.Swaps)
+
+	heldAcceptSignsLock.Lock()
+	delete(heldAcceptSigns, decision.KeyID)
+	heldAcceptSignsLock.Unlock()
+}
@@ -0,0 +1,144 @@
+package worker
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/anyswap/CrossChain-Bridge/admin"
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/dcrm"
+	"github.com/anyswap/CrossChain-Bridge/log"
+	"github.com/anyswap/CrossChain-Bridge/params"
+	"github.com/anyswap/CrossChain-Bridge/rpc/client"
+)
+
+const acceptSignInfoAdminMethod = "acceptsigninfo"
+
+// the pending sign requests of dcrm node are removed once they are accepted,
+// so shadow oracle can not poll them reliably along with the live oracle.
+// the live oracle keeps the sign requests it has seen until they are expired,
+// and shadow oracle gets sign requests from the live oracle's accept API.
+var (
+	seenSignInfos     = make(map[string]*SeenSignInfo)
+	seenSignInfosLock sync.Mutex
+
+	shadowSignInfoURL string
+)
+
+// SeenSignInfo sign request seen by live oracle
+type SeenSignInfo struct {
+	FirstSeenTime int64              `json:"firstSeenTime"` // milliseconds
+	SignInfo      *dcrm.SignInfoData `json:"signInfo"`
+}
+
+func isSignInfoExpired(info *dcrm.SignInfoData, nowTime int64) bool {
+	timestamp, err := common.GetUint64FromStr(info.TimeStamp)
+	return err != nil || int64(timestamp/1000)+maxAcceptSignTimeInterval < nowTime
+}
+
+// getAcceptSignInfos get pending sign requests, from dcrm node in live mode,
+// and from the live oracle in shadow mode
+func getAcceptSignInfos() ([]*dcrm.SignInfoData, error) {
+	if params.IsOracleShadowMode() {
+		return getShadowSignInfos()
+	}
+	signInfos, err := dcrm.GetCurNodeSignInfo()
+	if err == nil && params.GetOracleConfig().AuditAPIAddress != "" {
+		recordSeenSignInfos(signInfos)
+	}
+	return signInfos, err
+}
+
+func recordSeenSignInfos(signInfos []*dcrm.SignInfoData) {
+	seenSignInfosLock.Lock()
+	defer seenSignInfosLock.Unlock()
+	nowTime := now()
+	for keyID, seen := range seenSignInfos {
+		if isSignInfoExpired(seen.SignInfo, nowTime) {
+			delete(seenSignInfos, keyID)
+		}
+	}
+	for _, info := range signInfos {
+		if info == nil || info.Key == "" || isSignInfoExpired(info, nowTime) {
+			continue
+		}
+		keyID := strings.ToLower(info.Key)
+		if _, exist := seenSignInfos[keyID]; !exist {
+			seenSignInfos[keyID] = &SeenSignInfo{
+				FirstSeenTime: common.NowMilli(),
+				SignInfo:      info,
+			}
+		}
+	}
+}
+
+// getSeenSignInfos get seen sign requests which are not expired ordered by first seen time
+func getSeenSignInfos() []*SeenSignInfo {
+	seenSignInfosLock.Lock()
+	defer seenSignInfosLock.Unlock()
+	nowTime := now()
+	result := make([]*SeenSignInfo, 0, len(seenSignInfos))
+	for _, seen := range seenSignInfos {
+		if !isSignInfoExpired(seen.SignInfo, nowTime) {
+			result = append(result, seen)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].FirstSeenTime < result[j].FirstSeenTime
+	})
+	return result
+}
+
+// acceptSignInfoHandler get sign requests seen by live oracle, the request is signed by admin
+func acceptSignInfoHandler(w http.ResponseWriter, r *http.Request) {
+	if _, err := verifyAdminQuery(r, acceptSignInfoAdminMethod); err != nil {
+		http.Error(w, "unauthorized: "+err.Error(), http.StatusUnauthorized)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(getSeenSignInfos())
+}
+
+// initShadowSource init the live oracle's accept API to get sign requests in shadow mode,
+// the requests are signed by the dcrm user key of shadow oracle, which must be admin of the live oracle
+func initShadowSource() {
+	source := params.GetOracleConfig().ShadowSource
+	if source == "" {
+		log.Fatal("shadow mode must config 'ShadowSource' to get sign requests from the live oracle")
+	}
+	if !strings.Contains(source, "://") {
+		source = "http://" + source
+	}
+	shadowSignInfoURL = source + "/accept/signinfo"
+
+	nodeCfg := params.GetConfig().Dcrm.DefaultNode
+	if err := admin.LoadKeyStore(*nodeCfg.KeystoreFile, *nodeCfg.PasswordFile); err != nil {
+		log.Fatal("shadow mode load keystore failed", "err", err)
+	}
+}
+
+func getShadowSignInfos() ([]*dcrm.SignInfoData, error) {
+	if shadowSignInfoURL == "" {
+		return nil, errors.New("shadow source is not initialized")
+	}
+	rawTx, err := admin.Sign(acceptSignInfoAdminMethod, []string{""})
+	if err != nil {
+		return nil, err
+	}
+	var result []*SeenSignInfo
+	err = client.RPCGetRequest(&result, shadowSignInfoURL, map[string]string{"rawtx": rawTx}, nil, 60)
+	if err != nil {
+		return nil, err
+	}
+	signInfos := make([]*dcrm.SignInfoData, 0, len(result))
+	for _, seen := range result {
+		if seen != nil && seen.SignInfo != nil {
+			signInfos = append(signInfos, seen.SignInfo)
+		}
+	}
+	return signInfos, nil
+}
@@ -73,6 +73,10 @@ func findUtxosAndAggregate(addr string) {
 		if isUtxoExist(utxo) {
 			continue
 		}
+		if btc.BridgeInstance.IsUtxoReserved(*utxo.Txid, *utxo.Vout) {
+			logWorkerTrace("aggregate", "ignore reserved utxo", "address", addr, "utxo", utxo.String())
+			continue
+		}
 		outspend, err := btc.BridgeInstance.GetOutspend(*utxo.Txid, *utxo.Vout)
 		if err != nil {
 			logWorkerError("aggregate", "get out spend failed", err, "address", addr, "utxo", utxo.String())
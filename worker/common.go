@@ -251,12 +251,30 @@ func verifySwapTransaction(bridge tokens.CrossChainBridge, pairID, txid, bind st
 	return swapInfo, err
 }
 
+// signTransaction sign raw tx with retry (used by swap, refund and replace)
+func signTransaction(job string, bridge tokens.CrossChainBridge, rawTx interface{}, args *tokens.BuildTxArgs, retrySignCount int) (signedTx interface{}, signTxHash string, err error) {
+	for i := 1; i <= retrySignCount; i++ {
+		signedTx, signTxHash, err = bridge.DcrmSignTransaction(rawTx, args.GetExtraArgs())
+		if err == nil {
+			return signedTx, signTxHash, nil
+		}
+		logWorkerError(job, "sign tx failed", err, "pairID", args.PairID, "txid", args.SwapID, "bind", args.Bind, "swapType", args.SwapType.String(), "signCount", i)
+		if i < retrySignCount {
+			restInJob(retrySignInterval)
+		}
+	}
+	return nil, "", err
+}
+
+// sendSignedTransaction send signed swap or refund tx with retry,
+// the swap history and swap height are not updated for refund tx
 func sendSignedTransaction(bridge tokens.CrossChainBridge, signedTx interface{}, args *tokens.BuildTxArgs) (txHash string, err error) {
 	var (
 		retrySendTxCount    = 3
 		retrySendTxInterval = 1 * time.Second
 		txid, pairID, bind  = args.SwapID, args.PairID, args.Bind
 		isSwapin            = args.SwapType == tokens.SwapinType
+		isRefund            = args.SwapType == tokens.RefundType
 	)
 	for i := 0; i < retrySendTxCount; i++ {
 		txHash, err = bridge.SendTransaction(signedTx)
@@ -266,7 +284,7 @@ func sendSignedTransaction(bridge tokens.CrossChainBridge, signedTx interface{},
 		}
 		time.Sleep(retrySendTxInterval)
 	}
-	if txHash != "" {
+	if txHash != "" && !isRefund {
 		addSwapHistory(isSwapin, txid, bind)
 		_ = mongodb.AddSwapHistory(isSwapin, txid, bind, txHash)
 	}
@@ -281,6 +299,9 @@ func sendSignedTransaction(bridge tokens.CrossChainBridge, signedTx interface{},
 	}
 
 	nonceSetter.SetNonce(pairID, args.GetTxNonce()+1) // increase for next usage
+	if isRefund {
+		return txHash, err // refund height is updated by refund stable job
+	}
 
 	// update swap result tx height in goroutine
 	go func() {
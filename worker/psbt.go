@@ -0,0 +1,219 @@
+package worker
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Bridge/mongodb"
+	"github.com/anyswap/CrossChain-Bridge/params"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tokens/btc"
+	"github.com/anyswap/CrossChain-Bridge/tokens/btc/electrs"
+)
+
+var (
+	errNoUtxosToAggregate = errors.New("no utxos to aggregate")
+)
+
+// ExportSwapoutPsbt build swapout tx and export it as psbt (btc only)
+func ExportSwapoutPsbt(txid, pairID, bind string) (string, error) {
+	if btc.BridgeInstance == nil {
+		return "", tokens.ErrNoBtcBridge
+	}
+	isSwapin := false
+
+	swap, err := mongodb.FindSwapout(txid, pairID, bind)
+	if err != nil {
+		return "", err
+	}
+	res, err := mongodb.FindSwapoutResult(txid, pairID, bind)
+	if err != nil {
+		return "", err
+	}
+	err = preventReswap(res, isSwapin)
+	if err != nil {
+		return "", err
+	}
+	dcrmAddress, err := checkSwapResult(res, isSwapin)
+	if err != nil {
+		return "", err
+	}
+
+	swapInfo, err := reverifyPsbtSwapout(swap, res)
+	if err != nil {
+		return "", err
+	}
+
+	args := &tokens.BuildTxArgs{
+		SwapInfo: tokens.SwapInfo{
+			Identifier: params.GetIdentifier(),
+			PairID:     pairID,
+			SwapID:     txid,
+			SwapType:   tokens.SwapoutType,
+			TxType:     tokens.SwapTxType(swap.TxType),
+			Bind:       bind,
+			Reswapping: res.Status == mongodb.Reswapping,
+		},
+		From:        dcrmAddress,
+		OriginValue: swapInfo.Value,
+	}
+	rawTx, err := btc.BridgeInstance.BuildRawTransaction(args)
+	if err != nil {
+		logWorkerError("psbt", "build tx failed", err, "pairID", pairID, "txid", txid, "bind", bind)
+		return "", err
+	}
+	psbtStr, err := btc.BridgeInstance.ExportPsbt(rawTx, args)
+	if err != nil {
+		releaseReservedUtxos(btc.BridgeInstance, txid, pairID, bind)
+		return "", err
+	}
+	logWorker("psbt", "export swapout psbt success", "pairID", pairID, "txid", txid, "bind", bind)
+	return psbtStr, nil
+}
+
+// ExportAggregatePsbt build aggregate tx of p2sh address and export it as psbt
+func ExportAggregatePsbt(p2shAddress string) (string, error) {
+	if btc.BridgeInstance == nil {
+		return "", tokens.ErrNoBtcBridge
+	}
+	findUtxos, err := btc.BridgeInstance.FindUtxos(p2shAddress)
+	if err != nil {
+		return "", err
+	}
+	var (
+		addrs []string
+		utxos []*electrs.ElectUtxo
+	)
+	for _, utxo := range findUtxos {
+		if utxo.Value == nil || *utxo.Value == 0 {
+			continue
+		}
+		if btc.BridgeInstance.IsUtxoReserved(*utxo.Txid, *utxo.Vout) {
+			continue
+		}
+		outspend, errf := btc.BridgeInstance.GetOutspend(*utxo.Txid, *utxo.Vout)
+		if errf != nil || *outspend.Spent {
+			continue
+		}
+		addrs = append(addrs, p2shAddress)
+		utxos = append(utxos, utxo)
+	}
+	if len(utxos) == 0 {
+		return "", errNoUtxosToAggregate
+	}
+	psbtStr, err := btc.BridgeInstance.ExportAggregatePsbt(addrs, utxos)
+	if err != nil {
+		return "", err
+	}
+	logWorker("psbt", "export aggregate psbt success", "address", p2shAddress, "utxos", len(utxos))
+	return psbtStr, nil
+}
+
+// ImportPsbt import signed swapout or aggregate psbt, verify and send it
+func ImportPsbt(psbtStr string) (txHash string, err error) {
+	if btc.BridgeInstance == nil {
+		return "", tokens.ErrNoBtcBridge
+	}
+	bridge := btc.BridgeInstance
+	signedTx, signTxHash, args, err := bridge.ImportPsbt(psbtStr)
+	if err != nil {
+		return "", err
+	}
+
+	if args.Identifier == tokens.AggregateIdentifier {
+		txHash, err = bridge.SendTransaction(signedTx)
+		if err != nil {
+			return "", err
+		}
+		logWorker("psbt", "import aggregate psbt success", "txHash", txHash)
+		return txHash, nil
+	}
+
+	if args.SwapType != tokens.SwapoutType {
+		return "", tokens.ErrSwapTypeNotSupported
+	}
+	isSwapin := false
+	txid, pairID, bind := args.SwapID, args.PairID, args.Bind
+
+	cacheKey := getSwapCacheKey(isSwapin, txid, bind)
+	err = checkAndUpdateProcessSwapTaskCache(cacheKey)
+	if err != nil {
+		return "", err
+	}
+	isCachedSwapProcessed := false
+	defer func() {
+		if !isCachedSwapProcessed {
+			cachedSwapTasks.Remove(cacheKey)
+		}
+	}()
+
+	swap, err := mongodb.FindSwapout(txid, pairID, bind)
+	if err != nil {
+		return "", err
+	}
+	res, err := mongodb.FindSwapoutResult(txid, pairID, bind)
+	if err != nil {
+		return "", err
+	}
+	err = preventReswap(res, isSwapin)
+	if err != nil {
+		return "", err
+	}
+	_, err = checkSwapResult(res, isSwapin)
+	if err != nil {
+		return "", err
+	}
+	// build tx args in psbt is not trusted, the signed tx is verified against them
+	swapInfo, err := reverifyPsbtSwapout(swap, res)
+	if err != nil {
+		return "", err
+	}
+	if args.OriginValue == nil || args.OriginValue.Cmp(swapInfo.Value) != 0 {
+		return "", fmt.Errorf("[psbt] swap value mismatch, in psbt %v != %v", args.OriginValue, swapInfo.Value)
+	}
+	if args.TxType != tokens.SwapTxType(swap.TxType) {
+		return "", fmt.Errorf("[psbt] swap tx type mismatch, in psbt %v != %v", args.TxType, swap.TxType)
+	}
+
+	matchTx := &MatchTx{
+		SwapTx:    signTxHash,
+		SwapType:  args.SwapType,
+		SwapValue: tokens.CalcSwappedValue(pairID, args.OriginValue, isSwapin).String(),
+	}
+	err = updateSwapResult(txid, pairID, bind, matchTx)
+	if err != nil {
+		logWorkerError("psbt", "update swap result failed", err, "pairID", pairID, "txid", txid, "bind", bind)
+		return "", err
+	}
+	isCachedSwapProcessed = true
+
+	err = mongodb.UpdateSwapStatus(isSwapin, txid, pairID, bind, mongodb.TxProcessed, now(), "")
+	if err != nil {
+		logWorkerError("psbt", "update swap status failed", err, "pairID", pairID, "txid", txid, "bind", bind)
+		return "", err
+	}
+
+	txHash, err = sendSignedTransaction(bridge, signedTx, args)
+	if err != nil {
+		return "", err
+	}
+	logWorker("psbt", "import swapout psbt success", "pairID", pairID, "txid", txid, "bind", bind, "txHash", txHash)
+	return txHash, nil
+}
+
+func reverifyPsbtSwapout(swap *mongodb.MgoSwap, res *mongodb.MgoSwapResult) (*tokens.TxSwapInfo, error) {
+	txid, pairID, bind := res.TxID, res.PairID, res.Bind
+	srcBridge := tokens.GetCrossChainBridge(false)
+	swapInfo, err := verifySwapTransaction(srcBridge, pairID, txid, bind, tokens.SwapTxType(swap.TxType))
+	if err != nil {
+		return nil, fmt.Errorf("[psbt] reverify swap failed, %w", err)
+	}
+	if swapInfo.Value.String() != res.Value {
+		return nil, fmt.Errorf("[psbt] reverify swap value mismatch, in db %v != %v", res.Value, swapInfo.Value)
+	}
+	if !strings.EqualFold(swapInfo.Bind, bind) {
+		return nil, fmt.Errorf("[psbt] reverify swap bind address mismatch, in db %v != %v", bind, swapInfo.Bind)
+	}
+	return swapInfo, nil
+}
@@ -0,0 +1,474 @@
+package worker
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/anyswap/CrossChain-Bridge/cmd/utils"
+	"github.com/anyswap/CrossChain-Bridge/common"
+	"github.com/anyswap/CrossChain-Bridge/mongodb"
+	"github.com/anyswap/CrossChain-Bridge/params"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+var (
+	refundStarter       sync.Once
+	refundStableStarter sync.Once
+
+	errAlreadyRefunded     = errors.New("already refunded")
+	errRefundWithoutSender = errors.New("refund deposit without sender or value")
+	errRefundValueMismatch = errors.New("refund deposit value mismatch")
+	errSwapRefundConflict  = errors.New("deposit is both swapped and refunded")
+	errRefundTxNotPending  = errors.New("refund tx is not pending")
+	errRefundTxOnChain     = errors.New("refund tx exist in chain")
+	errRefundTxNotDropped  = errors.New("refund tx is not dropped")
+)
+
+// StartRefundJob refund job
+func StartRefundJob() {
+	mongodb.MgoWaitGroup.Add(2)
+	go startRefundJob()
+	go startRefundStableJob()
+}
+
+func startRefundJob() {
+	refundStarter.Do(func() {
+		logWorker("refund", "start refund job")
+		defer mongodb.MgoWaitGroup.Done()
+		for {
+			if utils.IsCleanuping() {
+				logWorker("refund", "stop refund job")
+				return
+			}
+			markAutoRefundSwapins()
+			processRefunds()
+			restInJob(restIntervalInDoSwapJob)
+		}
+	})
+}
+
+func startRefundStableJob() {
+	refundStableStarter.Do(func() {
+		logWorker("refund", "start refund stable job")
+		defer mongodb.MgoWaitGroup.Done()
+		for {
+			septime := getSepTimeInFind(maxStableLifetime)
+			res, err := mongodb.FindSwapinResultsWithStatus(mongodb.RefundTxNotStable, septime)
+			if err != nil {
+				logWorkerError("refund", "find refund results error", err)
+			}
+			for _, swap := range res {
+				if utils.IsCleanuping() {
+					logWorker("refund", "stop refund stable job")
+					return
+				}
+				err = processRefundStable(swap)
+				if err != nil {
+					logWorkerError("refund", "process refund stable error", err, "pairID", swap.PairID, "txid", swap.TxID, "bind", swap.Bind, "refundTx", swap.RefundTx)
+				}
+			}
+			if utils.IsCleanuping() {
+				logWorker("refund", "stop refund stable job")
+				return
+			}
+			restInJob(restIntervalInStableJob)
+		}
+	})
+}
+
+// IsRefundSupported is refund supported by source chain bridge
+func IsRefundSupported() bool {
+	refunder, ok := tokens.SrcBridge.(tokens.Refunder)
+	return ok && refunder.IsRefundSupported()
+}
+
+// MarkRefundable admin mark swapin refundable
+func MarkRefundable(txid, pairID, bind string) error {
+	if !IsRefundSupported() {
+		return tokens.ErrRefundNotSupported
+	}
+	swap, err := mongodb.FindSwap(true, txid, pairID, bind)
+	if err != nil {
+		return err
+	}
+	if swap.Status == mongodb.SwapInBlacklist {
+		if err = addBlacklistRefundResult(swap); err != nil {
+			return err
+		}
+	}
+	return mongodb.MarkSwapinRefundable(txid, pairID, bind, mongodb.ActorAdmin, "refund")
+}
+
+// addBlacklistRefundResult add the initial swap result of swapin in blacklist,
+// which is not added when verifying, from the re-verified deposit.
+// the sender must be removed from blacklist before refunding to it.
+func addBlacklistRefundResult(swap *mongodb.MgoSwap) error {
+	swapInfo, err := verifyRefundDeposit(swap.PairID, swap.TxID, swap.Bind, tokens.SwapTxType(swap.TxType))
+	if err != nil {
+		return err
+	}
+	isBlacked, err := mongodb.QueryBlacklist(swapInfo.From, swapInfo.PairID)
+	if err != nil {
+		return err
+	}
+	if isBlacked {
+		return tokens.ErrAddressIsInBlacklist
+	}
+	_, err = mongodb.FindSwapResult(true, swap.TxID, swap.PairID, swap.Bind)
+	if !errors.Is(err, mongodb.ErrItemNotFound) {
+		return err
+	}
+	return addInitialSwapResult(swapInfo, mongodb.MatchTxEmpty, true)
+}
+
+// RetryRefund admin refund swapin again after refund tx is failed
+func RetryRefund(txid, pairID, bind string) error {
+	if !IsRefundSupported() {
+		return tokens.ErrRefundNotSupported
+	}
+	err := mongodb.RetrySwapinRefund(txid, pairID, bind)
+	if err == nil {
+		cachedSwapTasks.Remove(getRefundCacheKey(txid, bind))
+	}
+	return err
+}
+
+// FailRefund admin mark refund failed after the refund tx is dropped, then it can be retried
+func FailRefund(txid, pairID, bind string) error {
+	if !IsRefundSupported() {
+		return tokens.ErrRefundNotSupported
+	}
+	res, err := mongodb.FindSwapResult(true, txid, pairID, bind)
+	if err != nil {
+		return err
+	}
+	if res.Status != mongodb.RefundTxNotStable {
+		return errRefundTxNotPending
+	}
+	bridge := tokens.SrcBridge
+	if txStatus, _ := bridge.GetTransactionStatus(res.RefundTx); txStatus != nil && txStatus.BlockHeight > 0 {
+		return errRefundTxOnChain
+	}
+	dropped, memo, err := isRefundTxDropped(bridge, res, true)
+	if err != nil {
+		return err
+	}
+	if !dropped {
+		return errRefundTxNotDropped
+	}
+	logWorker("refund", "admin mark refund failed", "pairID", pairID, "txid", txid, "bind", bind, "refundTx", res.RefundTx, "memo", memo)
+	return markRefundResult(res, mongodb.RefundTxFailed, memo)
+}
+
+func getRefundCacheKey(txid, bind string) string {
+	return strings.ToLower(fmt.Sprintf("%s:%s:refund", txid, bind))
+}
+
+// markAutoRefundSwapins mark swapins with too small or too large value refundable by policy
+func markAutoRefundSwapins() {
+	refundCfg := params.GetRefundConfig()
+	if !refundCfg.IsAutoRefundEnabled() || !IsRefundSupported() {
+		return
+	}
+	septime := getSepTimeInFind(maxDoSwapLifetime)
+	swaps, err := mongodb.FindSwapinsWithStatus(mongodb.TxWithWrongValue, septime)
+	if err != nil {
+		logWorkerError("refund", "find wrong value swapins error", err)
+		return
+	}
+	delay := refundCfg.GetAutoRefundDelay()
+	for _, swap := range swaps {
+		if utils.IsCleanuping() {
+			return
+		}
+		if swap.InitTime/1000+delay > now() {
+			continue
+		}
+		res, err := mongodb.FindSwapResult(true, swap.TxID, swap.PairID, swap.Bind)
+		if err != nil {
+			continue
+		}
+		value, err := common.GetBigIntFromStr(res.Value)
+		if err != nil {
+			continue
+		}
+		tooSmall, tooLarge := tokens.CheckSwapValueRange(swap.PairID, value, true)
+		var reason string
+		switch {
+		case tooSmall && refundCfg.AutoRefundTooSmall:
+			reason = "auto refund value too small"
+		case tooLarge && refundCfg.AutoRefundTooLarge:
+			reason = "auto refund value too large"
+		default:
+			continue
+		}
+		if tokens.CalcRefundValue(swap.PairID, value, true).Sign() <= 0 {
+			logWorkerTrace("refund", "ignore auto refund as value is not above refund fee", "pairID", swap.PairID, "txid", swap.TxID, "bind", swap.Bind, "value", res.Value)
+			continue
+		}
+		err = mongodb.MarkSwapinRefundable(swap.TxID, swap.PairID, swap.Bind, mongodb.ActorWorker, reason)
+		if err != nil {
+			logWorkerError("refund", "mark auto refund failed", err, "pairID", swap.PairID, "txid", swap.TxID, "bind", swap.Bind, "value", res.Value)
+			continue
+		}
+		logWorker("refund", "mark auto refund success", "pairID", swap.PairID, "txid", swap.TxID, "bind", swap.Bind, "value", res.Value, "reason", reason)
+	}
+}
+
+func processRefunds() {
+	if !IsRefundSupported() {
+		return
+	}
+	septime := getSepTimeInFind(maxDoSwapLifetime)
+	swaps, err := mongodb.FindSwapinsWithStatus(mongodb.TxRefundable, septime)
+	if err != nil {
+		logWorkerError("refund", "find refundable swapins error", err)
+		return
+	}
+	for _, swap := range swaps {
+		if utils.IsCleanuping() {
+			return
+		}
+		err = processRefund(swap)
+		switch {
+		case err == nil,
+			errors.Is(err, errAlreadyRefunded),
+			errors.Is(err, errSwapQueueFull):
+		default:
+			logWorkerError("refund", "process refund error", err, "pairID", swap.PairID, "txid", swap.TxID, "bind", swap.Bind)
+		}
+	}
+}
+
+func processRefund(swap *mongodb.MgoSwap) error {
+	pairID := swap.PairID
+	txid := swap.TxID
+	bind := swap.Bind
+
+	cacheKey := getRefundCacheKey(txid, bind)
+	if cachedSwapTasks.Contains(cacheKey) {
+		return errAlreadyRefunded
+	}
+
+	res, err := mongodb.FindSwapResult(true, txid, pairID, bind)
+	if err != nil {
+		return err
+	}
+	if res.Status != mongodb.TxRefundable || res.RefundTx != "" {
+		if res.RefundTx != "" && res.Status != swap.Status {
+			// result is updated but swap status is not, sync it
+			_ = mongodb.UpdateSwapStatus(true, txid, pairID, bind, res.Status, now(), "")
+		}
+		return errAlreadyRefunded
+	}
+	if res.SwapTx != "" || res.SwapNonce != 0 || res.SwapHeight != 0 || isSwapHistoryExist(true, txid, bind) {
+		logWorkerWarn("refund", "forbid refund of swapped swapin", "pairID", pairID, "txid", txid, "bind", bind, "swapTx", res.SwapTx)
+		return errAlreadySwapped
+	}
+
+	fromTokenCfg := tokens.GetTokenConfig(pairID, true)
+	if fromTokenCfg == nil {
+		return tokens.ErrUnknownPairID
+	}
+	if isSwapQueued(false, fromTokenCfg.DcrmAddress, cacheKey) {
+		return nil
+	}
+
+	swapInfo, err := verifyRefundDeposit(pairID, txid, bind, tokens.SwapTxType(swap.TxType))
+	if err != nil {
+		return err
+	}
+	if swapInfo.Value.String() != res.Value {
+		return errRefundValueMismatch
+	}
+
+	args := &tokens.BuildTxArgs{
+		SwapInfo: tokens.SwapInfo{
+			Identifier: params.GetIdentifier(),
+			PairID:     pairID,
+			SwapID:     txid,
+			SwapType:   tokens.RefundType,
+			TxType:     tokens.SwapTxType(swap.TxType),
+			Bind:       bind,
+		},
+		From:        fromTokenCfg.DcrmAddress,
+		OriginValue: swapInfo.Value,
+		RefundTo:    swapInfo.From,
+	}
+	return dispatchSwapTask(args, swap.InitTime)
+}
+
+// verifyRefundDeposit verify deposit on source chain to refund,
+// deposit which is valid or can never be swapped can be refunded.
+func verifyRefundDeposit(pairID, txid, bind string, txType tokens.SwapTxType) (*tokens.TxSwapInfo, error) {
+	swapInfo, err := verifySwapTransaction(tokens.SrcBridge, pairID, txid, bind, txType)
+	switch {
+	case err == nil:
+	case errors.Is(err, tokens.ErrTxWithWrongMemo),
+		errors.Is(err, tokens.ErrTxWithWrongValue),
+		errors.Is(err, tokens.ErrBindAddrIsContract):
+		// verify returns early with these errors, check confirmations here
+		if err = tokens.CheckTieredConfirmations(tokens.SrcBridge, swapInfo); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, err
+	}
+	if swapInfo.From == "" || swapInfo.Value == nil || swapInfo.Value.Sign() <= 0 {
+		return nil, errRefundWithoutSender
+	}
+	return swapInfo, nil
+}
+
+func processRefundTaskArgs(args *tokens.BuildTxArgs) {
+	err := doRefund(args)
+	switch {
+	case err == nil,
+		errors.Is(err, errAlreadyRefunded):
+	default:
+		logWorkerError("refund", "process failed", err, "pairID", args.PairID, "txid", args.SwapID, "bind", args.Bind, "value", args.OriginValue)
+	}
+}
+
+func doRefund(args *tokens.BuildTxArgs) (err error) {
+	pairID := args.PairID
+	txid := args.SwapID
+	bind := args.Bind
+	bridge := tokens.SrcBridge
+
+	cacheKey := getRefundCacheKey(txid, bind)
+	if err = checkAndUpdateProcessSwapTaskCache(cacheKey); err != nil {
+		return errAlreadyRefunded
+	}
+	isCachedRefundProcessed := false
+	var sessionKey string
+	defer func() {
+		finishSwapSignSession(sessionKey, isCachedRefundProcessed)
+		if !isCachedRefundProcessed {
+			logWorkerError("refund", "delete refund cache", err, "pairID", pairID, "txid", txid, "bind", bind)
+			cachedSwapTasks.Remove(cacheKey)
+			releaseReservedUtxos(bridge, txid, pairID, bind)
+		}
+	}()
+
+	logWorker("refund", "start to process", "pairID", pairID, "txid", txid, "bind", bind, "refundTo", args.RefundTo, "value", args.OriginValue)
+
+	rawTx, err := bridge.BuildRawTransaction(args)
+	if err != nil {
+		logWorkerError("refund", "build tx failed", err, "pairID", pairID, "txid", txid, "bind", bind)
+		return err
+	}
+	sessionKey = addSwapSignSession(cacheKey, args, true)
+
+	signedTx, signTxHash, err := signTransaction("refund", bridge, rawTx, args, 3)
+	if err != nil {
+		return err
+	}
+
+	// update database before sending transaction, status transition is checked
+	err = mongodb.UpdateSwapinRefund(txid, pairID, bind, &mongodb.RefundUpdateItems{
+		RefundTx:    signTxHash,
+		RefundTo:    args.RefundTo,
+		RefundValue: args.SwapValue.String(),
+		RefundNonce: args.GetTxNonce(),
+		Status:      mongodb.RefundTxNotStable,
+		Timestamp:   now(),
+	})
+	if err != nil {
+		logWorkerError("refund", "update refund result failed", err, "pairID", pairID, "txid", txid, "bind", bind)
+		return err
+	}
+	isCachedRefundProcessed = true
+
+	err = mongodb.UpdateSwapStatus(true, txid, pairID, bind, mongodb.RefundTxNotStable, now(), "")
+	if err != nil {
+		logWorkerError("refund", "update swap status failed", err, "pairID", pairID, "txid", txid, "bind", bind)
+		return err
+	}
+
+	txHash, err := sendSignedTransaction(bridge, signedTx, args)
+	if err == nil && txHash != signTxHash {
+		logWorkerWarn("refund", "send tx success but with different hash", "pairID", pairID, "txid", txid, "bind", bind, "txHash", txHash, "signTxHash", signTxHash)
+	}
+	return err
+}
+
+func processRefundStable(res *mongodb.MgoSwapResult) error {
+	bridge := tokens.SrcBridge
+	txStatus, err := bridge.GetTransactionStatus(res.RefundTx)
+	if err != nil || txStatus == nil || txStatus.BlockHeight == 0 {
+		return checkIfRefundNonceHasPassed(bridge, res)
+	}
+	if res.RefundHeight == 0 {
+		return mongodb.UpdateSwapinRefund(res.TxID, res.PairID, res.Bind, &mongodb.RefundUpdateItems{
+			RefundHeight: txStatus.BlockHeight,
+			RefundTime:   txStatus.BlockTime,
+			Status:       mongodb.KeepStatus,
+			Timestamp:    now(),
+		})
+	}
+	chainCfg := bridge.GetChainConfig()
+	if !txStatus.IsStable(chainCfg, *chainCfg.Confirmations) {
+		return nil
+	}
+	status := mongodb.RefundTxStable
+	if txStatus.IsSwapTxOnChainAndFailed(bridge.GetTokenConfig(res.PairID)) {
+		logWorkerWarn("refund", "mark refund failed with wrong status", "pairID", res.PairID, "txid", res.TxID, "bind", res.Bind, "refundTx", res.RefundTx)
+		status = mongodb.RefundTxFailed
+	}
+	return markRefundResult(res, status, "")
+}
+
+func checkIfRefundNonceHasPassed(bridge tokens.CrossChainBridge, res *mongodb.MgoSwapResult) error {
+	isTimeout := res.Timestamp < getSepTimeInFind(treatAsNoncePassedInterval)
+	dropped, memo, err := isRefundTxDropped(bridge, res, isTimeout)
+	if err != nil || !dropped {
+		return err
+	}
+	logWorkerWarn("refund", "mark refund failed with tx dropped", "pairID", res.PairID, "txid", res.TxID, "bind", res.Bind, "refundTx", res.RefundTx, "memo", memo)
+	return markRefundResult(res, mongodb.RefundTxFailed, memo)
+}
+
+// isRefundTxDropped check by nonce passed for eth-like bridges,
+// and by reserved inputs spent or tx missing for bridges without nonce
+func isRefundTxDropped(bridge tokens.CrossChainBridge, res *mongodb.MgoSwapResult, isTimeout bool) (dropped bool, memo string, err error) {
+	if nonceSetter, ok := bridge.(tokens.NonceSetter); ok {
+		if res.RefundNonce == 0 || !isTimeout {
+			return false, "", nil
+		}
+		tokenCfg := bridge.GetTokenConfig(res.PairID)
+		if tokenCfg == nil {
+			return false, "", tokens.ErrUnknownPairID
+		}
+		if blockHeight, _ := nonceSetter.GetTxBlockInfo(res.RefundTx); blockHeight > 0 {
+			return false, "", nil
+		}
+		nonce, err := nonceSetter.GetPoolNonce(tokenCfg.DcrmAddress, "latest")
+		if err != nil {
+			return false, "", errGetNonceFailed
+		}
+		logWorkerTrace("refund", "check refund nonce", "pairID", res.PairID, "txid", res.TxID, "bind", res.Bind, "refundNonce", res.RefundNonce, "latestNonce", nonce)
+		return nonce > res.RefundNonce, "refund nonce passed", nil
+	}
+	if checker, ok := bridge.(tokens.DroppedTxChecker); ok {
+		swapKey := mongodb.GetSwapKey(res.TxID, res.PairID, res.Bind)
+		dropped, err = checker.IsTxDropped(res.RefundTx, swapKey, isTimeout)
+		return dropped, "refund tx dropped", err
+	}
+	return false, "", nil
+}
+
+func markRefundResult(res *mongodb.MgoSwapResult, status mongodb.SwapStatus, memo string) error {
+	err := mongodb.UpdateSwapinRefund(res.TxID, res.PairID, res.Bind, &mongodb.RefundUpdateItems{
+		Status:    status,
+		Timestamp: now(),
+		Memo:      memo,
+	})
+	if err != nil {
+		return err
+	}
+	logWorker("refund", "mark refund result", "pairID", res.PairID, "txid", res.TxID, "bind", res.Bind, "refundTx", res.RefundTx, "status", status.String())
+	return mongodb.UpdateSwapStatus(true, res.TxID, res.PairID, res.Bind, status, now(), memo)
+}
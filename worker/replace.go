@@ -12,6 +12,7 @@ var (
 	defWaitTimeToReplace = int64(900) // seconds
 	defMaxReplaceCount   = 20
 
+	replaceChanSize = 10
 	// key is signer address
 	swapinReplaceChanMap  = make(map[string]chan *mongodb.MgoSwapResult)
 	swapoutReplaceChanMap = make(map[string]chan *mongodb.MgoSwapResult)
@@ -147,14 +148,14 @@ func dispatchReplaceTask(swap *mongodb.MgoSwapResult) {
 	if isSwapin {
 		swapinDcrmAddr := strings.ToLower(pairCfg.DestToken.DcrmAddress)
 		if _, exist := swapinReplaceChanMap[swapinDcrmAddr]; !exist {
-			swapinReplaceChanMap[swapinDcrmAddr] = make(chan *mongodb.MgoSwapResult, swapChanSize)
+			swapinReplaceChanMap[swapinDcrmAddr] = make(chan *mongodb.MgoSwapResult, replaceChanSize)
 			go processReplaceSwapTask(swapinReplaceChanMap[swapinDcrmAddr])
 		}
 		swapinReplaceChanMap[swapinDcrmAddr] <- swap
 	} else {
 		swapoutDcrmAddr := strings.ToLower(pairCfg.SrcToken.DcrmAddress)
 		if _, exist := swapoutReplaceChanMap[swapoutDcrmAddr]; !exist {
-			swapoutReplaceChanMap[swapoutDcrmAddr] = make(chan *mongodb.MgoSwapResult, swapChanSize)
+			swapoutReplaceChanMap[swapoutDcrmAddr] = make(chan *mongodb.MgoSwapResult, replaceChanSize)
 			go processReplaceSwapTask(swapoutReplaceChanMap[swapoutDcrmAddr])
 		}
 		swapoutReplaceChanMap[swapoutDcrmAddr] <- swap
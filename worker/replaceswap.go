@@ -8,8 +8,10 @@ import (
 	"sync"
 
 	"github.com/anyswap/CrossChain-Bridge/mongodb"
+	"github.com/anyswap/CrossChain-Bridge/notifier"
 	"github.com/anyswap/CrossChain-Bridge/params"
 	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tokens/cosmos"
 )
 
 var (
@@ -23,6 +25,7 @@ var (
 	errSignTxFailed       = errors.New("sign tx failed")
 	errUpdateOldTxsFailed = errors.New("update old swaptxs failed")
 	errNotNonceSupport    = errors.New("not nonce support bridge")
+	errSwapNonceMismatch  = errors.New("replace swap nonce mismatch")
 
 	updateOldSwapTxsLock sync.Mutex
 
@@ -167,27 +170,19 @@ func replaceSwap(txid, pairID, bind, gasPriceStr string, isSwapin bool) (txHash
 		From:        tokenCfg.DcrmAddress,
 		OriginValue: swapInfo.Value,
 		ReplaceNum:  replaceNum,
-		Extra: &tokens.AllExtras{
-			EthExtra: &tokens.EthExtraArgs{
-				GasPrice: gasPrice,
-				Nonce:    &nonce,
-			},
-		},
+		Extra:       getReplaceExtraArgs(bridge, nonce, gasPrice),
 	}
 	rawTx, err := bridge.BuildRawTransaction(args)
 	if err != nil {
 		logWorkerError("replaceSwap", "build tx failed", err, "txid", txid, "bind", bind, "isSwapin", isSwapin)
 		return "", errBuildTxFailed
 	}
-	var signedTx interface{}
-	var signTxHash string
-	if tokenCfg.GetDcrmAddressPrivateKey() != nil {
-		signedTx, signTxHash, err = bridge.SignTransaction(rawTx, pairID)
-	} else {
-		signedTx, signTxHash, err = bridge.DcrmSignTransaction(rawTx, args.GetExtraArgs())
+	if args.GetTxNonce() != nonce {
+		logWorkerError("replaceSwap", "build tx failed", errSwapNonceMismatch, "txid", txid, "bind", bind, "isSwapin", isSwapin, "swapNonce", nonce, "txNonce", args.GetTxNonce())
+		return "", errBuildTxFailed
 	}
+	signedTx, signTxHash, err := signTransaction("replaceSwap", bridge, rawTx, args, 1)
 	if err != nil {
-		logWorkerError("replaceSwap", "sign tx failed", err, "txid", txid, "bind", bind, "isSwapin", isSwapin)
 		return "", errSignTxFailed
 	}
 
@@ -244,6 +239,7 @@ func replaceSwapResult(txid, pairID, bind, txHash, swapValue string, isSwapin bo
 		logWorkerError("replace", "replaceSwapResult", err, "txid", txid, "pairID", pairID, "bind", bind, "swaptx", txHash, "swapType", swapType, "nonce", res.SwapNonce, "swapValue", swapValue)
 	} else {
 		logWorker("replace", "replaceSwapResult", "txid", txid, "pairID", pairID, "bind", bind, "swaptx", txHash, "swapType", swapType, "nonce", res.SwapNonce, "swapValue", swapValue)
+		notifier.NotifySwapReplaced(isSwapin, pairID, txid, bind, txHash, swapValue)
 	}
 	return err
 }
@@ -267,3 +263,20 @@ func preventReplaceswapByHistory(res *mongodb.MgoSwapResult, isSwapin bool) erro
 	}
 	return nil
 }
+
+// getReplaceExtraArgs replace swap with the same nonce (account sequence of cosmos)
+func getReplaceExtraArgs(bridge tokens.CrossChainBridge, nonce uint64, gasPrice *big.Int) *tokens.AllExtras {
+	if _, ok := bridge.(*cosmos.Bridge); ok {
+		return &tokens.AllExtras{
+			CosmosExtra: &tokens.CosmosExtraArgs{
+				Sequence: &nonce,
+			},
+		}
+	}
+	return &tokens.AllExtras{
+		EthExtra: &tokens.EthExtraArgs{
+			GasPrice: gasPrice,
+			Nonce:    &nonce,
+		},
+	}
+}
@@ -0,0 +1,44 @@
+package worker
+
+import (
+	"time"
+
+	"github.com/anyswap/CrossChain-Bridge/cmd/utils"
+	"github.com/anyswap/CrossChain-Bridge/mongodb"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	"github.com/anyswap/CrossChain-Bridge/tokens/btc"
+)
+
+var (
+	checkUtxoReservationInterval = 5 * time.Minute
+)
+
+// StartUtxoReservationJob release reserved utxos of dropped txs
+func StartUtxoReservationJob() {
+	if btc.BridgeInstance == nil {
+		return
+	}
+
+	mongodb.MgoWaitGroup.Add(1)
+	go loopCheckUtxoReservations()
+}
+
+func loopCheckUtxoReservations() {
+	defer mongodb.MgoWaitGroup.Done()
+	for loop := 1; ; loop++ {
+		if utils.IsCleanuping() {
+			return
+		}
+		logWorkerTrace("reserveutxo", "start check utxo reservations", "loop", loop)
+		btc.BridgeInstance.CheckUtxoReservations()
+		restInJob(checkUtxoReservationInterval)
+	}
+}
+
+func releaseReservedUtxos(bridge tokens.CrossChainBridge, txid, pairID, bind string) {
+	btcBridge, ok := bridge.(btc.BridgeInterface)
+	if !ok {
+		return
+	}
+	btcBridge.ReleaseReservedUtxos(mongodb.GetSwapKey(txid, pairID, bind))
+}
@@ -3,6 +3,8 @@ package worker
 import (
 	"github.com/anyswap/CrossChain-Bridge/tokens"
 	"github.com/anyswap/CrossChain-Bridge/tokens/btc"
+	"github.com/anyswap/CrossChain-Bridge/tokens/substrate"
+	"github.com/anyswap/CrossChain-Bridge/tokens/tron"
 )
 
 // StartScanJob scan job
@@ -15,4 +17,12 @@ func StartScanJob(isServer bool) {
 		}
 		go btc.BridgeInstance.StartSwapHistoryScanJob()
 	}
+	if srcChainCfg.EnableScan {
+		switch bridge := tokens.SrcBridge.(type) {
+		case *substrate.Bridge:
+			go bridge.StartChainTransactionScanJob()
+		case *tron.Bridge:
+			go bridge.StartChainTransactionScanJob()
+		}
+	}
 }
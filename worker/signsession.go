@@ -0,0 +1,184 @@
+package worker
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Bridge/dcrm"
+	"github.com/anyswap/CrossChain-Bridge/mongodb"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+var (
+	errSignSessionNotStarted = errors.New("sign session has no keyID")
+	errWrongSignSession      = errors.New("wrong sign session")
+)
+
+func newSignSession(batchSigner tokens.BatchSigner, pubkey string, msgHashes []string, tasks []*batchSwapTask, isSwapin bool) (*mongodb.MgoSignSession, error) {
+	session := &mongodb.MgoSignSession{
+		Key:       mongodb.GetSignSessionKey(pubkey, msgHashes),
+		Pubkey:    pubkey,
+		MsgHashes: msgHashes,
+		IsSwapin:  isSwapin,
+		SwapKeys:  make([]string, len(tasks)),
+		SwapArgs:  make([]string, len(tasks)),
+		RawTxs:    make([]string, len(tasks)),
+	}
+	for i, task := range tasks {
+		rawTx, err := batchSigner.MarshalRawTransaction(task.rawTx)
+		if err != nil {
+			return nil, err
+		}
+		jsondata, err := json.Marshal(task.args)
+		if err != nil {
+			return nil, err
+		}
+		session.SwapKeys[i] = task.cacheKey
+		session.SwapArgs[i] = string(jsondata)
+		session.RawTxs[i] = rawTx
+	}
+	return session, nil
+}
+
+// addSwapSignSession persist args of single swap before signing,
+// the args is filled with extras (eg. nonce, utxos and fee) when building,
+// so the same raw tx can be rebuilt from it to resume the sign session
+func addSwapSignSession(cacheKey string, args *tokens.BuildTxArgs, isSwapin bool) (sessionKey string) {
+	jsondata, err := json.Marshal(args)
+	if err != nil {
+		return ""
+	}
+	session := &mongodb.MgoSignSession{
+		Key:      mongodb.GetSwapSignSessionKey(cacheKey),
+		IsSwapin: isSwapin,
+		SwapKeys: []string{cacheKey},
+		SwapArgs: []string{string(jsondata)},
+	}
+	if mongodb.AddSignSession(session) != nil {
+		return ""
+	}
+	return session.Key
+}
+
+func finishSwapSignSession(sessionKey string, processed bool) {
+	if sessionKey == "" {
+		return
+	}
+	status := mongodb.SignSessionDone
+	if !processed {
+		status = mongodb.SignSessionFailed
+	}
+	_ = mongodb.UpdateSignSessionStatus(sessionKey, status, nil)
+}
+
+// resumeSignSessions get sign results of in-flight sign sessions before restart,
+// then update database and send the signed txs, instead of signing the swaps again
+func resumeSignSessions() {
+	sessions, err := mongodb.FindUnfinishedSignSessions()
+	if err != nil {
+		logWorkerError("resume", "find unfinished sign sessions failed", err)
+		return
+	}
+	for _, session := range sessions {
+		logWorker("resume", "resume sign session", "key", session.Key, "keyID", session.KeyID, "status", session.Status, "swapKeys", session.SwapKeys)
+		err = resumeSignSession(session)
+		if err != nil {
+			logWorkerError("resume", "resume sign session failed", err, "key", session.Key, "keyID", session.KeyID, "swapKeys", session.SwapKeys)
+		}
+	}
+}
+
+func resumeSignSession(session *mongodb.MgoSignSession) (err error) {
+	if len(session.RawTxs) == 0 {
+		return resumeSwapSignSession(session)
+	}
+	isSwapin := session.IsSwapin
+	resBridge := tokens.GetCrossChainBridge(!isSwapin)
+	batchSigner, ok := resBridge.(tokens.BatchSigner)
+	if !ok {
+		_ = mongodb.UpdateSignSessionStatus(session.Key, mongodb.SignSessionFailed, nil)
+		return errBatchSignNotSupported
+	}
+
+	rsvs := session.Rsvs
+	if session.Status == mongodb.SignSessionSigning {
+		if session.KeyID == "" {
+			// sign request is not sent, swaps will be signed again
+			_ = mongodb.UpdateSignSessionStatus(session.Key, mongodb.SignSessionFailed, nil)
+			return errSignSessionNotStarted
+		}
+		rsvs, err = dcrm.GetSignStatusByInitiator(session.KeyID, session.Initiator)
+		if err != nil {
+			_ = mongodb.UpdateSignSessionStatus(session.Key, mongodb.SignSessionFailed, nil)
+			return err
+		}
+		_ = mongodb.UpdateSignSessionStatus(session.Key, mongodb.SignSessionSigned, rsvs)
+	}
+
+	rawTxs := make([]interface{}, len(session.RawTxs))
+	argsList := make([]*tokens.BuildTxArgs, len(session.SwapArgs))
+	for i, data := range session.RawTxs {
+		rawTxs[i], err = batchSigner.UnmarshalRawTransaction(data)
+		if err != nil {
+			_ = mongodb.UpdateSignSessionStatus(session.Key, mongodb.SignSessionFailed, nil)
+			return err
+		}
+	}
+	for i, data := range session.SwapArgs {
+		args := &tokens.BuildTxArgs{}
+		err = json.Unmarshal([]byte(data), args)
+		if err != nil {
+			_ = mongodb.UpdateSignSessionStatus(session.Key, mongodb.SignSessionFailed, nil)
+			return err
+		}
+		argsList[i] = args
+	}
+	signedTxs, txHashes, err := batchSigner.MakeSignedTransactions(rawTxs, rsvs, argsList)
+	if err != nil {
+		_ = mongodb.UpdateSignSessionStatus(session.Key, mongodb.SignSessionFailed, nil)
+		return err
+	}
+
+	defer func() {
+		_ = mongodb.UpdateSignSessionStatus(session.Key, mongodb.SignSessionDone, nil)
+	}()
+	for i, args := range argsList {
+		res, errf := mongodb.FindSwapResult(isSwapin, args.SwapID, args.PairID, args.Bind)
+		if errf != nil {
+			return errf
+		}
+		_ = checkAndUpdateProcessSwapTaskCache(getSwapCacheKey(isSwapin, args.SwapID, args.Bind))
+		if strings.EqualFold(res.SwapTx, txHashes[i]) {
+			// database is updated before restart, only resend the signed tx
+			_, _ = sendSignedTransaction(resBridge, signedTxs[i], args)
+			continue
+		}
+		processed, errs := updateAndSendSwapTx(resBridge, args, signedTxs[i], txHashes[i])
+		if !processed {
+			// the later txs can not be sent because of the nonce gap, they will be reprocessed later
+			cachedSwapTasks.Remove(getSwapCacheKey(isSwapin, args.SwapID, args.Bind))
+			return errs
+		}
+	}
+	return nil
+}
+
+// resumeSwapSignSession rebuild the same raw tx of single swap from its args,
+// and the signer resumes its in-flight sign session of the same msg hashes
+func resumeSwapSignSession(session *mongodb.MgoSignSession) error {
+	// replaced by a new session of the same key if the swap is signed again
+	_ = mongodb.UpdateSignSessionStatus(session.Key, mongodb.SignSessionFailed, nil)
+	if len(session.SwapArgs) != 1 {
+		return errWrongSignSession
+	}
+	args := &tokens.BuildTxArgs{}
+	err := json.Unmarshal([]byte(session.SwapArgs[0]), args)
+	if err != nil {
+		return err
+	}
+	if args.SwapType == tokens.RefundType {
+		return doRefund(args)
+	}
+	return doSwap(args)
+}
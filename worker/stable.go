@@ -139,7 +139,8 @@ func processSwapStable(swap *mongodb.MgoSwapResult, isSwapin bool) (err error) {
 	}
 
 	if swap.SwapHeight != 0 {
-		if txStatus.Confirmations < *resBridge.GetChainConfig().Confirmations {
+		chainCfg := resBridge.GetChainConfig()
+		if !txStatus.IsStable(chainCfg, *chainCfg.Confirmations) {
 			return nil
 		}
 		if swap.SwapTx != oldSwapTx {
@@ -20,13 +20,11 @@ var (
 	cachedSwapTasks    = mapset.NewSet()
 	maxCachedSwapTasks = 1000
 
-	swapChanSize       = 10
-	swapinTaskChanMap  = make(map[string]chan *tokens.BuildTxArgs)
-	swapoutTaskChanMap = make(map[string]chan *tokens.BuildTxArgs)
-
-	errAlreadySwapped     = errors.New("already swapped")
-	errDBError            = errors.New("database error")
-	errSendTxWithDiffHash = errors.New("send tx with different hash")
+	errAlreadySwapped      = errors.New("already swapped")
+	errDBError             = errors.New("database error")
+	errSendTxWithDiffHash  = errors.New("send tx with different hash")
+	errSwapQueueFull       = errors.New("swap queue is full")
+	errTooManyBoostedSwaps = errors.New("too many boosted swaps")
 )
 
 // StartSwapJob swap job
@@ -38,6 +36,9 @@ func StartSwapJob() {
 	if tokens.SrcNonceSetter != nil {
 		tokens.SrcNonceSetter.InitNonces(swapoutNonces)
 	}
+	// finish in-flight sign sessions before building new swap txs
+	resumeSignSessions()
+
 	for _, pairCfg := range tokens.GetTokenPairsConfig() {
 		AddSwapJob(pairCfg)
 	}
@@ -49,17 +50,13 @@ func StartSwapJob() {
 
 // AddSwapJob add swap job
 func AddSwapJob(pairCfg *tokens.TokenPairConfig) {
-	swapinDcrmAddr := strings.ToLower(pairCfg.DestToken.DcrmAddress)
-	if _, exist := swapinTaskChanMap[swapinDcrmAddr]; !exist {
-		swapinTaskChanMap[swapinDcrmAddr] = make(chan *tokens.BuildTxArgs, swapChanSize)
+	if queue := addSwapTaskQueue(true, pairCfg.DestToken.DcrmAddress); queue != nil {
 		utils.TopWaitGroup.Add(1)
-		go processSwapTask(swapinTaskChanMap[swapinDcrmAddr], swapinDcrmAddr, true)
+		go processSwapTask(queue)
 	}
-	swapoutDcrmAddr := strings.ToLower(pairCfg.SrcToken.DcrmAddress)
-	if _, exist := swapoutTaskChanMap[swapoutDcrmAddr]; !exist {
-		swapoutTaskChanMap[swapoutDcrmAddr] = make(chan *tokens.BuildTxArgs, swapChanSize)
+	if queue := addSwapTaskQueue(false, pairCfg.SrcToken.DcrmAddress); queue != nil {
 		utils.TopWaitGroup.Add(1)
-		go processSwapTask(swapoutTaskChanMap[swapoutDcrmAddr], swapoutDcrmAddr, false)
+		go processSwapTask(queue)
 	}
 }
 
@@ -108,6 +105,7 @@ func processSwapins(status mongodb.SwapStatus) {
 		case err == nil,
 			errors.Is(err, errAlreadySwapped),
 			errors.Is(err, errDBError),
+			errors.Is(err, errSwapQueueFull),
 			errors.Is(err, tokens.ErrUnknownPairID),
 			errors.Is(err, tokens.ErrAddressIsInBlacklist),
 			errors.Is(err, tokens.ErrSwapIsClosed):
@@ -135,6 +133,7 @@ func processSwapouts(status mongodb.SwapStatus) {
 		case err == nil,
 			errors.Is(err, errAlreadySwapped),
 			errors.Is(err, errDBError),
+			errors.Is(err, errSwapQueueFull),
 			errors.Is(err, tokens.ErrUnknownPairID),
 			errors.Is(err, tokens.ErrAddressIsInBlacklist),
 			errors.Is(err, tokens.ErrSwapIsClosed):
@@ -202,6 +201,9 @@ func processSwap(swap *mongodb.MgoSwap, isSwapin bool) (err error) {
 	if err != nil {
 		return err
 	}
+	if isSwapQueued(isSwapin, dcrmAddress, cacheKey) {
+		return nil
+	}
 
 	logWorker("swap", "start process swap", "pairID", pairID, "txid", txid, "bind", bind, "status", swap.Status, "isSwapin", isSwapin, "value", res.Value)
 
@@ -232,7 +234,7 @@ func processSwap(swap *mongodb.MgoSwap, isSwapin bool) (err error) {
 		OriginValue: swapInfo.Value,
 	}
 
-	return dispatchSwapTask(args)
+	return dispatchSwapTask(args, swap.InitTime)
 }
 
 func checkSwapResult(res *mongodb.MgoSwapResult, isSwapin bool) (dcrmAddress string, err error) {
@@ -323,51 +325,68 @@ func preventReswapByHistory(res *mongodb.MgoSwapResult, isSwapin bool) error {
 	return nil
 }
 
-func dispatchSwapTask(args *tokens.BuildTxArgs) error {
-	from := strings.ToLower(args.From)
+// dispatchSwapTask push swap task into the queue of its dcrm account,
+// timestamp is the register time of swap to order by age
+func dispatchSwapTask(args *tokens.BuildTxArgs, timestamp int64) error {
+	var isSwapin bool
 	switch args.SwapType {
 	case tokens.SwapinType:
-		swapChan, exist := swapinTaskChanMap[from]
-		if !exist {
-			return fmt.Errorf("no swapin task channel for dcrm address '%v'", args.From)
-		}
-		swapChan <- args
-	case tokens.SwapoutType:
-		swapChan, exist := swapoutTaskChanMap[from]
-		if !exist {
-			return fmt.Errorf("no swapout task channel for dcrm address '%v'", args.From)
-		}
-		swapChan <- args
+		isSwapin = true
+	case tokens.SwapoutType, tokens.RefundType: // refund shares nonce with swapout
 	default:
 		return fmt.Errorf("wrong swap type '%v'", args.SwapType.String())
 	}
+	queue := getSwapTaskQueue(isSwapin, args.From)
+	if queue == nil {
+		return fmt.Errorf("no %v task queue for dcrm address '%v'", args.SwapType.String(), args.From)
+	}
+	err := queue.push(newSwapTask(args, timestamp))
+	if err != nil {
+		return err
+	}
 	logWorker("doSwap", "dispatch swap task", "pairID", args.PairID, "txid", args.SwapID, "bind", args.Bind, "swapType", args.SwapType.String(), "value", args.OriginValue)
 	return nil
 }
 
-func processSwapTask(swapChan <-chan *tokens.BuildTxArgs, dcrmAddress string, isSwapin bool) {
+func processSwapTask(queue *swapTaskQueue) {
 	defer utils.TopWaitGroup.Done()
 	for {
-		select {
-		case <-utils.CleanupChan:
-			logWorker("doSwap", "stop process swap task", "isSwapin", isSwapin, "dcrmAddress", dcrmAddress)
-			return
-		case args := <-swapChan:
-			if !strings.EqualFold(args.From, dcrmAddress) || args.SwapType != getSwapType(isSwapin) {
-				logWorkerWarn("doSwap", "ignore swap task as mismatch reason", "isSwapin", isSwapin, "dcrmAddress", dcrmAddress, "args", args)
-				continue
-			}
-			err := doSwap(args)
-			switch {
-			case err == nil,
-				errors.Is(err, errAlreadySwapped):
-			default:
-				logWorkerError("doSwap", "process failed", err, "pairID", args.PairID, "txid", args.SwapID, "swapType", args.SwapType.String(), "value", args.OriginValue)
+		args := queue.pop(nil)
+		if args == nil {
+			select {
+			case <-utils.CleanupChan:
+				logWorker("doSwap", "stop process swap task", "isSwapin", queue.isSwapin, "dcrmAddress", queue.dcrmAddress)
+				return
+			case <-queue.notify:
 			}
+			continue
+		}
+		if utils.IsCleanuping() {
+			logWorker("doSwap", "stop process swap task", "isSwapin", queue.isSwapin, "dcrmAddress", queue.dcrmAddress)
+			return
+		}
+		if canBatchSign(args) {
+			processSwapBatch(collectSwapBatch(queue, args))
+		} else {
+			processSwapTaskArgs(args)
 		}
 	}
 }
 
+func processSwapTaskArgs(args *tokens.BuildTxArgs) {
+	if args.SwapType == tokens.RefundType {
+		processRefundTaskArgs(args)
+		return
+	}
+	err := doSwap(args)
+	switch {
+	case err == nil,
+		errors.Is(err, errAlreadySwapped):
+	default:
+		logWorkerError("doSwap", "process failed", err, "pairID", args.PairID, "txid", args.SwapID, "swapType", args.SwapType.String(), "value", args.OriginValue)
+	}
+}
+
 func getSwapCacheKey(isSwapin bool, txid, bind string) string {
 	return strings.ToLower(fmt.Sprintf("%s:%s:%t", txid, bind, isSwapin))
 }
@@ -399,10 +418,13 @@ func doSwap(args *tokens.BuildTxArgs) (err error) {
 	}
 	logWorker("doSwap", "add swap cache", "pairID", pairID, "txid", txid, "bind", bind, "isSwapin", isSwapin, "value", args.OriginValue)
 	isCachedSwapProcessed := false
+	var sessionKey string
 	defer func() {
+		finishSwapSignSession(sessionKey, isCachedSwapProcessed)
 		if !isCachedSwapProcessed {
 			logWorkerError("doSwap", "delete swap cache", err, "pairID", pairID, "txid", txid, "bind", bind, "isSwapin", isSwapin, "value", args.OriginValue)
 			cachedSwapTasks.Remove(cacheKey)
+			releaseReservedUtxos(resBridge, txid, pairID, bind)
 		}
 	}()
 
@@ -413,36 +435,36 @@ func doSwap(args *tokens.BuildTxArgs) (err error) {
 		logWorkerError("doSwap", "build tx failed", err, "pairID", pairID, "txid", txid, "bind", bind, "isSwapin", isSwapin)
 		return err
 	}
+	sessionKey = addSwapSignSession(cacheKey, args, isSwapin)
 
-	swapNonce := args.GetTxNonce()
-
-	var signedTx interface{}
-	var signTxHash string
-	tokenCfg := resBridge.GetTokenConfig(pairID)
-	for i := 1; i <= 3; i++ { // with retry
-		if tokenCfg.GetDcrmAddressPrivateKey() != nil {
-			signedTx, signTxHash, err = resBridge.SignTransaction(rawTx, pairID)
-		} else {
-			signedTx, signTxHash, err = resBridge.DcrmSignTransaction(rawTx, args.GetExtraArgs())
-		}
-		if err == nil {
-			break
-		}
-		logWorkerError("doSwap", "sign tx failed", err, "pairID", pairID, "txid", txid, "bind", bind, "isSwapin", isSwapin, "signCount", i)
-		restInJob(retrySignInterval)
-	}
+	signedTx, signTxHash, err := signTransaction("doSwap", resBridge, rawTx, args, 3)
 	if err != nil {
 		return err
 	}
 
+	isCachedSwapProcessed, err = updateAndSendSwapTx(resBridge, args, signedTx, signTxHash)
+	return err
+}
+
+// updateAndSendSwapTx recheck reswap, update swap result and send the signed swap tx.
+// processed is true if the swap result has been updated in database.
+func updateAndSendSwapTx(resBridge tokens.CrossChainBridge, args *tokens.BuildTxArgs, signedTx interface{}, signTxHash string) (processed bool, err error) {
+	pairID := args.PairID
+	txid := args.SwapID
+	bind := args.Bind
+	swapType := args.SwapType
+	swapNonce := args.GetTxNonce()
+
+	isSwapin := swapType == tokens.SwapinType
+
 	// recheck reswap before update db
 	res, err := mongodb.FindSwapResult(isSwapin, txid, pairID, bind)
 	if err != nil {
-		return err
+		return false, err
 	}
 	err = preventReswap(res, isSwapin)
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	// update database before sending transaction
@@ -459,14 +481,14 @@ func doSwap(args *tokens.BuildTxArgs) (err error) {
 	err = updateSwapResult(txid, pairID, bind, matchTx)
 	if err != nil {
 		logWorkerError("doSwap", "update swap result failed", err, "pairID", pairID, "txid", txid, "bind", bind, "isSwapin", isSwapin)
-		return err
+		return false, err
 	}
-	isCachedSwapProcessed = true
+	processed = true
 
 	err = mongodb.UpdateSwapStatus(isSwapin, txid, pairID, bind, mongodb.TxProcessed, now(), "")
 	if err != nil {
 		logWorkerError("doSwap", "update swap status failed", err, "pairID", pairID, "txid", txid, "bind", bind, "isSwapin", isSwapin)
-		return err
+		return processed, err
 	}
 
 	txHash, err := sendSignedTransaction(resBridge, signedTx, args)
@@ -477,7 +499,7 @@ func doSwap(args *tokens.BuildTxArgs) (err error) {
 			_ = replaceSwapResult(txid, pairID, bind, txHash, matchTx.SwapValue, isSwapin)
 		}
 	}
-	return err
+	return processed, err
 }
 
 // DeleteCachedSwap delete cached swap
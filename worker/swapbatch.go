@@ -0,0 +1,221 @@
+package worker
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Bridge/mongodb"
+	"github.com/anyswap/CrossChain-Bridge/params"
+	"github.com/anyswap/CrossChain-Bridge/signer"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+)
+
+var errBatchSignNotSupported = errors.New("batch sign is not supported")
+
+type batchSwapTask struct {
+	args      *tokens.BuildTxArgs
+	cacheKey  string
+	rawTx     interface{}
+	processed bool
+	err       error
+}
+
+// collectSwapBatch pop the queued swap tasks which can be signed together with the first one
+func collectSwapBatch(queue *swapTaskQueue, first *tokens.BuildTxArgs) []*tokens.BuildTxArgs {
+	batch := []*tokens.BuildTxArgs{first}
+	maxBatchSize := params.GetMaxSignBatchSize()
+	for len(batch) < maxBatchSize {
+		args := queue.pop(func(args *tokens.BuildTxArgs) bool {
+			return canBatchSignWith(first, args)
+		})
+		if args == nil {
+			break
+		}
+		batch = append(batch, args)
+	}
+	return batch
+}
+
+// canBatchSign only dcrm signed swaps on bridge supporting batch sign can be batched
+func canBatchSign(args *tokens.BuildTxArgs) bool {
+	if args.SwapType == tokens.RefundType {
+		return false
+	}
+	resBridge := tokens.GetCrossChainBridge(args.SwapType != tokens.SwapinType)
+	if _, ok := resBridge.(tokens.BatchSigner); !ok {
+		return false
+	}
+	tokenCfg := resBridge.GetTokenConfig(args.PairID)
+	return tokenCfg != nil && tokenCfg.GetSignerType() == tokens.DcrmSignerType
+}
+
+// canBatchSignWith swaps signed by the same dcrm public key can be batched
+func canBatchSignWith(first, args *tokens.BuildTxArgs) bool {
+	if args.SwapType != first.SwapType || !canBatchSign(args) {
+		return false
+	}
+	resBridge := tokens.GetCrossChainBridge(args.SwapType != tokens.SwapinType)
+	firstTokenCfg := resBridge.GetTokenConfig(first.PairID)
+	tokenCfg := resBridge.GetTokenConfig(args.PairID)
+	return strings.EqualFold(firstTokenCfg.DcrmPubkey, tokenCfg.DcrmPubkey)
+}
+
+func getBatchSwapIDs(tasks []*batchSwapTask) []string {
+	swapIDs := make([]string, len(tasks))
+	for i, task := range tasks {
+		swapIDs[i] = task.args.SwapID
+	}
+	return swapIDs
+}
+
+func processSwapBatch(batch []*tokens.BuildTxArgs) {
+	err := doSwapBatch(batch)
+	switch {
+	case err == nil,
+		errors.Is(err, errAlreadySwapped):
+	default:
+		logWorkerError("doSwap", "process batch failed", err, "count", len(batch))
+	}
+}
+
+// doSwapBatch build swap txs with consecutive nonces, sign them in one sign round,
+// then update database and send the signed txs in order of nonce
+func doSwapBatch(batch []*tokens.BuildTxArgs) (err error) {
+	isSwapin := batch[0].SwapType == tokens.SwapinType
+	resBridge := tokens.GetCrossChainBridge(!isSwapin)
+	batchSigner, ok := resBridge.(tokens.BatchSigner)
+	if !ok {
+		return errBatchSignNotSupported
+	}
+
+	tasks := make([]*batchSwapTask, 0, len(batch))
+	defer func() {
+		for _, task := range tasks {
+			if task.processed {
+				continue
+			}
+			args := task.args
+			logWorkerError("doSwap", "delete swap cache", task.err, "pairID", args.PairID, "txid", args.SwapID, "bind", args.Bind, "isSwapin", isSwapin, "value", args.OriginValue)
+			cachedSwapTasks.Remove(task.cacheKey)
+			releaseReservedUtxos(resBridge, args.SwapID, args.PairID, args.Bind)
+		}
+	}()
+
+	built := make([]*batchSwapTask, 0, len(batch))
+	var nextNonce uint64
+	for _, args := range batch {
+		cacheKey := getSwapCacheKey(isSwapin, args.SwapID, args.Bind)
+		if checkAndUpdateProcessSwapTaskCache(cacheKey) != nil {
+			continue
+		}
+		task := &batchSwapTask{args: args, cacheKey: cacheKey}
+		tasks = append(tasks, task)
+		logWorker("doSwap", "add swap cache", "pairID", args.PairID, "txid", args.SwapID, "bind", args.Bind, "isSwapin", isSwapin, "value", args.OriginValue)
+
+		if len(built) > 0 {
+			args.SetTxNonce(nextNonce)
+		}
+		task.rawTx, task.err = resBridge.BuildRawTransaction(args)
+		if task.err != nil {
+			logWorkerError("doSwap", "build tx failed", task.err, "pairID", args.PairID, "txid", args.SwapID, "bind", args.Bind, "isSwapin", isSwapin)
+			continue
+		}
+		nextNonce = args.GetTxNonce() + 1
+		built = append(built, task)
+	}
+	if len(built) == 0 {
+		return nil
+	}
+
+	swapIDs := getBatchSwapIDs(built)
+	logWorker("doSwap", "start to process batch", "isSwapin", isSwapin, "txids", swapIDs, "firstNonce", built[0].args.GetTxNonce())
+
+	signedTxs, signTxHashes, sessionKey, err := signSwapBatch(batchSigner, built, isSwapin)
+	if err != nil {
+		for _, task := range built {
+			task.err = err
+		}
+		return err
+	}
+	defer func() {
+		_ = mongodb.UpdateSignSessionStatus(sessionKey, mongodb.SignSessionDone, nil)
+	}()
+
+	for i, task := range built {
+		task.processed, task.err = updateAndSendSwapTx(resBridge, task.args, signedTxs[i], signTxHashes[i])
+		if task.processed {
+			continue
+		}
+		// the later txs can not be sent because of the nonce gap, they will be reprocessed later
+		err = task.err
+		for _, later := range built[i+1:] {
+			later.err = fmt.Errorf("previous swap %v in batch failed, %w", task.args.SwapID, err)
+		}
+		return err
+	}
+	return nil
+}
+
+// signSwapBatch sign swap txs in one sign round with retry,
+// the sign session is persisted before sending sign request to be resumed after restart
+func signSwapBatch(batchSigner tokens.BatchSigner, tasks []*batchSwapTask, isSwapin bool) (signedTxs []interface{}, txHashes []string, sessionKey string, err error) {
+	swapIDs := getBatchSwapIDs(tasks)
+	for i := 1; i <= 3; i++ { // with retry
+		signedTxs, txHashes, sessionKey, err = signSwapBatchOnce(batchSigner, tasks, isSwapin)
+		if err == nil {
+			return signedTxs, txHashes, sessionKey, nil
+		}
+		logWorkerError("doSwap", "batch sign tx failed", err, "isSwapin", isSwapin, "txids", swapIDs, "signCount", i)
+		restInJob(retrySignInterval)
+	}
+	return nil, nil, "", err
+}
+
+func signSwapBatchOnce(batchSigner tokens.BatchSigner, tasks []*batchSwapTask, isSwapin bool) (signedTxs []interface{}, txHashes []string, sessionKey string, err error) {
+	rawTxs := make([]interface{}, len(tasks))
+	argsList := make([]*tokens.BuildTxArgs, len(tasks))
+	for i, task := range tasks {
+		rawTxs[i] = task.rawTx
+		argsList[i] = task.args.GetExtraArgs()
+	}
+	msgHashes, msgContexts, err := batchSigner.PrepareSignTransactions(rawTxs, argsList)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	pairID := argsList[0].PairID
+	pubkey := tokens.GetCrossChainBridge(!isSwapin).GetTokenConfig(pairID).DcrmPubkey
+	session, err := newSignSession(batchSigner, pubkey, msgHashes, tasks, isSwapin)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	sessionKey = session.Key
+	err = mongodb.AddSignSession(session)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	defer func() {
+		if err != nil {
+			_ = mongodb.UpdateSignSessionStatus(sessionKey, mongodb.SignSessionFailed, nil)
+		}
+	}()
+
+	s, err := signer.GetSigner(pairID, !isSwapin)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	rsvs, err := s.Sign(pubkey, msgHashes, msgContexts)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	signedTxs, txHashes, err = batchSigner.MakeSignedTransactions(rawTxs, rsvs, argsList)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	err = mongodb.UpdateSignSessionStatus(sessionKey, mongodb.SignSessionSigned, rsvs)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	return signedTxs, txHashes, sessionKey, nil
+}
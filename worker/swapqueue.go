@@ -0,0 +1,370 @@
+package worker
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/anyswap/CrossChain-Bridge/params"
+	"github.com/anyswap/CrossChain-Bridge/tokens"
+	mapset "github.com/deckarep/golang-set"
+)
+
+var (
+	swapQueuesLock    sync.RWMutex
+	swapinTaskQueues  = make(map[string]*swapTaskQueue) // key is dcrm address
+	swapoutTaskQueues = make(map[string]*swapTaskQueue) // key is dcrm address
+
+	boostedSwaps = mapset.NewSet() // swap cache keys
+)
+
+// SwapQueueStatus swap task queue status
+type SwapQueueStatus struct {
+	DcrmAddress string         `json:"dcrmAddress"`
+	SwapType    string         `json:"swapType"`
+	Depth       int            `json:"depth"`
+	Boosted     int            `json:"boosted"`
+	PairDepths  map[string]int `json:"pairDepths"`
+}
+
+type swapTask struct {
+	args      *tokens.BuildTxArgs
+	key       string
+	pairKey   string
+	timestamp int64
+	value     float64
+	boosted   bool
+	seq       uint64
+}
+
+// swapTaskQueue priority queue of swap tasks of one dcrm account
+type swapTaskQueue struct {
+	dcrmAddress string
+	isSwapin    bool
+
+	lock   sync.Mutex
+	tasks  map[string]*swapTask // key is swap cache key
+	served map[string]float64   // key is pair, weighted serve count if fair per pair
+	seq    uint64               // push sequence to keep FIFO of the same order
+	notify chan struct{}        // signaled when task is pushed
+	config *params.SwapQueueConfig
+}
+
+func newSwapTaskQueue(dcrmAddress string, isSwapin bool) *swapTaskQueue {
+	return &swapTaskQueue{
+		dcrmAddress: dcrmAddress,
+		isSwapin:    isSwapin,
+		tasks:       make(map[string]*swapTask),
+		served:      make(map[string]float64),
+		notify:      make(chan struct{}, 1),
+		config:      params.GetSwapQueueConfig(),
+	}
+}
+
+func getSwapTaskQueue(isSwapin bool, dcrmAddress string) *swapTaskQueue {
+	swapQueuesLock.RLock()
+	defer swapQueuesLock.RUnlock()
+	if isSwapin {
+		return swapinTaskQueues[strings.ToLower(dcrmAddress)]
+	}
+	return swapoutTaskQueues[strings.ToLower(dcrmAddress)]
+}
+
+// addSwapTaskQueue add queue if not exist, return nil if already exist
+func addSwapTaskQueue(isSwapin bool, dcrmAddress string) *swapTaskQueue {
+	swapQueuesLock.Lock()
+	defer swapQueuesLock.Unlock()
+	queues := swapoutTaskQueues
+	if isSwapin {
+		queues = swapinTaskQueues
+	}
+	dcrmAddress = strings.ToLower(dcrmAddress)
+	if _, exist := queues[dcrmAddress]; exist {
+		return nil
+	}
+	queue := newSwapTaskQueue(dcrmAddress, isSwapin)
+	queues[dcrmAddress] = queue
+	return queue
+}
+
+func newSwapTask(args *tokens.BuildTxArgs, timestamp int64) *swapTask {
+	isSwapin := args.SwapType == tokens.SwapinType
+	key := getSwapCacheKey(isSwapin, args.SwapID, args.Bind)
+	if args.SwapType == tokens.RefundType {
+		key = getRefundCacheKey(args.SwapID, args.Bind)
+	}
+	task := &swapTask{
+		args:      args,
+		key:       key,
+		pairKey:   strings.ToLower(args.PairID),
+		timestamp: timestamp,
+	}
+	fromTokenCfg, _ := tokens.GetTokenConfigsByDirection(args.PairID, args.SwapType != tokens.SwapoutType)
+	if fromTokenCfg != nil && fromTokenCfg.Decimals != nil && args.OriginValue != nil {
+		task.value = tokens.FromBits(args.OriginValue, *fromTokenCfg.Decimals)
+	}
+	return task
+}
+
+// push add swap task, update args if already queued
+func (q *swapTaskQueue) push(task *swapTask) error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if old, exist := q.tasks[task.key]; exist {
+		old.args = task.args
+		return nil
+	}
+	if len(q.tasks) >= q.config.GetMaxQueueSize() {
+		return fmt.Errorf("%w, dcrm address is '%v'", errSwapQueueFull, q.dcrmAddress)
+	}
+	if q.config.FairPerPair && !q.hasPair(task.pairKey) {
+		// returning pair can not use the credit of idle time
+		if minServed, ok := q.minServed(); ok && q.served[task.pairKey] < minServed {
+			q.served[task.pairKey] = minServed
+		}
+	}
+	q.seq++
+	task.seq = q.seq
+	task.boosted = boostedSwaps.Contains(task.key)
+	q.tasks[task.key] = task
+
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// pop remove and return the first swap task matching 'match' (nil matches all)
+func (q *swapTaskQueue) pop(match func(*tokens.BuildTxArgs) bool) *tokens.BuildTxArgs {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	task := q.popTask(match)
+	if task == nil {
+		return nil
+	}
+	if task.boosted {
+		boostedSwaps.Remove(task.key)
+	}
+	return task.args
+}
+
+func (q *swapTaskQueue) popTask(match func(*tokens.BuildTxArgs) bool) *swapTask {
+	var best *swapTask
+	for _, task := range q.tasks {
+		if match != nil && !match(task.args) {
+			continue
+		}
+		if best == nil || q.less(task, best) {
+			best = task
+		}
+	}
+	if best == nil {
+		return nil
+	}
+	delete(q.tasks, best.key)
+	if q.config.FairPerPair && !best.boosted {
+		q.served[best.pairKey] += 1 / float64(q.config.GetPairPriority(best.pairKey))
+	}
+	return best
+}
+
+// less is task 'a' served before task 'b'
+func (q *swapTaskQueue) less(a, b *swapTask) bool {
+	if a.boosted != b.boosted {
+		return a.boosted
+	}
+	if !a.boosted && a.pairKey != b.pairKey {
+		if q.config.FairPerPair {
+			if servedA, servedB := q.served[a.pairKey], q.served[b.pairKey]; servedA != servedB {
+				return servedA < servedB
+			}
+		} else {
+			priorityA := q.config.GetPairPriority(a.pairKey)
+			priorityB := q.config.GetPairPriority(b.pairKey)
+			if priorityA != priorityB {
+				return priorityA > priorityB
+			}
+		}
+	}
+	if q.config.GetOrderBy() == params.SwapQueueOrderByValue && a.value != b.value {
+		return a.value > b.value
+	}
+	if a.timestamp != b.timestamp {
+		return a.timestamp < b.timestamp
+	}
+	return a.seq < b.seq
+}
+
+func (q *swapTaskQueue) hasPair(pairKey string) bool {
+	for _, task := range q.tasks {
+		if task.pairKey == pairKey {
+			return true
+		}
+	}
+	return false
+}
+
+func (q *swapTaskQueue) minServed() (minServed float64, ok bool) {
+	for _, task := range q.tasks {
+		served := q.served[task.pairKey]
+		if !ok || served < minServed {
+			minServed = served
+			ok = true
+		}
+	}
+	return minServed, ok
+}
+
+func (q *swapTaskQueue) contains(key string) bool {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	_, exist := q.tasks[key]
+	return exist
+}
+
+// boost mark queued task as boosted
+func (q *swapTaskQueue) boost(key string) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if task, exist := q.tasks[key]; exist {
+		task.boosted = true
+	}
+}
+
+// position get position (start from 1) of swap task in serving order, 0 if not queued.
+// the order is static except the served counts of pairs if fair per pair,
+// in which case only the heads of the sorted pair tasks are compared when simulating pops.
+func (q *swapTaskQueue) position(key string) (position, depth int) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	depth = len(q.tasks)
+	target, exist := q.tasks[key]
+	if !exist {
+		return 0, depth
+	}
+	if target.boosted || !q.config.FairPerPair {
+		for _, task := range q.tasks {
+			if q.less(task, target) {
+				position++
+			}
+		}
+		return position + 1, depth
+	}
+	pairTasks := make(map[string][]*swapTask)
+	for _, task := range q.tasks {
+		if task.boosted {
+			position++ // boosted tasks are served first
+			continue
+		}
+		pairTasks[task.pairKey] = append(pairTasks[task.pairKey], task)
+	}
+	clone := &swapTaskQueue{
+		served: make(map[string]float64, len(q.served)),
+		config: q.config,
+	}
+	for k, v := range q.served {
+		clone.served[k] = v
+	}
+	for _, tasks := range pairTasks {
+		sort.Slice(tasks, func(i, j int) bool { return clone.less(tasks[i], tasks[j]) })
+	}
+	heads := make(map[string]int, len(pairTasks))
+	for {
+		var best *swapTask
+		for pairKey, tasks := range pairTasks {
+			if head := heads[pairKey]; head < len(tasks) && (best == nil || clone.less(tasks[head], best)) {
+				best = tasks[head]
+			}
+		}
+		position++
+		if best == nil || best == target {
+			return position, depth
+		}
+		heads[best.pairKey]++
+		clone.served[best.pairKey] += 1 / float64(q.config.GetPairPriority(best.pairKey))
+	}
+}
+
+func (q *swapTaskQueue) status() *SwapQueueStatus {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	status := &SwapQueueStatus{
+		DcrmAddress: q.dcrmAddress,
+		SwapType:    getSwapType(q.isSwapin).String(),
+		Depth:       len(q.tasks),
+		PairDepths:  make(map[string]int),
+	}
+	for _, task := range q.tasks {
+		status.PairDepths[task.pairKey]++
+		if task.boosted {
+			status.Boosted++
+		}
+	}
+	return status
+}
+
+func isSwapQueued(isSwapin bool, dcrmAddress, key string) bool {
+	queue := getSwapTaskQueue(isSwapin, dcrmAddress)
+	return queue != nil && queue.contains(key)
+}
+
+// GetSwapQueueStatus get status of all swap task queues
+func GetSwapQueueStatus() []*SwapQueueStatus {
+	swapQueuesLock.RLock()
+	queues := make([]*swapTaskQueue, 0, len(swapinTaskQueues)+len(swapoutTaskQueues))
+	for _, queue := range swapinTaskQueues {
+		queues = append(queues, queue)
+	}
+	for _, queue := range swapoutTaskQueues {
+		queues = append(queues, queue)
+	}
+	swapQueuesLock.RUnlock()
+
+	result := make([]*SwapQueueStatus, len(queues))
+	for i, queue := range queues {
+		result[i] = queue.status()
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].SwapType != result[j].SwapType {
+			return result[i].SwapType < result[j].SwapType
+		}
+		return result[i].DcrmAddress < result[j].DcrmAddress
+	})
+	return result
+}
+
+// GetSwapQueuePosition get position (start from 1) of swap in its queue
+// and the queue depth, position is 0 if the swap is not queued
+func GetSwapQueuePosition(isSwapin bool, pairID, txid, bind string) (position, depth int) {
+	_, toTokenCfg := tokens.GetTokenConfigsByDirection(pairID, isSwapin)
+	if toTokenCfg == nil {
+		return 0, 0
+	}
+	queue := getSwapTaskQueue(isSwapin, toTokenCfg.DcrmAddress)
+	if queue == nil {
+		return 0, 0
+	}
+	return queue.position(getSwapCacheKey(isSwapin, txid, bind))
+}
+
+// BoostSwap serve swap before the others, it takes effect when
+// the swap is queued or the next time it is queued.
+// new boost is rejected if there are too many boosted swaps not served.
+func BoostSwap(isSwapin bool, pairID, txid, bind string) error {
+	_, toTokenCfg := tokens.GetTokenConfigsByDirection(pairID, isSwapin)
+	if toTokenCfg == nil {
+		return tokens.ErrUnknownPairID
+	}
+	key := getSwapCacheKey(isSwapin, txid, bind)
+	if !boostedSwaps.Contains(key) && boostedSwaps.Cardinality() >= maxCachedSwapTasks {
+		return errTooManyBoostedSwaps
+	}
+	boostedSwaps.Add(key)
+	if queue := getSwapTaskQueue(isSwapin, toTokenCfg.DcrmAddress); queue != nil {
+		queue.boost(key)
+	}
+	logWorker("swap", "boost swap", "pairID", pairID, "txid", txid, "bind", bind, "isSwapin", isSwapin)
+	return nil
+}
@@ -3,6 +3,8 @@ package worker
 import (
 	"time"
 
+	"github.com/anyswap/CrossChain-Bridge/notifier"
+	"github.com/anyswap/CrossChain-Bridge/params"
 	"github.com/anyswap/CrossChain-Bridge/rpc/client"
 	"github.com/anyswap/CrossChain-Bridge/tokens/bridge"
 )
@@ -11,17 +13,23 @@ const interval = 10 * time.Millisecond
 
 // StartWork start swap server work
 func StartWork(isServer bool) {
-	if isServer {
+	isShadow := !isServer && params.IsOracleShadowMode()
+	switch {
+	case isServer:
 		logWorker("worker", "start server worker")
-	} else {
+	case isShadow:
+		logWorker("worker", "start oracle worker in shadow mode")
+	default:
 		logWorker("worker", "start oracle worker")
 	}
 
 	client.InitHTTPClient()
 	bridge.InitCrossChainBridge(isServer)
 
-	StartScanJob(isServer)
-	time.Sleep(interval)
+	if !isShadow { // shadow oracle does not register swaps to server
+		StartScanJob(isServer)
+		time.Sleep(interval)
+	}
 
 	StartUpdateLatestBlockHeightJob()
 	time.Sleep(interval)
@@ -31,10 +39,15 @@ func StartWork(isServer bool) {
 		time.Sleep(interval)
 		AddTokenPairDynamically()
 		time.Sleep(interval)
-		StartReportStatJob()
+		if !isShadow {
+			StartReportStatJob()
+		}
 		return
 	}
 
+	notifier.Start()
+	time.Sleep(interval)
+
 	StartSwapJob()
 	time.Sleep(interval)
 
@@ -44,6 +57,9 @@ func StartWork(isServer bool) {
 	StartStableJob()
 	time.Sleep(interval)
 
+	StartRefundJob()
+	time.Sleep(interval)
+
 	StartReplaceJob()
 	time.Sleep(interval)
 
@@ -51,4 +67,7 @@ func StartWork(isServer bool) {
 	time.Sleep(interval)
 
 	StartAggregateJob()
+	time.Sleep(interval)
+
+	StartUtxoReservationJob()
 }